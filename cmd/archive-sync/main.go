@@ -0,0 +1,156 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2/textlogger"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/archive"
+	"kpt.dev/configsync/pkg/reconcilermanager"
+	"kpt.dev/configsync/pkg/util"
+	utillog "kpt.dev/configsync/pkg/util/log"
+)
+
+var flURL = flag.String("url", util.EnvString(reconcilermanager.ArchiveSyncURL, ""),
+	"the HTTP(S) URL of the gzipped tarball")
+var flAuth = flag.String("auth", util.EnvString(reconcilermanager.ArchiveSyncAuth, string(configsync.AuthNone)),
+	fmt.Sprintf("the authentication type for access to the archive. Must be one of %s, %s, or %s. Defaults to %s",
+		configsync.AuthToken, configsync.AuthHMAC, configsync.AuthNone, configsync.AuthNone))
+var flRegion = flag.String("region", util.EnvString(reconcilermanager.ArchiveSyncRegion, archive.DefaultRegion),
+	"the region of the S3-compatible bucket, used to sign the requests with hmac auth")
+var flRoot = flag.String("root", util.EnvString("ARCHIVE_SYNC_ROOT", util.EnvString("HOME", "")+"/archive"),
+	"the root directory for archive-sync operations, under which --dest will be created")
+var flDest = flag.String("dest", util.EnvString("ARCHIVE_SYNC_DEST", "rev"),
+	"the path (absolute or relative to --root) at which to create a symlink to the directory holding the retrieved files")
+var flErrorFile = flag.String("error-file", util.EnvString("ARCHIVE_SYNC_ERROR_FILE", ""),
+	"the name of a file into which errors will be written under --root (defaults to \"\", disabling error reporting)")
+var flWait = flag.Float64("wait", util.EnvFloat(reconcilermanager.ArchiveSyncWait, 1),
+	"the number of seconds between syncs")
+var flSyncTimeout = flag.Int("timeout", util.EnvInt("ARCHIVE_SYNC_TIMEOUT", 120),
+	"the max number of seconds allowed for a complete sync")
+var flOneTime = flag.Bool("one-time", util.EnvBool("ARCHIVE_SYNC_ONE_TIME", false),
+	"exit after the first sync")
+var flMaxSyncFailures = flag.Int("max-sync-failures", util.EnvInt("ARCHIVE_SYNC_MAX_SYNC_FAILURES", 0),
+	"the number of consecutive failures allowed before aborting (the first sync must succeed, -1 will retry forever after the initial sync)")
+
+func errorBackoff() wait.Backoff {
+	durationLimit := math.Max(*flWait, float64(util.MinimumSyncContainerBackoffCap))
+	return util.BackoffWithDurationAndStepLimit(util.WaitTime(durationLimit), math.MaxInt32)
+}
+
+func main() {
+	utillog.Setup()
+	log := utillog.NewLogger(textlogger.NewLogger(textlogger.NewConfig()), *flRoot, *flErrorFile)
+
+	log.Info("downloading archive with arguments", "--url", *flURL,
+		"--auth", *flAuth, "--region", *flRegion, "--root", *flRoot, "--dest", *flDest,
+		"--wait", *flWait, "--error-file", *flErrorFile, "--timeout", *flSyncTimeout,
+		"--one-time", *flOneTime, "--max-sync-failures", *flMaxSyncFailures)
+
+	if *flURL == "" {
+		utillog.HandleError(log, true, "ERROR: --url must be specified")
+	}
+
+	if *flRoot == "" {
+		utillog.HandleError(log, true, "ERROR: --root must be specified")
+	}
+
+	if *flWait < 0 {
+		utillog.HandleError(log, true, "ERROR: --wait must be greater than or equal to 0")
+	}
+
+	if *flSyncTimeout < 0 {
+		utillog.HandleError(log, true, "ERROR: --timeout must be greater than 0")
+	}
+
+	// The credentials are only set by the reconciler-manager with the keys of
+	// the Secret, so they are read from the environment rather than flags.
+	var credentials archive.Credentials
+	switch configsync.AuthType(*flAuth) {
+	case configsync.AuthNone:
+	case configsync.AuthToken:
+		credentials.Token = os.Getenv("ARCHIVE_SYNC_TOKEN")
+		credentials.Username = os.Getenv("ARCHIVE_SYNC_USERNAME")
+		credentials.Password = os.Getenv("ARCHIVE_SYNC_PASSWORD")
+		if credentials.Token == "" && (credentials.Username == "" || credentials.Password == "") {
+			utillog.HandleError(log, true, "ERROR: either a token or a username and password must be provided with %s auth", configsync.AuthToken)
+		}
+	case configsync.AuthHMAC:
+		credentials.AccessKeyID = os.Getenv("ARCHIVE_SYNC_ACCESS_KEY_ID")
+		credentials.SecretAccessKey = os.Getenv("ARCHIVE_SYNC_SECRET_ACCESS_KEY")
+		credentials.Region = *flRegion
+		if credentials.AccessKeyID == "" || credentials.SecretAccessKey == "" {
+			utillog.HandleError(log, true, "ERROR: an access key ID and a secret access key must be provided with %s auth", configsync.AuthHMAC)
+		}
+	default:
+		utillog.HandleError(log, true, "ERROR: --auth type must be one of %#v, but found %q",
+			[]configsync.AuthType{
+				configsync.AuthNone,
+				configsync.AuthToken,
+				configsync.AuthHMAC,
+			},
+			*flAuth)
+	}
+
+	fetcher := &archive.Fetcher{
+		Credentials: credentials,
+	}
+	initialSync := true
+	failCount := 0
+	backoff := errorBackoff()
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*time.Duration(*flSyncTimeout))
+		if err := fetcher.FetchArchive(ctx, *flURL, *flRoot, *flDest); err != nil {
+			if *flMaxSyncFailures != -1 && failCount >= *flMaxSyncFailures {
+				// Exit after too many retries, maybe the error is not recoverable.
+				log.Error(err, "too many failures, aborting", "failCount", failCount)
+				os.Exit(1)
+			}
+
+			step := backoff.Step()
+
+			failCount++
+			log.Error(err, "unexpected error fetching archive, will retry")
+			log.Info("waiting before retrying", "waitTime", step)
+			cancel()
+			time.Sleep(step)
+			continue
+		}
+
+		if initialSync {
+			if *flOneTime {
+				log.DeleteErrorFile()
+				os.Exit(0)
+			}
+			initialSync = false
+		}
+
+		backoff = errorBackoff()
+		failCount = 0
+		log.DeleteErrorFile()
+		log.Info("next sync", "wait_time", util.WaitTime(*flWait))
+		cancel()
+		time.Sleep(util.WaitTime(*flWait))
+	}
+}
@@ -0,0 +1,279 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// git-sync-launcher runs git-sync as a child process, and extends it with:
+//   - Fetch triggers: the launcher sends SIGHUP to git-sync when the reconciler
+//     touches the fetch trigger file, so git-sync fetches without waiting for
+//     the next poll. The launcher runs in the git-sync container, so signaling
+//     git-sync needs neither a shared process namespace, nor the KILL
+//     capability.
+//   - Revision constraints: when GITSYNC_REF is a semantic version constraint,
+//     the launcher lists the remote tags on each period, with the credentials
+//     of the git-sync container, and runs git-sync with the highest matching
+//     tag. git-sync is restarted when a higher tag is pushed.
+//
+// The git-sync image does not ship the launcher. An init container running the
+// reconciler image copies it into a volume shared with the git-sync containers,
+// using the `--install` flag.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/git"
+	utillog "kpt.dev/configsync/pkg/util/log"
+)
+
+const (
+	// gitSyncRef is the git-sync env var of the ref to sync.
+	gitSyncRef = "GITSYNC_REF"
+	// gitSyncRepo is the git-sync env var of the repository to sync.
+	gitSyncRepo = "GITSYNC_REPO"
+	// gitSyncPeriod is the git-sync env var of the polling period.
+	gitSyncPeriod = "GITSYNC_PERIOD"
+	// defaultPeriod is the default polling period of git-sync.
+	defaultPeriod = 10 * time.Second
+)
+
+var (
+	flInstall = flag.String("install", "",
+		"If set, copy the launcher executable to this path and exit.")
+	flTriggerFile = flag.String("trigger-file", git.FetchTriggerFile,
+		"The file touched by the reconciler to trigger a fetch.")
+	flPollPeriod = flag.Duration("poll-period", time.Second,
+		"Period of time between checking the trigger file for changes.")
+	flRevisionFile = flag.String("revision-file", "",
+		"The file where the tag resolved from the revision constraint is recorded, if any.")
+	flErrorFile = flag.String("error-file", "",
+		"The git-sync error file, where the errors resolving the revision constraint are written before git-sync starts.")
+)
+
+func main() {
+	utillog.Setup()
+
+	if *flInstall != "" {
+		if err := install(*flInstall); err != nil {
+			klog.Fatalf("Installing the launcher: %v", err)
+		}
+		return
+	}
+	args := flag.Args()
+	if len(args) == 0 {
+		klog.Fatal("The git-sync command is required")
+	}
+	l := &launcher{args: args}
+	if ref := os.Getenv(gitSyncRef); git.IsRevisionConstraint(ref) {
+		l.constraint = ref
+	}
+	os.Exit(l.run())
+}
+
+// install copies the running executable to the given path.
+func install(dest string) error {
+	src, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0755)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// launcher runs git-sync as a child process.
+type launcher struct {
+	// args is the git-sync command.
+	args []string
+	// constraint is the semantic version constraint of the ref, if any.
+	constraint string
+	// tag is the highest tag matching the constraint, synced by git-sync.
+	tag string
+
+	cmd  *exec.Cmd
+	done chan error
+}
+
+// run starts git-sync, forwards the termination signals to it, and signals it
+// when a fetch is triggered. It returns the exit code of git-sync.
+func (l *launcher) run() int {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+
+	watcher, err := git.NewFetchTriggerWatcher(*flTriggerFile)
+	if err != nil {
+		klog.Fatalf("Watching the fetch trigger file: %v", err)
+	}
+	period := syncPeriod()
+	if l.constraint != "" {
+		// git-sync can't start before the constraint is resolved. Until then,
+		// the errors are reported in the git-sync error file.
+		for !l.resolve(ctx) {
+			select {
+			case <-signals:
+				return 0
+			case <-time.After(period):
+			}
+		}
+		if *flErrorFile != "" {
+			if err := os.Remove(*flErrorFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+				klog.Warningf("Removing the error file: %v", err)
+			}
+		}
+	}
+	l.start()
+
+	triggerTicker := time.NewTicker(*flPollPeriod)
+	defer triggerTicker.Stop()
+	resolveTicker := time.NewTicker(period)
+	defer resolveTicker.Stop()
+	for {
+		select {
+		case err := <-l.done:
+			return exitCode(err)
+		case sig := <-signals:
+			l.signal(sig)
+		case <-resolveTicker.C:
+			l.resolveAndRestart(ctx)
+		case <-triggerTicker.C:
+			triggered, err := watcher.Triggered()
+			if err != nil {
+				klog.Warningf("Checking the fetch trigger file: %v", err)
+				continue
+			}
+			if !triggered {
+				continue
+			}
+			klog.V(1).Info("Fetch triggered")
+			// A pushed tag may match the constraint. Restarting git-sync with
+			// the new tag fetches it, otherwise git-sync is asked to fetch.
+			if !l.resolveAndRestart(ctx) {
+				l.signal(syscall.SIGHUP)
+			}
+		}
+	}
+}
+
+// start starts git-sync, with the resolved tag as ref if the ref is a
+// constraint.
+func (l *launcher) start() {
+	l.cmd = exec.Command(l.args[0], l.args[1:]...)
+	l.cmd.Stdin = os.Stdin
+	l.cmd.Stdout = os.Stdout
+	l.cmd.Stderr = os.Stderr
+	if l.tag != "" {
+		l.cmd.Env = append(os.Environ(), gitSyncRef+"="+l.tag)
+	}
+	if err := l.cmd.Start(); err != nil {
+		klog.Fatalf("Starting %s: %v", l.args[0], err)
+	}
+	l.done = make(chan error, 1)
+	go func(cmd *exec.Cmd, done chan<- error) {
+		done <- cmd.Wait()
+	}(l.cmd, l.done)
+}
+
+// signal sends the signal to git-sync.
+func (l *launcher) signal(sig os.Signal) {
+	if err := l.cmd.Process.Signal(sig); err != nil {
+		klog.Warningf("Sending %v to %s: %v", sig, l.args[0], err)
+	}
+}
+
+// resolveAndRestart resolves the constraint, and restarts git-sync if the
+// highest matching tag changed. It returns true if git-sync was restarted.
+// When the tags can't be listed, git-sync keeps syncing the current tag.
+func (l *launcher) resolveAndRestart(ctx context.Context) bool {
+	previous := l.tag
+	if l.constraint == "" || !l.resolve(ctx) || l.tag == previous {
+		return false
+	}
+	klog.Infof("Restarting %s to sync tag %q, previously %q", l.args[0], l.tag, previous)
+	l.signal(syscall.SIGTERM)
+	if err := <-l.done; err != nil {
+		klog.V(1).Infof("%s exited: %v", l.args[0], err)
+	}
+	l.start()
+	return true
+}
+
+// resolve lists the remote tags, and records the highest tag matching the
+// constraint. It returns false if the constraint could not be resolved.
+func (l *launcher) resolve(ctx context.Context) bool {
+	tag, err := git.ResolveRemoteTag(ctx, os.Getenv(gitSyncRepo), l.constraint, os.Getenv)
+	if err != nil {
+		klog.Warningf("Resolving the revision constraint %q: %v", l.constraint, err)
+		if l.tag == "" && *flErrorFile != "" {
+			msg := fmt.Sprintf("resolving the revision constraint %q: %v", l.constraint, err)
+			if err := os.WriteFile(*flErrorFile, []byte(msg), 0644); err != nil {
+				klog.Warningf("Writing the error file: %v", err)
+			}
+		}
+		return false
+	}
+	if tag != l.tag {
+		klog.Infof("Resolved the revision constraint %q to tag %q", l.constraint, tag)
+	}
+	l.tag = tag
+	if *flRevisionFile != "" {
+		if err := os.WriteFile(*flRevisionFile, []byte(tag), 0644); err != nil {
+			klog.Warningf("Writing the revision file: %v", err)
+		}
+	}
+	return true
+}
+
+// syncPeriod returns the polling period of git-sync.
+func syncPeriod() time.Duration {
+	period, err := time.ParseDuration(os.Getenv(gitSyncPeriod))
+	if err != nil || period <= 0 {
+		return defaultPeriod
+	}
+	return period
+}
+
+// exitCode returns the exit code of a command which has exited with the given
+// error.
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	fmt.Fprintln(os.Stderr, err)
+	return 1
+}
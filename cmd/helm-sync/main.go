@@ -75,6 +75,12 @@ var (
 		"the username to use for helm authantication")
 	flPassword = flag.String("password", util.EnvString("HELM_SYNC_PASSWORD", ""),
 		"the password or personal access token to use for helm authantication")
+	flVerificationKeysDir = flag.String("verification-keys-dir", util.EnvString(reconcilermanager.SourceVerificationKeysDirKey, ""),
+		"the directory of the trusted keys used to verify the chart (defaults to \"\", disabling verification)")
+	flVerificationIdentity = flag.String("verification-identity", util.EnvString(reconcilermanager.HelmVerificationIdentity, ""),
+		"the expected identity of keyless cosign signatures of OCI charts (defaults to \"\", requiring signatures by the trusted public keys)")
+	flVerificationIssuer = flag.String("verification-issuer", util.EnvString(reconcilermanager.HelmVerificationIssuer, ""),
+		"the expected OIDC issuer of keyless cosign signatures of OCI charts")
 )
 
 func errorBackoff() wait.Backoff {
@@ -90,7 +96,9 @@ func main() {
 		"--values", *flValuesYAML, "--values-file-paths", *flValuesFilePaths,
 		"--include-crds", *flIncludeCRDs, "--dest", *flDest, "--wait", *flWait,
 		"--error-file", *flErrorFile, "--timeout", *flSyncTimeout,
-		"--one-time", *flOneTime, "--max-sync-failures", *flMaxSyncFailures)
+		"--one-time", *flOneTime, "--max-sync-failures", *flMaxSyncFailures,
+		"--verification-keys-dir", *flVerificationKeysDir, "--verification-identity", *flVerificationIdentity,
+		"--verification-issuer", *flVerificationIssuer)
 
 	if *flRepo == "" {
 		utillog.HandleError(log, true, "ERROR: --repo must be specified")
@@ -145,6 +153,9 @@ func main() {
 			CredentialProvider: &auth.CachingCredentialProvider{
 				Scopes: auth.OCISourceScopes(),
 			},
+			VerificationKeysDir:  *flVerificationKeysDir,
+			VerificationIdentity: *flVerificationIdentity,
+			VerificationIssuer:   *flVerificationIssuer,
 		}
 
 		if err := hydrator.HelmTemplate(ctx); err != nil {
@@ -23,12 +23,14 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/klog/v2/textlogger"
 	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/helm"
 	"kpt.dev/configsync/pkg/hydrate"
 	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
 	"kpt.dev/configsync/pkg/kmetrics"
 	"kpt.dev/configsync/pkg/profiler"
 	"kpt.dev/configsync/pkg/reconcilermanager"
 	"kpt.dev/configsync/pkg/reconcilermanager/controllers"
+	"kpt.dev/configsync/pkg/util"
 	"kpt.dev/configsync/pkg/util/log"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
@@ -72,6 +74,30 @@ var (
 
 	reconcilerSignalsDir = flag.String("reconciler-signals", "/reconciler-signals",
 		"The absolute path in the container that contains reconciler signals written by the reconciler that unblock the rendering phase, for example, the latest image digest that is ready to render.")
+
+	hermetic = flag.Bool("hermetic", util.EnvBool(reconcilermanager.RenderingHermetic, false),
+		"Reject the kustomizations which fetch remote bases or Helm charts at render time.")
+
+	allowExecFunctions = flag.Bool("allow-exec-functions", util.EnvBool(reconcilermanager.RenderingAllowExecFunctions, false),
+		"Allow the Kptfile pipelines to run the exec functions in --kpt-functions-dir.")
+
+	kptFunctionsDir = flag.String("kpt-functions-dir", hydrate.DefaultKptFunctionsDir,
+		"The absolute path of the directory of the exec function binaries the Kptfile pipelines may run.")
+
+	helmReleaseName = flag.String("helm-release-name", os.Getenv(reconcilermanager.HelmReleaseName),
+		"The name of the release of the Helm chart stored in the sync directory.")
+
+	helmNamespace = flag.String("helm-namespace", os.Getenv(reconcilermanager.HelmReleaseNamespace),
+		"The target namespace of the release of the Helm chart stored in the sync directory.")
+
+	helmIncludeCRDs = flag.String("helm-include-crds", os.Getenv(reconcilermanager.HelmIncludeCRDs),
+		"Whether to render the CRDs of the Helm chart stored in the sync directory.")
+
+	helmValuesFiles = flag.String("helm-values-files", os.Getenv(reconcilermanager.HelmGitValuesFiles),
+		"A comma-separated list of values files of the Helm chart, relative to the sync directory.")
+
+	helmValuesFilePaths = flag.String("helm-values-file-paths", os.Getenv(reconcilermanager.HelmValuesFilePaths),
+		"A comma-separated list of values files mounted from ConfigMaps, which override the values files in the sync directory.")
 )
 
 func main() {
@@ -116,6 +142,14 @@ func main() {
 	dir := strings.TrimPrefix(*syncDir, "/")
 	relSyncDir := cmpath.RelativeOS(dir)
 
+	var chartValuesFiles, valuesFilePaths []string
+	if len(*helmValuesFiles) != 0 {
+		chartValuesFiles = strings.Split(*helmValuesFiles, ",")
+	}
+	if len(*helmValuesFilePaths) != 0 {
+		valuesFilePaths = strings.Split(*helmValuesFilePaths, ",")
+	}
+
 	hydrator := &hydrate.Hydrator{
 		DonePath:            absDonePath,
 		SourceType:          configsync.SourceType(*sourceType),
@@ -128,6 +162,17 @@ func main() {
 		PollingPeriod:       *pollingPeriod,
 		RehydratePeriod:     *rehydratePeriod,
 		ReconcilerName:      *reconcilerName,
+		Hermetic:            *hermetic,
+		AllowExecFunctions:  *allowExecFunctions,
+		KptFunctionsDir:     *kptFunctionsDir,
+		ChartRenderer: &helm.Hydrator{
+			ReleaseName:     *helmReleaseName,
+			Namespace:       *helmNamespace,
+			ValuesFilePaths: valuesFilePaths,
+			IncludeCRDs:     *helmIncludeCRDs,
+		},
+		ChartValuesFiles: chartValuesFiles,
+		ExtraInputs:      valuesFilePaths,
 	}
 
 	hydrator.Run(context.Background())
@@ -0,0 +1,141 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"kpt.dev/configsync/cmd/nomos/flags"
+	"kpt.dev/configsync/cmd/nomos/util"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/client/restconfig"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/synchistory"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	commit      string
+	name        string
+	namespace   string
+	clusterName string
+)
+
+func init() {
+	flags.AddPath(Cmd)
+	flags.AddContext(Cmd)
+	flags.AddSourceFormat(Cmd)
+	Cmd.Flags().StringVar(&commit, "commit", "", "Prints what the sync of the commit, or of the commit with the given prefix, changed on the cluster.")
+	Cmd.Flags().StringVar(&name, "name", configsync.RootSyncName, "The name of the RootSync or RepoSync.")
+	Cmd.Flags().StringVar(&namespace, "namespace", configsync.ControllerNamespace, "The namespace of the RootSync or RepoSync.")
+	Cmd.Flags().StringVar(&clusterName, "cluster-name", "", "The name of the cluster, to select the objects of the --path with cluster selectors.")
+	Cmd.Flags().DurationVar(&flags.ClientTimeout, "timeout", restconfig.DefaultTimeout, "Timeout for connecting to the cluster")
+}
+
+// Cmd prints the changes the syncs of a RootSync or RepoSync made on the
+// cluster, from the sync history, or the changes the sync of a local
+// directory would make.
+var Cmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Prints what a sync changed, or would change, on the cluster.",
+	Long: `Prints what a RootSync or RepoSync changed, or would change, on the cluster.
+
+With --commit, prints the objects the RootSync or RepoSync created, updated, pruned or skipped when syncing the commit, and the declared fields it updated.
+The last ` + fmt.Sprint(synchistory.MaxEntries) + ` syncs are kept in the sync history.
+
+Otherwise, validates the directory of the --path like nomos vet, dry-runs its objects on the cluster and prints the changes syncing it would make as a unified diff, including the objects which would be pruned.`,
+	Example: `  nomos diff --commit=1a2b3c4
+  nomos diff --commit=1a2b3c4 --namespace=bookstore --name=repo-sync
+  nomos diff --path=my/directory --source-format=unstructured
+  nomos diff --path=my/directory --context=prod --namespace=bookstore --name=repo-sync`,
+	Args: cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		if commit != "" && cmd.Flags().Changed("path") {
+			return errors.New("--commit and --path must not both be specified")
+		}
+		// Don't show usage on error, as argument validation passed.
+		cmd.SilenceUsage = true
+
+		cfg, err := restconfig.NewContextRestConfig(flags.ClientTimeout, flags.Context)
+		if err != nil {
+			return fmt.Errorf("failed to create rest config: %w", err)
+		}
+		c, err := client.New(cfg, client.Options{Scheme: core.Scheme})
+		if err != nil {
+			return fmt.Errorf("failed to create kubernetes client: %w", err)
+		}
+
+		syncKey := client.ObjectKey{Namespace: namespace, Name: name}
+		if commit == "" {
+			return runPreview(cmd.Context(), c, os.Stdout, previewOptions{
+				SyncKey:          syncKey,
+				ClusterName:      clusterName,
+				SourceFormat:     configsync.SourceFormat(flags.SourceFormat),
+				APIServerTimeout: flags.ClientTimeout,
+			})
+		}
+
+		entries, err := synchistory.Get(cmd.Context(), c, syncKey)
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("no sync history found for %s", syncKey)
+		} else if err != nil {
+			return fmt.Errorf("failed to get the sync history of %s: %w", syncKey, err)
+		}
+		entries = synchistory.ForCommit(entries, commit)
+		if len(entries) == 0 {
+			return fmt.Errorf("commit %q not found in the sync history of %s", commit, syncKey)
+		}
+
+		writer := util.NewWriter(os.Stdout)
+		printEntries(writer, entries)
+		return writer.Flush()
+	},
+}
+
+// printEntries prints the changed objects of each entry, one per line.
+func printEntries(w io.Writer, entries []synchistory.Entry) {
+	for i, entry := range entries {
+		if i > 0 {
+			util.MustFprintf(w, "\n")
+		}
+		util.MustFprintf(w, "commit %s synced at %s\n", entry.Commit, entry.Time.Format(time.RFC3339))
+		if len(entry.Objects) == 0 {
+			util.MustFprintf(w, "  no changes\n")
+		}
+		for _, obj := range entry.Objects {
+			gk := schema.GroupKind{Group: obj.Group, Kind: obj.Kind}
+			objName := obj.Name
+			if obj.Namespace != "" {
+				objName = obj.Namespace + "/" + obj.Name
+			}
+			util.MustFprintf(w, "  %s\t%s\t%s", obj.Operation, gk, objName)
+			if len(obj.Fields) > 0 {
+				util.MustFprintf(w, "\t%s", strings.Join(obj.Fields, ","))
+			}
+			util.MustFprintf(w, "\n")
+		}
+		if entry.Truncated {
+			util.MustFprintf(w, "  (more changes were not recorded)\n")
+		}
+	}
+}
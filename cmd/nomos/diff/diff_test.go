@@ -0,0 +1,58 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kpt.dev/configsync/cmd/nomos/util"
+	"kpt.dev/configsync/pkg/synchistory"
+)
+
+func TestPrintEntries(t *testing.T) {
+	syncTime := metav1.NewTime(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))
+	entries := []synchistory.Entry{
+		{
+			Commit: "abc123",
+			Time:   syncTime,
+			Objects: []synchistory.ObjectChange{
+				{Group: "apps", Kind: "Deployment", Namespace: "prod", Name: "app", Operation: synchistory.Update, Fields: []string{".spec.replicas", ".spec.template"}},
+				{Kind: "Namespace", Name: "prod", Operation: synchistory.Create},
+				{Kind: "ConfigMap", Namespace: "prod", Name: "old", Operation: synchistory.Prune},
+			},
+			Truncated: true,
+		},
+		{Commit: "abc123", Time: syncTime},
+	}
+
+	var out bytes.Buffer
+	w := util.NewWriter(&out)
+	printEntries(w, entries)
+	require.NoError(t, w.Flush())
+	assert.Equal(t, `commit abc123 synced at 2025-03-01T12:00:00Z
+  update   Deployment.apps   prod/app   .spec.replicas,.spec.template
+  create   Namespace         prod
+  prune    ConfigMap         prod/old
+  (more changes were not recorded)
+
+commit abc123 synced at 2025-03-01T12:00:00Z
+  no changes
+`, out.String())
+}
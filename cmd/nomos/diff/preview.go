@@ -0,0 +1,387 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	nomosparse "kpt.dev/configsync/cmd/nomos/parse"
+	"kpt.dev/configsync/cmd/nomos/util"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/kpt.dev/v1alpha1"
+	"kpt.dev/configsync/pkg/applier"
+	"kpt.dev/configsync/pkg/applyset"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/hydrate"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/filesystem"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
+	"kpt.dev/configsync/pkg/importer/reader"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/lifecycle"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/parse"
+	"kpt.dev/configsync/pkg/reconcilermanager"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/synchistory"
+	"kpt.dev/configsync/pkg/validate"
+	"kpt.dev/configsync/pkg/validate/policy"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+type previewOptions struct {
+	// SyncKey is the RootSync or RepoSync which would sync the directory.
+	SyncKey client.ObjectKey
+	// ClusterName is the name of the cluster, to evaluate the cluster
+	// selectors.
+	ClusterName      string
+	SourceFormat     configsync.SourceFormat
+	APIServerTimeout time.Duration
+}
+
+// objectDiff is the change syncing the directory would make to an object.
+type objectDiff struct {
+	Operation synchistory.Operation
+	// Live is the object on the cluster, or nil if it doesn't exist.
+	Live *unstructured.Unstructured
+	// Merged is the object after the sync, or nil if it would be pruned.
+	Merged *unstructured.Unstructured
+}
+
+// runPreview prints the changes the sync of the directory of the --path
+// would make on the cluster of the client, as a unified diff.
+func runPreview(ctx context.Context, c client.Client, out io.Writer, opts previewOptions) error {
+	scope := declared.RootScope
+	if opts.SyncKey.Namespace != configsync.ControllerNamespace {
+		scope = declared.Scope(opts.SyncKey.Namespace)
+	}
+	objs, err := declaredObjects(ctx, scope, opts)
+	if err != nil {
+		return err
+	}
+	diffs, err := previewChanges(ctx, c, scope, opts.SyncKey.Name, objs)
+	if err != nil {
+		return err
+	}
+	if len(diffs) == 0 {
+		_, err = fmt.Fprintln(out, "No changes.")
+		return err
+	}
+	return printDiffs(out, diffs)
+}
+
+// declaredObjects parses and validates the directory of the --path, like
+// nomos vet, and returns the objects the RSync would apply.
+func declaredObjects(ctx context.Context, scope declared.Scope, opts previewOptions) ([]ast.FileObject, error) {
+	sourceFormat := opts.SourceFormat
+	if sourceFormat == "" {
+		if scope == declared.RootScope {
+			sourceFormat = configsync.SourceFormatHierarchy
+		} else {
+			sourceFormat = configsync.SourceFormatUnstructured
+		}
+	}
+
+	rootDir, needsHydrate, err := hydrate.ValidateSourceDir(sourceFormat)
+	if err != nil {
+		return nil, err
+	}
+	if needsHydrate {
+		// update rootDir to point to the hydrated output for further processing.
+		if rootDir, err = hydrate.ValidateAndRunKustomize(rootDir.OSPath()); err != nil {
+			return nil, err
+		}
+		// delete the hydrated output directory in the end.
+		defer func() {
+			_ = os.RemoveAll(rootDir.OSPath())
+		}()
+	}
+
+	files, err := nomosparse.FindFiles(rootDir)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := filesystem.NewParser(&reader.File{})
+
+	validateOpts, err := hydrate.ValidateOptions(ctx, rootDir, opts.APIServerTimeout)
+	if err != nil {
+		return nil, err
+	}
+	validateOpts.FieldManager = util.FieldManager
+	validateOpts.ClusterName = opts.ClusterName
+	validateOpts.SyncName = opts.SyncKey.Name
+
+	switch sourceFormat {
+	case configsync.SourceFormatHierarchy:
+		if scope != declared.RootScope {
+			return nil, fmt.Errorf("a RepoSync requires --%s=%s",
+				reconcilermanager.SourceFormat, configsync.SourceFormatUnstructured)
+		}
+		files = filesystem.FilterHierarchyFiles(rootDir, files)
+	case configsync.SourceFormatUnstructured:
+		validateOpts = parse.OptionsForScope(validateOpts, scope)
+	default:
+		return nil, fmt.Errorf("unknown %s value %q", reconcilermanager.SourceFormat, sourceFormat)
+	}
+
+	filePaths := reader.FilePaths{
+		RootDir:   rootDir,
+		PolicyDir: cmpath.RelativeOS(rootDir.OSPath()),
+		Files:     files,
+	}
+
+	policies, errs := policy.Load(parser, filePaths)
+	if errs != nil {
+		return nil, errs
+	}
+	validateOpts.Visitors = append(validateOpts.Visitors, policy.Visitor(policies))
+
+	objs, errs := parser.Parse(filePaths)
+	if errs != nil {
+		return nil, errs
+	}
+	if sourceFormat == configsync.SourceFormatHierarchy {
+		objs, errs = validate.Hierarchical(objs, validateOpts)
+	} else {
+		objs, errs = validate.Unstructured(ctx, nil, objs, validateOpts)
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return objs, nil
+}
+
+// previewChanges dry-runs a server-side apply of the declared objects, and
+// compares the results with the objects on the cluster. The objects in the
+// ResourceGroup inventory of the RSync which are no longer declared are
+// pruned.
+//
+// Objects of a type whose CRD is declared, and namespaced objects in a
+// Namespace which is declared, can't be dry-run before the CRD or the
+// Namespace is applied. They are created as declared instead.
+func previewChanges(ctx context.Context, c client.Client, scope declared.Scope, syncName string, objs []ast.FileObject) ([]objectDiff, error) {
+	csm := metadata.ConfigSyncMetadata{
+		ApplySetID:   applyset.IDFromSync(syncName, scope),
+		ManagerValue: declared.ResourceManager(scope, syncName),
+		InventoryID:  applier.InventoryID(syncName, scope.SyncNamespace()),
+	}
+	declaredIDs := make(map[core.ID]bool)
+	declaredCRDs := make(map[schema.GroupKind]bool)
+	declaredNamespaces := make(map[string]bool)
+	for _, obj := range objs {
+		declaredIDs[core.IDOf(obj.Unstructured)] = true
+		switch obj.GroupVersionKind().GroupKind() {
+		case kinds.CustomResourceDefinition():
+			group, _, _ := unstructured.NestedString(obj.Object, "spec", "group")
+			kind, _, _ := unstructured.NestedString(obj.Object, "spec", "names", "kind")
+			declaredCRDs[schema.GroupKind{Group: group, Kind: kind}] = true
+		case kinds.Namespace().GroupKind():
+			declaredNamespaces[obj.GetName()] = true
+		}
+	}
+
+	var diffs []objectDiff
+	var errs status.MultiError
+	for _, obj := range objs {
+		if metadata.IsManagementDisabled(obj.Unstructured) {
+			continue
+		}
+		merged := obj.Unstructured.DeepCopy()
+		csm.SetConfigSyncMetadata(merged)
+
+		live, err := getObject(ctx, c, merged.GroupVersionKind(), client.ObjectKeyFromObject(merged))
+		if err != nil {
+			return nil, err
+		}
+
+		err = c.Patch(ctx, merged, client.Apply,
+			client.DryRunAll,
+			client.ForceOwnership,
+			client.FieldOwner(configsync.FieldManager))
+		switch {
+		case err == nil:
+		case declaredCRDs[merged.GroupVersionKind().GroupKind()] && (meta.IsNoMatchError(err) || apierrors.IsNotFound(err)),
+			declaredNamespaces[merged.GetNamespace()] && apierrors.IsNotFound(err):
+			merged = obj.Unstructured.DeepCopy()
+		default:
+			errs = status.Append(errs, status.DryRunError(err, obj.Unstructured))
+			continue
+		}
+
+		d := objectDiff{Operation: synchistory.Update, Live: live, Merged: cleanForDiff(merged, csm.ApplySetID)}
+		if live == nil {
+			d.Operation = synchistory.Create
+		} else {
+			d.Live = cleanForDiff(live, csm.ApplySetID)
+			if equality.Semantic.DeepEqual(d.Live.Object, d.Merged.Object) {
+				continue
+			}
+		}
+		diffs = append(diffs, d)
+	}
+	if errs != nil {
+		return nil, errs
+	}
+
+	prunes, err := previewPrunes(ctx, c, client.ObjectKey{Namespace: scope.SyncNamespace(), Name: syncName}, declaredIDs, csm.ApplySetID)
+	if err != nil {
+		return nil, err
+	}
+	return append(diffs, prunes...), nil
+}
+
+// previewPrunes returns the objects in the ResourceGroup inventory of the
+// RSync which are not declared, and would be deleted by the sync. Objects
+// with deletion prevented are abandoned instead.
+func previewPrunes(ctx context.Context, c client.Client, syncKey client.ObjectKey, declaredIDs map[core.ID]bool, applySetID string) ([]objectDiff, error) {
+	rg := &v1alpha1.ResourceGroup{}
+	if err := c.Get(ctx, syncKey, rg); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			// Nothing was synced yet.
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get the ResourceGroup inventory %s: %w", syncKey, err)
+	}
+
+	var diffs []objectDiff
+	for _, res := range rg.Spec.Resources {
+		gk := schema.GroupKind{Group: res.Group, Kind: res.Kind}
+		key := client.ObjectKey{Namespace: res.Namespace, Name: res.Name}
+		if declaredIDs[core.ID{GroupKind: gk, ObjectKey: key}] {
+			continue
+		}
+		mapping, err := c.RESTMapper().RESTMapping(gk)
+		if meta.IsNoMatchError(err) {
+			// The type was deleted, and so were its objects.
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		live, err := getObject(ctx, c, mapping.GroupVersionKind, key)
+		if err != nil {
+			return nil, err
+		}
+		if live == nil || lifecycle.HasPreventDeletion(live) {
+			continue
+		}
+		diffs = append(diffs, objectDiff{
+			Operation: synchistory.Prune,
+			Live:      cleanForDiff(live, applySetID),
+		})
+	}
+	return diffs, nil
+}
+
+// getObject returns the object on the cluster, or nil if it doesn't exist.
+func getObject(ctx context.Context, c client.Client, gvk schema.GroupVersionKind, key client.ObjectKey) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	err := c.Get(ctx, key, obj)
+	switch {
+	case apierrors.IsNotFound(err), meta.IsNoMatchError(err):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to get %s %s: %w", gvk.Kind, key, err)
+	}
+	return obj, nil
+}
+
+// cleanForDiff removes the managed fields, the resource version and the Config
+// Sync metadata, which change with every commit, from a copy of the object.
+func cleanForDiff(obj *unstructured.Unstructured, applySetID string) *unstructured.Unstructured {
+	obj = obj.DeepCopy()
+	obj.SetManagedFields(nil)
+	obj.SetResourceVersion("")
+	metadata.RemoveConfigSyncMetadata(obj)
+	metadata.RemoveApplySetPartOfLabel(obj, applySetID)
+	// Drop the maps left empty, so they don't show up in the diff.
+	if len(obj.GetAnnotations()) == 0 {
+		obj.SetAnnotations(nil)
+	}
+	if len(obj.GetLabels()) == 0 {
+		obj.SetLabels(nil)
+	}
+	return obj
+}
+
+// printDiffs prints the changes like kubectl diff, from the live object to the
+// merged object.
+func printDiffs(out io.Writer, diffs []objectDiff) error {
+	for _, d := range diffs {
+		obj := d.Merged
+		if obj == nil {
+			obj = d.Live
+		}
+		file := diffName(obj)
+		live, err := toYAMLLines(d.Live)
+		if err != nil {
+			return err
+		}
+		merged, err := toYAMLLines(d.Merged)
+		if err != nil {
+			return err
+		}
+		util.MustFprintf(out, "diff -u -N live/%s merged/%s\n", file, file)
+		err = difflib.WriteUnifiedDiff(out, difflib.UnifiedDiff{
+			A:        live,
+			B:        merged,
+			FromFile: "live/" + file,
+			ToFile:   "merged/" + file,
+			Context:  3,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffName returns the name kubectl diff uses for the object.
+func diffName(obj *unstructured.Unstructured) string {
+	gvk := obj.GroupVersionKind()
+	group := ""
+	if gvk.Group != "" {
+		group = gvk.Group + "."
+	}
+	return fmt.Sprintf("%s%s.%s.%s.%s", group, gvk.Version, gvk.Kind, obj.GetNamespace(), obj.GetName())
+}
+
+// toYAMLLines returns the lines of the object in YAML, or no lines if the
+// object is nil.
+func toYAMLLines(obj *unstructured.Unstructured) ([]string, error) {
+	if obj == nil {
+		return nil, nil
+	}
+	data, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s to YAML: %w", core.IDOf(obj), err)
+	}
+	lines := strings.SplitAfter(string(data), "\n")
+	return lines[:len(lines)-1], nil
+}
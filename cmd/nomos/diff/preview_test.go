@@ -0,0 +1,152 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"kpt.dev/configsync/pkg/api/kpt.dev/v1alpha1"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	syncertest "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"kpt.dev/configsync/pkg/synchistory"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func configMap(name, value string, opts ...core.MetaMutator) *unstructured.Unstructured {
+	obj := k8sobjects.UnstructuredObject(kinds.ConfigMap(), append(opts, core.Namespace("bookstore"), core.Name(name))...)
+	obj.Object["data"] = map[string]interface{}{"key": value}
+	return obj
+}
+
+// applyClient keeps the server-populated metadata of the objects it applies,
+// like the apiserver, as the fake client replaces the objects.
+type applyClient struct {
+	client.Client
+}
+
+func (c *applyClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
+	getErr := c.Get(ctx, client.ObjectKeyFromObject(obj), live)
+	if getErr != nil && !apierrors.IsNotFound(getErr) {
+		return getErr
+	}
+	if err := c.Client.Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+	if getErr == nil {
+		merged := obj.(*unstructured.Unstructured)
+		for _, field := range []string{"uid", "creationTimestamp"} {
+			value, _, _ := unstructured.NestedFieldCopy(live.Object, "metadata", field)
+			if err := unstructured.SetNestedField(merged.Object, value, "metadata", field); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func TestPreviewChanges(t *testing.T) {
+	syncScope := declared.Scope("bookstore")
+	syncName := "repo-sync"
+	declaredMeta := core.Annotation(metadata.SourcePathAnnotationKey, "bookstore/cm.yaml")
+	inventory := &v1alpha1.ResourceGroup{}
+	inventory.Namespace = "bookstore"
+	inventory.Name = syncName
+	for _, name := range []string{"updated", "unchanged", "pruned", "detached", "deleted"} {
+		inventory.Spec.Resources = append(inventory.Spec.Resources, v1alpha1.ObjMetadata{
+			Namespace: "bookstore",
+			Name:      name,
+			GroupKind: v1alpha1.GroupKind{Kind: "ConfigMap"},
+		})
+	}
+	fakeClient := syncertest.NewClient(t, core.Scheme,
+		inventory,
+		configMap("updated", "old", core.Label(metadata.ManagedByKey, metadata.ManagedByValue)),
+		configMap("unchanged", "same", core.Label(metadata.ManagedByKey, metadata.ManagedByValue)),
+		configMap("pruned", "old"),
+		configMap("detached", "old", core.Annotation(common.LifecycleDeleteAnnotation, common.PreventDeletion)),
+		configMap("unmanaged", "old"),
+	)
+	objs := []ast.FileObject{
+		k8sobjects.FileObject(configMap("created", "new", declaredMeta), "bookstore/created.yaml"),
+		k8sobjects.FileObject(configMap("updated", "new", declaredMeta), "bookstore/updated.yaml"),
+		k8sobjects.FileObject(configMap("unchanged", "same", declaredMeta), "bookstore/unchanged.yaml"),
+		k8sobjects.FileObject(configMap("unmanaged", "new", declaredMeta,
+			core.Annotation(metadata.ManagementModeAnnotationKey, metadata.ManagementDisabled.String())), "bookstore/unmanaged.yaml"),
+	}
+
+	diffs, err := previewChanges(context.Background(), &applyClient{Client: fakeClient}, syncScope, syncName, objs)
+	require.NoError(t, err)
+
+	var got []string
+	for _, d := range diffs {
+		obj := d.Merged
+		if obj == nil {
+			obj = d.Live
+		}
+		got = append(got, string(d.Operation)+" "+obj.GetName())
+	}
+	assert.Equal(t, []string{"create created", "update updated", "prune pruned"}, got)
+}
+
+func TestPrintDiffs(t *testing.T) {
+	diffs := []objectDiff{
+		{
+			Operation: synchistory.Update,
+			Live:      cleanForDiff(configMap("updated", "old"), ""),
+			Merged:    cleanForDiff(configMap("updated", "new"), ""),
+		},
+		{
+			Operation: synchistory.Prune,
+			Live:      cleanForDiff(k8sobjects.UnstructuredObject(kinds.Namespace(), core.Name("old")), ""),
+		},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, printDiffs(&out, diffs))
+	assert.Equal(t, `diff -u -N live/v1.ConfigMap.bookstore.updated merged/v1.ConfigMap.bookstore.updated
+--- live/v1.ConfigMap.bookstore.updated
++++ merged/v1.ConfigMap.bookstore.updated
+@@ -1,6 +1,6 @@
+ apiVersion: v1
+ data:
+-  key: old
++  key: new
+ kind: ConfigMap
+ metadata:
+   name: updated
+diff -u -N live/v1.Namespace..old merged/v1.Namespace..old
+--- live/v1.Namespace..old
++++ merged/v1.Namespace..old
+@@ -1,4 +0,0 @@
+-apiVersion: v1
+-kind: Namespace
+-metadata:
+-  name: old
+`, out.String())
+}
@@ -34,6 +34,9 @@ const (
 	// contextsFlag is the flag name for the Contexts below.
 	contextsFlag = "contexts"
 
+	// contextFlag is the flag name for the Context below.
+	contextFlag = "context"
+
 	// clusterFlag is the flag name for the Clusters below.
 	clustersFlag = "clusters"
 
@@ -55,6 +58,10 @@ var (
 	// commands.
 	Contexts []string
 
+	// Context is the .kubeconfig context of the target cluster of single-cluster
+	// commands. The current context is used if empty.
+	Context string
+
 	// Clusters contains the list of Cluster names (specified in clusters/) to perform an action on.
 	Clusters []string
 
@@ -83,6 +90,12 @@ func AddContexts(cmd *cobra.Command) {
 		`Accepts a comma-separated list of contexts to use in multi-cluster commands. Defaults to all contexts. Use "" for no contexts.`)
 }
 
+// AddContext adds the --context flag.
+func AddContext(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&Context, contextFlag, "",
+		`The kubeconfig context of the target cluster. Defaults to the current context.`)
+}
+
 // AddClusters adds the --clusters flag.
 func AddClusters(cmd *cobra.Command) {
 	cmd.Flags().StringSliceVar(&Clusters, clustersFlag, nil,
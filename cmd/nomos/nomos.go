@@ -22,10 +22,12 @@ import (
 	"github.com/spf13/cobra"
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/cmd/nomos/bugreport"
+	"kpt.dev/configsync/cmd/nomos/diff"
 	"kpt.dev/configsync/cmd/nomos/hydrate"
 	"kpt.dev/configsync/cmd/nomos/initialize"
 	"kpt.dev/configsync/cmd/nomos/migrate"
 	"kpt.dev/configsync/cmd/nomos/status"
+	"kpt.dev/configsync/cmd/nomos/vendoring"
 	"kpt.dev/configsync/cmd/nomos/version"
 	"kpt.dev/configsync/cmd/nomos/vet"
 	"kpt.dev/configsync/pkg/api/configmanagement"
@@ -60,6 +62,8 @@ func init() {
 	rootCmd.AddCommand(status.Cmd)
 	rootCmd.AddCommand(bugreport.Cmd)
 	rootCmd.AddCommand(migrate.Cmd)
+	rootCmd.AddCommand(vendoring.Cmd)
+	rootCmd.AddCommand(diff.Cmd)
 }
 
 func main() {
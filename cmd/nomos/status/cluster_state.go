@@ -80,6 +80,7 @@ type RepoState struct {
 	git               *v1beta1.Git
 	oci               *v1beta1.Oci
 	helm              *v1beta1.HelmBase
+	archive           *v1beta1.Archive
 	status            string
 	commit            string
 	lastSyncTimestamp metav1.Time
@@ -90,7 +91,7 @@ type RepoState struct {
 }
 
 func (r *RepoState) printRows(writer io.Writer) {
-	util.MustFprintf(writer, "%s%s:%s\t%s\t\n", util.Indent, r.scope, r.syncName, sourceString(r.sourceType, r.git, r.oci, r.helm))
+	util.MustFprintf(writer, "%s%s:%s\t%s\t\n", util.Indent, r.scope, r.syncName, sourceString(r.sourceType, r.git, r.oci, r.helm, r.archive))
 	if r.status == syncedMsg {
 		util.MustFprintf(writer, "%s%s @ %v\t%s\t\n", util.Indent, r.status, r.lastSyncTimestamp, r.commit)
 	} else {
@@ -134,8 +135,10 @@ func (r *RepoState) printRows(writer io.Writer) {
 	}
 }
 
-func sourceString(sourceType configsync.SourceType, git *v1beta1.Git, oci *v1beta1.Oci, helm *v1beta1.HelmBase) string {
+func sourceString(sourceType configsync.SourceType, git *v1beta1.Git, oci *v1beta1.Oci, helm *v1beta1.HelmBase, archive *v1beta1.Archive) string {
 	switch sourceType {
+	case configsync.ArchiveSource:
+		return archiveString(archive)
 	case configsync.OciSource:
 		return ociString(oci)
 	case configsync.HelmSource:
@@ -183,6 +186,16 @@ func ociString(oci *v1beta1.Oci) string {
 	return ociStr
 }
 
+func archiveString(archive *v1beta1.Archive) string {
+	if archive == nil {
+		return "N/A"
+	}
+	if archive.Dir == "" || archive.Dir == "." || archive.Dir == "/" {
+		return archive.URL
+	}
+	return archive.URL + "/" + path.Clean(strings.TrimPrefix(archive.Dir, "/"))
+}
+
 func helmString(helm *v1beta1.HelmBase) string {
 	var helmStr string
 	if helm == nil {
@@ -312,6 +325,7 @@ func namespaceRepoStatus(rs *v1beta1.RepoSync, rg *unstructured.Unstructured, sy
 		git:        rs.Spec.Git,
 		oci:        rs.Spec.Oci,
 		helm:       reposync.GetHelmBase(rs.Spec.Helm),
+		archive:    rs.Spec.Archive,
 		commit:     emptyCommit,
 	}
 
@@ -414,6 +428,7 @@ func RootRepoStatus(rs *v1beta1.RootSync, rg *unstructured.Unstructured, syncing
 		git:        rs.Spec.Git,
 		oci:        rs.Spec.Oci,
 		helm:       rootsync.GetHelmBase(rs.Spec.Helm),
+		archive:    rs.Spec.Archive,
 		commit:     emptyCommit,
 	}
 	stalledCondition := rootsync.GetCondition(rs.Status.Conditions, v1beta1.RootSyncStalled)
@@ -0,0 +1,78 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vendoring
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"kpt.dev/configsync/cmd/nomos/flags"
+	"kpt.dev/configsync/pkg/hydrate"
+)
+
+var vendorDir string
+
+func init() {
+	flags.AddPath(Cmd)
+	Cmd.Flags().StringVar(&vendorDir, "vendor-dir", hydrate.DefaultVendorDir,
+		`Directory to copy the remote bases to, relative to --path.`)
+}
+
+// Cmd is the Cobra object representing the vendor command.
+var Cmd = &cobra.Command{
+	Use:   "vendor",
+	Short: "Vendors the remote bases and Helm charts of the kustomizations in the local repository.",
+	Long: `Vendors the remote bases and Helm charts of the kustomizations in the local repository.
+
+Each remote base is fetched at the commit its ref resolves to, copied to a
+directory of --vendor-dir named after the repository and the commit, and the
+kustomization is rewritten to reference the local copy. Helm charts downloaded
+by kustomize are pulled into the chart home of their kustomization.
+The vendored sources are recorded in vendor-lock.yaml in --vendor-dir.
+
+The vendored repository can be rendered with spec.override.rendering.hermetic,
+which rejects the remote sources fetched at render time.`,
+	Args: cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		// Don't show usage on error, as argument validation passed.
+		cmd.SilenceUsage = true
+
+		root, err := filepath.Abs(flags.Path)
+		if err != nil {
+			return err
+		}
+		if filepath.IsAbs(vendorDir) {
+			return fmt.Errorf("--vendor-dir must be relative to --path: %s", vendorDir)
+		}
+		vendorer := &hydrate.Vendorer{Root: root, VendorDir: vendorDir}
+		sources, err := vendorer.Vendor(cmd.Context())
+		if err != nil {
+			return err
+		}
+		if len(sources) == 0 {
+			fmt.Println("No remote sources to vendor")
+			return nil
+		}
+		for _, s := range sources {
+			pin := s.Commit
+			if pin == "" {
+				pin = s.Version
+			}
+			fmt.Printf("Vendored %s@%s to %s\n", s.URL, pin, s.Path)
+		}
+		return nil
+	},
+}
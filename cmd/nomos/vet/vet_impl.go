@@ -36,6 +36,7 @@ import (
 	"kpt.dev/configsync/pkg/parse"
 	"kpt.dev/configsync/pkg/reconcilermanager"
 	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/validate/policy"
 )
 
 type vetOptions struct {
@@ -78,6 +79,9 @@ func runVet(ctx context.Context, out io.Writer, opts vetOptions) error {
 		return err
 	}
 
+	// sourceDir is the directory before rendering, which still includes the
+	// ValidationPolicies.
+	sourceDir := rootDir
 	if needsHydrate {
 		// update rootDir to point to the hydrated output for further processing.
 		if rootDir, err = hydrate.ValidateAndRunKustomize(rootDir.OSPath()); err != nil {
@@ -129,6 +133,30 @@ func runVet(ctx context.Context, out io.Writer, opts vetOptions) error {
 		Files:     files,
 	}
 
+	var policies []*policy.Policy
+	if needsHydrate {
+		// Rendering drops the ValidationPolicies, since they are local
+		// configuration, so they are read from the source directory.
+		sourceFiles, err := nomosparse.FindFiles(sourceDir)
+		if err != nil {
+			return err
+		}
+		policies, err = policy.LoadSource(parser, reader.FilePaths{
+			RootDir:   sourceDir,
+			PolicyDir: cmpath.RelativeOS(sourceDir.OSPath()),
+			Files:     sourceFiles,
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		policies, err = policy.Load(parser, filePaths)
+		if err != nil {
+			return err
+		}
+	}
+	validateOpts.Visitors = append(validateOpts.Visitors, policy.Visitor(policies))
+
 	parseOpts := hydrate.ParseOptions{
 		Parser:       parser,
 		SourceFormat: sourceFormat,
@@ -44,6 +44,7 @@ import (
 	"kpt.dev/configsync/pkg/status"
 	"kpt.dev/configsync/pkg/syncer/client"
 	"kpt.dev/configsync/pkg/util/clusterconfig"
+	"kpt.dev/configsync/pkg/validate/policy"
 	"kpt.dev/configsync/pkg/validate/raw/validate"
 	rsyncvalidate "kpt.dev/configsync/pkg/validate/rsync/validate"
 	"kpt.dev/configsync/pkg/vet"
@@ -325,6 +326,21 @@ func Generate() AllExamples {
 	// 1070
 	result.add(system.MaxObjectCountError(system.DefaultMaxObjectCount, system.DefaultMaxObjectCount+1))
 
+	// 1071
+	result.add(validate.SchemaError(k8sobjects.Deployment("namespaces/foo"), errors.New(".spec.replicas: expected numeric (int or float), got string")))
+
+	// 1072
+	result.add(policy.ValidationPolicyViolationError(k8sobjects.Deployment("namespaces/foo"), "require-limits", "policies/require-limits.yaml", "every container must set resource limits"))
+
+	// 1073
+	result.add(policy.InvalidValidationPolicyError(k8sobjects.UnstructuredAtPath(policy.ValidationPolicyGVK, "policies/require-limits.yaml", core.Name("require-limits")), "spec.validations must not be empty"))
+
+	// 1074
+	result.add(nonhierarchical.IllegalSyncWaveAnnotationError(k8sobjects.Role(), "first"))
+
+	// 1075
+	result.add(nonhierarchical.IllegalHookAnnotationError(k8sobjects.Role(), csmetadata.HookPreSync.String()))
+
 	// 2001
 	result.add(status.PathWrapError(errors.New("error creating directory"), "namespaces/foo"))
 
@@ -384,6 +400,12 @@ func Generate() AllExamples {
 	// 2017
 	result.add(selectors.ListNamespaceError(errors.New("k8s api List error")))
 
+	// 2018
+	result.add(status.SourceVerificationError(errors.New("commit is not signed by a trusted key")))
+
+	// 2019
+	result.add(status.DryRunError(errors.New("admission webhook denied the request"), k8sobjects.NamespaceObject("shipping")))
+
 	// 9998
 	result.add(status.InternalError("we made a mistake"))
 
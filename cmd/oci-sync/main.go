@@ -54,6 +54,20 @@ var flOneTime = flag.Bool("one-time", util.EnvBool("OCI_SYNC_ONE_TIME", false),
 	"exit after the first sync")
 var flMaxSyncFailures = flag.Int("max-sync-failures", util.EnvInt("OCI_SYNC_MAX_SYNC_FAILURES", 0),
 	"the number of consecutive failures allowed before aborting (the first sync must succeed, -1 will retry forever after the initial sync)")
+var flVerificationKeysDir = flag.String("verification-keys-dir", util.EnvString(reconcilermanager.SourceVerificationKeysDirKey, ""),
+	"the directory of the trusted material used to verify the image signatures (defaults to \"\", disabling verification)")
+var flVerificationIdentity = flag.String("verification-identity", util.EnvString(reconcilermanager.OciSyncVerificationIdentity, ""),
+	"the expected identity of keyless image signatures (defaults to \"\", verifying signatures with the trusted public keys)")
+var flVerificationIssuer = flag.String("verification-issuer", util.EnvString(reconcilermanager.OciSyncVerificationIssuer, ""),
+	"the expected OIDC issuer of keyless image signatures")
+var flVerificationAttestations = flag.String("verification-attestations", util.EnvString(reconcilermanager.OciSyncVerificationAttestations, ""),
+	"the comma-separated predicate types that must be attested for the image by a trusted signer")
+var flTagSemver = flag.String("tag-semver", util.EnvString(reconcilermanager.OciSyncTagSemver, ""),
+	"the semantic version constraint selecting the highest matching tag of --image (defaults to \"\", pulling --image as specified)")
+var flTagRegex = flag.String("tag-regex", util.EnvString(reconcilermanager.OciSyncTagRegex, ""),
+	"the regular expression the selected tag of --image must match")
+var flTagLatest = flag.Bool("tag-latest", util.EnvBool(reconcilermanager.OciSyncTagLatest, false),
+	"select the most recently created tag of --image")
 
 func errorBackoff() wait.Backoff {
 	durationLimit := math.Max(*flWait, float64(util.MinimumSyncContainerBackoffCap))
@@ -67,7 +81,10 @@ func main() {
 	log.Info("pulling OCI image with arguments", "--image", *flImage,
 		"--auth", *flAuth, "--root", *flRoot, "--dest", *flDest, "--wait", *flWait,
 		"--error-file", *flErrorFile, "--timeout", *flSyncTimeout,
-		"--one-time", *flOneTime, "--max-sync-failures", *flMaxSyncFailures)
+		"--one-time", *flOneTime, "--max-sync-failures", *flMaxSyncFailures,
+		"--verification-keys-dir", *flVerificationKeysDir, "--verification-identity", *flVerificationIdentity,
+		"--verification-issuer", *flVerificationIssuer, "--verification-attestations", *flVerificationAttestations,
+		"--tag-semver", *flTagSemver, "--tag-regex", *flTagRegex, "--tag-latest", *flTagLatest)
 
 	if *flImage == "" {
 		utillog.HandleError(log, true, "ERROR: --image must be specified")
@@ -119,6 +136,29 @@ func main() {
 	fetcher := &oci.Fetcher{
 		Authenticator: authenticator,
 	}
+	if *flVerificationKeysDir != "" {
+		fetcher.Verifier = &oci.Verifier{
+			KeysDir:  *flVerificationKeysDir,
+			Identity: *flVerificationIdentity,
+			Issuer:   *flVerificationIssuer,
+		}
+		if *flVerificationAttestations != "" {
+			fetcher.Verifier.Attestations = strings.Split(*flVerificationAttestations, ",")
+		}
+	}
+	if *flTagSemver != "" || *flTagRegex != "" || *flTagLatest {
+		if imageFromSpecHasDigest {
+			utillog.HandleError(log, true, "ERROR: --image must not specify a digest with a tag policy")
+		}
+		fetcher.TagPolicy = &oci.TagPolicy{
+			Semver: *flTagSemver,
+			Regex:  *flTagRegex,
+			Latest: *flTagLatest,
+		}
+		if err := fetcher.TagPolicy.Validate(); err != nil {
+			utillog.HandleError(log, true, "ERROR: %v", err)
+		}
+	}
 
 	for {
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second*time.Duration(*flSyncTimeout))
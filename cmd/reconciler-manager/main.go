@@ -15,11 +15,15 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"io/fs"
 	"net/http"
 	"os"
+	"time"
 
 	traceapi "cloud.google.com/go/trace/apiv2"
 	"github.com/go-logr/logr"
@@ -38,11 +42,13 @@ import (
 	"kpt.dev/configsync/pkg/profiler"
 	"kpt.dev/configsync/pkg/reconcilermanager"
 	"kpt.dev/configsync/pkg/reconcilermanager/controllers"
+	"kpt.dev/configsync/pkg/reconcilermanager/sourcewebhook"
 	"kpt.dev/configsync/pkg/util/customresource"
 	"kpt.dev/configsync/pkg/util/log"
 	utilwatch "kpt.dev/configsync/pkg/util/watch"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -57,6 +63,13 @@ var (
 	hydrationPollingPeriod = flag.Duration("hydration-polling-period",
 		controllers.PollingPeriod(reconcilermanager.HydrationPollingPeriod, configsync.DefaultHydrationPollingPeriod),
 		"Period of time between checking the filesystem for source updates to render.")
+
+	sourceWebhookAddr = flag.String("source-webhook-addr", "",
+		"The address the source webhook receiver binds to. "+
+			"If set, pushes notified by Git providers trigger a fetch, in addition to polling.")
+
+	sourceWebhookSecretFile = flag.String("source-webhook-secret-file", "",
+		"Path of the file containing the secret used to authenticate source webhooks.")
 )
 
 func main() {
@@ -161,6 +174,27 @@ func main() {
 	})
 	setupLog.Info("RootSync controller registration scheduled")
 
+	if *sourceWebhookAddr != "" {
+		secret, err := readSourceWebhookSecret(*sourceWebhookSecretFile)
+		if err != nil {
+			setupLog.Error(err, "failed to add source webhook receiver")
+			os.Exit(1)
+		}
+		if secret == nil {
+			// The receiver is enabled by creating the secret, which is mounted
+			// as an optional volume.
+			setupLog.Info("Source webhook receiver disabled: the secret file does not exist", "file", *sourceWebhookSecretFile)
+		} else {
+			if err := addSourceWebhookReceiver(mgr, *sourceWebhookAddr, secret); err != nil {
+				setupLog.Error(err, "failed to add source webhook receiver")
+				os.Exit(1)
+			}
+			repoSyncController.EnableSourceWebhook()
+			rootSyncController.EnableSourceWebhook()
+			setupLog.Info("Source webhook receiver registration successful", "address", *sourceWebhookAddr)
+		}
+	}
+
 	otelCredentialProvider := &auth.CachingCredentialProvider{
 		Scopes: traceapi.DefaultAuthScopes(),
 	}
@@ -235,3 +269,50 @@ func fleetMembershipCRDExists(dc dynamic.Interface, mapper meta.RESTMapper,
 	}
 	return true
 }
+
+// readSourceWebhookSecret returns the secret used to authenticate source
+// webhooks, or nil if the secret file does not exist.
+func readSourceWebhookSecret(secretFile string) ([]byte, error) {
+	if secretFile == "" {
+		return nil, fmt.Errorf("--source-webhook-secret-file is required with --source-webhook-addr")
+	}
+	secret, err := os.ReadFile(secretFile)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading source webhook secret: %w", err)
+	}
+	secret = bytes.TrimSpace(secret)
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("source webhook secret file %q is empty", secretFile)
+	}
+	return secret, nil
+}
+
+// addSourceWebhookReceiver adds a runnable serving the source webhook receiver
+// until the manager stops.
+func addSourceWebhookReceiver(mgr ctrl.Manager, addr string, secret []byte) error {
+	server := &http.Server{
+		Addr: addr,
+		Handler: &sourcewebhook.Receiver{
+			Client: mgr.GetClient(),
+			Secret: secret,
+		},
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- server.ListenAndServe()
+		}()
+		select {
+		case err := <-errCh:
+			return fmt.Errorf("serving source webhooks: %w", err)
+		case <-ctx.Done():
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			return server.Shutdown(shutdownCtx)
+		}
+	}))
+}
@@ -15,6 +15,7 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
@@ -23,6 +24,7 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/klog/v2/textlogger"
 	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/importer/filesystem"
 	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
@@ -58,8 +60,18 @@ var (
 		"The branch of the git repo being synced.")
 	sourceRev = flag.String("source-rev", os.Getenv(reconcilermanager.SourceRevKey),
 		"The reference we're syncing to in the repo. Could be a specific commit or a chart version.")
+	sourceRevConstraint = flag.String("source-rev-constraint", os.Getenv(reconcilermanager.SourceRevConstraintKey),
+		"The semantic version constraint the git reference was resolved from, if any.")
 	syncDir = flag.String("sync-dir", os.Getenv(reconcilermanager.SyncDirKey),
 		"The relative path of the root configuration directory within the repo.")
+	sparseCheckout = flag.Bool("sparse-checkout", util.EnvBool(reconcilermanager.SparseCheckoutKey, false),
+		"Whether git-sync checks out only the sync directory and the Kustomize bases it references.")
+	sourceVerificationKeysDir = flag.String("source-verification-keys-dir", os.Getenv(reconcilermanager.SourceVerificationKeysDirKey),
+		"The absolute path of the directory containing the trusted public keys used to verify the source. Verification is disabled if empty.")
+	additionalSources = flag.String(flags.additionalSources, os.Getenv(reconcilermanager.AdditionalSourcesKey),
+		"The JSON-encoded list of the additional sources synced by a RootSync reconciler.")
+	rollout = flag.String(flags.rollout, os.Getenv(reconcilermanager.RolloutKey),
+		"The JSON-encoded rollout gate of a RootSync reconciler, which gates the sync of new commits on the health of a canary cluster group.")
 
 	// Performance tuning flags.
 	sourceDir = flag.String(flags.sourceDir, "/repo/source/rev",
@@ -104,6 +116,16 @@ var (
 	dynamicNSSelectorEnabled = flag.Bool("dynamic-ns-selector-enabled", util.EnvBool(reconcilermanager.DynamicNSSelectorEnabled, false), "")
 
 	webhookEnabled       = flag.Bool("webhook-enabled", util.EnvBool(reconcilermanager.WebhookEnabled, false), "")
+	sourceWebhookEnabled = flag.Bool("source-webhook-enabled", util.EnvBool(reconcilermanager.SourceWebhookEnabled, false),
+		"Whether the source webhook receiver of the reconciler-manager notifies the reconciler of source changes.")
+	serverSideDryRun = flag.Bool("server-side-dry-run", util.EnvBool(reconcilermanager.ServerSideDryRun, false),
+		"Whether to validate the changed objects with a server-side dry-run before applying them.")
+	schemaValidation = flag.Bool("schema-validation", util.EnvBool(reconcilermanager.SchemaValidation, false),
+		"Whether to validate the declared objects against the OpenAPI schemas of their types.")
+	driftReportMode = flag.Bool("drift-report-mode", util.EnvBool(reconcilermanager.DriftReportMode, false),
+		"Whether to report the drift of the managed objects on the ResourceGroup status, instead of reverting it.")
+	ignoreDifferences = flag.String("ignore-differences", os.Getenv(reconcilermanager.IgnoreDifferencesKey),
+		"The JSON-encoded list of the fields of the managed objects whose differences from the declared state are ignored.")
 	reconcilerSignalsDir = flag.String(flags.reconcilerSignalDir, "/reconciler-signals",
 		"The absolute path in the container that contains reconciler signals that unblock the rendering phase, for example, the latest image digest that is ready to render.")
 )
@@ -118,6 +140,8 @@ var flags = struct {
 	statusMode          string
 	reconcileTimeout    string
 	namespaceStrategy   string
+	additionalSources   string
+	rollout             string
 }{
 	repoRootDir:         "repo-root",
 	sourceDir:           "source-dir",
@@ -128,6 +152,8 @@ var flags = struct {
 	statusMode:          "status-mode",
 	reconcileTimeout:    "reconcile-timeout",
 	namespaceStrategy:   "namespace-strategy",
+	additionalSources:   "additional-sources",
+	rollout:             "rollout",
 }
 
 func main() {
@@ -189,33 +215,46 @@ func main() {
 	}
 
 	opts := reconciler.Options{
-		Logger:                   logger,
-		ClusterName:              *clusterName,
-		FightDetectionThreshold:  *fightDetectionThreshold,
-		NumWorkers:               *workers,
-		ReconcilerScope:          scope,
-		FullSyncPeriod:           *fullSyncPeriod,
-		PollingPeriod:            *pollingPeriod,
-		RetryPeriod:              configsync.DefaultReconcilerRetryPeriod,
-		StatusUpdatePeriod:       configsync.DefaultReconcilerSyncStatusUpdatePeriod,
-		SourceRoot:               absSourceDir,
-		RepoRoot:                 absRepoRoot,
-		HydratedRoot:             *hydratedRootDir,
-		HydratedLink:             *hydratedLinkDir,
-		SourceRev:                *sourceRev,
-		SourceBranch:             *sourceBranch,
-		SourceType:               configsync.SourceType(*sourceType),
-		SourceRepo:               *sourceRepo,
-		SyncDir:                  relSyncDir,
-		SyncName:                 *syncName,
-		ReconcilerName:           *reconcilerName,
-		StatusMode:               metadata.StatusMode(*statusMode),
-		ReconcileTimeout:         *reconcileTimeout,
-		APIServerTimeout:         *apiServerTimeout,
-		RenderingEnabled:         *renderingEnabled,
-		DynamicNSSelectorEnabled: *dynamicNSSelectorEnabled,
-		WebhookEnabled:           *webhookEnabled,
-		ReconcilerSignalsDir:     absReconcilerSignalDir,
+		Logger:                    logger,
+		ClusterName:               *clusterName,
+		FightDetectionThreshold:   *fightDetectionThreshold,
+		NumWorkers:                *workers,
+		ReconcilerScope:           scope,
+		FullSyncPeriod:            *fullSyncPeriod,
+		PollingPeriod:             *pollingPeriod,
+		RetryPeriod:               configsync.DefaultReconcilerRetryPeriod,
+		StatusUpdatePeriod:        configsync.DefaultReconcilerSyncStatusUpdatePeriod,
+		SourceRoot:                absSourceDir,
+		RepoRoot:                  absRepoRoot,
+		HydratedRoot:              *hydratedRootDir,
+		HydratedLink:              *hydratedLinkDir,
+		SourceRev:                 *sourceRev,
+		SourceRevConstraint:       *sourceRevConstraint,
+		SourceBranch:              *sourceBranch,
+		SourceType:                configsync.SourceType(*sourceType),
+		SourceRepo:                *sourceRepo,
+		SourceVerificationKeysDir: *sourceVerificationKeysDir,
+		SyncDir:                   relSyncDir,
+		SparseCheckout:            *sparseCheckout,
+		SyncName:                  *syncName,
+		ReconcilerName:            *reconcilerName,
+		StatusMode:                metadata.StatusMode(*statusMode),
+		ReconcileTimeout:          *reconcileTimeout,
+		APIServerTimeout:          *apiServerTimeout,
+		RenderingEnabled:          *renderingEnabled,
+		DynamicNSSelectorEnabled:  *dynamicNSSelectorEnabled,
+		WebhookEnabled:            *webhookEnabled,
+		SourceWebhookEnabled:      *sourceWebhookEnabled,
+		ServerSideDryRun:          *serverSideDryRun,
+		SchemaValidation:          *schemaValidation,
+		DriftReportMode:           *driftReportMode,
+		ReconcilerSignalsDir:      absReconcilerSignalDir,
+	}
+
+	if *ignoreDifferences != "" {
+		if err := json.Unmarshal([]byte(*ignoreDifferences), &opts.IgnoreDifferences); err != nil {
+			klog.Fatalf("Invalid ignored differences %q: %v", *ignoreDifferences, err)
+		}
 	}
 
 	if scope == declared.RootScope {
@@ -230,10 +269,27 @@ func main() {
 			nsStrat = configsync.NamespaceStrategyImplicit
 		}
 
+		var sources []reconcilermanager.AdditionalSource
+		if *additionalSources != "" {
+			if err := json.Unmarshal([]byte(*additionalSources), &sources); err != nil {
+				klog.Fatalf("Invalid additional sources %q: %v", *additionalSources, err)
+			}
+		}
+
+		var rolloutSpec *v1beta1.RolloutSpec
+		if *rollout != "" {
+			rolloutSpec = &v1beta1.RolloutSpec{}
+			if err := json.Unmarshal([]byte(*rollout), rolloutSpec); err != nil {
+				klog.Fatalf("Invalid rollout gate %q: %v", *rollout, err)
+			}
+		}
+
 		klog.Info("Starting reconciler for: root")
 		opts.RootOptions = &reconciler.RootOptions{
 			SourceFormat:      format,
 			NamespaceStrategy: nsStrat,
+			AdditionalSources: sources,
+			Rollout:           rolloutSpec,
 		}
 	} else {
 		klog.Infof("Starting reconciler for: %s", scope)
@@ -246,6 +302,14 @@ func main() {
 			klog.Fatalf("Flag %s and environment variable %s must not be passed to a Namespace reconciler",
 				flags.namespaceStrategy, reconcilermanager.NamespaceStrategy)
 		}
+		if *additionalSources != "" {
+			klog.Fatalf("Flag %s and environment variable %s must not be passed to a Namespace reconciler",
+				flags.additionalSources, reconcilermanager.AdditionalSourcesKey)
+		}
+		if *rollout != "" {
+			klog.Fatalf("Flag %s and environment variable %s must not be passed to a Namespace reconciler",
+				flags.rollout, reconcilermanager.RolloutKey)
+		}
 	}
 	reconciler.Run(opts)
 }
@@ -185,7 +185,7 @@ func statusHasSyncCommitAndNoErrors(status v1beta1.Status, commit string) error
 	if renderCommit := status.Rendering.Commit; renderCommit != commit {
 		return fmt.Errorf("status.rendering.commit %q does not match git revision %q", renderCommit, commit)
 	}
-	if message := status.Rendering.Message; message != parse.RenderingSucceeded && message != parse.RenderingSkipped {
+	if message := status.Rendering.Message; message != parse.RenderingSucceeded && message != parse.RenderingSkipped && message != parse.RenderingCached {
 		return fmt.Errorf("status.rendering.message %q does not indicate a successful state", message)
 	}
 	if lastSyncedCommit := status.LastSyncedCommit; lastSyncedCommit != commit {
@@ -204,7 +204,7 @@ func statusHasSyncDirAndNoErrors(status v1beta1.Status, sourceType configsync.So
 	if status.Rendering.ErrorSummary != nil && status.Rendering.ErrorSummary.TotalCount > 0 {
 		return fmt.Errorf("status.rendering contains %d errors", status.Rendering.ErrorSummary.TotalCount)
 	}
-	if message := status.Rendering.Message; message != parse.RenderingSucceeded && message != parse.RenderingSkipped {
+	if message := status.Rendering.Message; message != parse.RenderingSucceeded && message != parse.RenderingSkipped && message != parse.RenderingCached {
 		return fmt.Errorf("status.rendering.message %q does not indicate a successful state", message)
 	}
 	switch sourceType {
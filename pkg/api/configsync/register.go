@@ -118,6 +118,10 @@ const (
 
 	// HelmSource represents the source type is Helm repository.
 	HelmSource SourceType = "helm"
+
+	// ArchiveSource represents the source type is a tarball served over
+	// HTTP(S), e.g. from an S3-compatible bucket.
+	ArchiveSource SourceType = "archive"
 )
 
 // AuthType specifies the type to authenticate to a repository.
@@ -142,6 +146,23 @@ const (
 	AuthK8sServiceAccount AuthType = "k8sserviceaccount"
 	// AuthGithubApp indicates using a GitHub app to authenticate with Git.
 	AuthGithubApp AuthType = "githubapp"
+	// AuthHMAC indicates using an HMAC access key to sign the requests to an
+	// S3-compatible bucket. It only applies to archives.
+	AuthHMAC AuthType = "hmac"
+)
+
+// GitSubmodules specifies how the submodules of a Git repository are fetched.
+type GitSubmodules string
+
+const (
+	// GitSubmodulesOff indicates that the submodules are not fetched.
+	GitSubmodulesOff GitSubmodules = "off"
+	// GitSubmodulesShallow indicates that the submodules of the repository are
+	// fetched, but not their own submodules.
+	GitSubmodulesShallow GitSubmodules = "shallow"
+	// GitSubmodulesRecursive indicates that the submodules are fetched
+	// recursively. Default
+	GitSubmodulesRecursive GitSubmodules = "recursive"
 )
 
 // NamespaceStrategy specifies the strategy used by the reconciler for undeclared
@@ -156,3 +177,16 @@ const (
 	// declared to be created by the reconciler.
 	NamespaceStrategyExplicit NamespaceStrategy = "explicit"
 )
+
+// DriftMode specifies how the reconciler handles drift, i.e. changes made to
+// the managed objects on the cluster.
+type DriftMode string
+
+const (
+	// DriftModeEnforce indicates that the reconciler should revert the drift.
+	// Default
+	DriftModeEnforce DriftMode = "enforce"
+	// DriftModeReport indicates that the reconciler should only report the
+	// drift on the ResourceGroup status, without reverting it.
+	DriftModeReport DriftMode = "report"
+)
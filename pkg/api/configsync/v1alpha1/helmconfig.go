@@ -120,6 +120,35 @@ type HelmBase struct {
 	// +nullable
 	// +optional
 	CACertSecretRef *SecretReference `json:"caCertSecretRef,omitempty"`
+
+	// verify specifies how to verify the provenance of the chart.
+	// When set, charts which are not signed by a trusted signer are not
+	// rendered. Charts from HTTP(S) repositories must have a provenance file
+	// (.prov) signed with GPG. Charts from OCI registries must be signed with
+	// cosign.
+	// +nullable
+	// +optional
+	Verify *HelmVerification `json:"verify,omitempty"`
+}
+
+// HelmVerification contains the configs to verify the provenance of Helm
+// charts.
+type HelmVerification struct {
+	// keyringSecretRef specifies the name of the secret where the trusted keys
+	// are stored. For charts from HTTP(S) repositories, each key in the secret
+	// data contains a binary GPG public keyring, as exported by `gpg --export`. For charts
+	// from OCI registries, the secret contains the cosign trusted material, in
+	// the same format as spec.oci.verification.trustedKeysSecretRef.
+	// For RepoSync resources, the secret must be created in the same namespace
+	// as the RepoSync. For RootSync resource, the secret must be created in the
+	// config-management-system namespace.
+	KeyringSecretRef *SecretReference `json:"keyringSecretRef"`
+
+	// keyless specifies the identity constraints of keyless cosign signatures.
+	// Only supported for charts from OCI registries.
+	// +nullable
+	// +optional
+	Keyless *OciKeylessVerification `json:"keyless,omitempty"`
 }
 
 // ValuesFileRef references a ConfigMap object that contains a values file to use for
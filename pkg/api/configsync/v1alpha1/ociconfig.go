@@ -27,6 +27,7 @@ type Oci struct {
 	// - Pull by tag: `LOCATION-docker.pkg.dev/PROJECT_ID/REPOSITORY_NAME/PACKAGE_NAME:TAG`.
 	// - Pull by digest: `LOCATION-docker.pkg.dev/PROJECT_ID/REPOSITORY_NAME/PACKAGE_NAME@sha256:DIGEST`.
 	// If neither TAG nor DIGEST is specified, it pulls with the `latest` tag by default.
+	// When tagPolicy is set, the image must not specify a TAG or DIGEST.
 	// Required
 	Image string `json:"image"`
 
@@ -64,4 +65,79 @@ type Oci struct {
 	// +nullable
 	// +optional
 	CACertSecretRef *SecretReference `json:"caCertSecretRef,omitempty"`
+
+	// verification specifies how to verify the cosign signatures of the image.
+	// When set, oci-sync refuses to sync an image that is not signed by a
+	// trusted signer.
+	// +nullable
+	// +optional
+	Verification *OciVerification `json:"verification,omitempty"`
+
+	// tagPolicy specifies how to select the tag of the image to sync, among
+	// the tags of the image repository. The tags are listed on each sync, so
+	// new artifacts are rolled out without editing the image.
+	// +nullable
+	// +optional
+	TagPolicy *OciTagPolicy `json:"tagPolicy,omitempty"`
+}
+
+// OciTagPolicy specifies how to select the tag of an OCI image.
+// Exactly one of semver or latest must be set.
+type OciTagPolicy struct {
+	// semver is a semantic version constraint, e.g. `>=1.4.0 <2.0.0` or `~1.4`.
+	// The highest tag matching the constraint is synced. Tags which are not
+	// semantic versions, with or without a `v` prefix, are ignored.
+	// +optional
+	Semver string `json:"semver,omitempty"`
+
+	// regex is a regular expression the tags must match to be selected,
+	// e.g. `^main-[0-9a-f]+$`.
+	// +optional
+	Regex string `json:"regex,omitempty"`
+
+	// latest selects the most recently pushed tag, as recorded by the creation
+	// time of the image, among the tags matching regex, which is required.
+	// At most 50 tags may match.
+	// +optional
+	Latest bool `json:"latest,omitempty"`
+}
+
+// OciVerification contains the configs to verify the cosign signatures and
+// attestations of OCI images.
+type OciVerification struct {
+	// trustedKeysSecretRef specifies the name of the secret where the trusted
+	// material is stored. For key-based verification, each key in the secret
+	// data contains a PEM-encoded cosign public key. For keyless verification,
+	// the secret contains the PEM-encoded Fulcio root and intermediate
+	// certificates, the PEM-encoded Rekor public keys in a key named
+	// "rekor.pub", and the PEM-encoded certificate transparency log public
+	// keys in a key named "ctfe.pub". For RepoSync resources, the secret must
+	// be created in the same namespace as the RepoSync. For RootSync resource,
+	// the secret must be created in the config-management-system namespace.
+	TrustedKeysSecretRef *SecretReference `json:"trustedKeysSecretRef"`
+
+	// keyless specifies the identity constraints of keyless signatures.
+	// When set, the image must be signed with a Fulcio certificate issued to
+	// the specified identity. Otherwise, it must be signed with one of the
+	// trusted public keys.
+	// +nullable
+	// +optional
+	Keyless *OciKeylessVerification `json:"keyless,omitempty"`
+
+	// attestations is the list of in-toto predicate types that must be
+	// attested by a trusted signer, e.g. `https://slsa.dev/provenance/v1`.
+	// +optional
+	Attestations []string `json:"attestations,omitempty"`
+}
+
+// OciKeylessVerification contains the identity constraints of keyless
+// signatures.
+type OciKeylessVerification struct {
+	// identity is the subject alternative name of the signing certificate,
+	// e.g. an email address or a workflow URI. Required.
+	Identity string `json:"identity"`
+
+	// issuer is the OIDC issuer of the signing identity,
+	// e.g. `https://accounts.google.com`. Required.
+	Issuer string `json:"issuer"`
 }
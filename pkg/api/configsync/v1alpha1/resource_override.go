@@ -68,6 +68,44 @@ type OverrideSpec struct {
 	// +optional
 	EnableShellInRendering *bool `json:"enableShellInRendering,omitempty"`
 
+	// rendering allows one to override the settings of the rendering process.
+	// +optional
+	Rendering *RenderingOverride `json:"rendering,omitempty"`
+
+	// serverSideDryRun specifies whether to validate the changed objects with a server-side dry-run
+	// before applying them. Default: false.
+	// If set to true, the objects which are new or changed since the last successful apply are validated
+	// by the API server and the admission webhooks first. If any of them is rejected, none of the
+	// objects are applied, and the errors are reported in the sync status.
+	// +optional
+	ServerSideDryRun *bool `json:"serverSideDryRun,omitempty"`
+
+	// schemaValidation specifies whether to validate the declared objects against the OpenAPI schemas
+	// of their types before applying them. Default: false.
+	// If set to true, the objects with fields which are unknown or have the wrong type are rejected
+	// with a KNV1071 error, instead of being applied. Otherwise, the API server prunes the unknown fields.
+	// +optional
+	SchemaValidation *bool `json:"schemaValidation,omitempty"`
+
+	// driftMode specifies how the reconciler handles changes made to the managed objects on the cluster.
+	// Must be "enforce" or "report". Default: "enforce".
+	// "enforce" means that the reconciler reverts the changes as soon as they are detected.
+	// "report" means that the reconciler does not revert the changes between syncs, but lists the
+	// drifted objects and their differing fields in the ResourceGroup status, so they can be audited
+	// before enforcing. The drifted fields keep their values, and the deleted objects are not
+	// recreated, when the objects are applied again, including when a new commit is synced.
+	//
+	// +kubebuilder:validation:Enum=enforce;report
+	// +optional
+	DriftMode configsync.DriftMode `json:"driftMode,omitempty"`
+
+	// ignoreDifferences lists fields of the managed objects whose differences from the declared
+	// state are ignored, e.g. `spec.replicas` of a Deployment scaled by a HorizontalPodAutoscaler.
+	// The remediator neither reverts nor reports changes to these fields, and the admission
+	// webhook does not protect them.
+	// +optional
+	IgnoreDifferences []IgnoreDifference `json:"ignoreDifferences,omitempty"`
+
 	// logLevels specify the container name and log level override value for the reconciler deployment container.
 	// Each entry must contain the name of the reconciler deployment container and the desired log level.
 	// +listType=map
@@ -76,6 +114,52 @@ type OverrideSpec struct {
 	LogLevels []ContainerLogLevelOverride `json:"logLevels,omitempty"`
 }
 
+// IgnoreDifference selects fields of the managed objects whose differences
+// from the declared state are ignored.
+type IgnoreDifference struct {
+	// group of the selected objects. Empty for the core group.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// kind of the selected objects. Required.
+	Kind string `json:"kind"`
+
+	// name of the selected objects. All the objects of the kind are selected if empty.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// namespace of the selected objects. Objects in all the namespaces are selected if empty.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// jsonPaths lists the paths of the ignored fields, in the JSONPath notation.
+	// Supports field names, quoted keys, list indexes and wildcards, e.g. ".spec.replicas",
+	// ".metadata.annotations['example.com/hash']" or ".spec.template.spec.containers[*].image".
+	// +optional
+	JSONPaths []string `json:"jsonPaths,omitempty"`
+
+	// managedFieldsManagers lists field managers, e.g. "kube-controller-manager".
+	// The fields they own on the cluster are ignored.
+	// +optional
+	ManagedFieldsManagers []string `json:"managedFieldsManagers,omitempty"`
+}
+
+// RenderingOverride allows to override the settings of the rendering process
+type RenderingOverride struct {
+	// hermetic specifies whether to render the configs without fetching remote sources. Default: false.
+	// If set to true, the rendering fails if a kustomization references remote bases or Helm charts
+	// which are not vendored in the repository. Use `nomos vendor` to vendor them.
+	// +optional
+	Hermetic *bool `json:"hermetic,omitempty"`
+
+	// allowExecFunctions specifies whether the Kptfile pipelines may run exec functions. Default: false.
+	// Only the function binaries shipped in the hydration-controller image, under /kpt-functions, are run.
+	// Binaries stored in the repository are never run. WASM functions run in a sandbox, and are
+	// allowed regardless of this setting.
+	// +optional
+	AllowExecFunctions *bool `json:"allowExecFunctions,omitempty"`
+}
+
 // RootSyncOverrideSpec allows to override the settings for a RootSync reconciler pod
 type RootSyncOverrideSpec struct {
 	OverrideSpec `json:",inline"`
@@ -132,9 +216,9 @@ type RepoSyncOverrideSpec struct {
 // ContainerResourcesSpec allows to override the resource requirements for a container
 type ContainerResourcesSpec struct {
 	// containerName specifies the name of a container whose resource requirements will be overridden.
-	// Must be "reconciler", "git-sync", "hydration-controller", "oci-sync", or "helm-sync".
+	// Must be "reconciler", "git-sync", "hydration-controller", "oci-sync", "helm-sync", or "archive-sync".
 	//
-	// +kubebuilder:validation:Pattern=^(reconciler|git-sync|hydration-controller|oci-sync|helm-sync|gcenode-askpass-sidecar|otel-agent)$
+	// +kubebuilder:validation:Pattern=^(reconciler|git-sync|hydration-controller|oci-sync|helm-sync|archive-sync|gcenode-askpass-sidecar|otel-agent)$
 	// +optional
 	ContainerName string `json:"containerName,omitempty"`
 	// cpuRequest allows one to override the CPU request of a container
@@ -154,10 +238,10 @@ type ContainerResourcesSpec struct {
 // ContainerLogLevelOverride specifies the container name and log level override value
 type ContainerLogLevelOverride struct {
 	// containerName specifies the name of the reconciler deployment container for which log level will be overridden.
-	// Must be one of the following: "reconciler", "git-sync", "hydration-controller", "oci-sync", or "helm-sync".
+	// Must be one of the following: "reconciler", "git-sync", "hydration-controller", "oci-sync", "helm-sync", or "archive-sync".
 	//
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Pattern=^(reconciler|git-sync|hydration-controller|oci-sync|helm-sync|gcenode-askpass-sidecar|otel-agent)$
+	// +kubebuilder:validation:Pattern=^(reconciler|git-sync|hydration-controller|oci-sync|helm-sync|archive-sync|gcenode-askpass-sidecar|otel-agent)$
 	ContainerName string `json:"containerName"`
 
 	// logLevel specifies the verbosity level of the logging for a specific container.
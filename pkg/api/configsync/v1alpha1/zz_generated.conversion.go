@@ -23,6 +23,36 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*AdditionalSourceStatus)(nil), (*v1beta1.AdditionalSourceStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_AdditionalSourceStatus_To_v1beta1_AdditionalSourceStatus(a.(*AdditionalSourceStatus), b.(*v1beta1.AdditionalSourceStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.AdditionalSourceStatus)(nil), (*AdditionalSourceStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_AdditionalSourceStatus_To_v1alpha1_AdditionalSourceStatus(a.(*v1beta1.AdditionalSourceStatus), b.(*AdditionalSourceStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Archive)(nil), (*v1beta1.Archive)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Archive_To_v1beta1_Archive(a.(*Archive), b.(*v1beta1.Archive), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.Archive)(nil), (*Archive)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_Archive_To_v1alpha1_Archive(a.(*v1beta1.Archive), b.(*Archive), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ArchiveStatus)(nil), (*v1beta1.ArchiveStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ArchiveStatus_To_v1beta1_ArchiveStatus(a.(*ArchiveStatus), b.(*v1beta1.ArchiveStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.ArchiveStatus)(nil), (*ArchiveStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ArchiveStatus_To_v1alpha1_ArchiveStatus(a.(*v1beta1.ArchiveStatus), b.(*ArchiveStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ConfigSyncError)(nil), (*v1beta1.ConfigSyncError)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ConfigSyncError_To_v1beta1_ConfigSyncError(a.(*ConfigSyncError), b.(*v1beta1.ConfigSyncError), scope)
 	}); err != nil {
@@ -73,6 +103,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*GitHelm)(nil), (*v1beta1.GitHelm)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_GitHelm_To_v1beta1_GitHelm(a.(*GitHelm), b.(*v1beta1.GitHelm), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.GitHelm)(nil), (*GitHelm)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_GitHelm_To_v1alpha1_GitHelm(a.(*v1beta1.GitHelm), b.(*GitHelm), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*GitStatus)(nil), (*v1beta1.GitStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_GitStatus_To_v1beta1_GitStatus(a.(*GitStatus), b.(*v1beta1.GitStatus), scope)
 	}); err != nil {
@@ -83,6 +123,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*GitSubmoduleStatus)(nil), (*v1beta1.GitSubmoduleStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_GitSubmoduleStatus_To_v1beta1_GitSubmoduleStatus(a.(*GitSubmoduleStatus), b.(*v1beta1.GitSubmoduleStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.GitSubmoduleStatus)(nil), (*GitSubmoduleStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_GitSubmoduleStatus_To_v1alpha1_GitSubmoduleStatus(a.(*v1beta1.GitSubmoduleStatus), b.(*GitSubmoduleStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*GitVerification)(nil), (*v1beta1.GitVerification)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_GitVerification_To_v1beta1_GitVerification(a.(*GitVerification), b.(*v1beta1.GitVerification), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.GitVerification)(nil), (*GitVerification)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_GitVerification_To_v1alpha1_GitVerification(a.(*v1beta1.GitVerification), b.(*GitVerification), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*HelmBase)(nil), (*v1beta1.HelmBase)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_HelmBase_To_v1beta1_HelmBase(a.(*HelmBase), b.(*v1beta1.HelmBase), scope)
 	}); err != nil {
@@ -113,6 +173,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*HelmVerification)(nil), (*v1beta1.HelmVerification)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_HelmVerification_To_v1beta1_HelmVerification(a.(*HelmVerification), b.(*v1beta1.HelmVerification), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.HelmVerification)(nil), (*HelmVerification)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_HelmVerification_To_v1alpha1_HelmVerification(a.(*v1beta1.HelmVerification), b.(*HelmVerification), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*HookStatus)(nil), (*v1beta1.HookStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_HookStatus_To_v1beta1_HookStatus(a.(*HookStatus), b.(*v1beta1.HookStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.HookStatus)(nil), (*HookStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_HookStatus_To_v1alpha1_HookStatus(a.(*v1beta1.HookStatus), b.(*HookStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*IgnoreDifference)(nil), (*v1beta1.IgnoreDifference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_IgnoreDifference_To_v1beta1_IgnoreDifference(a.(*IgnoreDifference), b.(*v1beta1.IgnoreDifference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.IgnoreDifference)(nil), (*IgnoreDifference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_IgnoreDifference_To_v1alpha1_IgnoreDifference(a.(*v1beta1.IgnoreDifference), b.(*IgnoreDifference), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Oci)(nil), (*v1beta1.Oci)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_Oci_To_v1beta1_Oci(a.(*Oci), b.(*v1beta1.Oci), scope)
 	}); err != nil {
@@ -123,6 +213,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*OciKeylessVerification)(nil), (*v1beta1.OciKeylessVerification)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_OciKeylessVerification_To_v1beta1_OciKeylessVerification(a.(*OciKeylessVerification), b.(*v1beta1.OciKeylessVerification), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.OciKeylessVerification)(nil), (*OciKeylessVerification)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OciKeylessVerification_To_v1alpha1_OciKeylessVerification(a.(*v1beta1.OciKeylessVerification), b.(*OciKeylessVerification), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*OciStatus)(nil), (*v1beta1.OciStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_OciStatus_To_v1beta1_OciStatus(a.(*OciStatus), b.(*v1beta1.OciStatus), scope)
 	}); err != nil {
@@ -133,6 +233,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*OciTagPolicy)(nil), (*v1beta1.OciTagPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_OciTagPolicy_To_v1beta1_OciTagPolicy(a.(*OciTagPolicy), b.(*v1beta1.OciTagPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.OciTagPolicy)(nil), (*OciTagPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OciTagPolicy_To_v1alpha1_OciTagPolicy(a.(*v1beta1.OciTagPolicy), b.(*OciTagPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OciVerification)(nil), (*v1beta1.OciVerification)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_OciVerification_To_v1beta1_OciVerification(a.(*OciVerification), b.(*v1beta1.OciVerification), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.OciVerification)(nil), (*OciVerification)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OciVerification_To_v1alpha1_OciVerification(a.(*v1beta1.OciVerification), b.(*OciVerification), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*OverrideSpec)(nil), (*v1beta1.OverrideSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_OverrideSpec_To_v1beta1_OverrideSpec(a.(*OverrideSpec), b.(*v1beta1.OverrideSpec), scope)
 	}); err != nil {
@@ -143,6 +263,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*RenderingOverride)(nil), (*v1beta1.RenderingOverride)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RenderingOverride_To_v1beta1_RenderingOverride(a.(*RenderingOverride), b.(*v1beta1.RenderingOverride), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.RenderingOverride)(nil), (*RenderingOverride)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_RenderingOverride_To_v1alpha1_RenderingOverride(a.(*v1beta1.RenderingOverride), b.(*RenderingOverride), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*RenderingStatus)(nil), (*v1beta1.RenderingStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_RenderingStatus_To_v1beta1_RenderingStatus(a.(*RenderingStatus), b.(*v1beta1.RenderingStatus), scope)
 	}); err != nil {
@@ -223,6 +353,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*RolloutConfigMapRef)(nil), (*v1beta1.RolloutConfigMapRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RolloutConfigMapRef_To_v1beta1_RolloutConfigMapRef(a.(*RolloutConfigMapRef), b.(*v1beta1.RolloutConfigMapRef), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.RolloutConfigMapRef)(nil), (*RolloutConfigMapRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_RolloutConfigMapRef_To_v1alpha1_RolloutConfigMapRef(a.(*v1beta1.RolloutConfigMapRef), b.(*RolloutConfigMapRef), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RolloutSpec)(nil), (*v1beta1.RolloutSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RolloutSpec_To_v1beta1_RolloutSpec(a.(*RolloutSpec), b.(*v1beta1.RolloutSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.RolloutSpec)(nil), (*RolloutSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_RolloutSpec_To_v1alpha1_RolloutSpec(a.(*v1beta1.RolloutSpec), b.(*RolloutSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*RootSync)(nil), (*v1beta1.RootSync)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_RootSync_To_v1beta1_RootSync(a.(*RootSync), b.(*v1beta1.RootSync), scope)
 	}); err != nil {
@@ -273,6 +423,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*RootSyncSource)(nil), (*v1beta1.RootSyncSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RootSyncSource_To_v1beta1_RootSyncSource(a.(*RootSyncSource), b.(*v1beta1.RootSyncSource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.RootSyncSource)(nil), (*RootSyncSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_RootSyncSource_To_v1alpha1_RootSyncSource(a.(*v1beta1.RootSyncSource), b.(*RootSyncSource), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*RootSyncSpec)(nil), (*v1beta1.RootSyncSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_RootSyncSpec_To_v1beta1_RootSyncSpec(a.(*RootSyncSpec), b.(*v1beta1.RootSyncSpec), scope)
 	}); err != nil {
@@ -333,6 +493,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*SyncWaveStatus)(nil), (*v1beta1.SyncWaveStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SyncWaveStatus_To_v1beta1_SyncWaveStatus(a.(*SyncWaveStatus), b.(*v1beta1.SyncWaveStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.SyncWaveStatus)(nil), (*SyncWaveStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_SyncWaveStatus_To_v1alpha1_SyncWaveStatus(a.(*v1beta1.SyncWaveStatus), b.(*SyncWaveStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ValuesFileRef)(nil), (*v1beta1.ValuesFileRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ValuesFileRef_To_v1beta1_ValuesFileRef(a.(*ValuesFileRef), b.(*v1beta1.ValuesFileRef), scope)
 	}); err != nil {
@@ -356,6 +526,88 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha1_AdditionalSourceStatus_To_v1beta1_AdditionalSourceStatus(in *AdditionalSourceStatus, out *v1beta1.AdditionalSourceStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Git = (*v1beta1.GitStatus)(unsafe.Pointer(in.Git))
+	out.Oci = (*v1beta1.OciStatus)(unsafe.Pointer(in.Oci))
+	out.Helm = (*v1beta1.HelmStatus)(unsafe.Pointer(in.Helm))
+	out.Commit = in.Commit
+	return nil
+}
+
+// Convert_v1alpha1_AdditionalSourceStatus_To_v1beta1_AdditionalSourceStatus is an autogenerated conversion function.
+func Convert_v1alpha1_AdditionalSourceStatus_To_v1beta1_AdditionalSourceStatus(in *AdditionalSourceStatus, out *v1beta1.AdditionalSourceStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_AdditionalSourceStatus_To_v1beta1_AdditionalSourceStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_AdditionalSourceStatus_To_v1alpha1_AdditionalSourceStatus(in *v1beta1.AdditionalSourceStatus, out *AdditionalSourceStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Git = (*GitStatus)(unsafe.Pointer(in.Git))
+	out.Oci = (*OciStatus)(unsafe.Pointer(in.Oci))
+	out.Helm = (*HelmStatus)(unsafe.Pointer(in.Helm))
+	out.Commit = in.Commit
+	return nil
+}
+
+// Convert_v1beta1_AdditionalSourceStatus_To_v1alpha1_AdditionalSourceStatus is an autogenerated conversion function.
+func Convert_v1beta1_AdditionalSourceStatus_To_v1alpha1_AdditionalSourceStatus(in *v1beta1.AdditionalSourceStatus, out *AdditionalSourceStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_AdditionalSourceStatus_To_v1alpha1_AdditionalSourceStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_Archive_To_v1beta1_Archive(in *Archive, out *v1beta1.Archive, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Dir = in.Dir
+	out.Period = in.Period
+	out.Auth = configsync.AuthType(in.Auth)
+	out.SecretRef = (*v1beta1.SecretReference)(unsafe.Pointer(in.SecretRef))
+	out.Region = in.Region
+	out.CACertSecretRef = (*v1beta1.SecretReference)(unsafe.Pointer(in.CACertSecretRef))
+	return nil
+}
+
+// Convert_v1alpha1_Archive_To_v1beta1_Archive is an autogenerated conversion function.
+func Convert_v1alpha1_Archive_To_v1beta1_Archive(in *Archive, out *v1beta1.Archive, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Archive_To_v1beta1_Archive(in, out, s)
+}
+
+func autoConvert_v1beta1_Archive_To_v1alpha1_Archive(in *v1beta1.Archive, out *Archive, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Dir = in.Dir
+	out.Period = in.Period
+	out.Auth = configsync.AuthType(in.Auth)
+	out.SecretRef = (*SecretReference)(unsafe.Pointer(in.SecretRef))
+	out.Region = in.Region
+	out.CACertSecretRef = (*SecretReference)(unsafe.Pointer(in.CACertSecretRef))
+	return nil
+}
+
+// Convert_v1beta1_Archive_To_v1alpha1_Archive is an autogenerated conversion function.
+func Convert_v1beta1_Archive_To_v1alpha1_Archive(in *v1beta1.Archive, out *Archive, s conversion.Scope) error {
+	return autoConvert_v1beta1_Archive_To_v1alpha1_Archive(in, out, s)
+}
+
+func autoConvert_v1alpha1_ArchiveStatus_To_v1beta1_ArchiveStatus(in *ArchiveStatus, out *v1beta1.ArchiveStatus, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Dir = in.Dir
+	return nil
+}
+
+// Convert_v1alpha1_ArchiveStatus_To_v1beta1_ArchiveStatus is an autogenerated conversion function.
+func Convert_v1alpha1_ArchiveStatus_To_v1beta1_ArchiveStatus(in *ArchiveStatus, out *v1beta1.ArchiveStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ArchiveStatus_To_v1beta1_ArchiveStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_ArchiveStatus_To_v1alpha1_ArchiveStatus(in *v1beta1.ArchiveStatus, out *ArchiveStatus, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Dir = in.Dir
+	return nil
+}
+
+// Convert_v1beta1_ArchiveStatus_To_v1alpha1_ArchiveStatus is an autogenerated conversion function.
+func Convert_v1beta1_ArchiveStatus_To_v1alpha1_ArchiveStatus(in *v1beta1.ArchiveStatus, out *ArchiveStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_ArchiveStatus_To_v1alpha1_ArchiveStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_ConfigSyncError_To_v1beta1_ConfigSyncError(in *ConfigSyncError, out *v1beta1.ConfigSyncError, s conversion.Scope) error {
 	out.Code = in.Code
 	out.ErrorMessage = in.ErrorMessage
@@ -459,6 +711,9 @@ func autoConvert_v1alpha1_Git_To_v1beta1_Git(in *Git, out *v1beta1.Git, s conver
 	out.Branch = in.Branch
 	out.Revision = in.Revision
 	out.Dir = in.Dir
+	out.SparseCheckout = in.SparseCheckout
+	out.Submodules = configsync.GitSubmodules(in.Submodules)
+	out.LFS = in.LFS
 	out.Period = in.Period
 	out.Auth = configsync.AuthType(in.Auth)
 	out.GCPServiceAccountEmail = in.GCPServiceAccountEmail
@@ -466,6 +721,8 @@ func autoConvert_v1alpha1_Git_To_v1beta1_Git(in *Git, out *v1beta1.Git, s conver
 	out.SecretRef = (*v1beta1.SecretReference)(unsafe.Pointer(in.SecretRef))
 	out.NoSSLVerify = in.NoSSLVerify
 	out.CACertSecretRef = (*v1beta1.SecretReference)(unsafe.Pointer(in.CACertSecretRef))
+	out.Verification = (*v1beta1.GitVerification)(unsafe.Pointer(in.Verification))
+	out.Helm = (*v1beta1.GitHelm)(unsafe.Pointer(in.Helm))
 	return nil
 }
 
@@ -479,6 +736,9 @@ func autoConvert_v1beta1_Git_To_v1alpha1_Git(in *v1beta1.Git, out *Git, s conver
 	out.Branch = in.Branch
 	out.Revision = in.Revision
 	out.Dir = in.Dir
+	out.SparseCheckout = in.SparseCheckout
+	out.Submodules = configsync.GitSubmodules(in.Submodules)
+	out.LFS = in.LFS
 	out.Period = in.Period
 	out.Auth = configsync.AuthType(in.Auth)
 	out.GCPServiceAccountEmail = in.GCPServiceAccountEmail
@@ -486,6 +746,8 @@ func autoConvert_v1beta1_Git_To_v1alpha1_Git(in *v1beta1.Git, out *Git, s conver
 	out.SecretRef = (*SecretReference)(unsafe.Pointer(in.SecretRef))
 	out.NoSSLVerify = in.NoSSLVerify
 	out.CACertSecretRef = (*SecretReference)(unsafe.Pointer(in.CACertSecretRef))
+	out.Verification = (*GitVerification)(unsafe.Pointer(in.Verification))
+	out.Helm = (*GitHelm)(unsafe.Pointer(in.Helm))
 	return nil
 }
 
@@ -494,11 +756,42 @@ func Convert_v1beta1_Git_To_v1alpha1_Git(in *v1beta1.Git, out *Git, s conversion
 	return autoConvert_v1beta1_Git_To_v1alpha1_Git(in, out, s)
 }
 
+func autoConvert_v1alpha1_GitHelm_To_v1beta1_GitHelm(in *GitHelm, out *v1beta1.GitHelm, s conversion.Scope) error {
+	out.ReleaseName = in.ReleaseName
+	out.Namespace = in.Namespace
+	out.ValuesFiles = *(*[]string)(unsafe.Pointer(&in.ValuesFiles))
+	out.ValuesFileRefs = *(*[]v1beta1.ValuesFileRef)(unsafe.Pointer(&in.ValuesFileRefs))
+	out.IncludeCRDs = in.IncludeCRDs
+	return nil
+}
+
+// Convert_v1alpha1_GitHelm_To_v1beta1_GitHelm is an autogenerated conversion function.
+func Convert_v1alpha1_GitHelm_To_v1beta1_GitHelm(in *GitHelm, out *v1beta1.GitHelm, s conversion.Scope) error {
+	return autoConvert_v1alpha1_GitHelm_To_v1beta1_GitHelm(in, out, s)
+}
+
+func autoConvert_v1beta1_GitHelm_To_v1alpha1_GitHelm(in *v1beta1.GitHelm, out *GitHelm, s conversion.Scope) error {
+	out.ReleaseName = in.ReleaseName
+	out.Namespace = in.Namespace
+	out.ValuesFiles = *(*[]string)(unsafe.Pointer(&in.ValuesFiles))
+	out.ValuesFileRefs = *(*[]ValuesFileRef)(unsafe.Pointer(&in.ValuesFileRefs))
+	out.IncludeCRDs = in.IncludeCRDs
+	return nil
+}
+
+// Convert_v1beta1_GitHelm_To_v1alpha1_GitHelm is an autogenerated conversion function.
+func Convert_v1beta1_GitHelm_To_v1alpha1_GitHelm(in *v1beta1.GitHelm, out *GitHelm, s conversion.Scope) error {
+	return autoConvert_v1beta1_GitHelm_To_v1alpha1_GitHelm(in, out, s)
+}
+
 func autoConvert_v1alpha1_GitStatus_To_v1beta1_GitStatus(in *GitStatus, out *v1beta1.GitStatus, s conversion.Scope) error {
 	out.Repo = in.Repo
 	out.Revision = in.Revision
+	out.RevisionConstraint = in.RevisionConstraint
 	out.Branch = in.Branch
 	out.Dir = in.Dir
+	out.SparsePaths = *(*[]string)(unsafe.Pointer(&in.SparsePaths))
+	out.Submodules = *(*[]v1beta1.GitSubmoduleStatus)(unsafe.Pointer(&in.Submodules))
 	return nil
 }
 
@@ -510,8 +803,11 @@ func Convert_v1alpha1_GitStatus_To_v1beta1_GitStatus(in *GitStatus, out *v1beta1
 func autoConvert_v1beta1_GitStatus_To_v1alpha1_GitStatus(in *v1beta1.GitStatus, out *GitStatus, s conversion.Scope) error {
 	out.Repo = in.Repo
 	out.Revision = in.Revision
+	out.RevisionConstraint = in.RevisionConstraint
 	out.Branch = in.Branch
 	out.Dir = in.Dir
+	out.SparsePaths = *(*[]string)(unsafe.Pointer(&in.SparsePaths))
+	out.Submodules = *(*[]GitSubmoduleStatus)(unsafe.Pointer(&in.Submodules))
 	return nil
 }
 
@@ -520,6 +816,48 @@ func Convert_v1beta1_GitStatus_To_v1alpha1_GitStatus(in *v1beta1.GitStatus, out
 	return autoConvert_v1beta1_GitStatus_To_v1alpha1_GitStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_GitSubmoduleStatus_To_v1beta1_GitSubmoduleStatus(in *GitSubmoduleStatus, out *v1beta1.GitSubmoduleStatus, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Commit = in.Commit
+	return nil
+}
+
+// Convert_v1alpha1_GitSubmoduleStatus_To_v1beta1_GitSubmoduleStatus is an autogenerated conversion function.
+func Convert_v1alpha1_GitSubmoduleStatus_To_v1beta1_GitSubmoduleStatus(in *GitSubmoduleStatus, out *v1beta1.GitSubmoduleStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_GitSubmoduleStatus_To_v1beta1_GitSubmoduleStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_GitSubmoduleStatus_To_v1alpha1_GitSubmoduleStatus(in *v1beta1.GitSubmoduleStatus, out *GitSubmoduleStatus, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Commit = in.Commit
+	return nil
+}
+
+// Convert_v1beta1_GitSubmoduleStatus_To_v1alpha1_GitSubmoduleStatus is an autogenerated conversion function.
+func Convert_v1beta1_GitSubmoduleStatus_To_v1alpha1_GitSubmoduleStatus(in *v1beta1.GitSubmoduleStatus, out *GitSubmoduleStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_GitSubmoduleStatus_To_v1alpha1_GitSubmoduleStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_GitVerification_To_v1beta1_GitVerification(in *GitVerification, out *v1beta1.GitVerification, s conversion.Scope) error {
+	out.TrustedKeysSecretRef = (*v1beta1.SecretReference)(unsafe.Pointer(in.TrustedKeysSecretRef))
+	return nil
+}
+
+// Convert_v1alpha1_GitVerification_To_v1beta1_GitVerification is an autogenerated conversion function.
+func Convert_v1alpha1_GitVerification_To_v1beta1_GitVerification(in *GitVerification, out *v1beta1.GitVerification, s conversion.Scope) error {
+	return autoConvert_v1alpha1_GitVerification_To_v1beta1_GitVerification(in, out, s)
+}
+
+func autoConvert_v1beta1_GitVerification_To_v1alpha1_GitVerification(in *v1beta1.GitVerification, out *GitVerification, s conversion.Scope) error {
+	out.TrustedKeysSecretRef = (*SecretReference)(unsafe.Pointer(in.TrustedKeysSecretRef))
+	return nil
+}
+
+// Convert_v1beta1_GitVerification_To_v1alpha1_GitVerification is an autogenerated conversion function.
+func Convert_v1beta1_GitVerification_To_v1alpha1_GitVerification(in *v1beta1.GitVerification, out *GitVerification, s conversion.Scope) error {
+	return autoConvert_v1beta1_GitVerification_To_v1alpha1_GitVerification(in, out, s)
+}
+
 func autoConvert_v1alpha1_HelmBase_To_v1beta1_HelmBase(in *HelmBase, out *v1beta1.HelmBase, s conversion.Scope) error {
 	out.Repo = in.Repo
 	out.Chart = in.Chart
@@ -533,6 +871,7 @@ func autoConvert_v1alpha1_HelmBase_To_v1beta1_HelmBase(in *HelmBase, out *v1beta
 	out.GCPServiceAccountEmail = in.GCPServiceAccountEmail
 	out.SecretRef = (*v1beta1.SecretReference)(unsafe.Pointer(in.SecretRef))
 	out.CACertSecretRef = (*v1beta1.SecretReference)(unsafe.Pointer(in.CACertSecretRef))
+	out.Verify = (*v1beta1.HelmVerification)(unsafe.Pointer(in.Verify))
 	return nil
 }
 
@@ -554,6 +893,7 @@ func autoConvert_v1beta1_HelmBase_To_v1alpha1_HelmBase(in *v1beta1.HelmBase, out
 	out.GCPServiceAccountEmail = in.GCPServiceAccountEmail
 	out.SecretRef = (*SecretReference)(unsafe.Pointer(in.SecretRef))
 	out.CACertSecretRef = (*SecretReference)(unsafe.Pointer(in.CACertSecretRef))
+	out.Verify = (*HelmVerification)(unsafe.Pointer(in.Verify))
 	return nil
 }
 
@@ -608,6 +948,8 @@ func autoConvert_v1alpha1_HelmStatus_To_v1beta1_HelmStatus(in *HelmStatus, out *
 	out.Repo = in.Repo
 	out.Version = in.Version
 	out.Chart = in.Chart
+	out.Digest = in.Digest
+	out.Signer = in.Signer
 	return nil
 }
 
@@ -620,6 +962,8 @@ func autoConvert_v1beta1_HelmStatus_To_v1alpha1_HelmStatus(in *v1beta1.HelmStatu
 	out.Repo = in.Repo
 	out.Version = in.Version
 	out.Chart = in.Chart
+	out.Digest = in.Digest
+	out.Signer = in.Signer
 	return nil
 }
 
@@ -628,6 +972,88 @@ func Convert_v1beta1_HelmStatus_To_v1alpha1_HelmStatus(in *v1beta1.HelmStatus, o
 	return autoConvert_v1beta1_HelmStatus_To_v1alpha1_HelmStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_HelmVerification_To_v1beta1_HelmVerification(in *HelmVerification, out *v1beta1.HelmVerification, s conversion.Scope) error {
+	out.KeyringSecretRef = (*v1beta1.SecretReference)(unsafe.Pointer(in.KeyringSecretRef))
+	out.Keyless = (*v1beta1.OciKeylessVerification)(unsafe.Pointer(in.Keyless))
+	return nil
+}
+
+// Convert_v1alpha1_HelmVerification_To_v1beta1_HelmVerification is an autogenerated conversion function.
+func Convert_v1alpha1_HelmVerification_To_v1beta1_HelmVerification(in *HelmVerification, out *v1beta1.HelmVerification, s conversion.Scope) error {
+	return autoConvert_v1alpha1_HelmVerification_To_v1beta1_HelmVerification(in, out, s)
+}
+
+func autoConvert_v1beta1_HelmVerification_To_v1alpha1_HelmVerification(in *v1beta1.HelmVerification, out *HelmVerification, s conversion.Scope) error {
+	out.KeyringSecretRef = (*SecretReference)(unsafe.Pointer(in.KeyringSecretRef))
+	out.Keyless = (*OciKeylessVerification)(unsafe.Pointer(in.Keyless))
+	return nil
+}
+
+// Convert_v1beta1_HelmVerification_To_v1alpha1_HelmVerification is an autogenerated conversion function.
+func Convert_v1beta1_HelmVerification_To_v1alpha1_HelmVerification(in *v1beta1.HelmVerification, out *HelmVerification, s conversion.Scope) error {
+	return autoConvert_v1beta1_HelmVerification_To_v1alpha1_HelmVerification(in, out, s)
+}
+
+func autoConvert_v1alpha1_HookStatus_To_v1beta1_HookStatus(in *HookStatus, out *v1beta1.HookStatus, s conversion.Scope) error {
+	out.Phase = in.Phase
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	out.Succeeded = in.Succeeded
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_v1alpha1_HookStatus_To_v1beta1_HookStatus is an autogenerated conversion function.
+func Convert_v1alpha1_HookStatus_To_v1beta1_HookStatus(in *HookStatus, out *v1beta1.HookStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_HookStatus_To_v1beta1_HookStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_HookStatus_To_v1alpha1_HookStatus(in *v1beta1.HookStatus, out *HookStatus, s conversion.Scope) error {
+	out.Phase = in.Phase
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	out.Succeeded = in.Succeeded
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_v1beta1_HookStatus_To_v1alpha1_HookStatus is an autogenerated conversion function.
+func Convert_v1beta1_HookStatus_To_v1alpha1_HookStatus(in *v1beta1.HookStatus, out *HookStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_HookStatus_To_v1alpha1_HookStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_IgnoreDifference_To_v1beta1_IgnoreDifference(in *IgnoreDifference, out *v1beta1.IgnoreDifference, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Kind = in.Kind
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	out.JSONPaths = *(*[]string)(unsafe.Pointer(&in.JSONPaths))
+	out.ManagedFieldsManagers = *(*[]string)(unsafe.Pointer(&in.ManagedFieldsManagers))
+	return nil
+}
+
+// Convert_v1alpha1_IgnoreDifference_To_v1beta1_IgnoreDifference is an autogenerated conversion function.
+func Convert_v1alpha1_IgnoreDifference_To_v1beta1_IgnoreDifference(in *IgnoreDifference, out *v1beta1.IgnoreDifference, s conversion.Scope) error {
+	return autoConvert_v1alpha1_IgnoreDifference_To_v1beta1_IgnoreDifference(in, out, s)
+}
+
+func autoConvert_v1beta1_IgnoreDifference_To_v1alpha1_IgnoreDifference(in *v1beta1.IgnoreDifference, out *IgnoreDifference, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Kind = in.Kind
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	out.JSONPaths = *(*[]string)(unsafe.Pointer(&in.JSONPaths))
+	out.ManagedFieldsManagers = *(*[]string)(unsafe.Pointer(&in.ManagedFieldsManagers))
+	return nil
+}
+
+// Convert_v1beta1_IgnoreDifference_To_v1alpha1_IgnoreDifference is an autogenerated conversion function.
+func Convert_v1beta1_IgnoreDifference_To_v1alpha1_IgnoreDifference(in *v1beta1.IgnoreDifference, out *IgnoreDifference, s conversion.Scope) error {
+	return autoConvert_v1beta1_IgnoreDifference_To_v1alpha1_IgnoreDifference(in, out, s)
+}
+
 func autoConvert_v1alpha1_Oci_To_v1beta1_Oci(in *Oci, out *v1beta1.Oci, s conversion.Scope) error {
 	out.Image = in.Image
 	out.Dir = in.Dir
@@ -635,6 +1061,8 @@ func autoConvert_v1alpha1_Oci_To_v1beta1_Oci(in *Oci, out *v1beta1.Oci, s conver
 	out.Auth = configsync.AuthType(in.Auth)
 	out.GCPServiceAccountEmail = in.GCPServiceAccountEmail
 	out.CACertSecretRef = (*v1beta1.SecretReference)(unsafe.Pointer(in.CACertSecretRef))
+	out.Verification = (*v1beta1.OciVerification)(unsafe.Pointer(in.Verification))
+	out.TagPolicy = (*v1beta1.OciTagPolicy)(unsafe.Pointer(in.TagPolicy))
 	return nil
 }
 
@@ -650,6 +1078,8 @@ func autoConvert_v1beta1_Oci_To_v1alpha1_Oci(in *v1beta1.Oci, out *Oci, s conver
 	out.Auth = configsync.AuthType(in.Auth)
 	out.GCPServiceAccountEmail = in.GCPServiceAccountEmail
 	out.CACertSecretRef = (*SecretReference)(unsafe.Pointer(in.CACertSecretRef))
+	out.Verification = (*OciVerification)(unsafe.Pointer(in.Verification))
+	out.TagPolicy = (*OciTagPolicy)(unsafe.Pointer(in.TagPolicy))
 	return nil
 }
 
@@ -658,9 +1088,33 @@ func Convert_v1beta1_Oci_To_v1alpha1_Oci(in *v1beta1.Oci, out *Oci, s conversion
 	return autoConvert_v1beta1_Oci_To_v1alpha1_Oci(in, out, s)
 }
 
+func autoConvert_v1alpha1_OciKeylessVerification_To_v1beta1_OciKeylessVerification(in *OciKeylessVerification, out *v1beta1.OciKeylessVerification, s conversion.Scope) error {
+	out.Identity = in.Identity
+	out.Issuer = in.Issuer
+	return nil
+}
+
+// Convert_v1alpha1_OciKeylessVerification_To_v1beta1_OciKeylessVerification is an autogenerated conversion function.
+func Convert_v1alpha1_OciKeylessVerification_To_v1beta1_OciKeylessVerification(in *OciKeylessVerification, out *v1beta1.OciKeylessVerification, s conversion.Scope) error {
+	return autoConvert_v1alpha1_OciKeylessVerification_To_v1beta1_OciKeylessVerification(in, out, s)
+}
+
+func autoConvert_v1beta1_OciKeylessVerification_To_v1alpha1_OciKeylessVerification(in *v1beta1.OciKeylessVerification, out *OciKeylessVerification, s conversion.Scope) error {
+	out.Identity = in.Identity
+	out.Issuer = in.Issuer
+	return nil
+}
+
+// Convert_v1beta1_OciKeylessVerification_To_v1alpha1_OciKeylessVerification is an autogenerated conversion function.
+func Convert_v1beta1_OciKeylessVerification_To_v1alpha1_OciKeylessVerification(in *v1beta1.OciKeylessVerification, out *OciKeylessVerification, s conversion.Scope) error {
+	return autoConvert_v1beta1_OciKeylessVerification_To_v1alpha1_OciKeylessVerification(in, out, s)
+}
+
 func autoConvert_v1alpha1_OciStatus_To_v1beta1_OciStatus(in *OciStatus, out *v1beta1.OciStatus, s conversion.Scope) error {
 	out.Image = in.Image
 	out.Dir = in.Dir
+	out.Tag = in.Tag
+	out.Digest = in.Digest
 	return nil
 }
 
@@ -672,6 +1126,8 @@ func Convert_v1alpha1_OciStatus_To_v1beta1_OciStatus(in *OciStatus, out *v1beta1
 func autoConvert_v1beta1_OciStatus_To_v1alpha1_OciStatus(in *v1beta1.OciStatus, out *OciStatus, s conversion.Scope) error {
 	out.Image = in.Image
 	out.Dir = in.Dir
+	out.Tag = in.Tag
+	out.Digest = in.Digest
 	return nil
 }
 
@@ -680,6 +1136,54 @@ func Convert_v1beta1_OciStatus_To_v1alpha1_OciStatus(in *v1beta1.OciStatus, out
 	return autoConvert_v1beta1_OciStatus_To_v1alpha1_OciStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_OciTagPolicy_To_v1beta1_OciTagPolicy(in *OciTagPolicy, out *v1beta1.OciTagPolicy, s conversion.Scope) error {
+	out.Semver = in.Semver
+	out.Regex = in.Regex
+	out.Latest = in.Latest
+	return nil
+}
+
+// Convert_v1alpha1_OciTagPolicy_To_v1beta1_OciTagPolicy is an autogenerated conversion function.
+func Convert_v1alpha1_OciTagPolicy_To_v1beta1_OciTagPolicy(in *OciTagPolicy, out *v1beta1.OciTagPolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha1_OciTagPolicy_To_v1beta1_OciTagPolicy(in, out, s)
+}
+
+func autoConvert_v1beta1_OciTagPolicy_To_v1alpha1_OciTagPolicy(in *v1beta1.OciTagPolicy, out *OciTagPolicy, s conversion.Scope) error {
+	out.Semver = in.Semver
+	out.Regex = in.Regex
+	out.Latest = in.Latest
+	return nil
+}
+
+// Convert_v1beta1_OciTagPolicy_To_v1alpha1_OciTagPolicy is an autogenerated conversion function.
+func Convert_v1beta1_OciTagPolicy_To_v1alpha1_OciTagPolicy(in *v1beta1.OciTagPolicy, out *OciTagPolicy, s conversion.Scope) error {
+	return autoConvert_v1beta1_OciTagPolicy_To_v1alpha1_OciTagPolicy(in, out, s)
+}
+
+func autoConvert_v1alpha1_OciVerification_To_v1beta1_OciVerification(in *OciVerification, out *v1beta1.OciVerification, s conversion.Scope) error {
+	out.TrustedKeysSecretRef = (*v1beta1.SecretReference)(unsafe.Pointer(in.TrustedKeysSecretRef))
+	out.Keyless = (*v1beta1.OciKeylessVerification)(unsafe.Pointer(in.Keyless))
+	out.Attestations = *(*[]string)(unsafe.Pointer(&in.Attestations))
+	return nil
+}
+
+// Convert_v1alpha1_OciVerification_To_v1beta1_OciVerification is an autogenerated conversion function.
+func Convert_v1alpha1_OciVerification_To_v1beta1_OciVerification(in *OciVerification, out *v1beta1.OciVerification, s conversion.Scope) error {
+	return autoConvert_v1alpha1_OciVerification_To_v1beta1_OciVerification(in, out, s)
+}
+
+func autoConvert_v1beta1_OciVerification_To_v1alpha1_OciVerification(in *v1beta1.OciVerification, out *OciVerification, s conversion.Scope) error {
+	out.TrustedKeysSecretRef = (*SecretReference)(unsafe.Pointer(in.TrustedKeysSecretRef))
+	out.Keyless = (*OciKeylessVerification)(unsafe.Pointer(in.Keyless))
+	out.Attestations = *(*[]string)(unsafe.Pointer(&in.Attestations))
+	return nil
+}
+
+// Convert_v1beta1_OciVerification_To_v1alpha1_OciVerification is an autogenerated conversion function.
+func Convert_v1beta1_OciVerification_To_v1alpha1_OciVerification(in *v1beta1.OciVerification, out *OciVerification, s conversion.Scope) error {
+	return autoConvert_v1beta1_OciVerification_To_v1alpha1_OciVerification(in, out, s)
+}
+
 func autoConvert_v1alpha1_OverrideSpec_To_v1beta1_OverrideSpec(in *OverrideSpec, out *v1beta1.OverrideSpec, s conversion.Scope) error {
 	out.Resources = *(*[]v1beta1.ContainerResourcesSpec)(unsafe.Pointer(&in.Resources))
 	out.GitSyncDepth = (*int64)(unsafe.Pointer(in.GitSyncDepth))
@@ -687,6 +1191,11 @@ func autoConvert_v1alpha1_OverrideSpec_To_v1beta1_OverrideSpec(in *OverrideSpec,
 	out.ReconcileTimeout = (*metav1.Duration)(unsafe.Pointer(in.ReconcileTimeout))
 	out.APIServerTimeout = (*metav1.Duration)(unsafe.Pointer(in.APIServerTimeout))
 	out.EnableShellInRendering = (*bool)(unsafe.Pointer(in.EnableShellInRendering))
+	out.Rendering = (*v1beta1.RenderingOverride)(unsafe.Pointer(in.Rendering))
+	out.ServerSideDryRun = (*bool)(unsafe.Pointer(in.ServerSideDryRun))
+	out.SchemaValidation = (*bool)(unsafe.Pointer(in.SchemaValidation))
+	out.DriftMode = configsync.DriftMode(in.DriftMode)
+	out.IgnoreDifferences = *(*[]v1beta1.IgnoreDifference)(unsafe.Pointer(&in.IgnoreDifferences))
 	out.LogLevels = *(*[]v1beta1.ContainerLogLevelOverride)(unsafe.Pointer(&in.LogLevels))
 	return nil
 }
@@ -703,6 +1212,11 @@ func autoConvert_v1beta1_OverrideSpec_To_v1alpha1_OverrideSpec(in *v1beta1.Overr
 	out.ReconcileTimeout = (*metav1.Duration)(unsafe.Pointer(in.ReconcileTimeout))
 	out.APIServerTimeout = (*metav1.Duration)(unsafe.Pointer(in.APIServerTimeout))
 	out.EnableShellInRendering = (*bool)(unsafe.Pointer(in.EnableShellInRendering))
+	out.Rendering = (*RenderingOverride)(unsafe.Pointer(in.Rendering))
+	out.ServerSideDryRun = (*bool)(unsafe.Pointer(in.ServerSideDryRun))
+	out.SchemaValidation = (*bool)(unsafe.Pointer(in.SchemaValidation))
+	out.DriftMode = configsync.DriftMode(in.DriftMode)
+	out.IgnoreDifferences = *(*[]IgnoreDifference)(unsafe.Pointer(&in.IgnoreDifferences))
 	out.LogLevels = *(*[]ContainerLogLevelOverride)(unsafe.Pointer(&in.LogLevels))
 	return nil
 }
@@ -712,10 +1226,33 @@ func Convert_v1beta1_OverrideSpec_To_v1alpha1_OverrideSpec(in *v1beta1.OverrideS
 	return autoConvert_v1beta1_OverrideSpec_To_v1alpha1_OverrideSpec(in, out, s)
 }
 
+func autoConvert_v1alpha1_RenderingOverride_To_v1beta1_RenderingOverride(in *RenderingOverride, out *v1beta1.RenderingOverride, s conversion.Scope) error {
+	out.Hermetic = (*bool)(unsafe.Pointer(in.Hermetic))
+	out.AllowExecFunctions = (*bool)(unsafe.Pointer(in.AllowExecFunctions))
+	return nil
+}
+
+// Convert_v1alpha1_RenderingOverride_To_v1beta1_RenderingOverride is an autogenerated conversion function.
+func Convert_v1alpha1_RenderingOverride_To_v1beta1_RenderingOverride(in *RenderingOverride, out *v1beta1.RenderingOverride, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RenderingOverride_To_v1beta1_RenderingOverride(in, out, s)
+}
+
+func autoConvert_v1beta1_RenderingOverride_To_v1alpha1_RenderingOverride(in *v1beta1.RenderingOverride, out *RenderingOverride, s conversion.Scope) error {
+	out.Hermetic = (*bool)(unsafe.Pointer(in.Hermetic))
+	out.AllowExecFunctions = (*bool)(unsafe.Pointer(in.AllowExecFunctions))
+	return nil
+}
+
+// Convert_v1beta1_RenderingOverride_To_v1alpha1_RenderingOverride is an autogenerated conversion function.
+func Convert_v1beta1_RenderingOverride_To_v1alpha1_RenderingOverride(in *v1beta1.RenderingOverride, out *RenderingOverride, s conversion.Scope) error {
+	return autoConvert_v1beta1_RenderingOverride_To_v1alpha1_RenderingOverride(in, out, s)
+}
+
 func autoConvert_v1alpha1_RenderingStatus_To_v1beta1_RenderingStatus(in *RenderingStatus, out *v1beta1.RenderingStatus, s conversion.Scope) error {
 	out.Git = (*v1beta1.GitStatus)(unsafe.Pointer(in.Git))
 	out.Oci = (*v1beta1.OciStatus)(unsafe.Pointer(in.Oci))
 	out.Helm = (*v1beta1.HelmStatus)(unsafe.Pointer(in.Helm))
+	out.Archive = (*v1beta1.ArchiveStatus)(unsafe.Pointer(in.Archive))
 	out.Commit = in.Commit
 	out.Message = in.Message
 	out.LastUpdate = in.LastUpdate
@@ -733,6 +1270,7 @@ func autoConvert_v1beta1_RenderingStatus_To_v1alpha1_RenderingStatus(in *v1beta1
 	out.Git = (*GitStatus)(unsafe.Pointer(in.Git))
 	out.Oci = (*OciStatus)(unsafe.Pointer(in.Oci))
 	out.Helm = (*HelmStatus)(unsafe.Pointer(in.Helm))
+	out.Archive = (*ArchiveStatus)(unsafe.Pointer(in.Archive))
 	out.Commit = in.Commit
 	out.LastUpdate = in.LastUpdate
 	out.Message = in.Message
@@ -887,6 +1425,7 @@ func autoConvert_v1alpha1_RepoSyncSpec_To_v1beta1_RepoSyncSpec(in *RepoSyncSpec,
 	out.SourceType = configsync.SourceType(in.SourceType)
 	out.Git = (*v1beta1.Git)(unsafe.Pointer(in.Git))
 	out.Oci = (*v1beta1.Oci)(unsafe.Pointer(in.Oci))
+	out.Archive = (*v1beta1.Archive)(unsafe.Pointer(in.Archive))
 	if in.Helm != nil {
 		in, out := &in.Helm, &out.Helm
 		*out = new(v1beta1.HelmRepoSync)
@@ -910,6 +1449,7 @@ func autoConvert_v1beta1_RepoSyncSpec_To_v1alpha1_RepoSyncSpec(in *v1beta1.RepoS
 	out.SourceType = configsync.SourceType(in.SourceType)
 	out.Git = (*Git)(unsafe.Pointer(in.Git))
 	out.Oci = (*Oci)(unsafe.Pointer(in.Oci))
+	out.Archive = (*Archive)(unsafe.Pointer(in.Archive))
 	if in.Helm != nil {
 		in, out := &in.Helm, &out.Helm
 		*out = new(HelmRepoSync)
@@ -980,6 +1520,52 @@ func Convert_v1beta1_ResourceRef_To_v1alpha1_ResourceRef(in *v1beta1.ResourceRef
 	return autoConvert_v1beta1_ResourceRef_To_v1alpha1_ResourceRef(in, out, s)
 }
 
+func autoConvert_v1alpha1_RolloutConfigMapRef_To_v1beta1_RolloutConfigMapRef(in *RolloutConfigMapRef, out *v1beta1.RolloutConfigMapRef, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	return nil
+}
+
+// Convert_v1alpha1_RolloutConfigMapRef_To_v1beta1_RolloutConfigMapRef is an autogenerated conversion function.
+func Convert_v1alpha1_RolloutConfigMapRef_To_v1beta1_RolloutConfigMapRef(in *RolloutConfigMapRef, out *v1beta1.RolloutConfigMapRef, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RolloutConfigMapRef_To_v1beta1_RolloutConfigMapRef(in, out, s)
+}
+
+func autoConvert_v1beta1_RolloutConfigMapRef_To_v1alpha1_RolloutConfigMapRef(in *v1beta1.RolloutConfigMapRef, out *RolloutConfigMapRef, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	return nil
+}
+
+// Convert_v1beta1_RolloutConfigMapRef_To_v1alpha1_RolloutConfigMapRef is an autogenerated conversion function.
+func Convert_v1beta1_RolloutConfigMapRef_To_v1alpha1_RolloutConfigMapRef(in *v1beta1.RolloutConfigMapRef, out *RolloutConfigMapRef, s conversion.Scope) error {
+	return autoConvert_v1beta1_RolloutConfigMapRef_To_v1alpha1_RolloutConfigMapRef(in, out, s)
+}
+
+func autoConvert_v1alpha1_RolloutSpec_To_v1beta1_RolloutSpec(in *RolloutSpec, out *v1beta1.RolloutSpec, s conversion.Scope) error {
+	out.CanaryGroup = in.CanaryGroup
+	out.ConfigMapRef = (*v1beta1.RolloutConfigMapRef)(unsafe.Pointer(in.ConfigMapRef))
+	out.URL = in.URL
+	return nil
+}
+
+// Convert_v1alpha1_RolloutSpec_To_v1beta1_RolloutSpec is an autogenerated conversion function.
+func Convert_v1alpha1_RolloutSpec_To_v1beta1_RolloutSpec(in *RolloutSpec, out *v1beta1.RolloutSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RolloutSpec_To_v1beta1_RolloutSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_RolloutSpec_To_v1alpha1_RolloutSpec(in *v1beta1.RolloutSpec, out *RolloutSpec, s conversion.Scope) error {
+	out.CanaryGroup = in.CanaryGroup
+	out.ConfigMapRef = (*RolloutConfigMapRef)(unsafe.Pointer(in.ConfigMapRef))
+	out.URL = in.URL
+	return nil
+}
+
+// Convert_v1beta1_RolloutSpec_To_v1alpha1_RolloutSpec is an autogenerated conversion function.
+func Convert_v1beta1_RolloutSpec_To_v1alpha1_RolloutSpec(in *v1beta1.RolloutSpec, out *RolloutSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_RolloutSpec_To_v1alpha1_RolloutSpec(in, out, s)
+}
+
 func autoConvert_v1alpha1_RootSync_To_v1beta1_RootSync(in *RootSync, out *v1beta1.RootSync, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha1_RootSyncSpec_To_v1beta1_RootSyncSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1144,11 +1730,56 @@ func Convert_v1beta1_RootSyncRoleRef_To_v1alpha1_RootSyncRoleRef(in *v1beta1.Roo
 	return autoConvert_v1beta1_RootSyncRoleRef_To_v1alpha1_RootSyncRoleRef(in, out, s)
 }
 
+func autoConvert_v1alpha1_RootSyncSource_To_v1beta1_RootSyncSource(in *RootSyncSource, out *v1beta1.RootSyncSource, s conversion.Scope) error {
+	out.Name = in.Name
+	out.SourceType = configsync.SourceType(in.SourceType)
+	out.Git = (*v1beta1.Git)(unsafe.Pointer(in.Git))
+	out.Oci = (*v1beta1.Oci)(unsafe.Pointer(in.Oci))
+	if in.Helm != nil {
+		in, out := &in.Helm, &out.Helm
+		*out = new(v1beta1.HelmRootSync)
+		if err := Convert_v1alpha1_HelmRootSync_To_v1beta1_HelmRootSync(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Helm = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha1_RootSyncSource_To_v1beta1_RootSyncSource is an autogenerated conversion function.
+func Convert_v1alpha1_RootSyncSource_To_v1beta1_RootSyncSource(in *RootSyncSource, out *v1beta1.RootSyncSource, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RootSyncSource_To_v1beta1_RootSyncSource(in, out, s)
+}
+
+func autoConvert_v1beta1_RootSyncSource_To_v1alpha1_RootSyncSource(in *v1beta1.RootSyncSource, out *RootSyncSource, s conversion.Scope) error {
+	out.Name = in.Name
+	out.SourceType = configsync.SourceType(in.SourceType)
+	out.Git = (*Git)(unsafe.Pointer(in.Git))
+	out.Oci = (*Oci)(unsafe.Pointer(in.Oci))
+	if in.Helm != nil {
+		in, out := &in.Helm, &out.Helm
+		*out = new(HelmRootSync)
+		if err := Convert_v1beta1_HelmRootSync_To_v1alpha1_HelmRootSync(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Helm = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_RootSyncSource_To_v1alpha1_RootSyncSource is an autogenerated conversion function.
+func Convert_v1beta1_RootSyncSource_To_v1alpha1_RootSyncSource(in *v1beta1.RootSyncSource, out *RootSyncSource, s conversion.Scope) error {
+	return autoConvert_v1beta1_RootSyncSource_To_v1alpha1_RootSyncSource(in, out, s)
+}
+
 func autoConvert_v1alpha1_RootSyncSpec_To_v1beta1_RootSyncSpec(in *RootSyncSpec, out *v1beta1.RootSyncSpec, s conversion.Scope) error {
 	out.SourceFormat = configsync.SourceFormat(in.SourceFormat)
 	out.SourceType = configsync.SourceType(in.SourceType)
 	out.Git = (*v1beta1.Git)(unsafe.Pointer(in.Git))
 	out.Oci = (*v1beta1.Oci)(unsafe.Pointer(in.Oci))
+	out.Archive = (*v1beta1.Archive)(unsafe.Pointer(in.Archive))
 	if in.Helm != nil {
 		in, out := &in.Helm, &out.Helm
 		*out = new(v1beta1.HelmRootSync)
@@ -1159,6 +1790,18 @@ func autoConvert_v1alpha1_RootSyncSpec_To_v1beta1_RootSyncSpec(in *RootSyncSpec,
 		out.Helm = nil
 	}
 	out.Override = (*v1beta1.RootSyncOverrideSpec)(unsafe.Pointer(in.Override))
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]v1beta1.RootSyncSource, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha1_RootSyncSource_To_v1beta1_RootSyncSource(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Sources = nil
+	}
+	out.Rollout = (*v1beta1.RolloutSpec)(unsafe.Pointer(in.Rollout))
 	return nil
 }
 
@@ -1172,6 +1815,7 @@ func autoConvert_v1beta1_RootSyncSpec_To_v1alpha1_RootSyncSpec(in *v1beta1.RootS
 	out.SourceType = configsync.SourceType(in.SourceType)
 	out.Git = (*Git)(unsafe.Pointer(in.Git))
 	out.Oci = (*Oci)(unsafe.Pointer(in.Oci))
+	out.Archive = (*Archive)(unsafe.Pointer(in.Archive))
 	if in.Helm != nil {
 		in, out := &in.Helm, &out.Helm
 		*out = new(HelmRootSync)
@@ -1182,6 +1826,18 @@ func autoConvert_v1beta1_RootSyncSpec_To_v1alpha1_RootSyncSpec(in *v1beta1.RootS
 		out.Helm = nil
 	}
 	out.Override = (*RootSyncOverrideSpec)(unsafe.Pointer(in.Override))
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]RootSyncSource, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_RootSyncSource_To_v1alpha1_RootSyncSource(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Sources = nil
+	}
+	out.Rollout = (*RolloutSpec)(unsafe.Pointer(in.Rollout))
 	return nil
 }
 
@@ -1240,7 +1896,9 @@ func autoConvert_v1alpha1_SourceStatus_To_v1beta1_SourceStatus(in *SourceStatus,
 	out.Git = (*v1beta1.GitStatus)(unsafe.Pointer(in.Git))
 	out.Oci = (*v1beta1.OciStatus)(unsafe.Pointer(in.Oci))
 	out.Helm = (*v1beta1.HelmStatus)(unsafe.Pointer(in.Helm))
+	out.Archive = (*v1beta1.ArchiveStatus)(unsafe.Pointer(in.Archive))
 	out.Commit = in.Commit
+	out.Sources = *(*[]v1beta1.AdditionalSourceStatus)(unsafe.Pointer(&in.Sources))
 	out.LastUpdate = in.LastUpdate
 	out.Errors = *(*[]v1beta1.ConfigSyncError)(unsafe.Pointer(&in.Errors))
 	out.ErrorSummary = (*v1beta1.ErrorSummary)(unsafe.Pointer(in.ErrorSummary))
@@ -1256,7 +1914,9 @@ func autoConvert_v1beta1_SourceStatus_To_v1alpha1_SourceStatus(in *v1beta1.Sourc
 	out.Git = (*GitStatus)(unsafe.Pointer(in.Git))
 	out.Oci = (*OciStatus)(unsafe.Pointer(in.Oci))
 	out.Helm = (*HelmStatus)(unsafe.Pointer(in.Helm))
+	out.Archive = (*ArchiveStatus)(unsafe.Pointer(in.Archive))
 	out.Commit = in.Commit
+	out.Sources = *(*[]AdditionalSourceStatus)(unsafe.Pointer(&in.Sources))
 	out.LastUpdate = in.LastUpdate
 	out.Errors = *(*[]ConfigSyncError)(unsafe.Pointer(&in.Errors))
 	out.ErrorSummary = (*ErrorSummary)(unsafe.Pointer(in.ErrorSummary))
@@ -1314,10 +1974,13 @@ func autoConvert_v1alpha1_SyncStatus_To_v1beta1_SyncStatus(in *SyncStatus, out *
 	out.Git = (*v1beta1.GitStatus)(unsafe.Pointer(in.Git))
 	out.Oci = (*v1beta1.OciStatus)(unsafe.Pointer(in.Oci))
 	out.Helm = (*v1beta1.HelmStatus)(unsafe.Pointer(in.Helm))
+	out.Archive = (*v1beta1.ArchiveStatus)(unsafe.Pointer(in.Archive))
 	out.Commit = in.Commit
 	out.LastUpdate = in.LastUpdate
 	out.Errors = *(*[]v1beta1.ConfigSyncError)(unsafe.Pointer(&in.Errors))
 	out.ErrorSummary = (*v1beta1.ErrorSummary)(unsafe.Pointer(in.ErrorSummary))
+	out.Waves = (*v1beta1.SyncWaveStatus)(unsafe.Pointer(in.Waves))
+	out.Hooks = *(*[]v1beta1.HookStatus)(unsafe.Pointer(&in.Hooks))
 	return nil
 }
 
@@ -1330,10 +1993,13 @@ func autoConvert_v1beta1_SyncStatus_To_v1alpha1_SyncStatus(in *v1beta1.SyncStatu
 	out.Git = (*GitStatus)(unsafe.Pointer(in.Git))
 	out.Oci = (*OciStatus)(unsafe.Pointer(in.Oci))
 	out.Helm = (*HelmStatus)(unsafe.Pointer(in.Helm))
+	out.Archive = (*ArchiveStatus)(unsafe.Pointer(in.Archive))
 	out.Commit = in.Commit
 	out.LastUpdate = in.LastUpdate
 	out.Errors = *(*[]ConfigSyncError)(unsafe.Pointer(&in.Errors))
 	out.ErrorSummary = (*ErrorSummary)(unsafe.Pointer(in.ErrorSummary))
+	out.Waves = (*SyncWaveStatus)(unsafe.Pointer(in.Waves))
+	out.Hooks = *(*[]HookStatus)(unsafe.Pointer(&in.Hooks))
 	return nil
 }
 
@@ -1342,6 +2008,30 @@ func Convert_v1beta1_SyncStatus_To_v1alpha1_SyncStatus(in *v1beta1.SyncStatus, o
 	return autoConvert_v1beta1_SyncStatus_To_v1alpha1_SyncStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_SyncWaveStatus_To_v1beta1_SyncWaveStatus(in *SyncWaveStatus, out *v1beta1.SyncWaveStatus, s conversion.Scope) error {
+	out.Total = in.Total
+	out.Completed = in.Completed
+	out.Current = in.Current
+	return nil
+}
+
+// Convert_v1alpha1_SyncWaveStatus_To_v1beta1_SyncWaveStatus is an autogenerated conversion function.
+func Convert_v1alpha1_SyncWaveStatus_To_v1beta1_SyncWaveStatus(in *SyncWaveStatus, out *v1beta1.SyncWaveStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SyncWaveStatus_To_v1beta1_SyncWaveStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_SyncWaveStatus_To_v1alpha1_SyncWaveStatus(in *v1beta1.SyncWaveStatus, out *SyncWaveStatus, s conversion.Scope) error {
+	out.Total = in.Total
+	out.Completed = in.Completed
+	out.Current = in.Current
+	return nil
+}
+
+// Convert_v1beta1_SyncWaveStatus_To_v1alpha1_SyncWaveStatus is an autogenerated conversion function.
+func Convert_v1beta1_SyncWaveStatus_To_v1alpha1_SyncWaveStatus(in *v1beta1.SyncWaveStatus, out *SyncWaveStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_SyncWaveStatus_To_v1alpha1_SyncWaveStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_ValuesFileRef_To_v1beta1_ValuesFileRef(in *ValuesFileRef, out *v1beta1.ValuesFileRef, s conversion.Scope) error {
 	out.Name = in.Name
 	out.DataKey = in.DataKey
@@ -11,6 +11,80 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalSourceStatus) DeepCopyInto(out *AdditionalSourceStatus) {
+	*out = *in
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(GitStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Oci != nil {
+		in, out := &in.Oci, &out.Oci
+		*out = new(OciStatus)
+		**out = **in
+	}
+	if in.Helm != nil {
+		in, out := &in.Helm, &out.Helm
+		*out = new(HelmStatus)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalSourceStatus.
+func (in *AdditionalSourceStatus) DeepCopy() *AdditionalSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalSourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Archive) DeepCopyInto(out *Archive) {
+	*out = *in
+	out.Period = in.Period
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.CACertSecretRef != nil {
+		in, out := &in.CACertSecretRef, &out.CACertSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Archive.
+func (in *Archive) DeepCopy() *Archive {
+	if in == nil {
+		return nil
+	}
+	out := new(Archive)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArchiveStatus) DeepCopyInto(out *ArchiveStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArchiveStatus.
+func (in *ArchiveStatus) DeepCopy() *ArchiveStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArchiveStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigSyncError) DeepCopyInto(out *ConfigSyncError) {
 	*out = *in
@@ -98,6 +172,16 @@ func (in *Git) DeepCopyInto(out *Git) {
 		*out = new(SecretReference)
 		**out = **in
 	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(GitVerification)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Helm != nil {
+		in, out := &in.Helm, &out.Helm
+		*out = new(GitHelm)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -111,9 +195,45 @@ func (in *Git) DeepCopy() *Git {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHelm) DeepCopyInto(out *GitHelm) {
+	*out = *in
+	if in.ValuesFiles != nil {
+		in, out := &in.ValuesFiles, &out.ValuesFiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ValuesFileRefs != nil {
+		in, out := &in.ValuesFileRefs, &out.ValuesFileRefs
+		*out = make([]ValuesFileRef, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHelm.
+func (in *GitHelm) DeepCopy() *GitHelm {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHelm)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitStatus) DeepCopyInto(out *GitStatus) {
 	*out = *in
+	if in.SparsePaths != nil {
+		in, out := &in.SparsePaths, &out.SparsePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Submodules != nil {
+		in, out := &in.Submodules, &out.Submodules
+		*out = make([]GitSubmoduleStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -127,6 +247,43 @@ func (in *GitStatus) DeepCopy() *GitStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitSubmoduleStatus) DeepCopyInto(out *GitSubmoduleStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitSubmoduleStatus.
+func (in *GitSubmoduleStatus) DeepCopy() *GitSubmoduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GitSubmoduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitVerification) DeepCopyInto(out *GitVerification) {
+	*out = *in
+	if in.TrustedKeysSecretRef != nil {
+		in, out := &in.TrustedKeysSecretRef, &out.TrustedKeysSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitVerification.
+func (in *GitVerification) DeepCopy() *GitVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(GitVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HelmBase) DeepCopyInto(out *HelmBase) {
 	*out = *in
@@ -151,6 +308,11 @@ func (in *HelmBase) DeepCopyInto(out *HelmBase) {
 		*out = new(SecretReference)
 		**out = **in
 	}
+	if in.Verify != nil {
+		in, out := &in.Verify, &out.Verify
+		*out = new(HelmVerification)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -214,6 +376,74 @@ func (in *HelmStatus) DeepCopy() *HelmStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmVerification) DeepCopyInto(out *HelmVerification) {
+	*out = *in
+	if in.KeyringSecretRef != nil {
+		in, out := &in.KeyringSecretRef, &out.KeyringSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.Keyless != nil {
+		in, out := &in.Keyless, &out.Keyless
+		*out = new(OciKeylessVerification)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmVerification.
+func (in *HelmVerification) DeepCopy() *HelmVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HookStatus) DeepCopyInto(out *HookStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookStatus.
+func (in *HookStatus) DeepCopy() *HookStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HookStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IgnoreDifference) DeepCopyInto(out *IgnoreDifference) {
+	*out = *in
+	if in.JSONPaths != nil {
+		in, out := &in.JSONPaths, &out.JSONPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManagedFieldsManagers != nil {
+		in, out := &in.ManagedFieldsManagers, &out.ManagedFieldsManagers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IgnoreDifference.
+func (in *IgnoreDifference) DeepCopy() *IgnoreDifference {
+	if in == nil {
+		return nil
+	}
+	out := new(IgnoreDifference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Oci) DeepCopyInto(out *Oci) {
 	*out = *in
@@ -223,6 +453,16 @@ func (in *Oci) DeepCopyInto(out *Oci) {
 		*out = new(SecretReference)
 		**out = **in
 	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(OciVerification)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TagPolicy != nil {
+		in, out := &in.TagPolicy, &out.TagPolicy
+		*out = new(OciTagPolicy)
+		**out = **in
+	}
 	return
 }
 
@@ -236,6 +476,22 @@ func (in *Oci) DeepCopy() *Oci {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OciKeylessVerification) DeepCopyInto(out *OciKeylessVerification) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OciKeylessVerification.
+func (in *OciKeylessVerification) DeepCopy() *OciKeylessVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(OciKeylessVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OciStatus) DeepCopyInto(out *OciStatus) {
 	*out = *in
@@ -252,6 +508,53 @@ func (in *OciStatus) DeepCopy() *OciStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OciTagPolicy) DeepCopyInto(out *OciTagPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OciTagPolicy.
+func (in *OciTagPolicy) DeepCopy() *OciTagPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(OciTagPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OciVerification) DeepCopyInto(out *OciVerification) {
+	*out = *in
+	if in.TrustedKeysSecretRef != nil {
+		in, out := &in.TrustedKeysSecretRef, &out.TrustedKeysSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.Keyless != nil {
+		in, out := &in.Keyless, &out.Keyless
+		*out = new(OciKeylessVerification)
+		**out = **in
+	}
+	if in.Attestations != nil {
+		in, out := &in.Attestations, &out.Attestations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OciVerification.
+func (in *OciVerification) DeepCopy() *OciVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(OciVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OverrideSpec) DeepCopyInto(out *OverrideSpec) {
 	*out = *in
@@ -282,6 +585,28 @@ func (in *OverrideSpec) DeepCopyInto(out *OverrideSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Rendering != nil {
+		in, out := &in.Rendering, &out.Rendering
+		*out = new(RenderingOverride)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServerSideDryRun != nil {
+		in, out := &in.ServerSideDryRun, &out.ServerSideDryRun
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SchemaValidation != nil {
+		in, out := &in.SchemaValidation, &out.SchemaValidation
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IgnoreDifferences != nil {
+		in, out := &in.IgnoreDifferences, &out.IgnoreDifferences
+		*out = make([]IgnoreDifference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.LogLevels != nil {
 		in, out := &in.LogLevels, &out.LogLevels
 		*out = make([]ContainerLogLevelOverride, len(*in))
@@ -300,13 +625,39 @@ func (in *OverrideSpec) DeepCopy() *OverrideSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RenderingOverride) DeepCopyInto(out *RenderingOverride) {
+	*out = *in
+	if in.Hermetic != nil {
+		in, out := &in.Hermetic, &out.Hermetic
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AllowExecFunctions != nil {
+		in, out := &in.AllowExecFunctions, &out.AllowExecFunctions
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RenderingOverride.
+func (in *RenderingOverride) DeepCopy() *RenderingOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(RenderingOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RenderingStatus) DeepCopyInto(out *RenderingStatus) {
 	*out = *in
 	if in.Git != nil {
 		in, out := &in.Git, &out.Git
 		*out = new(GitStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Oci != nil {
 		in, out := &in.Oci, &out.Oci
@@ -318,6 +669,11 @@ func (in *RenderingStatus) DeepCopyInto(out *RenderingStatus) {
 		*out = new(HelmStatus)
 		**out = **in
 	}
+	if in.Archive != nil {
+		in, out := &in.Archive, &out.Archive
+		*out = new(ArchiveStatus)
+		**out = **in
+	}
 	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
 	if in.Errors != nil {
 		in, out := &in.Errors, &out.Errors
@@ -470,6 +826,11 @@ func (in *RepoSyncSpec) DeepCopyInto(out *RepoSyncSpec) {
 		*out = new(Oci)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Archive != nil {
+		in, out := &in.Archive, &out.Archive
+		*out = new(Archive)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Helm != nil {
 		in, out := &in.Helm, &out.Helm
 		*out = new(HelmRepoSync)
@@ -534,6 +895,43 @@ func (in *ResourceRef) DeepCopy() *ResourceRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutConfigMapRef) DeepCopyInto(out *RolloutConfigMapRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutConfigMapRef.
+func (in *RolloutConfigMapRef) DeepCopy() *RolloutConfigMapRef {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutConfigMapRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutSpec) DeepCopyInto(out *RolloutSpec) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(RolloutConfigMapRef)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutSpec.
+func (in *RolloutSpec) DeepCopy() *RolloutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RootSync) DeepCopyInto(out *RootSync) {
 	*out = *in
@@ -668,6 +1066,37 @@ func (in *RootSyncRoleRef) DeepCopy() *RootSyncRoleRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RootSyncSource) DeepCopyInto(out *RootSyncSource) {
+	*out = *in
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(Git)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Oci != nil {
+		in, out := &in.Oci, &out.Oci
+		*out = new(Oci)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Helm != nil {
+		in, out := &in.Helm, &out.Helm
+		*out = new(HelmRootSync)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RootSyncSource.
+func (in *RootSyncSource) DeepCopy() *RootSyncSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RootSyncSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RootSyncSpec) DeepCopyInto(out *RootSyncSpec) {
 	*out = *in
@@ -681,6 +1110,11 @@ func (in *RootSyncSpec) DeepCopyInto(out *RootSyncSpec) {
 		*out = new(Oci)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Archive != nil {
+		in, out := &in.Archive, &out.Archive
+		*out = new(Archive)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Helm != nil {
 		in, out := &in.Helm, &out.Helm
 		*out = new(HelmRootSync)
@@ -691,6 +1125,18 @@ func (in *RootSyncSpec) DeepCopyInto(out *RootSyncSpec) {
 		*out = new(RootSyncOverrideSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]RootSyncSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -750,7 +1196,7 @@ func (in *SourceStatus) DeepCopyInto(out *SourceStatus) {
 	if in.Git != nil {
 		in, out := &in.Git, &out.Git
 		*out = new(GitStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Oci != nil {
 		in, out := &in.Oci, &out.Oci
@@ -762,6 +1208,18 @@ func (in *SourceStatus) DeepCopyInto(out *SourceStatus) {
 		*out = new(HelmStatus)
 		**out = **in
 	}
+	if in.Archive != nil {
+		in, out := &in.Archive, &out.Archive
+		*out = new(ArchiveStatus)
+		**out = **in
+	}
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]AdditionalSourceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
 	if in.Errors != nil {
 		in, out := &in.Errors, &out.Errors
@@ -813,7 +1271,7 @@ func (in *SyncStatus) DeepCopyInto(out *SyncStatus) {
 	if in.Git != nil {
 		in, out := &in.Git, &out.Git
 		*out = new(GitStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Oci != nil {
 		in, out := &in.Oci, &out.Oci
@@ -825,6 +1283,11 @@ func (in *SyncStatus) DeepCopyInto(out *SyncStatus) {
 		*out = new(HelmStatus)
 		**out = **in
 	}
+	if in.Archive != nil {
+		in, out := &in.Archive, &out.Archive
+		*out = new(ArchiveStatus)
+		**out = **in
+	}
 	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
 	if in.Errors != nil {
 		in, out := &in.Errors, &out.Errors
@@ -838,6 +1301,16 @@ func (in *SyncStatus) DeepCopyInto(out *SyncStatus) {
 		*out = new(ErrorSummary)
 		**out = **in
 	}
+	if in.Waves != nil {
+		in, out := &in.Waves, &out.Waves
+		*out = new(SyncWaveStatus)
+		**out = **in
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = make([]HookStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -851,6 +1324,22 @@ func (in *SyncStatus) DeepCopy() *SyncStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncWaveStatus) DeepCopyInto(out *SyncWaveStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncWaveStatus.
+func (in *SyncWaveStatus) DeepCopy() *SyncWaveStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncWaveStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValuesFileRef) DeepCopyInto(out *ValuesFileRef) {
 	*out = *in
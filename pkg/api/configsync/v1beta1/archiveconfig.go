@@ -0,0 +1,80 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kpt.dev/configsync/pkg/api/configsync"
+)
+
+// Archive contains configuration specific to importing resources from a
+// tarball served over HTTP(S).
+type Archive struct {
+	// url is the HTTP(S) URL of the gzipped tarball to sync from, e.g.
+	// `https://BUCKET.s3.REGION.amazonaws.com/configs.tar.gz` for an
+	// S3-compatible bucket, or any other `.tar.gz` file served over HTTP(S).
+	// Required
+	URL string `json:"url"`
+
+	// dir is the absolute path of the directory that contains
+	// the local resources.  Default: the root directory of the archive.
+	// +optional
+	Dir string `json:"dir,omitempty"`
+
+	// period is the time duration between consecutive syncs. Default: 15s.
+	// The archive is only downloaded again when its ETag or, if the server
+	// doesn't return an ETag, its SHA-256 checksum changed.
+	// Note to developers that customers specify this value using
+	// string (https://golang.org/pkg/time/#Duration.String) like "3s"
+	// in their Custom Resource YAML. However, time.Duration is at a nanosecond
+	// granularity, and it is easy to introduce a bug where it looks like the
+	// code is dealing with seconds but its actually nanoseconds (or vice versa).
+	// +optional
+	Period metav1.Duration `json:"period,omitempty"`
+
+	// auth is the type of secret configured for access to the archive.
+	// Must be one of none, token, or hmac.
+	// - token: the secret contains either a `token` key, sent as a bearer
+	//   token, or the `username` and `password` keys, sent with basic auth.
+	// - hmac: the secret contains the `access_key_id` and
+	//   `secret_access_key` keys of an HMAC key, used to sign the requests to
+	//   an S3-compatible bucket.
+	// The validation of this is case-sensitive. Required.
+	//
+	// +kubebuilder:validation:Enum=none;token;hmac
+	Auth configsync.AuthType `json:"auth"`
+
+	// secretRef is the secret used to connect to the archive, when auth is
+	// token or hmac. For RepoSync resources, the secret must be created in
+	// the same namespace as the RepoSync. For RootSync resource, the secret
+	// must be created in the config-management-system namespace.
+	// +nullable
+	// +optional
+	SecretRef *SecretReference `json:"secretRef,omitempty"`
+
+	// region is the region of the S3-compatible bucket, used to sign the
+	// requests when auth is hmac. Default: us-east-1.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// caCertSecretRef specifies the name of the secret where the CA certificate is stored.
+	// The creation of the secret should be done out of band by the user and should store the
+	// certificate in a key named "cert". For RepoSync resources, the secret must be
+	// created in the same namespace as the RepoSync. For RootSync resource, the secret
+	// must be created in the config-management-system namespace.
+	// +nullable
+	// +optional
+	CACertSecretRef *SecretReference `json:"caCertSecretRef,omitempty"`
+}
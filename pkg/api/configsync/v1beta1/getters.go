@@ -59,6 +59,37 @@ func (rs *RootSyncSpec) SafeOverride() *RootSyncOverrideSpec {
 	return rs.Override
 }
 
+// IsHermeticRendering returns whether the rendering must not fetch remote
+// sources, defaulting to false if unset.
+func (o *OverrideSpec) IsHermeticRendering() bool {
+	return o.Rendering != nil && o.Rendering.Hermetic != nil && *o.Rendering.Hermetic
+}
+
+// AllowsExecFunctions returns whether the Kptfile pipelines may run exec
+// functions, defaulting to false if unset.
+func (o *OverrideSpec) AllowsExecFunctions() bool {
+	return o.Rendering != nil && o.Rendering.AllowExecFunctions != nil && *o.Rendering.AllowExecFunctions
+}
+
+// IsServerSideDryRun returns whether the changed objects must be validated
+// with a server-side dry-run before they are applied, defaulting to false if
+// unset.
+func (o *OverrideSpec) IsServerSideDryRun() bool {
+	return o.ServerSideDryRun != nil && *o.ServerSideDryRun
+}
+
+// IsSchemaValidation returns whether the declared objects must be validated
+// against the OpenAPI schemas of their types, defaulting to false if unset.
+func (o *OverrideSpec) IsSchemaValidation() bool {
+	return o.SchemaValidation != nil && *o.SchemaValidation
+}
+
+// IsDriftReportMode returns whether the drift must be reported instead of
+// reverted, defaulting to false if unset.
+func (o *OverrideSpec) IsDriftReportMode() bool {
+	return o.DriftMode == configsync.DriftModeReport
+}
+
 // GetReconcileTimeout returns reconcile timeout in string, defaulting to 5m if empty
 func GetReconcileTimeout(d *metav1.Duration) string {
 	if d == nil || d.Duration == 0 {
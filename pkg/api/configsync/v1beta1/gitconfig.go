@@ -36,6 +36,11 @@ type Git struct {
 	// is specified in the 'branch' field.
 	// If neither 'revision' nor 'branch' is specified, it defaults to the HEAD of
 	// the 'master' branch.
+	// 'revision' may also be a semantic version constraint, like
+	// '>=1.4.0 <2.0.0' or '~1.4', to track the highest matching tag. A
+	// constraint must include one of the operators '<', '>', '=', '!=', '~',
+	// '^' or '*', so it can't be mistaken for a ref. The tags are resolved by
+	// the git-sync container on each period, with its credentials.
 	// +optional
 	Revision string `json:"revision,omitempty"`
 
@@ -44,6 +49,32 @@ type Git struct {
 	// +optional
 	Dir string `json:"dir,omitempty"`
 
+	// sparseCheckout specifies whether to only check out the directory specified
+	// by 'dir', and the Kustomize bases it references, instead of the whole
+	// repository. This reduces the time and memory required to fetch large
+	// repositories.
+	// The repository is fetched as a partial clone, without the blobs outside
+	// of the checked out paths. Kustomize bases outside of 'dir' are only
+	// discovered once 'dir' is checked out: the first render of a commit
+	// referencing a new base fails, and the reconciler Pod is rolled out again
+	// to check out the base.
+	// Default: false.
+	// +optional
+	SparseCheckout bool `json:"sparseCheckout,omitempty"`
+
+	// submodules specifies how to fetch the Git submodules of the repository.
+	// Must be one of off, shallow, recursive. With shallow, only the submodules
+	// of the repository are fetched, not their own submodules. Default: recursive.
+	// +kubebuilder:validation:Enum=off;shallow;recursive
+	// +optional
+	Submodules configsync.GitSubmodules `json:"submodules,omitempty"`
+
+	// lfs specifies whether to download the Git LFS objects of the repository,
+	// instead of checking out the LFS pointer files. git-lfs must be installed
+	// in the git-sync image, otherwise the checkout fails. Default: false.
+	// +optional
+	LFS bool `json:"lfs,omitempty"`
+
 	// period is the time duration between consecutive syncs. Default: 15s.
 	// Note to developers that customers specify this value using
 	// string (https://golang.org/pkg/time/#Duration.String) like "3s"
@@ -93,6 +124,64 @@ type Git struct {
 	// +nullable
 	// +optional
 	CACertSecretRef *SecretReference `json:"caCertSecretRef,omitempty"`
+
+	// verification specifies how to verify the signature of the synced commit.
+	// When set, the reconciler refuses to sync a commit that is not signed by
+	// one of the trusted keys.
+	// +nullable
+	// +optional
+	Verification *GitVerification `json:"verification,omitempty"`
+
+	// helm specifies how to render the Helm chart stored in the sync directory.
+	// A chart is detected by a Chart.yaml file in spec.git.dir, and rendered
+	// by the hydration-controller, with the default values of the chart when
+	// helm is not set.
+	// +nullable
+	// +optional
+	Helm *GitHelm `json:"helm,omitempty"`
+}
+
+// GitHelm contains the configs to render a Helm chart stored in a Git repo.
+type GitHelm struct {
+	// releaseName is the name of the Helm release.
+	// +optional
+	ReleaseName string `json:"releaseName,omitempty"`
+
+	// namespace sets the value of {{Release.Namespace}} defined in the chart
+	// templates. Default: default.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// valuesFiles are the paths of the values files in the repo, relative to
+	// spec.git.dir, e.g. a values-prod.yaml file next to the Chart.yaml file.
+	// When multiple values files are specified, duplicated keys in later files
+	// will override the value from earlier files.
+	// +optional
+	ValuesFiles []string `json:"valuesFiles,omitempty"`
+
+	// valuesFileRefs holds references to objects in the cluster that represent
+	// values to use instead of default values that accompany the chart. Currently,
+	// only ConfigMaps are supported. The ConfigMaps must be immutable and in the same
+	// namespace as the RootSync/RepoSync. Fields from `valuesFileRefs` override
+	// fields from `valuesFiles`.
+	// +optional
+	ValuesFileRefs []ValuesFileRef `json:"valuesFileRefs,omitempty"`
+
+	// includeCRDs specifies if Helm template should also generate CustomResourceDefinitions.
+	// Default: false.
+	// +optional
+	IncludeCRDs bool `json:"includeCRDs,omitempty"`
+}
+
+// GitVerification contains the configs to verify Git commit signatures.
+type GitVerification struct {
+	// trustedKeysSecretRef specifies the name of the secret where the trusted
+	// public keys are stored. Each key in the secret data may contain either
+	// an ASCII-armored GPG public keyring or SSH public keys in the
+	// authorized_keys format. For RepoSync resources, the secret must be
+	// created in the same namespace as the RepoSync. For RootSync resource,
+	// the secret must be created in the config-management-system namespace.
+	TrustedKeysSecretRef *SecretReference `json:"trustedKeysSecretRef"`
 }
 
 // SecretReference contains the reference to the secret used to connect to
@@ -58,8 +58,8 @@ type RepoSyncSpec struct {
 
 	// sourceType specifies the type of the source of truth.
 	//
-	// Must be one of git, oci, helm. Optional. Set to git if not specified.
-	// +kubebuilder:validation:Pattern=^(git|oci|helm)$
+	// Must be one of git, oci, helm, archive. Optional. Set to git if not specified.
+	// +kubebuilder:validation:Pattern=^(git|oci|helm|archive)$
 	// +kubebuilder:default:=git
 	// +kubebuilder:validation:Type:=string
 	// +optional
@@ -73,6 +73,11 @@ type RepoSyncSpec struct {
 	// +optional
 	Oci *Oci `json:"oci,omitempty"`
 
+	// archive contains configuration specific to importing resources from a
+	// tarball served over HTTP(S).
+	// +optional
+	Archive *Archive `json:"archive,omitempty"`
+
 	// helm contains configuration specific to importing resources from a Helm repo.
 	// +optional
 	Helm *HelmRepoSync `json:"helm,omitempty"`
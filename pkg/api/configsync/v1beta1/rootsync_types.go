@@ -58,8 +58,8 @@ type RootSyncSpec struct {
 
 	// sourceType specifies the type of the source of truth.
 	//
-	// Must be one of git, oci, helm. Optional. Set to git if not specified.
-	// +kubebuilder:validation:Pattern=^(git|oci|helm)$
+	// Must be one of git, oci, helm, archive. Optional. Set to git if not specified.
+	// +kubebuilder:validation:Pattern=^(git|oci|helm|archive)$
 	// +kubebuilder:default:=git
 	// +kubebuilder:validation:Type:=string
 	// +optional
@@ -73,6 +73,11 @@ type RootSyncSpec struct {
 	// +optional
 	Oci *Oci `json:"oci,omitempty"`
 
+	// archive contains configuration specific to importing resources from a
+	// tarball served over HTTP(S).
+	// +optional
+	Archive *Archive `json:"archive,omitempty"`
+
 	// helm contains configuration specific to importing resources from a Helm repo.
 	// +optional
 	Helm *HelmRootSync `json:"helm,omitempty"`
@@ -81,6 +86,90 @@ type RootSyncSpec struct {
 	// +nullable
 	// +optional
 	Override *RootSyncOverrideSpec `json:"override,omitempty"`
+
+	// sources is a list of additional sources of truth. Each source is fetched
+	// into its own directory by its own sidecar container, and the objects
+	// declared in all the sources are synced as a single inventory, along with
+	// the objects from the primary source. An object must not be declared by
+	// more than one source.
+	//
+	// Additional sources are only supported with the unstructured format.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Sources []RootSyncSource `json:"sources,omitempty"`
+
+	// rollout gates the sync of new commits on the health of a canary
+	// cluster group. If set, a new commit is only synced after the canary
+	// cluster group reports that it synced the commit.
+	// The commits must match exactly: while the canary cluster group reports
+	// a newer commit, the sync waits until this RootSync fetches that commit
+	// too. The gated commit is reported in the source errors.
+	// +optional
+	Rollout *RolloutSpec `json:"rollout,omitempty"`
+}
+
+// RootSyncSource is an additional source of truth of a RootSync.
+type RootSyncSource struct {
+	// name uniquely identifies the source among the sources of the RootSync.
+	// It is used to name the container and the directory the source is
+	// fetched into.
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +kubebuilder:validation:MaxLength=40
+	Name string `json:"name"`
+
+	// sourceType specifies the type of the source of truth.
+	//
+	// Must be one of git, oci, helm. Optional. Set to git if not specified.
+	// +kubebuilder:validation:Pattern=^(git|oci|helm)$
+	// +kubebuilder:default:=git
+	// +kubebuilder:validation:Type:=string
+	// +optional
+	SourceType configsync.SourceType `json:"sourceType,omitempty"`
+
+	// git contains configuration specific to importing resources from a Git repo.
+	// +optional
+	Git *Git `json:"git,omitempty"`
+
+	// oci contains configuration specific to importing resources from an OCI package.
+	// +optional
+	Oci *Oci `json:"oci,omitempty"`
+
+	// helm contains configuration specific to importing resources from a Helm repo.
+	// +optional
+	Helm *HelmRootSync `json:"helm,omitempty"`
+}
+
+// RolloutSpec gates the sync of new commits on the health of a canary
+// cluster group.
+//
+// The health source maps the name of each cluster group to the latest commit
+// that all the clusters of the group have synced. Exactly one of configMapRef
+// and url must be specified.
+type RolloutSpec struct {
+	// canaryGroup is the name of the cluster group which must report that it
+	// synced a commit, before this RootSync syncs it. Required.
+	CanaryGroup string `json:"canaryGroup"`
+
+	// configMapRef references the ConfigMap with the health of the cluster
+	// groups, keyed by the name of the group.
+	// +optional
+	ConfigMapRef *RolloutConfigMapRef `json:"configMapRef,omitempty"`
+
+	// url is the HTTP endpoint which serves the health of the cluster groups,
+	// as a JSON object keyed by the name of the group.
+	// +optional
+	URL string `json:"url,omitempty"`
+}
+
+// RolloutConfigMapRef references the ConfigMap with the health of the
+// cluster groups.
+type RolloutConfigMapRef struct {
+	// name is the name of the ConfigMap. Required.
+	Name string `json:"name"`
+
+	// namespace is the namespace of the ConfigMap. Required.
+	Namespace string `json:"namespace"`
 }
 
 // RootSyncStatus defines the observed state of RootSync
@@ -66,11 +66,21 @@ type SourceStatus struct {
 	// +optional
 	Helm *HelmStatus `json:"helmStatus,omitempty"`
 
+	// archiveStatus contains fields describing the status of an archive
+	// source of truth.
+	// +optional
+	Archive *ArchiveStatus `json:"archiveStatus,omitempty"`
+
 	// hash of the source of truth that is rendered.
 	// It can be a git commit hash, or an OCI image digest.
 	// +optional
 	Commit string `json:"commit,omitempty"`
 
+	// sources contains the status of the additional sources of truth of a
+	// RootSync, listed in spec.sources.
+	// +optional
+	Sources []AdditionalSourceStatus `json:"sources,omitempty"`
+
 	// lastUpdate is the timestamp of when this status was last updated by a
 	// reconciler.
 	// +nullable
@@ -86,6 +96,30 @@ type SourceStatus struct {
 	ErrorSummary *ErrorSummary `json:"errorSummary,omitempty"`
 }
 
+// AdditionalSourceStatus describes the status of an additional source of truth
+// of a RootSync.
+type AdditionalSourceStatus struct {
+	// name of the source in spec.sources.
+	Name string `json:"name"`
+
+	// gitStatus contains fields describing the status of a Git source of truth.
+	// +optional
+	Git *GitStatus `json:"gitStatus,omitempty"`
+
+	// ociStatus contains fields describing the status of an OCI source of truth.
+	// +optional
+	Oci *OciStatus `json:"ociStatus,omitempty"`
+
+	// helmStatus contains fields describing the status of a Helm source of truth.
+	// +optional
+	Helm *HelmStatus `json:"helmStatus,omitempty"`
+
+	// hash of the source of truth that is synced.
+	// It can be a git commit hash, or an OCI image digest.
+	// +optional
+	Commit string `json:"commit,omitempty"`
+}
+
 // RenderingStatus describes the status of rendering the source DRY configs to the WET format.
 type RenderingStatus struct {
 	// gitStatus contains fields describing the status of a Git source of truth.
@@ -100,6 +134,11 @@ type RenderingStatus struct {
 	// +optional
 	Helm *HelmStatus `json:"helmStatus,omitempty"`
 
+	// archiveStatus contains fields describing the status of an archive
+	// source of truth.
+	// +optional
+	Archive *ArchiveStatus `json:"archiveStatus,omitempty"`
+
 	// hash of the source of truth that is rendered.
 	// It can be a git commit hash, or an OCI image digest.
 	// +optional
@@ -137,6 +176,11 @@ type SyncStatus struct {
 	// +optional
 	Helm *HelmStatus `json:"helmStatus,omitempty"`
 
+	// archiveStatus contains fields describing the status of an archive
+	// source of truth.
+	// +optional
+	Archive *ArchiveStatus `json:"archiveStatus,omitempty"`
+
 	// hash of the source of truth that is rendered.
 	// It can be a git commit hash, or an OCI image digest.
 	// +optional
@@ -156,6 +200,17 @@ type SyncStatus struct {
 	// errorSummary summarizes the errors encountered during the process of syncing the resources.
 	// +optional
 	ErrorSummary *ErrorSummary `json:"errorSummary,omitempty"`
+
+	// waves describes the progress of applying the sync waves of the commit.
+	// Only set when the objects are grouped into more than one sync wave,
+	// using the `configsync.gke.io/sync-wave` annotation.
+	// +optional
+	Waves *SyncWaveStatus `json:"waves,omitempty"`
+
+	// hooks are the outcomes of the sync hooks run for the commit, declared
+	// using the `configsync.gke.io/hook` annotation.
+	// +optional
+	Hooks []HookStatus `json:"hooks,omitempty"`
 }
 
 // GitStatus describes the status of a Git source of truth.
@@ -166,12 +221,38 @@ type GitStatus struct {
 	// revision is the git revision (tag, ref, or commit) being fetched.
 	Revision string `json:"revision"`
 
+	// revisionConstraint is the semantic version constraint in
+	// 'spec.git.revision', if any. The 'revision' is then the highest tag
+	// matching the constraint.
+	// +optional
+	RevisionConstraint string `json:"revisionConstraint,omitempty"`
+
 	// branch is the git branch being fetched
 	Branch string `json:"branch"`
 
 	// dir is the path within the Git repository that represents the top level of the repo to sync.
 	// Default: the root directory of the repository
 	Dir string `json:"dir"`
+
+	// sparsePaths are the paths within the Git repository which are checked
+	// out, when sparse checkout is enabled. They include the 'dir' and the
+	// Kustomize bases it references.
+	// +optional
+	SparsePaths []string `json:"sparsePaths,omitempty"`
+
+	// submodules are the Git submodules checked out with the commit, and the
+	// commits they are resolved to.
+	// +optional
+	Submodules []GitSubmoduleStatus `json:"submodules,omitempty"`
+}
+
+// GitSubmoduleStatus describes a Git submodule checked out with the commit.
+type GitSubmoduleStatus struct {
+	// path is the path of the submodule within the Git repository.
+	Path string `json:"path"`
+
+	// commit is the commit hash the submodule is resolved to.
+	Commit string `json:"commit"`
 }
 
 // OciStatus describes the status of the source of truth of an OCI image.
@@ -182,6 +263,25 @@ type OciStatus struct {
 	// dir is the absolute path of the directory that contains the local resources.
 	// Default: the root directory of the repository
 	Dir string `json:"dir"`
+
+	// tag is the tag of the image being synced, if the image was pulled by
+	// tag. When spec.oci.tagPolicy is set, it is the resolved tag.
+	// +optional
+	Tag string `json:"tag,omitempty"`
+
+	// digest is the digest of the image being synced, e.g. `sha256:...`.
+	// +optional
+	Digest string `json:"digest,omitempty"`
+}
+
+// ArchiveStatus describes the status of an archive source of truth.
+type ArchiveStatus struct {
+	// url is the URL of the archive being synced from.
+	URL string `json:"url"`
+
+	// dir is the absolute path of the directory that contains the local resources.
+	// Default: the root directory of the archive
+	Dir string `json:"dir"`
 }
 
 // HelmStatus describes the status of a Helm source of truth.
@@ -194,6 +294,17 @@ type HelmStatus struct {
 
 	// chart is the name of helm chart being fetched
 	Chart string `json:"chart"`
+
+	// digest is the digest of the verified chart: the SHA-256 checksum of the
+	// chart archive for HTTP(S) repositories, or the image digest for OCI
+	// registries. Only set when spec.helm.verify is specified.
+	// +optional
+	Digest string `json:"digest,omitempty"`
+
+	// signer is the identity of the trusted signer of the chart.
+	// Only set when spec.helm.verify is specified.
+	// +optional
+	Signer string `json:"signer,omitempty"`
 }
 
 // ConfigSyncError represents an error that occurs while parsing, applying, or
@@ -225,6 +336,37 @@ type ErrorSummary struct {
 	ErrorCountAfterTruncation int `json:"errorCountAfterTruncation,omitempty"`
 }
 
+// SyncWaveStatus describes the progress of applying the sync waves.
+type SyncWaveStatus struct {
+	// total is the number of sync waves.
+	Total int `json:"total"`
+	// completed is the number of sync waves which were applied, and whose
+	// objects have all reconciled.
+	Completed int `json:"completed"`
+	// current is the `configsync.gke.io/sync-wave` annotation value of the
+	// sync wave being applied, or of the last sync wave which was applied.
+	Current int `json:"current"`
+}
+
+// HookStatus describes the outcome of a sync hook.
+type HookStatus struct {
+	// phase is the `configsync.gke.io/hook` annotation value of the hook:
+	// pre-sync, post-sync, or sync-fail.
+	Phase string `json:"phase"`
+	// kind is the kind of the hook object: Job or Pod.
+	Kind string `json:"kind"`
+	// namespace is the namespace of the hook object.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// name is the name of the hook object.
+	Name string `json:"name"`
+	// succeeded is true if the hook ran to completion successfully.
+	Succeeded bool `json:"succeeded"`
+	// message describes why the hook failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 // ResourceRef contains the identification bits of a single managed resource.
 type ResourceRef struct {
 	// sourcePath is the repo-relative slash path to where the config is defined.
@@ -83,6 +83,11 @@ type ResourceGroupStatus struct {
 	// conditions lists the conditions of the current status for the group
 	// +optional
 	Conditions []Condition `json:"conditions,omitempty"`
+
+	// driftedResources lists the resources which drifted from their declared
+	// state, when the drift is reported instead of corrected.
+	// +optional
+	DriftedResources []DriftedResource `json:"driftedResources,omitempty"`
 }
 
 // each item organizes and stores the identifying information
@@ -198,6 +203,18 @@ type ResourceStatus struct {
 	Reconcile   Reconcile   `json:"reconcile,omitempty"`
 }
 
+// each item describes how a given resource drifted from its declared state.
+type DriftedResource struct {
+	ObjMetadata `json:",inline"`
+	// operation is the operation which would correct the drift.
+	// Possible values: create, update, delete.
+	Operation string `json:"operation"`
+	// fields lists the paths of the declared fields which differ on the
+	// cluster.
+	// +optional
+	Fields []string `json:"fields,omitempty"`
+}
+
 // Each item contains the status of a given group uniquely identified by
 // its name and namespace.
 type GroupStatus struct {
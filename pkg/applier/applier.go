@@ -32,9 +32,11 @@ import (
 	"kpt.dev/configsync/pkg/applier/stats"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/diff"
 	"kpt.dev/configsync/pkg/kinds"
 	"kpt.dev/configsync/pkg/metadata"
 	m "kpt.dev/configsync/pkg/metrics"
+	"kpt.dev/configsync/pkg/remediator/drift"
 	"kpt.dev/configsync/pkg/resourcegroup"
 	"kpt.dev/configsync/pkg/status"
 	"kpt.dev/configsync/pkg/syncer/differ"
@@ -48,6 +50,7 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/apply/filter"
 	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -91,6 +94,10 @@ type EventType string
 const (
 	// ErrorEventType is the type of the ErrorEvent
 	ErrorEventType EventType = "ErrorEvent"
+	// SyncWaveEventType is the type of the SyncWaveEvent
+	SyncWaveEventType EventType = "SyncWaveEvent"
+	// HookEventType is the type of the HookEvent
+	HookEventType EventType = "HookEvent"
 )
 
 // Event is sent to the eventHandler by the supervisor.
@@ -110,6 +117,41 @@ func (e ErrorEvent) Type() EventType {
 	return ErrorEventType
 }
 
+// SyncWaveEvent is sent when the supervisor starts applying a sync wave, and
+// after the objects of the sync wave have reconciled.
+// It is only sent when the objects are grouped into more than one sync wave.
+type SyncWaveEvent struct {
+	// Wave is the sync-wave annotation value of the sync wave.
+	Wave int
+	// Total is the number of sync waves.
+	Total int
+	// Completed is the number of sync waves which were applied, and whose
+	// objects have all reconciled.
+	Completed int
+}
+
+// Type returns the type of the event.
+func (e SyncWaveEvent) Type() EventType {
+	return SyncWaveEventType
+}
+
+// HookEvent is sent after the supervisor has run a sync hook.
+type HookEvent struct {
+	// Phase is the hook annotation value of the hook.
+	Phase metadata.HookPhase
+	// ID is the ID of the hook object.
+	ID core.ID
+	// Succeeded is true if the hook ran to completion successfully.
+	Succeeded bool
+	// Message describes why the hook failed.
+	Message string
+}
+
+// Type returns the type of the event.
+func (e HookEvent) Type() EventType {
+	return HookEventType
+}
+
 // Supervisor is a bulk client for applying and deleting a mutable set of
 // resource objects. Managed objects are tracked in a ResourceGroup inventory
 // object.
@@ -142,6 +184,14 @@ type supervisor struct {
 	// reconcileTimeout controls the reconcile and prune timeout
 	reconcileTimeout time.Duration
 
+	// historyCommit is the latest commit whose changes were recorded in the
+	// sync history, to only record the changes of each commit once.
+	historyCommit string
+	// appliedObjects are the objects of the latest successful applies, to plan
+	// the changes recorded in the sync history without reading the objects
+	// from the cluster.
+	appliedObjects map[core.ID]*unstructured.Unstructured
+
 	// execMux prevents concurrent Apply/Destroy calls
 	execMux sync.Mutex
 }
@@ -495,7 +545,6 @@ func (s *supervisor) checkInventoryObjectSize(ctx context.Context, c client.Clie
 // applyInner triggers a kpt live apply library call to apply a set of resources.
 func (s *supervisor) applyInner(ctx context.Context, eventHandler func(Event), declaredResources *declared.Resources) (ObjectStatusMap, *stats.SyncStats) {
 	s.checkInventoryObjectSize(ctx, s.clientSet.Client)
-	isDestroy := false
 
 	syncStats := stats.NewSyncStats()
 	objStatusMap := make(ObjectStatusMap)
@@ -534,6 +583,23 @@ func (s *supervisor) applyInner(ctx context.Context, eventHandler func(Event), d
 		sendErrorEvent(err, eventHandler)
 		return objStatusMap, syncStats
 	}
+	if err := s.ignoreDifferences(ctx, resources); err != nil {
+		sendErrorEvent(err, eventHandler)
+		return objStatusMap, syncStats
+	}
+	resources, deletedRefs, err := s.keepDrift(ctx, resources)
+	if err != nil {
+		sendErrorEvent(err, eventHandler)
+		return objStatusMap, syncStats
+	}
+	if len(deletedRefs) > 0 {
+		retainer, ok := s.clientSet.InvClient.(*RetainingInventoryClient)
+		if !ok {
+			sendErrorEvent(Error(fmt.Errorf("reporting drift requires a %T, got %T", retainer, s.clientSet.InvClient)), eventHandler)
+			return objStatusMap, syncStats
+		}
+		defer retainer.Retain(deletedRefs)()
+	}
 
 	unknownTypeResources := make(map[core.ID]struct{})
 	options := apply.ApplierOptions{
@@ -569,7 +635,69 @@ func (s *supervisor) applyInner(ctx context.Context, eventHandler func(Event), d
 		resourceMap[idFrom(ObjMetaFromUnstructured(obj))] = obj
 	}
 
-	events := s.clientSet.KptApplier.Run(ctx, s.invInfo, resources, options)
+	// The changes of a new commit are recorded in the sync history.
+	hooks, commit := declaredResources.Hooks()
+	var changes *syncChanges
+	if commit != "" && commit != s.historyCommit {
+		changes = s.planChanges(ctx, resources)
+	}
+
+	// run applies the objects with the options, and returns the number of
+	// errors sent to the eventHandler.
+	run := func(objs []*unstructured.Unstructured, options apply.ApplierOptions) int {
+		events := s.clientSet.KptApplier.Run(ctx, s.invInfo, objs, options)
+		return s.processApplyEvents(ctx, events, eventHandler, syncStats, objStatusMap, unknownTypeResources, resourceMap, declaredResources, changes)
+	}
+
+	// The hooks which are no longer declared are deleted.
+	if errs := s.pruneHooks(ctx, hooks); errs != nil {
+		for _, err := range errs.Errors() {
+			sendErrorEvent(err, eventHandler)
+		}
+	}
+
+	// Pre-sync hooks must succeed before anything is applied.
+	if !s.applyHookNamespaces(ctx, eventHandler, resources, hooks, options, run) {
+		return objStatusMap, syncStats
+	}
+	switch s.runHooks(ctx, eventHandler, metadata.HookPreSync, hooks, commit) {
+	case hooksPending:
+		return objStatusMap, syncStats
+	case hooksFailed:
+		s.runHooks(ctx, eventHandler, metadata.HookSyncFail, hooks, commit)
+		return objStatusMap, syncStats
+	}
+
+	var succeeded bool
+	waves := groupSyncWaves(resources)
+	if len(waves) > 1 {
+		succeeded = s.applySyncWaves(ctx, eventHandler, waves, resources, options, run, objStatusMap)
+	} else {
+		succeeded = run(resources, options) == 0
+	}
+	if changes != nil {
+		s.recordHistory(ctx, commit, changes, succeeded)
+	}
+
+	if succeeded {
+		s.runHooks(ctx, eventHandler, metadata.HookPostSync, hooks, commit)
+	} else {
+		s.runHooks(ctx, eventHandler, metadata.HookSyncFail, hooks, commit)
+	}
+
+	return objStatusMap, syncStats
+}
+
+// processApplyEvents processes the events of an apply run, until the events
+// channel is closed. The changes are collected into changes, if not nil.
+// Returns the number of errors sent to the eventHandler.
+func (s *supervisor) processApplyEvents(ctx context.Context, events <-chan event.Event, eventHandler func(Event), syncStats *stats.SyncStats, objStatusMap ObjectStatusMap, unknownTypeResources map[core.ID]struct{}, resourceMap map[core.ID]client.Object, declaredResources *declared.Resources, changes *syncChanges) int {
+	isDestroy := false
+	errCount := 0
+	sendError := func(err error) {
+		sendErrorEvent(err, eventHandler)
+		errCount++
+	}
 	for e := range events {
 		switch e.Type {
 		case event.InitType:
@@ -584,7 +712,7 @@ func (s *supervisor) applyInner(ctx context.Context, eventHandler func(Event), d
 			if util.IsRequestTooLargeError(err) {
 				err = largeResourceGroupError(err, coreIDFromInventoryInfo(s.invInfo))
 			}
-			sendErrorEvent(err, eventHandler)
+			sendError(err)
 			syncStats.ErrorTypeEvents++
 		case event.WaitType:
 			// Pending events are sent for any objects that haven't reconciled
@@ -596,7 +724,7 @@ func (s *supervisor) applyInner(ctx context.Context, eventHandler func(Event), d
 				klog.V(1).Info(e.WaitEvent)
 			}
 			if err := s.processWaitEvent(e.WaitEvent, syncStats.WaitEvent, objStatusMap, isDestroy); err != nil {
-				sendErrorEvent(err, eventHandler)
+				sendError(err)
 			}
 		case event.ApplyType:
 			if e.ApplyEvent.Error != nil {
@@ -605,8 +733,13 @@ func (s *supervisor) applyInner(ctx context.Context, eventHandler func(Event), d
 				klog.V(1).Info(e.ApplyEvent)
 			}
 			if err := s.processApplyEvent(ctx, e.ApplyEvent, syncStats.ApplyEvent, objStatusMap, unknownTypeResources, resourceMap); err != nil {
-				sendErrorEvent(err, eventHandler)
+				sendError(err)
+			}
+			if e.ApplyEvent.Status == event.ApplySuccessful {
+				id := idFrom(e.ApplyEvent.Identifier)
+				s.cacheAppliedObject(id, resourceMap[id])
 			}
+			changes.addApplyEvent(e.ApplyEvent)
 		case event.PruneType:
 			if e.PruneEvent.Error != nil {
 				klog.Info(e.PruneEvent)
@@ -614,14 +747,18 @@ func (s *supervisor) applyInner(ctx context.Context, eventHandler func(Event), d
 				klog.V(1).Info(e.PruneEvent)
 			}
 			if err := s.processPruneEvent(ctx, e.PruneEvent, syncStats.PruneEvent, objStatusMap, declaredResources); err != nil {
-				sendErrorEvent(err, eventHandler)
+				sendError(err)
 			}
+			if e.PruneEvent.Status == event.PruneSuccessful {
+				delete(s.appliedObjects, idFrom(e.PruneEvent.Identifier))
+			}
+			changes.addPruneEvent(e.PruneEvent)
 		default:
 			klog.Infof("Unhandled event (%s): %v", e.Type, e)
 		}
 	}
 
-	return objStatusMap, syncStats
+	return errCount
 }
 
 func sendErrorEvent(err error, eventHandler func(Event)) {
@@ -702,6 +839,13 @@ func (s *supervisor) destroyInner(ctx context.Context, eventHandler func(Event))
 		}
 	}
 
+	// The hooks are not in the inventory, so they are deleted separately.
+	if errs := s.pruneHooks(ctx, nil); errs != nil {
+		for _, err := range errs.Errors() {
+			sendErrorEvent(err, eventHandler)
+		}
+	}
+
 	return objStatusMap, syncStats
 }
 
@@ -746,10 +890,7 @@ func (s *supervisor) handleDisabledObjects(ctx context.Context, objs []client.Ob
 	var disabledCount uint64
 	err := s.removeFromInventory(ctx, objs)
 	if err != nil {
-		if nomosutil.IsRequestTooLargeError(err) {
-			return disabledCount, largeResourceGroupError(err, coreIDFromInventoryInfo(s.invInfo))
-		}
-		return disabledCount, Error(err)
+		return disabledCount, s.inventoryError(err)
 	}
 	var errs status.MultiError
 	for _, obj := range objs {
@@ -784,6 +925,25 @@ func (s *supervisor) removeFromInventory(ctx context.Context, objs []client.Obje
 	return s.clientSet.InvClient.CreateOrUpdate(ctx, inv, inventory.UpdateOptions{})
 }
 
+// inventoryObjectRefs returns the objects in the inventory, if it exists.
+func (s *supervisor) inventoryObjectRefs(ctx context.Context) (object.ObjMetadataSet, error) {
+	inv, err := s.clientSet.InvClient.Get(ctx, s.invInfo, inventory.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return inv.GetObjectRefs(), nil
+}
+
+// inventoryError wraps an error reading or updating the inventory.
+func (s *supervisor) inventoryError(err error) status.Error {
+	if nomosutil.IsRequestTooLargeError(err) {
+		return largeResourceGroupError(err, coreIDFromInventoryInfo(s.invInfo))
+	}
+	return Error(err)
+}
+
 // abandonObject removes ConfigSync labels and annotations from an object,
 // disabling management.
 func (s *supervisor) abandonObject(ctx context.Context, obj client.Object) error {
@@ -828,6 +988,80 @@ func (s *supervisor) abandonObject(ctx context.Context, obj client.Object) error
 	return nil
 }
 
+// ignoreDifferences sets the fields selected by the ignore rules to their
+// values on the cluster, in the objects to apply. The ignored fields are not
+// removed instead, because a field removed from the applied configuration is
+// deleted by Server-Side Apply.
+func (s *supervisor) ignoreDifferences(ctx context.Context, objs []*unstructured.Unstructured) status.Error {
+	for i, obj := range objs {
+		if !s.clientSet.IgnoreRules.Matches(obj) {
+			continue
+		}
+		uObj := &unstructured.Unstructured{}
+		uObj.SetGroupVersionKind(obj.GroupVersionKind())
+		err := s.clientSet.Client.Get(ctx, client.ObjectKeyFromObject(obj), uObj)
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			// The object is created with the declared values.
+			continue
+		} else if err != nil {
+			return status.APIServerError(err, "failed to get the ignored fields of the object", obj)
+		}
+		d, statusErr := diff.Diff{Declared: obj, Actual: uObj}.IgnoringDifferences(s.clientSet.IgnoreRules)
+		if statusErr != nil {
+			return statusErr
+		}
+		objs[i] = d.Declared.(*unstructured.Unstructured)
+	}
+	return nil
+}
+
+// keepDrift keeps the drift recorded when the drift is reported instead of
+// reverted. The drifted fields are set to their values on the cluster, in the
+// objects to apply. The objects deleted from the cluster are not applied, so
+// they are not recreated, and their references are returned, to retain them
+// in the inventory.
+func (s *supervisor) keepDrift(ctx context.Context, objs []*unstructured.Unstructured) ([]*unstructured.Unstructured, object.ObjMetadataSet, status.Error) {
+	if s.clientSet.DriftHandler == nil {
+		return objs, nil, nil
+	}
+	drifts := make(map[core.ID]drift.Drift)
+	for _, d := range s.clientSet.DriftHandler.Drifts() {
+		drifts[d.ID] = d
+	}
+	if len(drifts) == 0 {
+		return objs, nil, nil
+	}
+	var result []*unstructured.Unstructured
+	var deletedRefs object.ObjMetadataSet
+	for _, obj := range objs {
+		d, found := drifts[core.IDOf(obj)]
+		if !found || (d.Operation != diff.Create && d.Operation != diff.Update) {
+			result = append(result, obj)
+			continue
+		}
+		uObj := &unstructured.Unstructured{}
+		uObj.SetGroupVersionKind(obj.GroupVersionKind())
+		err := s.clientSet.Client.Get(ctx, client.ObjectKeyFromObject(obj), uObj)
+		switch {
+		case apierrors.IsNotFound(err):
+			klog.Infof("Skipping the apply of %s: the object was deleted and its drift is reported", d.ID)
+			deletedRefs = append(deletedRefs, object.UnstructuredToObjMetadata(obj))
+			continue
+		case meta.IsNoMatchError(err):
+			result = append(result, obj)
+			continue
+		case err != nil:
+			return nil, nil, status.APIServerError(err, "failed to get the drifted fields of the object", obj)
+		}
+		kept, statusErr := diff.Diff{Declared: obj, Actual: uObj}.KeepingFields(d.Fields)
+		if statusErr != nil {
+			return nil, nil, statusErr
+		}
+		result = append(result, kept.Declared.(*unstructured.Unstructured))
+	}
+	return result, deletedRefs, nil
+}
+
 // cacheIgnoreMutationObjects gets the current cluster state of any declared objects with the ignore mutation annotation and puts it in the Resources ignore objects cache
 // Returns any errors that occur
 func (s *supervisor) cacheIgnoreMutationObjects(ctx context.Context, declaredResources *declared.Resources) error {
@@ -19,6 +19,7 @@ import (
 	"strings"
 
 	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/status"
 	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -71,6 +72,24 @@ func SkipErrorForResource(err error, id core.ID, strategy actuation.ActuationStr
 		strings.ToLower(strategy.String()), id, err)).Build()
 }
 
+// syncWaveError indicates that some objects of a sync wave were not applied
+// or did not reconcile, so the later sync waves were not applied.
+func syncWaveError(wave int, ids []core.ID) status.Error {
+	return applierErrorBuilder.Sprintf("sync wave %d is not ready, skipped applying the later sync waves: "+
+		"objects not applied or reconciled: %s", wave, joinIDs(commaSpaceDelimiter, ids...)).Build()
+}
+
+// hookError indicates that a sync hook failed to run to completion.
+func hookError(phase metadata.HookPhase, id core.ID, err error) status.Error {
+	return applierErrorBuilder.Wrap(fmt.Errorf("%s hook %v failed: %w", phase, id, err)).Build()
+}
+
+// hookPendingError indicates that a sync hook has not completed yet, so the
+// sync is retried later.
+func hookPendingError(phase metadata.HookPhase, id core.ID, reason string) status.Error {
+	return applierErrorBuilder.Sprintf("%s hook %v %s", phase, id, reason).Build()
+}
+
 // largeResourceGroupError indicates that the source repo has too many objects
 // to manage with a single resource group.
 func largeResourceGroupError(err error, id core.ID) status.Error {
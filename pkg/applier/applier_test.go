@@ -37,9 +37,11 @@ import (
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/core/k8sobjects"
 	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/diff"
 	"kpt.dev/configsync/pkg/diff/difftest"
 	"kpt.dev/configsync/pkg/kinds"
 	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/remediator/drift"
 	"kpt.dev/configsync/pkg/remediator/queue"
 	"kpt.dev/configsync/pkg/status"
 	"kpt.dev/configsync/pkg/syncer/reconcile"
@@ -1123,6 +1125,186 @@ func TestProcessWaitEvent(t *testing.T) {
 	}
 }
 
+// fakeSSAKptApplier server-side applies every object with the client, and
+// reports the applies as successful.
+type fakeSSAKptApplier struct {
+	client client.Client
+	// invClient stores the inventory of the applied objects, if not nil.
+	invClient inventory.Client
+}
+
+var _ KptApplier = &fakeSSAKptApplier{}
+
+func (a *fakeSSAKptApplier) Run(ctx context.Context, invInfo inventory.Info, objsToApply object.UnstructuredSet, options apply.ApplierOptions) <-chan event.Event {
+	if a.invClient != nil {
+		inv, err := a.invClient.NewInventory(invInfo)
+		if err != nil {
+			panic(err)
+		}
+		inv.SetObjectRefs(object.UnstructuredSetToObjMetadataSet(objsToApply))
+		if err := a.invClient.CreateOrUpdate(ctx, inv, inventory.UpdateOptions{}); err != nil {
+			panic(err)
+		}
+	}
+	events := make(chan event.Event, len(objsToApply))
+	for _, obj := range objsToApply {
+		obj = obj.DeepCopy()
+		err := a.client.Patch(ctx, obj, client.Apply, client.FieldOwner(options.ServerSideOptions.FieldManager), client.ForceOwnership)
+		if err != nil {
+			events <- formApplyEvent(event.ApplyFailed, obj, err)
+		} else {
+			events <- formApplyEvent(event.ApplySuccessful, obj, nil)
+		}
+	}
+	close(events)
+	return events
+}
+
+func TestApplyIgnoreDifferences(t *testing.T) {
+	syncScope := declared.Scope("test-namespace")
+	syncName := "rs"
+	ignoreRules, err := diff.NewIgnoreRules([]v1beta1.IgnoreDifference{{
+		Group:     "apps",
+		Kind:      "Deployment",
+		JSONPaths: []string{".spec.replicas"},
+	}})
+	require.NoError(t, err)
+
+	declaredObj := newDeploymentObj()
+	require.NoError(t, unstructured.SetNestedField(declaredObj.Object, int64(1), "spec", "replicas"))
+	require.NoError(t, unstructured.SetNestedField(declaredObj.Object, "declared", "spec", "template", "metadata", "labels", "app"))
+
+	fakeClient := testingfake.NewClient(t, core.Scheme)
+	cs := &ClientSet{
+		KptApplier:  &fakeSSAKptApplier{client: fakeClient},
+		Client:      fakeClient,
+		Mapper:      fakeClient.RESTMapper(),
+		IgnoreRules: ignoreRules,
+	}
+	supervisor := NewSupervisor(cs, syncScope, syncName, 5*time.Minute)
+
+	var errs status.MultiError
+	eventHandler := func(e Event) {
+		if errEvent, ok := e.(ErrorEvent); ok {
+			errs = status.Append(errs, errEvent.Error)
+		}
+	}
+	resources := &declared.Resources{}
+	_, err = resources.UpdateDeclared(context.Background(), []client.Object{declaredObj.DeepCopy()}, "")
+	require.NoError(t, err)
+
+	liveField := func(fields ...string) interface{} {
+		t.Helper()
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(declaredObj.GroupVersionKind())
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(declaredObj), live))
+		value, _, err := unstructured.NestedFieldNoCopy(live.Object, fields...)
+		require.NoError(t, err)
+		return value
+	}
+
+	// The object is created with the declared value of the ignored field.
+	supervisor.Apply(context.Background(), eventHandler, resources)
+	require.NoError(t, errs)
+	assert.Equal(t, int64(1), liveField("spec", "replicas"))
+
+	// Another manager, like an autoscaler, changes the ignored field, and
+	// someone changes a field which is not ignored.
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(declaredObj.GroupVersionKind())
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(declaredObj), live))
+	require.NoError(t, unstructured.SetNestedField(live.Object, int64(5), "spec", "replicas"))
+	require.NoError(t, unstructured.SetNestedField(live.Object, "changed", "spec", "template", "metadata", "labels", "app"))
+	// The fake client only supports the Config Sync field manager.
+	require.NoError(t, fakeClient.Update(context.Background(), live, client.FieldOwner(configsync.FieldManager)))
+
+	// The resync keeps the ignored field, and reverts the other field.
+	supervisor.Apply(context.Background(), eventHandler, resources)
+	require.NoError(t, errs)
+	assert.Equal(t, int64(5), liveField("spec", "replicas"))
+	assert.Equal(t, "declared", liveField("spec", "template", "metadata", "labels", "app"))
+}
+
+func TestApplyKeepsReportedDrift(t *testing.T) {
+	syncScope := declared.Scope("test-namespace")
+	syncName := "rs"
+
+	declaredObj := newDeploymentObj()
+	require.NoError(t, unstructured.SetNestedField(declaredObj.Object, int64(1), "spec", "replicas"))
+	require.NoError(t, unstructured.SetNestedField(declaredObj.Object, "declared", "spec", "template", "metadata", "labels", "app"))
+	deletedObj := newDeploymentObj()
+	deletedObj.SetName("deleted")
+
+	fakeClient := testingfake.NewClient(t, core.Scheme)
+	invClient := &RetainingInventoryClient{Client: inventory.NewFakeClient(nil)}
+	driftHandler := drift.NewHandler()
+	cs := &ClientSet{
+		KptApplier:   &fakeSSAKptApplier{client: fakeClient, invClient: invClient},
+		InvClient:    invClient,
+		Client:       fakeClient,
+		Mapper:       fakeClient.RESTMapper(),
+		DriftHandler: driftHandler,
+	}
+	supervisor := NewSupervisor(cs, syncScope, syncName, 5*time.Minute)
+
+	var errs status.MultiError
+	eventHandler := func(e Event) {
+		if errEvent, ok := e.(ErrorEvent); ok {
+			errs = status.Append(errs, errEvent.Error)
+		}
+	}
+	resources := &declared.Resources{}
+	_, err := resources.UpdateDeclared(context.Background(), []client.Object{declaredObj.DeepCopy(), deletedObj.DeepCopy()}, "")
+	require.NoError(t, err)
+
+	live := func(obj *unstructured.Unstructured) *unstructured.Unstructured {
+		t.Helper()
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(obj.GroupVersionKind())
+		err := fakeClient.Get(context.Background(), client.ObjectKeyFromObject(obj), u)
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		require.NoError(t, err)
+		return u
+	}
+	replicas := func(u *unstructured.Unstructured) int64 {
+		t.Helper()
+		value, _, err := unstructured.NestedInt64(u.Object, "spec", "replicas")
+		require.NoError(t, err)
+		return value
+	}
+
+	supervisor.Apply(context.Background(), eventHandler, resources)
+	require.NoError(t, errs)
+
+	// Someone scales up the Deployment and deletes the other Deployment, and
+	// the remediator reports the drift.
+	drifted := live(declaredObj)
+	require.NoError(t, unstructured.SetNestedField(drifted.Object, int64(5), "spec", "replicas"))
+	// The fake client only supports the Config Sync field manager.
+	require.NoError(t, fakeClient.Update(context.Background(), drifted, client.FieldOwner(configsync.FieldManager)))
+	require.NoError(t, fakeClient.Delete(context.Background(), live(deletedObj)))
+	driftHandler.AddDrift(drift.Drift{ID: core.IDOf(declaredObj), Operation: diff.Update, Fields: []string{".spec.replicas"}})
+	driftHandler.AddDrift(drift.Drift{ID: core.IDOf(deletedObj), Operation: diff.Create})
+
+	// The full resync keeps the drift: the drifted field keeps its live value,
+	// and the deleted object is not recreated, but is still in the inventory.
+	supervisor.Apply(context.Background(), eventHandler, resources)
+	require.NoError(t, errs)
+	assert.Equal(t, int64(5), replicas(live(declaredObj)))
+	assert.Nil(t, live(deletedObj))
+	assert.ElementsMatch(t, []string{"random-name", "deleted"}, inventoryNames(invClient))
+
+	// Once the drift is resolved, the objects are applied again.
+	driftHandler.RemoveDrift(core.IDOf(declaredObj))
+	driftHandler.RemoveDrift(core.IDOf(deletedObj))
+	supervisor.Apply(context.Background(), eventHandler, resources)
+	require.NoError(t, errs)
+	assert.Equal(t, int64(1), replicas(live(declaredObj)))
+	assert.NotNil(t, live(deletedObj))
+}
+
 func newDeploymentObj() *unstructured.Unstructured {
 	return k8sobjects.UnstructuredObject(kinds.Deployment(),
 		core.Namespace("test-namespace"), core.Name("random-name"), core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/foo/role.yaml"))
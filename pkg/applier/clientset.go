@@ -16,6 +16,8 @@ package applier
 
 import (
 	"context"
+	"slices"
+	"sync"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/labels"
@@ -23,7 +25,9 @@ import (
 	"k8s.io/kubectl/pkg/cmd/util"
 	csinventory "kpt.dev/configsync/pkg/applier/inventory"
 	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/diff"
 	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/remediator/drift"
 	"sigs.k8s.io/cli-utils/pkg/apply"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
@@ -54,6 +58,12 @@ type ClientSet struct {
 	Mapper       meta.RESTMapper
 	StatusMode   metadata.StatusMode
 	ApplySetID   string
+	// IgnoreRules select the fields whose live values are applied instead of
+	// the declared values, so their differences are not reverted.
+	IgnoreRules diff.IgnoreRules
+	// DriftHandler records the drift reported instead of reverted, which the
+	// applier keeps. Nil unless the drift is reported.
+	DriftHandler drift.Handler
 }
 
 // NewClientSet constructs a new ClientSet.
@@ -62,10 +72,11 @@ func NewClientSet(c client.Client, configFlags *genericclioptions.ConfigFlags, s
 	f := util.NewFactory(matchVersionKubeConfigFlags)
 
 	ic := csinventory.NewInventoryConverter(scope, syncName, statusMode)
-	invClient, err := ic.UnstructuredClientFromFactory(f)
+	unstructuredInvClient, err := ic.UnstructuredClientFromFactory(f)
 	if err != nil {
 		return nil, err
 	}
+	invClient := &RetainingInventoryClient{Client: unstructuredInvClient}
 
 	// Only watch objects applied by this reconciler for status updates.
 	// This reduces both the number of events processed and the memory used by
@@ -109,3 +120,47 @@ func NewClientSet(c client.Client, configFlags *genericclioptions.ConfigFlags, s
 		ApplySetID:   applySetID,
 	}, nil
 }
+
+// RetainingInventoryClient is an inventory client which keeps the retained
+// objects in every inventory it stores. The cli-utils applier replaces the
+// inventory with the objects of each apply run, so the objects which are
+// retained are still tracked after a run which only applies some of the
+// declared objects, like a sync wave.
+type RetainingInventoryClient struct {
+	inventory.Client
+
+	mux      sync.Mutex
+	retained []*object.ObjMetadataSet
+}
+
+var _ inventory.Client = &RetainingInventoryClient{}
+
+// Retain keeps the objects in the inventories stored until the returned
+// function is called. The objects retained by concurrent calls are all kept.
+func (c *RetainingInventoryClient) Retain(refs object.ObjMetadataSet) func() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	entry := &refs
+	c.retained = append(c.retained, entry)
+	return func() {
+		c.mux.Lock()
+		defer c.mux.Unlock()
+		c.retained = slices.DeleteFunc(c.retained, func(e *object.ObjMetadataSet) bool {
+			return e == entry
+		})
+	}
+}
+
+// CreateOrUpdate stores the inventory, with the retained objects.
+func (c *RetainingInventoryClient) CreateOrUpdate(ctx context.Context, inv inventory.Inventory, opts inventory.UpdateOptions) error {
+	c.mux.Lock()
+	var retained object.ObjMetadataSet
+	for _, refs := range c.retained {
+		retained = retained.Union(*refs)
+	}
+	c.mux.Unlock()
+	if len(retained) > 0 {
+		inv.SetObjectRefs(inv.GetObjectRefs().Union(retained))
+	}
+	return c.Client.CreateOrUpdate(ctx, inv, opts)
+}
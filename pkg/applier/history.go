@@ -0,0 +1,177 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/diff"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/synchistory"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// syncChanges collects the changes an apply makes to the managed objects, for
+// the sync history.
+type syncChanges struct {
+	// planned are the changes the apply makes to the objects to apply, from
+	// the objects applied before. Unchanged objects are not planned.
+	planned map[core.ID]synchistory.ObjectChange
+	// objects are the changes made by the apply. Each object is recorded at
+	// most once, with the latest change made by the apply runs.
+	objects []synchistory.ObjectChange
+	// indexes are the indexes of the objects in objects.
+	indexes map[core.ID]int
+}
+
+// planChanges compares the objects to apply with the objects applied before,
+// to tell whether the apply creates or updates them, and which of their
+// declared fields change. The objects which are not in the inventory are
+// created. The changes of the objects in the inventory which were applied
+// before the reconciler started are not known, so they are not recorded.
+func (s *supervisor) planChanges(ctx context.Context, objs []*unstructured.Unstructured) *syncChanges {
+	changes := &syncChanges{
+		planned: make(map[core.ID]synchistory.ObjectChange),
+		indexes: make(map[core.ID]int),
+	}
+	refs, invErr := s.inventoryObjectRefs(ctx)
+	if invErr != nil {
+		klog.Warningf("Failed to get the inventory for the sync history: %v", invErr)
+	}
+	inventoryIDs := make(map[core.ID]struct{}, len(refs))
+	for _, ref := range refs {
+		inventoryIDs[idFrom(ref)] = struct{}{}
+	}
+	for _, obj := range objs {
+		id := core.IDOf(obj)
+		if applied, found := s.appliedObjects[id]; found {
+			fields, err := s.changedFields(obj, applied)
+			if err != nil {
+				klog.Warningf("Failed to diff %s for the sync history: %v", id, err)
+			}
+			if len(fields) > 0 || err != nil {
+				changes.planned[id] = objectChange(id, synchistory.Update, fields)
+			}
+		} else if _, found := inventoryIDs[id]; !found && invErr == nil {
+			changes.planned[id] = objectChange(id, synchistory.Create, nil)
+		}
+	}
+	return changes
+}
+
+// changedFields returns the declared fields which differ from the object
+// applied before, ignoring the Config Sync metadata, which changes with every
+// commit, and the ignored fields, which are applied with their values on the
+// cluster.
+func (s *supervisor) changedFields(declared, applied *unstructured.Unstructured) ([]string, error) {
+	declared = declared.DeepCopy()
+	applied = applied.DeepCopy()
+	for _, obj := range []*unstructured.Unstructured{declared, applied} {
+		metadata.RemoveConfigSyncMetadata(obj)
+		metadata.RemoveApplySetPartOfLabel(obj, s.clientSet.ApplySetID)
+		s.clientSet.IgnoreRules.Strip(obj)
+	}
+	return diff.Diff{Declared: declared, Actual: applied}.DriftedFields()
+}
+
+// cacheAppliedObject keeps the object of a successful apply, to plan the
+// changes of the next commits.
+func (s *supervisor) cacheAppliedObject(id core.ID, obj client.Object) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	if s.appliedObjects == nil {
+		s.appliedObjects = make(map[core.ID]*unstructured.Unstructured)
+	}
+	s.appliedObjects[id] = u
+}
+
+// add records the change of the object, replacing its earlier change.
+func (c *syncChanges) add(change synchistory.ObjectChange) {
+	id := core.ID{
+		GroupKind: schema.GroupKind{Group: change.Group, Kind: change.Kind},
+		ObjectKey: client.ObjectKey{Namespace: change.Namespace, Name: change.Name},
+	}
+	if i, found := c.indexes[id]; found {
+		c.objects[i] = change
+		return
+	}
+	c.indexes[id] = len(c.objects)
+	c.objects = append(c.objects, change)
+}
+
+func (c *syncChanges) addApplyEvent(e event.ApplyEvent) {
+	if c == nil {
+		return
+	}
+	id := idFrom(e.Identifier)
+	switch e.Status {
+	case event.ApplySuccessful:
+		if change, found := c.planned[id]; found {
+			c.add(change)
+		}
+	case event.ApplySkipped:
+		c.add(objectChange(id, synchistory.Skip, nil))
+	}
+}
+
+func (c *syncChanges) addPruneEvent(e event.PruneEvent) {
+	if c == nil {
+		return
+	}
+	id := idFrom(e.Identifier)
+	switch e.Status {
+	case event.PruneSuccessful:
+		c.add(objectChange(id, synchistory.Prune, nil))
+	case event.PruneSkipped:
+		c.add(objectChange(id, synchistory.Skip, nil))
+	}
+}
+
+// recordHistory records the changes of the apply of the commit in the sync
+// history. Failing to record the history does not fail the sync.
+func (s *supervisor) recordHistory(ctx context.Context, commit string, changes *syncChanges, succeeded bool) {
+	entry := synchistory.Entry{
+		Commit:  commit,
+		Time:    metav1.Now(),
+		Objects: changes.objects,
+	}
+	syncKey := client.ObjectKey{Namespace: s.syncNamespace, Name: s.syncName}
+	if err := synchistory.Record(ctx, s.clientSet.Client, s.syncKind, syncKey, entry); err != nil {
+		klog.Warningf("Failed to record the sync history of commit %s: %v", commit, err)
+		return
+	}
+	if succeeded {
+		s.historyCommit = commit
+	}
+}
+
+func objectChange(id core.ID, operation synchistory.Operation, fields []string) synchistory.ObjectChange {
+	return synchistory.ObjectChange{
+		Group:     id.Group,
+		Kind:      id.Kind,
+		Namespace: id.Namespace,
+		Name:      id.Name,
+		Operation: operation,
+		Fields:    fields,
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	testingfake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"kpt.dev/configsync/pkg/synchistory"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestApplyRecordsHistory(t *testing.T) {
+	syncScope := declared.Scope("test-namespace")
+	syncName := "rs"
+	commit := "abc123"
+
+	newConfigMap := func(name, value string, opts ...core.MetaMutator) *unstructured.Unstructured {
+		obj := k8sobjects.UnstructuredObject(kinds.ConfigMap(), append(opts, core.Namespace("test-namespace"), core.Name(name))...)
+		obj.Object["data"] = map[string]interface{}{"key": value}
+		return obj
+	}
+	// The Config Sync metadata is not recorded as a change.
+	declaredMeta := core.Annotation(metadata.SourcePathAnnotationKey, "foo/cm.yaml")
+	createdObj := newDeploymentObj()
+	updatedObj := newConfigMap("updated", "new", declaredMeta)
+	unchangedObj := newConfigMap("unchanged", "same", declaredMeta)
+	skippedObj := newConfigMap("skipped", "new", declaredMeta)
+	prunedObj := newConfigMap("pruned", "old")
+
+	previousObjs := []*unstructured.Unstructured{
+		newConfigMap("updated", "old"),
+		newConfigMap("unchanged", "same"),
+		prunedObj,
+	}
+	var previousEvents []event.Event
+	for _, obj := range previousObjs {
+		previousEvents = append(previousEvents, formApplyEvent(event.ApplySuccessful, obj, nil))
+	}
+
+	events := []event.Event{
+		// Sync waves apply the objects in several runs, so the objects may
+		// be applied more than once. Only the latest change is recorded.
+		formApplyEvent(event.ApplySuccessful, createdObj, nil),
+		formApplyEvent(event.ApplySuccessful, skippedObj, nil),
+		formApplyEvent(event.ApplySuccessful, createdObj, nil),
+		formApplyEvent(event.ApplySuccessful, updatedObj, nil),
+		formApplyEvent(event.ApplySuccessful, unchangedObj, nil),
+		formApplySkipEvent(object.UnstructuredToObjMetadata(skippedObj), skippedObj, nil),
+		{
+			Type: event.PruneType,
+			PruneEvent: event.PruneEvent{
+				Status:     event.PruneSuccessful,
+				Identifier: object.UnstructuredToObjMetadata(prunedObj),
+				Object:     prunedObj,
+			},
+		},
+	}
+
+	// The objects are not read from the cluster: the changes are planned
+	// from the objects applied before.
+	fakeClient := testingfake.NewClient(t, core.Scheme)
+	cs := &ClientSet{
+		KptApplier: newFakeKptApplier(previousEvents),
+		InvClient:  inventory.NewFakeClient(nil),
+		Client:     fakeClient,
+		Mapper:     fakeClient.RESTMapper(),
+	}
+	supervisor := NewSupervisor(cs, syncScope, syncName, time.Minute)
+
+	ctx := context.Background()
+	resources := &declared.Resources{}
+	var previous []client.Object
+	for _, obj := range previousObjs {
+		previous = append(previous, obj)
+	}
+	_, statusErr := resources.UpdateDeclared(ctx, previous, "previous")
+	require.NoError(t, statusErr)
+	supervisor.Apply(ctx, func(Event) {}, resources)
+
+	cs.KptApplier = newFakeKptApplier(events)
+	_, statusErr = resources.UpdateDeclared(ctx, []client.Object{createdObj, updatedObj, unchangedObj, skippedObj}, commit)
+	require.NoError(t, statusErr)
+
+	// The sync fails because of the skipped object, and the changes of its retry
+	// are merged into the entry of the commit.
+	supervisor.Apply(ctx, func(Event) {}, resources)
+	supervisor.Apply(ctx, func(Event) {}, resources)
+
+	entries, err := synchistory.Get(ctx, fakeClient, client.ObjectKey{Namespace: syncScope.SyncNamespace(), Name: syncName})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, commit, entries[1].Commit)
+	assert.Equal(t, []synchistory.ObjectChange{
+		{Group: "apps", Kind: "Deployment", Namespace: "test-namespace", Name: "random-name", Operation: synchistory.Create},
+		{Kind: "ConfigMap", Namespace: "test-namespace", Name: "skipped", Operation: synchistory.Skip},
+		{Kind: "ConfigMap", Namespace: "test-namespace", Name: "updated", Operation: synchistory.Update, Fields: []string{".data.key"}},
+		{Kind: "ConfigMap", Namespace: "test-namespace", Name: "pruned", Operation: synchistory.Prune},
+	}, entries[1].Objects)
+}
@@ -0,0 +1,272 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/cli-utils/pkg/apply"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// hookState is the state of the hooks of a phase, for the commit.
+type hookState int
+
+const (
+	// hooksSucceeded means every hook ran to completion successfully.
+	hooksSucceeded hookState = iota
+	// hooksPending means a hook is still running, or could not be started
+	// yet. The hooks are checked again by the next apply.
+	hooksPending
+	// hooksFailed means a hook failed.
+	hooksFailed
+)
+
+// runHooks runs the hooks of the specified phase, in declaration order, and
+// sends a HookEvent for each hook which completed.
+//
+// The hooks are not waited for: each apply starts the next hook, once the
+// previous one completed, and returns hooksPending while a hook is running, so
+// the sync is not blocked by long-running hooks. The commit is recorded on the
+// hooks, so the hooks already run for a commit are known, even after the
+// reconciler restarts.
+//
+// A hook runs once per commit: a hook which failed is not run again until the
+// next commit, or until the hook is deleted. Retrying the failed Pods of a
+// Job is configured with the backoffLimit of the Job.
+func (s *supervisor) runHooks(ctx context.Context, eventHandler func(Event), phase metadata.HookPhase, hooks []*unstructured.Unstructured, commit string) hookState {
+	for _, hook := range hooks {
+		if !metadata.HasHookPhase(hook, phase) {
+			continue
+		}
+		id := core.IDOf(hook)
+		current := &unstructured.Unstructured{}
+		current.SetGroupVersionKind(hook.GroupVersionKind())
+		err := s.clientSet.Client.Get(ctx, client.ObjectKeyFromObject(hook), current)
+		switch {
+		case apierrors.IsNotFound(err):
+			current = nil
+		case err != nil:
+			sendErrorEvent(status.APIServerError(err, fmt.Sprintf("failed to get the %s hook", phase), hook), eventHandler)
+			return hooksPending
+		}
+
+		if current == nil || core.GetAnnotation(current, metadata.HookCommitAnnotationKey) != commit {
+			// The hook was not run for the commit yet.
+			started, err := s.startHook(ctx, hook, current, commit)
+			if err != nil {
+				sendErrorEvent(status.APIServerError(err, fmt.Sprintf("failed to start the %s hook", phase), hook), eventHandler)
+			} else if started {
+				klog.Infof("Started the %s hook %s", phase, id)
+				sendErrorEvent(hookPendingError(phase, id, "is running"), eventHandler)
+			} else {
+				sendErrorEvent(hookPendingError(phase, id, "is waiting for its previous run to be deleted"), eventHandler)
+			}
+			return hooksPending
+		}
+
+		done, hookErr := hookCompleted(current)
+		if !done {
+			sendErrorEvent(hookPendingError(phase, id, "is running"), eventHandler)
+			return hooksPending
+		}
+		e := HookEvent{Phase: phase, ID: id, Succeeded: hookErr == nil}
+		if hookErr != nil {
+			e.Message = hookErr.Error()
+		}
+		eventHandler(e)
+		if hookErr != nil {
+			klog.Warningf("The %s hook %s failed: %v", phase, id, hookErr)
+			sendErrorEvent(hookError(phase, id, hookErr), eventHandler)
+			return hooksFailed
+		}
+	}
+	return hooksSucceeded
+}
+
+// startHook creates the hook for the commit, after deleting the previous run,
+// if any. Returns false if the previous run is still being deleted, in which
+// case the hook is created by a later apply.
+//
+// The hook is created with the Config Sync metadata identifying its source
+// and its RSync, but without the management annotation, so the remediator
+// does not manage it. The labels identifying the RSync are used to delete the
+// hooks which are no longer declared.
+func (s *supervisor) startHook(ctx context.Context, hook, previous *unstructured.Unstructured, commit string) (bool, error) {
+	c := s.clientSet.Client
+	if previous != nil {
+		err := c.Delete(ctx, previous, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to delete the previous run: %w", err)
+		}
+	}
+
+	obj := hook.DeepCopy()
+	metadata.RemoveConfigSyncMetadata(obj)
+	for _, key := range []string{metadata.HookAnnotationKey, metadata.SourcePathAnnotationKey, metadata.GitContextKey, metadata.ResourceManagerKey} {
+		if value, found := hook.GetAnnotations()[key]; found {
+			core.SetAnnotation(obj, key, value)
+		}
+	}
+	core.SetAnnotation(obj, metadata.HookCommitAnnotationKey, commit)
+	core.AddLabels(obj, s.hookLabels())
+	obj.SetResourceVersion("")
+
+	err := c.Create(ctx, obj, client.FieldOwner(configsync.FieldManager))
+	if apierrors.IsAlreadyExists(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// hookLabels returns the labels identifying the hooks run by the reconciler.
+func (s *supervisor) hookLabels() map[string]string {
+	return map[string]string{
+		metadata.SyncKindLabel:      s.syncKind,
+		metadata.SyncNameLabel:      s.syncName,
+		metadata.SyncNamespaceLabel: s.syncNamespace,
+	}
+}
+
+// pruneHooks deletes the hooks run by the reconciler which are not in hooks,
+// because they were removed from the source, or because the RSync is being
+// deleted.
+func (s *supervisor) pruneHooks(ctx context.Context, hooks []*unstructured.Unstructured) status.MultiError {
+	declaredHooks := make(map[core.ID]struct{}, len(hooks))
+	for _, hook := range hooks {
+		declaredHooks[core.IDOf(hook)] = struct{}{}
+	}
+	opts := []client.ListOption{client.MatchingLabels(s.hookLabels())}
+	if s.syncKind == configsync.RepoSyncKind {
+		opts = append(opts, client.InNamespace(s.syncNamespace))
+	}
+
+	var errs status.MultiError
+	c := s.clientSet.Client
+	for _, gvk := range []schema.GroupVersionKind{kinds.Job(), kinds.Pod()} {
+		list := kinds.NewUnstructuredListForItemGVK(gvk)
+		if err := c.List(ctx, list, opts...); err != nil {
+			errs = status.Append(errs, status.APIServerErrorf(err, "failed to list the %s hooks", gvk.Kind))
+			continue
+		}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			if _, found := declaredHooks[core.IDOf(obj)]; found {
+				continue
+			}
+			klog.Infof("Deleting the hook %s, which is no longer declared", core.IDOf(obj))
+			err := c.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground))
+			if err != nil && !apierrors.IsNotFound(err) {
+				errs = status.Append(errs, status.APIServerError(err, "failed to delete the hook", obj))
+			}
+		}
+	}
+	return errs
+}
+
+// applyHookNamespaces applies the declared Namespaces of the pre-sync hooks
+// which do not exist yet, so pre-sync hooks can be declared in a Namespace
+// declared by the same commit. Like the objects of a sync wave, the Namespaces
+// are applied without pruning, and the objects of the inventory are retained.
+//
+// Returns false if the Namespaces failed to apply.
+func (s *supervisor) applyHookNamespaces(ctx context.Context, eventHandler func(Event), resources, hooks []*unstructured.Unstructured, options apply.ApplierOptions, run func([]*unstructured.Unstructured, apply.ApplierOptions) int) bool {
+	hookNamespaces := make(map[string]struct{})
+	for _, hook := range hooks {
+		if metadata.HasHookPhase(hook, metadata.HookPreSync) {
+			hookNamespaces[hook.GetNamespace()] = struct{}{}
+		}
+	}
+	var namespaces []*unstructured.Unstructured
+	for _, obj := range resources {
+		if obj.GroupVersionKind().GroupKind() != kinds.Namespace().GroupKind() {
+			continue
+		}
+		if _, found := hookNamespaces[obj.GetName()]; !found {
+			continue
+		}
+		current := &unstructured.Unstructured{}
+		current.SetGroupVersionKind(obj.GroupVersionKind())
+		err := s.clientSet.Client.Get(ctx, client.ObjectKeyFromObject(obj), current)
+		if apierrors.IsNotFound(err) {
+			namespaces = append(namespaces, obj)
+		} else if err != nil {
+			sendErrorEvent(status.APIServerError(err, "failed to get the Namespace of a pre-sync hook", obj), eventHandler)
+			return false
+		}
+	}
+	if len(namespaces) == 0 {
+		return true
+	}
+
+	retainer, ok := s.clientSet.InvClient.(*RetainingInventoryClient)
+	if !ok {
+		sendErrorEvent(Error(fmt.Errorf("pre-sync hooks require a %T, got %T", retainer, s.clientSet.InvClient)), eventHandler)
+		return false
+	}
+	prevRefs, err := s.inventoryObjectRefs(ctx)
+	if err != nil {
+		sendErrorEvent(s.inventoryError(err), eventHandler)
+		return false
+	}
+	klog.Infof("Applying %d Namespaces of the pre-sync hooks", len(namespaces))
+	nsOptions := options
+	nsOptions.NoPrune = true
+	release := retainer.Retain(prevRefs)
+	defer release()
+	return run(namespaces, nsOptions) == 0
+}
+
+// hookCompleted returns true if the Job or Pod has run to completion, and an
+// error if it failed.
+func hookCompleted(obj *unstructured.Unstructured) (bool, error) {
+	switch obj.GroupVersionKind().GroupKind() {
+	case kinds.Job().GroupKind():
+		conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+		for _, c := range conditions {
+			cond, ok := c.(map[string]interface{})
+			if !ok || cond["status"] != string(metav1.ConditionTrue) {
+				continue
+			}
+			switch cond["type"] {
+			case "Complete":
+				return true, nil
+			case "Failed":
+				return true, fmt.Errorf("job failed: %v", cond["message"])
+			}
+		}
+	case kinds.Pod().GroupKind():
+		phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+		switch phase {
+		case "Succeeded":
+			return true, nil
+		case "Failed":
+			return true, errors.New("pod failed")
+		}
+	}
+	return false, nil
+}
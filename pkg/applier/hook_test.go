@@ -0,0 +1,389 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	testingfake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// hookClient completes the Jobs it creates, failing the Jobs named in failed,
+// except the Jobs named in running, which are left running.
+type hookClient struct {
+	client.Client
+	failed  map[string]bool
+	running map[string]bool
+	created []string
+}
+
+func (c *hookClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if u, ok := obj.(*unstructured.Unstructured); ok && u.GetKind() == kinds.Job().Kind {
+		c.created = append(c.created, u.GetName())
+		if !c.running[u.GetName()] {
+			condType := "Complete"
+			if c.failed[u.GetName()] {
+				condType = "Failed"
+			}
+			err := unstructured.SetNestedSlice(u.Object, []interface{}{
+				map[string]interface{}{"type": condType, "status": "True", "message": "exit code 1"},
+			}, "status", "conditions")
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func newHookJob(name string, phase metadata.HookPhase) *unstructured.Unstructured {
+	return k8sobjects.UnstructuredObject(kinds.Job(), core.Namespace("test-namespace"), core.Name(name),
+		metadata.WithHookPhase(phase), core.Annotation(metadata.SourcePathAnnotationKey, "foo/job.yaml"),
+		core.Annotation(metadata.ManagementModeAnnotationKey, metadata.ManagementEnabled.String()))
+}
+
+func TestApplyHooks(t *testing.T) {
+	syncScope := declared.Scope("test-namespace")
+	syncName := "rs"
+
+	testCases := map[string]struct {
+		failed  map[string]bool
+		running map[string]bool
+		applies int
+		// restart uses a new supervisor for each apply, like a restarted
+		// reconciler.
+		restart     bool
+		wantCreated []string
+		wantApplies int
+		// wantHooks and wantErrCount are the results of the last apply.
+		wantHooks    []HookEvent
+		wantErrCount int
+	}{
+		"hooks run around the apply": {
+			applies:     3,
+			wantCreated: []string{"migrate", "notify"},
+			wantApplies: 2,
+			wantHooks: []HookEvent{
+				{Phase: metadata.HookPreSync, ID: hookID("migrate"), Succeeded: true},
+				{Phase: metadata.HookPostSync, ID: hookID("notify"), Succeeded: true},
+			},
+		},
+		"running pre-sync hook defers the apply": {
+			running:      map[string]bool{"migrate": true},
+			applies:      2,
+			wantCreated:  []string{"migrate"},
+			wantErrCount: 1,
+		},
+		"running post-sync hook is not waited for": {
+			running:      map[string]bool{"notify": true},
+			applies:      3,
+			wantCreated:  []string{"migrate", "notify"},
+			wantApplies:  2,
+			wantHooks:    []HookEvent{{Phase: metadata.HookPreSync, ID: hookID("migrate"), Succeeded: true}},
+			wantErrCount: 1,
+		},
+		"failed pre-sync hook blocks the apply": {
+			failed:      map[string]bool{"migrate": true},
+			applies:     3,
+			wantCreated: []string{"migrate", "rollback"},
+			wantHooks: []HookEvent{
+				{Phase: metadata.HookPreSync, ID: hookID("migrate"), Message: "job failed: exit code 1"},
+				{Phase: metadata.HookSyncFail, ID: hookID("rollback"), Succeeded: true},
+			},
+			wantErrCount: 1,
+		},
+		"failed hooks run once per commit": {
+			failed:      map[string]bool{"migrate": true, "rollback": true},
+			applies:     4,
+			wantCreated: []string{"migrate", "rollback"},
+			wantHooks: []HookEvent{
+				{Phase: metadata.HookPreSync, ID: hookID("migrate"), Message: "job failed: exit code 1"},
+				{Phase: metadata.HookSyncFail, ID: hookID("rollback"), Message: "job failed: exit code 1"},
+			},
+			wantErrCount: 2,
+		},
+		"successful hooks do not run again after a restart": {
+			applies:     4,
+			restart:     true,
+			wantCreated: []string{"migrate", "notify"},
+			wantApplies: 3,
+			wantHooks: []HookEvent{
+				{Phase: metadata.HookPreSync, ID: hookID("migrate"), Succeeded: true},
+				{Phase: metadata.HookPostSync, ID: hookID("notify"), Succeeded: true},
+			},
+		},
+		"successful hooks run once per commit": {
+			applies:     4,
+			wantCreated: []string{"migrate", "notify"},
+			wantApplies: 3,
+			wantHooks: []HookEvent{
+				{Phase: metadata.HookPreSync, ID: hookID("migrate"), Succeeded: true},
+				{Phase: metadata.HookPostSync, ID: hookID("notify"), Succeeded: true},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			fakeClient := testingfake.NewClient(t, core.Scheme)
+			c := &hookClient{Client: fakeClient, failed: tc.failed, running: tc.running}
+			invClient := inventory.NewFakeClient(object.ObjMetadataSet{})
+			kptApplier := &fakeWaveKptApplier{invClient: invClient}
+			cs := &ClientSet{
+				KptApplier: kptApplier,
+				InvClient:  invClient,
+				Client:     c,
+				Mapper:     fakeClient.RESTMapper(),
+			}
+			supervisor := NewSupervisor(cs, syncScope, syncName, time.Minute)
+
+			var errs status.MultiError
+			var hooks []HookEvent
+			eventHandler := func(e Event) {
+				switch typedEvent := e.(type) {
+				case ErrorEvent:
+					errs = status.Append(errs, typedEvent.Error)
+				case HookEvent:
+					hooks = append(hooks, typedEvent)
+				}
+			}
+
+			objs := []client.Object{
+				newTestObj("app"),
+				newHookJob("migrate", metadata.HookPreSync),
+				newHookJob("notify", metadata.HookPostSync),
+				newHookJob("rollback", metadata.HookSyncFail),
+			}
+			resources := &declared.Resources{}
+			_, err := resources.UpdateDeclared(context.Background(), objs, "abc123")
+			require.NoError(t, err)
+
+			for i := 0; i < tc.applies; i++ {
+				if tc.restart {
+					supervisor = NewSupervisor(cs, syncScope, syncName, time.Minute)
+				}
+				errs, hooks = nil, nil
+				supervisor.Apply(context.Background(), eventHandler, resources)
+			}
+
+			require.Len(t, status.ToCSE(errs), tc.wantErrCount, "%v", errs)
+			assert.Equal(t, tc.wantCreated, c.created)
+			assert.Len(t, kptApplier.runs, tc.wantApplies)
+			assert.Equal(t, tc.wantHooks, hooks)
+			// Hooks are never applied or tracked in the inventory.
+			for _, run := range kptApplier.runs {
+				assert.Equal(t, []string{"app"}, run.names)
+			}
+
+			// The hooks have the metadata of their source and RSync, but
+			// are not managed.
+			hook := &unstructured.Unstructured{}
+			hook.SetGroupVersionKind(kinds.Job())
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "test-namespace", Name: "migrate"}, hook))
+			assert.Equal(t, map[string]string{
+				metadata.SyncKindLabel:      configsync.RepoSyncKind,
+				metadata.SyncNameLabel:      syncName,
+				metadata.SyncNamespaceLabel: "test-namespace",
+			}, hook.GetLabels())
+			assert.Equal(t, map[string]string{
+				metadata.HookAnnotationKey:       metadata.HookPreSync.String(),
+				metadata.HookCommitAnnotationKey: "abc123",
+				metadata.SourcePathAnnotationKey: "foo/job.yaml",
+			}, hook.GetAnnotations())
+		})
+	}
+}
+
+func TestApplyHooksNewCommit(t *testing.T) {
+	fakeClient := testingfake.NewClient(t, core.Scheme)
+	c := &hookClient{Client: fakeClient}
+	invClient := inventory.NewFakeClient(object.ObjMetadataSet{})
+	cs := &ClientSet{
+		KptApplier: &fakeWaveKptApplier{invClient: invClient},
+		InvClient:  invClient,
+		Client:     c,
+		Mapper:     fakeClient.RESTMapper(),
+	}
+	supervisor := NewSupervisor(cs, declared.Scope("test-namespace"), "rs", time.Minute)
+	eventHandler := func(Event) {}
+
+	resources := &declared.Resources{}
+	_, err := resources.UpdateDeclared(context.Background(), []client.Object{
+		newHookJob("migrate", metadata.HookPreSync), newHookJob("notify", metadata.HookPostSync),
+	}, "abc123")
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		supervisor.Apply(context.Background(), eventHandler, resources)
+	}
+	assert.Equal(t, []string{"migrate", "notify"}, c.created)
+
+	// The hooks of the new commit run again, and the hooks which are no
+	// longer declared are deleted.
+	_, err = resources.UpdateDeclared(context.Background(), []client.Object{
+		newHookJob("migrate", metadata.HookPreSync),
+	}, "def456")
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		supervisor.Apply(context.Background(), eventHandler, resources)
+	}
+	assert.Equal(t, []string{"migrate", "notify", "migrate"}, c.created)
+	hook := &unstructured.Unstructured{}
+	hook.SetGroupVersionKind(kinds.Job())
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "test-namespace", Name: "migrate"}, hook))
+	assert.Equal(t, "def456", core.GetAnnotation(hook, metadata.HookCommitAnnotationKey))
+	getErr := fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "test-namespace", Name: "notify"}, hook)
+	assert.True(t, apierrors.IsNotFound(getErr), "the undeclared hook should be deleted, got %v", getErr)
+}
+
+func TestPruneHooks(t *testing.T) {
+	hookLabels := map[string]string{
+		metadata.SyncKindLabel:      configsync.RepoSyncKind,
+		metadata.SyncNameLabel:      "rs",
+		metadata.SyncNamespaceLabel: "test-namespace",
+	}
+	otherLabels := map[string]string{
+		metadata.SyncKindLabel:      configsync.RepoSyncKind,
+		metadata.SyncNameLabel:      "other",
+		metadata.SyncNamespaceLabel: "test-namespace",
+	}
+	declaredHook := k8sobjects.UnstructuredObject(kinds.Job(), core.Namespace("test-namespace"), core.Name("declared"), core.Labels(hookLabels))
+	removedJob := k8sobjects.UnstructuredObject(kinds.Job(), core.Namespace("test-namespace"), core.Name("removed"), core.Labels(hookLabels))
+	removedPod := k8sobjects.UnstructuredObject(kinds.Pod(), core.Namespace("test-namespace"), core.Name("removed"), core.Labels(hookLabels))
+	otherHook := k8sobjects.UnstructuredObject(kinds.Job(), core.Namespace("test-namespace"), core.Name("other"), core.Labels(otherLabels))
+	job := k8sobjects.UnstructuredObject(kinds.Job(), core.Namespace("test-namespace"), core.Name("job"))
+
+	testCases := map[string]struct {
+		hooks      []*unstructured.Unstructured
+		wantExists map[*unstructured.Unstructured]bool
+	}{
+		"hooks which are no longer declared are deleted": {
+			hooks: []*unstructured.Unstructured{declaredHook},
+			wantExists: map[*unstructured.Unstructured]bool{
+				declaredHook: true, removedJob: false, removedPod: false, otherHook: true, job: true,
+			},
+		},
+		"every hook is deleted when nothing is declared": {
+			wantExists: map[*unstructured.Unstructured]bool{
+				declaredHook: false, removedJob: false, removedPod: false, otherHook: true, job: true,
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			fakeClient := testingfake.NewClient(t, core.Scheme,
+				declaredHook.DeepCopy(), removedJob.DeepCopy(), removedPod.DeepCopy(), otherHook.DeepCopy(), job.DeepCopy())
+			cs := &ClientSet{Client: fakeClient, Mapper: fakeClient.RESTMapper()}
+			s := NewSupervisor(cs, declared.Scope("test-namespace"), "rs", time.Minute).(*supervisor)
+
+			errs := s.pruneHooks(context.Background(), tc.hooks)
+			require.NoError(t, errs)
+			for obj, wantExists := range tc.wantExists {
+				current := &unstructured.Unstructured{}
+				current.SetGroupVersionKind(obj.GroupVersionKind())
+				err := fakeClient.Get(context.Background(), client.ObjectKeyFromObject(obj), current)
+				if wantExists {
+					assert.NoError(t, err, "%s should exist", core.IDOf(obj))
+				} else {
+					assert.True(t, apierrors.IsNotFound(err), "%s should be deleted, got %v", core.IDOf(obj), err)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyHookNamespaces(t *testing.T) {
+	hookNamespace := k8sobjects.UnstructuredObject(kinds.Namespace(), core.Name("hooks"))
+	otherNamespace := k8sobjects.UnstructuredObject(kinds.Namespace(), core.Name("other"))
+	hook := k8sobjects.UnstructuredObject(kinds.Job(), core.Namespace("hooks"), core.Name("migrate"),
+		metadata.WithHookPhase(metadata.HookPreSync))
+
+	testCases := map[string]struct {
+		existing []client.Object
+		wantRuns []waveRun
+	}{
+		"missing Namespace of a pre-sync hook is applied first": {
+			wantRuns: []waveRun{
+				{names: []string{"hooks"}, noPrune: true},
+				{names: []string{"hooks", "other"}},
+			},
+		},
+		"existing Namespace of a pre-sync hook is not applied first": {
+			existing: []client.Object{hookNamespace.DeepCopy()},
+			wantRuns: []waveRun{
+				{names: []string{"hooks", "other"}},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			fakeClient := testingfake.NewClient(t, core.Scheme, tc.existing...)
+			c := &hookClient{Client: fakeClient}
+			invClient := &RetainingInventoryClient{Client: inventory.NewFakeClient(object.ObjMetadataSet{})}
+			kptApplier := &fakeWaveKptApplier{invClient: invClient}
+			cs := &ClientSet{
+				KptApplier: kptApplier,
+				InvClient:  invClient,
+				Client:     c,
+				Mapper:     fakeClient.RESTMapper(),
+			}
+			s := NewSupervisor(cs, declared.RootScope, "rs", time.Minute)
+
+			var errs status.MultiError
+			eventHandler := func(e Event) {
+				if errEvent, ok := e.(ErrorEvent); ok {
+					errs = status.Append(errs, errEvent.Error)
+				}
+			}
+			resources := &declared.Resources{}
+			_, err := resources.UpdateDeclared(context.Background(), []client.Object{hookNamespace, otherNamespace, hook}, "abc123")
+			require.NoError(t, err)
+			// The first apply starts the hook, the second one applies the
+			// objects.
+			s.Apply(context.Background(), eventHandler, resources)
+			if len(tc.existing) == 0 {
+				// The fake applier does not create the applied Namespace.
+				require.NoError(t, fakeClient.Create(context.Background(), hookNamespace.DeepCopy(), client.FieldOwner(configsync.FieldManager)))
+			}
+			errs = nil
+			s.Apply(context.Background(), eventHandler, resources)
+
+			require.NoError(t, errs)
+			assert.Equal(t, []string{"migrate"}, c.created)
+			assert.Equal(t, tc.wantRuns, kptApplier.runs)
+		})
+	}
+}
+
+func hookID(name string) core.ID {
+	return core.ID{
+		GroupKind: kinds.Job().GroupKind(),
+		ObjectKey: client.ObjectKey{Namespace: "test-namespace", Name: name},
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/metadata"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/apply"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// syncWave is a group of objects with the same sync-wave annotation value.
+type syncWave struct {
+	// wave is the sync-wave annotation value of the objects.
+	wave int
+	objs []*unstructured.Unstructured
+}
+
+// groupSyncWaves groups the objects into sync waves, in ascending order of
+// their sync-wave annotation values.
+// Objects without the annotation, or with an invalid value, are in wave 0.
+// Invalid values are rejected by the parser, so they are not expected here.
+func groupSyncWaves(objs []*unstructured.Unstructured) []syncWave {
+	byWave := make(map[int][]*unstructured.Unstructured)
+	for _, obj := range objs {
+		wave, err := metadata.GetSyncWave(obj)
+		if err != nil {
+			klog.Warningf("Ignoring the invalid %s annotation of %s: %v",
+				metadata.SyncWaveAnnotationKey, core.IDOf(obj), err)
+			wave = 0
+		}
+		byWave[wave] = append(byWave[wave], obj)
+	}
+	waves := make([]syncWave, 0, len(byWave))
+	for wave, waveObjs := range byWave {
+		waves = append(waves, syncWave{wave: wave, objs: waveObjs})
+	}
+	sort.Slice(waves, func(i, j int) bool {
+		return waves[i].wave < waves[j].wave
+	})
+	return waves
+}
+
+// applySyncWaves applies the sync waves in ascending order, using run.
+//
+// Every sync wave but the last one is applied on its own, without pruning.
+// The objects of a sync wave must all be applied and reconciled before the
+// next sync wave is applied. The last run applies all the objects, like a
+// sync without waves, so the objects which are no longer declared are pruned
+// after every sync wave has been applied.
+//
+// If a sync wave fails to apply or reconcile, the later sync waves are not
+// applied and nothing is pruned.
+//
+// Each run stores the inventory with the objects it applied. The objects which
+// were in the inventory before the first run, and the objects of the earlier
+// sync waves, are retained in the inventory stored by every sync wave run, so
+// they are still tracked (and pruned, if no longer declared) if a sync wave
+// fails.
+//
+// Returns true if every sync wave was applied without errors.
+func (s *supervisor) applySyncWaves(ctx context.Context, eventHandler func(Event), waves []syncWave, resources []*unstructured.Unstructured, options apply.ApplierOptions, run func([]*unstructured.Unstructured, apply.ApplierOptions) int, objStatusMap ObjectStatusMap) bool {
+	retainer, ok := s.clientSet.InvClient.(*RetainingInventoryClient)
+	if !ok {
+		sendErrorEvent(Error(fmt.Errorf("sync waves require a %T, got %T", retainer, s.clientSet.InvClient)), eventHandler)
+		return false
+	}
+	prevRefs, err := s.inventoryObjectRefs(ctx)
+	if err != nil {
+		sendErrorEvent(s.inventoryError(err), eventHandler)
+		return false
+	}
+	waveOptions := options
+	waveOptions.NoPrune = true
+
+	var appliedRefs object.ObjMetadataSet
+	for i, wave := range waves {
+		eventHandler(SyncWaveEvent{Wave: wave.wave, Total: len(waves), Completed: i})
+		klog.Infof("Sync wave %d (%d of %d) starting: %d objects", wave.wave, i+1, len(waves), len(wave.objs))
+
+		var errCount int
+		if i == len(waves)-1 {
+			errCount = run(resources, options)
+		} else {
+			release := retainer.Retain(prevRefs.Union(appliedRefs))
+			errCount = run(wave.objs, waveOptions)
+			release()
+		}
+		if errCount > 0 {
+			klog.Warningf("Sync wave %d failed with %d errors: skipping the later sync waves", wave.wave, errCount)
+			return false
+		}
+		if ids := unreconciledIDs(wave.objs, objStatusMap); len(ids) > 0 {
+			sendErrorEvent(syncWaveError(wave.wave, ids), eventHandler)
+			return false
+		}
+		appliedRefs = appliedRefs.Union(object.UnstructuredSetToObjMetadataSet(wave.objs))
+		eventHandler(SyncWaveEvent{Wave: wave.wave, Total: len(waves), Completed: i + 1})
+		klog.Infof("Sync wave %d (%d of %d) reconciled", wave.wave, i+1, len(waves))
+	}
+	return true
+}
+
+// unreconciledIDs returns the IDs of the objects which were not both applied
+// and reconciled successfully.
+func unreconciledIDs(objs []*unstructured.Unstructured, objStatusMap ObjectStatusMap) []core.ID {
+	var ids []core.ID
+	for _, obj := range objs {
+		id := idFrom(ObjMetaFromUnstructured(obj))
+		objStatus, found := objStatusMap[id]
+		if !found || objStatus.Actuation != actuation.ActuationSucceeded ||
+			objStatus.Reconcile != actuation.ReconcileSucceeded {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,224 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	testingfake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"sigs.k8s.io/cli-utils/pkg/apply"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// waveRun records the objects and options of a call to fakeWaveKptApplier.Run.
+type waveRun struct {
+	names   []string
+	noPrune bool
+}
+
+// fakeWaveKptApplier applies and reconciles every object, except the
+// notReady objects, which time out reconciling. Like the cli-utils applier,
+// it stores the inventory with the objects it applied, and records which
+// objects the stored inventory contains at the end of each run.
+type fakeWaveKptApplier struct {
+	invClient     inventory.Client
+	notReady      map[string]bool
+	runs          []waveRun
+	inventoryRuns [][]string
+}
+
+var _ KptApplier = &fakeWaveKptApplier{}
+
+func (a *fakeWaveKptApplier) Run(ctx context.Context, invInfo inventory.Info, objs object.UnstructuredSet, options apply.ApplierOptions) <-chan event.Event {
+	run := waveRun{noPrune: options.NoPrune}
+	var events []event.Event
+	var applied object.ObjMetadataSet
+	for _, obj := range objs {
+		run.names = append(run.names, obj.GetName())
+		id := object.UnstructuredToObjMetadata(obj)
+		applied = append(applied, id)
+		events = append(events, formApplyEvent(event.ApplySuccessful, obj, nil))
+		if a.notReady[obj.GetName()] {
+			events = append(events, formWaitEvent(event.ReconcileTimeout, &id))
+		} else {
+			events = append(events, formWaitEvent(event.ReconcileSuccessful, &id))
+		}
+	}
+	a.runs = append(a.runs, run)
+	inv, err := a.invClient.NewInventory(invInfo)
+	if err != nil {
+		panic(err)
+	}
+	inv.SetObjectRefs(applied)
+	if err := a.invClient.CreateOrUpdate(ctx, inv, inventory.UpdateOptions{}); err != nil {
+		panic(err)
+	}
+	a.inventoryRuns = append(a.inventoryRuns, inventoryNames(a.invClient))
+
+	eventCh := make(chan event.Event, len(events))
+	for _, e := range events {
+		eventCh <- e
+	}
+	close(eventCh)
+	return eventCh
+}
+
+func TestApplySyncWaves(t *testing.T) {
+	syncScope := declared.Scope("test-namespace")
+	syncName := "rs"
+	crd := newTestObj("crd")
+	crd.SetAnnotations(map[string]string{metadata.SyncWaveAnnotationKey: "-1"})
+	operator := newTestObj("operator")
+	app := newTestObj("app")
+	app.SetAnnotations(map[string]string{metadata.SyncWaveAnnotationKey: "2"})
+	old := object.UnstructuredToObjMetadata(newTestObj("old"))
+
+	testCases := map[string]struct {
+		objs      []*unstructured.Unstructured
+		notReady  map[string]bool
+		wantRuns  []waveRun
+		wantWaves []SyncWaveEvent
+		wantErrs  int
+		// wantInventoryRuns are the objects in the inventory after each run.
+		wantInventoryRuns [][]string
+		wantInventory     []string
+	}{
+		"objects without sync waves are applied in one run": {
+			objs: []*unstructured.Unstructured{operator, newTestObj("other")},
+			wantRuns: []waveRun{
+				{names: []string{"operator", "other"}},
+			},
+			wantInventoryRuns: [][]string{{"operator", "other"}},
+			wantInventory:     []string{"operator", "other"},
+		},
+		"sync waves are applied in order": {
+			objs: []*unstructured.Unstructured{app, operator, crd},
+			wantRuns: []waveRun{
+				{names: []string{"crd"}, noPrune: true},
+				{names: []string{"operator"}, noPrune: true},
+				{names: []string{"app", "operator", "crd"}},
+			},
+			wantWaves: []SyncWaveEvent{
+				{Wave: -1, Total: 3, Completed: 0},
+				{Wave: -1, Total: 3, Completed: 1},
+				{Wave: 0, Total: 3, Completed: 1},
+				{Wave: 0, Total: 3, Completed: 2},
+				{Wave: 2, Total: 3, Completed: 2},
+				{Wave: 2, Total: 3, Completed: 3},
+			},
+			// The objects in the inventory before the sync are kept until
+			// the final run prunes them.
+			wantInventoryRuns: [][]string{
+				{"crd", "old"},
+				{"operator", "old", "crd"},
+				{"app", "operator", "crd"},
+			},
+			wantInventory: []string{"app", "operator", "crd"},
+		},
+		"sync wave which does not reconcile blocks the later sync waves": {
+			objs:     []*unstructured.Unstructured{app, operator, crd},
+			notReady: map[string]bool{"operator": true},
+			wantRuns: []waveRun{
+				{names: []string{"crd"}, noPrune: true},
+				{names: []string{"operator"}, noPrune: true},
+			},
+			wantWaves: []SyncWaveEvent{
+				{Wave: -1, Total: 3, Completed: 0},
+				{Wave: -1, Total: 3, Completed: 1},
+				{Wave: 0, Total: 3, Completed: 1},
+			},
+			wantErrs: 1,
+			// The objects in the inventory before the sync are kept, so they
+			// can still be pruned.
+			wantInventoryRuns: [][]string{
+				{"crd", "old"},
+				{"operator", "old", "crd"},
+			},
+			wantInventory: []string{"operator", "old", "crd"},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			fakeClient := testingfake.NewClient(t, core.Scheme)
+			invClient := &RetainingInventoryClient{Client: inventory.NewFakeClient(object.ObjMetadataSet{old})}
+			kptApplier := &fakeWaveKptApplier{invClient: invClient, notReady: tc.notReady}
+			cs := &ClientSet{
+				KptApplier: kptApplier,
+				InvClient:  invClient,
+				Client:     fakeClient,
+				Mapper:     fakeClient.RESTMapper(),
+			}
+			supervisor := NewSupervisor(cs, syncScope, syncName, 5*time.Minute)
+
+			var errs status.MultiError
+			var waves []SyncWaveEvent
+			eventHandler := func(e Event) {
+				switch typedEvent := e.(type) {
+				case ErrorEvent:
+					errs = status.Append(errs, typedEvent.Error)
+				case SyncWaveEvent:
+					waves = append(waves, typedEvent)
+				}
+			}
+
+			var objs []client.Object
+			for _, obj := range tc.objs {
+				objs = append(objs, obj.DeepCopy())
+			}
+			resources := &declared.Resources{}
+			_, err := resources.UpdateDeclared(context.Background(), objs, "")
+			require.NoError(t, err)
+
+			supervisor.Apply(context.Background(), eventHandler, resources)
+
+			require.Len(t, status.ToCSE(errs), tc.wantErrs, "%v", errs)
+			for _, cse := range status.ToCSE(errs) {
+				assert.Equal(t, ApplierErrorCode, cse.Code)
+			}
+			assert.Equal(t, tc.wantRuns, kptApplier.runs)
+			assert.Equal(t, tc.wantWaves, waves)
+			require.Len(t, kptApplier.inventoryRuns, len(tc.wantInventoryRuns))
+			for i, want := range tc.wantInventoryRuns {
+				assert.ElementsMatch(t, want, kptApplier.inventoryRuns[i], "run %d", i)
+			}
+			assert.ElementsMatch(t, tc.wantInventory, inventoryNames(invClient))
+		})
+	}
+}
+
+// inventoryNames returns the names of the objects in the stored inventory.
+func inventoryNames(invClient inventory.Client) []string {
+	inv, err := invClient.Get(context.Background(), nil, inventory.GetOptions{})
+	if err != nil {
+		panic(err)
+	}
+	var names []string
+	for _, ref := range inv.GetObjectRefs() {
+		names = append(names, ref.Name)
+	}
+	return names
+}
@@ -0,0 +1,312 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package archive fetches gzipped tarballs served over HTTP(S), e.g. from an
+// S3-compatible bucket.
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/util"
+)
+
+const (
+	// etagSuffix is the suffix of the file recording the ETag of the archive
+	// extracted in the directory of the same name. It is written next to the
+	// directory, so it is never parsed as a config file.
+	etagSuffix = ".etag"
+	// downloadPattern is the pattern of the temporary file the archive is
+	// downloaded to.
+	downloadPattern = ".download-*"
+)
+
+// Credentials authenticate the requests for the archive. At most one of the
+// token, the username and password, or the HMAC key should be set.
+type Credentials struct {
+	// Token is sent as a bearer token.
+	Token string
+	// Username and Password are sent with basic auth.
+	Username string
+	Password string
+	// AccessKeyID and SecretAccessKey sign the requests to an S3-compatible
+	// bucket, with the AWS Signature Version 4.
+	AccessKeyID     string
+	SecretAccessKey string
+	// Region is the region of the S3-compatible bucket.
+	Region string
+}
+
+// Fetcher fetches archives over HTTP(S).
+type Fetcher struct {
+	// Client sends the requests. http.DefaultClient is used when nil.
+	Client *http.Client
+	// Credentials authenticate the requests.
+	Credentials Credentials
+	// Now returns the time the requests are signed at. time.Now is used when
+	// nil.
+	Now func() time.Time
+}
+
+// FetchArchive downloads the archive and extracts it into a directory under
+// archiveRoot named after its SHA-256 checksum, then points the rev symlink to
+// it. The archive is only downloaded again if its ETag changed, and only
+// extracted again if its checksum changed.
+func (f *Fetcher) FetchArchive(ctx context.Context, archiveURL, archiveRoot, rev string) error {
+	linkPath := filepath.Join(archiveRoot, rev)
+	oldDir, err := filepath.EvalSymlinks(linkPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to evaluate the symbolic path %q to the archive: %w", linkPath, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, archiveURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create the request for %s: %w", archiveURL, err)
+	}
+	if oldDir != "" {
+		if etag := readETag(oldDir); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+	}
+	if err := f.authorize(req); err != nil {
+		return err
+	}
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", archiveURL, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		klog.Infof("no update required with the same ETag %s", req.Header.Get("If-None-Match"))
+		return nil
+	default:
+		return fmt.Errorf("failed to download %s: unexpected HTTP status: %s", archiveURL, resp.Status)
+	}
+	etag := resp.Header.Get("ETag")
+
+	downloadPath, checksum, err := download(resp.Body, archiveRoot)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", archiveURL, err)
+	}
+	defer func() {
+		if err := os.Remove(downloadPath); err != nil && !os.IsNotExist(err) {
+			klog.Warningf("failed to remove the downloaded archive %q: %v", downloadPath, err)
+		}
+	}()
+
+	destDir := filepath.Join(archiveRoot, checksum)
+	if oldDir == destDir {
+		klog.Infof("no update required with the same checksum %q", checksum)
+		// The ETag may change without the content changing.
+		return writeETag(destDir, etag)
+	}
+
+	if err := os.RemoveAll(destDir); err != nil {
+		return fmt.Errorf("failed to clean up the directory %q: %w", destDir, err)
+	}
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %q: %w", destDir, err)
+	}
+	if err := extract(downloadPath, destDir); err != nil {
+		if rmErr := os.RemoveAll(destDir); rmErr != nil {
+			klog.Warningf("failed to remove the directory %q: %v", destDir, rmErr)
+		}
+		return fmt.Errorf("failed to extract the archive to the directory %q: %w", destDir, err)
+	}
+	if err := writeETag(destDir, etag); err != nil {
+		return err
+	}
+
+	klog.Infof("downloaded archive with checksum %q", checksum)
+	if err := util.UpdateSymlink(archiveRoot, linkPath, destDir, oldDir); err != nil {
+		return err
+	}
+	if oldDir != "" {
+		if err := os.Remove(oldDir + etagSuffix); err != nil && !os.IsNotExist(err) {
+			klog.Warningf("unable to remove the previous ETag: %v", err)
+		}
+	}
+	return nil
+}
+
+// authorize adds the credentials to the request.
+func (f *Fetcher) authorize(req *http.Request) error {
+	c := f.Credentials
+	switch {
+	case c.AccessKeyID != "" || c.SecretAccessKey != "":
+		if c.AccessKeyID == "" || c.SecretAccessKey == "" {
+			return fmt.Errorf("both the access key ID and the secret access key must be specified")
+		}
+		now := time.Now
+		if f.Now != nil {
+			now = f.Now
+		}
+		signV4(req, c.AccessKeyID, c.SecretAccessKey, c.Region, now())
+	case c.Token != "":
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	case c.Username != "" || c.Password != "":
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+	return nil
+}
+
+// download writes the body to a temporary file under dir, and returns its
+// path and SHA-256 checksum.
+func download(body io.Reader, dir string) (string, string, error) {
+	file, err := os.CreateTemp(dir, downloadPattern)
+	if err != nil {
+		return "", "", err
+	}
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(file, hash), body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return "", "", err
+	}
+	return file.Name(), hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// extract extracts the gzipped tarball to the directory. Entries which would
+// be written outside the directory are rejected.
+func extract(archivePath, dir string) error {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = gz.Close()
+	}()
+
+	tarReader := tar.NewReader(gz)
+	for {
+		hdr, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		path, err := safeJoin(dir, hdr.Name)
+		if err != nil {
+			return err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return err
+			}
+			if err := writeFile(path, tarReader, hdr.FileInfo().Mode().Perm()); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			// Links must stay inside the directory, so that the entries
+			// written through them do too.
+			if filepath.IsAbs(hdr.Linkname) {
+				return fmt.Errorf("symbolic link %q points outside of the archive", hdr.Name)
+			}
+			if _, err := safeJoin(dir, filepath.Join(filepath.Dir(hdr.Name), hdr.Linkname)); err != nil {
+				return fmt.Errorf("symbolic link %q points outside of the archive", hdr.Name)
+			}
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return err
+			}
+			if err := os.Symlink(hdr.Linkname, path); err != nil {
+				return err
+			}
+		default:
+			klog.Warningf("skipping the unsupported archive entry %q of type %q", hdr.Name, hdr.Typeflag)
+		}
+	}
+}
+
+// safeJoin joins the directory and the name of an archive entry, and returns
+// an error if the entry would be outside of the directory.
+func safeJoin(dir, name string) (string, error) {
+	path := filepath.Join(dir, name)
+	if path != dir && !strings.HasPrefix(path, dir+string(os.PathSeparator)) {
+		return "", fmt.Errorf("archive entry %q is outside of the archive", name)
+	}
+	return path, nil
+}
+
+func writeFile(path string, r io.Reader, mode os.FileMode) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode|0200)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(file, r)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// readETag returns the ETag of the archive extracted in the directory, or an
+// empty string if it was not recorded.
+func readETag(dir string) string {
+	content, err := os.ReadFile(dir + etagSuffix)
+	if err != nil {
+		return ""
+	}
+	return string(content)
+}
+
+// writeETag records the ETag of the archive extracted in the directory.
+func writeETag(dir, etag string) error {
+	path := dir + etagSuffix
+	if etag == "" {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove the ETag: %w", err)
+		}
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(etag), 0644); err != nil {
+		return fmt.Errorf("failed to write the ETag: %w", err)
+	}
+	return nil
+}
@@ -140,6 +140,23 @@ func AllKubectlConfigs(timeout time.Duration, wantContexts []string) (map[string
 	return configs, nil
 }
 
+// NewContextRestConfig returns a REST config for the named kubeconfig context,
+// or the REST config of NewRestConfig if contextName is empty.
+func NewContextRestConfig(timeout time.Duration, contextName string) (*rest.Config, error) {
+	if contextName == "" {
+		return NewRestConfig(timeout)
+	}
+	configs, err := AllKubectlConfigs(timeout, []string{contextName})
+	if err != nil {
+		return nil, err
+	}
+	cfg, found := configs[contextName]
+	if !found {
+		return nil, fmt.Errorf("context %q not found in the kubeconfig", contextName)
+	}
+	return cfg, nil
+}
+
 func filterContexts(wantContexts []string, contexts map[string]*clientcmdapi.Context) map[string]*clientcmdapi.Context {
 	filteredContexts := make(map[string]*clientcmdapi.Context)
 	if wantContexts == nil {
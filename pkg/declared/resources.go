@@ -26,6 +26,7 @@ import (
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/metrics"
 	"kpt.dev/configsync/pkg/remediator/queue"
 	"kpt.dev/configsync/pkg/status"
@@ -49,6 +50,11 @@ type Resources struct {
 	// The cluster-state is initialized by the applier and updated by the remediator.
 	mutationIgnoredObjectsMap *orderedmap.OrderedMap[core.ID, client.Object]
 
+	// hooks are the declared objects with the hook annotation. They are not
+	// managed like the other declared objects, but run by the applier.
+	// The slice should be treated as read-only once assigned.
+	hooks []*unstructured.Unstructured
+
 	// commit of the source in which the resources were declared
 	commit string
 	// previousCommit is the preceding commit to the commit
@@ -119,12 +125,14 @@ func (r *Resources) DeleteIgnored(id core.ID) bool {
 }
 
 // UpdateDeclared performs an atomic update on the resource declaration set.
+// Objects with the hook annotation are stored separately, as hooks.
 func (r *Resources) UpdateDeclared(ctx context.Context, objects []client.Object, commit string) ([]client.Object, status.Error) {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 	// First build up the new map using a local pointer/reference.
 	newSet := orderedmap.NewOrderedMap[core.ID, *unstructured.Unstructured]()
 	newObjects := []client.Object{}
+	var newHooks []*unstructured.Unstructured
 	for _, obj := range objects {
 		if obj == nil {
 			klog.Warning("Resources received nil declared resource")
@@ -138,6 +146,10 @@ func (r *Resources) UpdateDeclared(ctx context.Context, objects []client.Object,
 			return nil, status.InternalErrorBuilder.Wrap(err).
 				Sprintf("converting %v to unstructured.Unstructured", id).Build()
 		}
+		if metadata.IsHook(obj) {
+			newHooks = append(newHooks, u)
+			continue
+		}
 		newSet.Set(id, u)
 		newObjects = append(newObjects, obj)
 	}
@@ -159,6 +171,7 @@ func (r *Resources) UpdateDeclared(ctx context.Context, objects []client.Object,
 
 	r.previousCommit = commit
 	r.declaredObjectsMap = newSet
+	r.hooks = newHooks
 	r.commit = commit
 	return newObjects, nil
 }
@@ -178,6 +191,18 @@ func (r *Resources) GetDeclared(id core.ID) (*unstructured.Unstructured, string,
 	return u.DeepCopy(), r.commit, found
 }
 
+// Hooks returns a copy of the declared objects with the hook annotation, along
+// with the source commit. Hooks are not included in the other declared objects.
+func (r *Resources) Hooks() ([]*unstructured.Unstructured, string) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	var hooks []*unstructured.Unstructured
+	for _, hook := range r.hooks {
+		hooks = append(hooks, hook.DeepCopy())
+	}
+	return hooks, r.commit
+}
+
 // DeclaredUnstructureds returns all resource objects declared in the source,
 // along with the source commit.
 func (r *Resources) DeclaredUnstructureds() []*unstructured.Unstructured {
@@ -191,6 +191,28 @@ func TestGetDeclared(t *testing.T) {
 	}
 }
 
+func TestHooks(t *testing.T) {
+	dr := Resources{}
+	hook := k8sobjects.UnstructuredObject(kinds.Job(), core.Name("migrate"), core.Namespace("foo"),
+		metadata.WithHookPhase(metadata.HookPreSync))
+	objects := []client.Object{obj1, hook, obj2}
+	expectedCommit := "example"
+	newObjects, err := dr.UpdateDeclared(context.Background(), objects, expectedCommit)
+	require.NoError(t, err)
+
+	// Hooks are not declared objects.
+	assert.Equal(t, getIDs(testSet), getIDs(newObjects))
+	_, _, found := dr.GetDeclared(core.IDOf(hook))
+	assert.False(t, found)
+	gvks, _ := dr.DeclaredGVKs()
+	assert.NotContains(t, gvks, kinds.Job())
+
+	hooks, commit := dr.Hooks()
+	require.Equal(t, expectedCommit, commit)
+	require.Len(t, hooks, 1)
+	assert.Equal(t, core.IDOf(hook), core.IDOf(hooks[0]))
+}
+
 func TestGVKSet(t *testing.T) {
 	dr := Resources{}
 	expectedCommit := "example"
@@ -0,0 +1,162 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+
+	"kpt.dev/configsync/pkg/status"
+)
+
+// DriftedFields returns the paths of the declared fields which differ on the
+// cluster, sorted. Fields which are only set on the cluster, like defaults and
+// the status, are not drift.
+//
+// The paths use the JSONPath notation, for example
+// `.spec.template.spec.containers[0].image`, or
+// `.metadata.labels['app.kubernetes.io/name']` for keys which are not
+// identifiers.
+//
+// Returns nil if either the declared or the actual object is missing.
+func (d Diff) DriftedFields() ([]string, status.Error) {
+	declared, err := d.UnstructuredDeclared()
+	if err != nil {
+		return nil, err
+	}
+	actual, err := d.UnstructuredActual()
+	if err != nil {
+		return nil, err
+	}
+	if declared == nil || actual == nil {
+		return nil, nil
+	}
+	delete(declared.Object, "status")
+	fields := driftedFields("", declared.Object, actual.Object)
+	sort.Strings(fields)
+	return fields, nil
+}
+
+// driftedFields returns the paths of the fields in declared which differ in
+// actual, relative to path.
+func driftedFields(path string, declared, actual interface{}) []string {
+	switch declaredValue := declared.(type) {
+	case map[string]interface{}:
+		actualValue, ok := actual.(map[string]interface{})
+		if !ok {
+			if actual == nil && len(declaredValue) == 0 {
+				// The API server drops empty maps.
+				return nil
+			}
+			return []string{path}
+		}
+		var fields []string
+		for key, value := range declaredValue {
+			fields = append(fields, driftedFields(fieldPath(path, key), value, actualValue[key])...)
+		}
+		return fields
+	case []interface{}:
+		actualValue, ok := actual.([]interface{})
+		if !ok {
+			if actual == nil && len(declaredValue) == 0 {
+				// The API server drops empty lists.
+				return nil
+			}
+			return []string{path}
+		}
+		if len(declaredValue) != len(actualValue) {
+			return []string{path}
+		}
+		var fields []string
+		for i, value := range declaredValue {
+			fields = append(fields, driftedFields(fmt.Sprintf("%s[%d]", path, i), value, actualValue[i])...)
+		}
+		return fields
+	case nil:
+		// Null fields are removed by the API server.
+		return nil
+	default:
+		if actual == nil && reflect.ValueOf(declaredValue).IsZero() {
+			// The API server drops empty values of optional fields.
+			return nil
+		}
+		if !equalScalars(declaredValue, actual) {
+			return []string{path}
+		}
+		return nil
+	}
+}
+
+var identifier = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+
+// fieldPath appends the key to the JSONPath path.
+func fieldPath(path, key string) string {
+	if identifier.MatchString(key) {
+		return path + "." + key
+	}
+	return fmt.Sprintf("%s['%s']", path, key)
+}
+
+// equalScalars returns true if the scalar values are equal. Numbers are
+// compared by value, because the same number may be decoded as an integer or
+// a float.
+func equalScalars(a, b interface{}) bool {
+	if x, ok := toFloat(a); ok {
+		y, ok := toFloat(b)
+		return ok && x == y
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
+// KeepingFields returns a copy of the diff whose declared object has the
+// values of the actual object for the fields at the paths, which use the
+// JSONPath notation of DriftedFields. The drift of these fields is then
+// neither reverted nor reported.
+func (d Diff) KeepingFields(paths []string) (Diff, status.Error) {
+	if d.Declared == nil || d.Actual == nil || len(paths) == 0 {
+		return d, nil
+	}
+	declared, err := d.UnstructuredDeclared()
+	if err != nil {
+		return d, err
+	}
+	actual, err := d.UnstructuredActual()
+	if err != nil {
+		return d, err
+	}
+	for _, path := range paths {
+		p, err := parseJSONPath(path)
+		if err != nil {
+			return d, status.InternalErrorf("invalid drifted field path %q: %v", path, err)
+		}
+		declared.Object = copyField(declared.Object, actual.Object, p).(map[string]interface{})
+	}
+	return Diff{Declared: declared, Actual: d.Actual}, nil
+}
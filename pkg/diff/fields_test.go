@@ -0,0 +1,117 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/kinds"
+)
+
+func TestDriftedFields(t *testing.T) {
+	newDeployment := func(replicas int64, image string, opts ...core.MetaMutator) *unstructured.Unstructured {
+		u := k8sobjects.UnstructuredObject(kinds.Deployment(), append(opts, core.Name("app"), core.Namespace("prod"))...)
+		u.Object["spec"] = map[string]interface{}{
+			"replicas": replicas,
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "app", "image": image},
+					},
+				},
+			},
+		}
+		return u
+	}
+	withDefaults := func(u *unstructured.Unstructured) *unstructured.Unstructured {
+		u.SetResourceVersion("1")
+		require.NoError(t, unstructured.SetNestedField(u.Object, int64(10), "spec", "revisionHistoryLimit"))
+		require.NoError(t, unstructured.SetNestedField(u.Object, float64(1), "status", "replicas"))
+		containers, _, _ := unstructured.NestedSlice(u.Object, "spec", "template", "spec", "containers")
+		containers[0].(map[string]interface{})["imagePullPolicy"] = "IfNotPresent"
+		require.NoError(t, unstructured.SetNestedSlice(u.Object, containers, "spec", "template", "spec", "containers"))
+		return u
+	}
+
+	testCases := map[string]struct {
+		declared *unstructured.Unstructured
+		actual   *unstructured.Unstructured
+		want     []string
+	}{
+		"no drift, ignoring defaulted fields": {
+			declared: newDeployment(1, "app:v1"),
+			actual:   withDefaults(newDeployment(1, "app:v1")),
+		},
+		"changed fields": {
+			declared: newDeployment(1, "app:v1"),
+			actual:   withDefaults(newDeployment(3, "app:v2")),
+			want:     []string{".spec.replicas", ".spec.template.spec.containers[0].image"},
+		},
+		"removed label": {
+			declared: newDeployment(1, "app:v1", core.Label("app.kubernetes.io/name", "app")),
+			actual:   withDefaults(newDeployment(1, "app:v1")),
+			want:     []string{".metadata.labels['app.kubernetes.io/name']"},
+		},
+		"changed label": {
+			declared: newDeployment(1, "app:v1", core.Label("app.kubernetes.io/name", "app")),
+			actual:   withDefaults(newDeployment(1, "app:v1", core.Label("app.kubernetes.io/name", "other"))),
+			want:     []string{".metadata.labels['app.kubernetes.io/name']"},
+		},
+		"missing actual": {
+			declared: newDeployment(1, "app:v1"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			d := Diff{Declared: tc.declared}
+			if tc.actual != nil {
+				d.Actual = tc.actual
+			}
+			got, err := d.DriftedFields()
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestKeepingFields(t *testing.T) {
+	declared := k8sobjects.UnstructuredObject(kinds.Deployment(), core.Name("app"), core.Namespace("prod"),
+		core.Label("app.kubernetes.io/name", "app"), core.Label("team", "a"))
+	declared.Object["spec"] = map[string]interface{}{"replicas": int64(1), "paused": true}
+	actual := k8sobjects.UnstructuredObject(kinds.Deployment(), core.Name("app"), core.Namespace("prod"),
+		core.Label("app.kubernetes.io/name", "other"), core.Label("team", "b"))
+	actual.Object["spec"] = map[string]interface{}{"replicas": int64(3)}
+
+	d, err := Diff{Declared: declared, Actual: actual}.KeepingFields([]string{
+		".spec.replicas", ".spec.paused", ".metadata.labels['app.kubernetes.io/name']",
+	})
+	require.NoError(t, err)
+	got := d.Declared.(*unstructured.Unstructured)
+	// The drifted fields have their actual values, or are removed if they are
+	// not on the cluster.
+	assert.Equal(t, map[string]interface{}{"replicas": int64(3)}, got.Object["spec"])
+	// The other fields keep their declared values.
+	assert.Equal(t, map[string]string{"app.kubernetes.io/name": "other", "team": "a"}, got.GetLabels())
+	// The declared object is not modified.
+	assert.Equal(t, int64(1), declared.Object["spec"].(map[string]interface{})["replicas"])
+
+	_, err = Diff{Declared: declared, Actual: actual}.KeepingFields([]string{"spec"})
+	assert.Error(t, err)
+}
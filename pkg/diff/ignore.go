@@ -0,0 +1,347 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+	"sigs.k8s.io/structured-merge-diff/v4/value"
+)
+
+// IgnoreRules select the fields of the managed objects whose differences from
+// the declared state are ignored.
+type IgnoreRules []ignoreRule
+
+type ignoreRule struct {
+	v1beta1.IgnoreDifference
+	paths []jsonPath
+}
+
+// NewIgnoreRules validates and parses the spec.override.ignoreDifferences of
+// an RSync.
+func NewIgnoreRules(ignores []v1beta1.IgnoreDifference) (IgnoreRules, error) {
+	var rules IgnoreRules
+	for i, ignore := range ignores {
+		if ignore.Kind == "" {
+			return nil, fmt.Errorf("ignoreDifferences[%d]: kind must be specified", i)
+		}
+		if len(ignore.JSONPaths) == 0 && len(ignore.ManagedFieldsManagers) == 0 {
+			return nil, fmt.Errorf("ignoreDifferences[%d]: either jsonPaths or managedFieldsManagers must be specified", i)
+		}
+		rule := ignoreRule{IgnoreDifference: ignore}
+		for _, path := range ignore.JSONPaths {
+			p, err := parseJSONPath(path)
+			if err != nil {
+				return nil, fmt.Errorf("ignoreDifferences[%d]: invalid JSONPath %q: %w", i, path, err)
+			}
+			rule.paths = append(rule.paths, p)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// Matches returns true if any of the rules selects the object.
+func (rules IgnoreRules) Matches(obj client.Object) bool {
+	for _, rule := range rules {
+		if rule.matches(obj) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r ignoreRule) matches(obj client.Object) bool {
+	gk := obj.GetObjectKind().GroupVersionKind().GroupKind()
+	return gk.Group == r.Group && gk.Kind == r.Kind &&
+		(r.Name == "" || r.Name == obj.GetName()) &&
+		(r.Namespace == "" || r.Namespace == obj.GetNamespace())
+}
+
+// Strip removes the fields selected by the JSONPaths of the rules from the
+// object. The fields owned by the ignored field managers are only known once
+// the object is on the cluster, so they are not removed.
+func (rules IgnoreRules) Strip(obj *unstructured.Unstructured) {
+	for _, rule := range rules {
+		if !rule.matches(obj) {
+			continue
+		}
+		for _, path := range rule.paths {
+			obj.Object = copyField(obj.Object, nil, path).(map[string]interface{})
+		}
+	}
+}
+
+// IgnoringDifferences returns a copy of the diff whose declared object has the
+// values of the actual object for the ignored fields, so the ignored fields
+// are neither reverted nor reported as drift. The ignored fields are not
+// removed from the declared object instead, because a field removed from the
+// applied configuration is deleted by Server-Side Apply.
+func (d Diff) IgnoringDifferences(rules IgnoreRules) (Diff, status.Error) {
+	if d.Declared == nil || d.Actual == nil || !rules.Matches(d.Declared) {
+		return d, nil
+	}
+	declared, err := d.UnstructuredDeclared()
+	if err != nil {
+		return d, err
+	}
+	actual, err := d.UnstructuredActual()
+	if err != nil {
+		return d, err
+	}
+	for _, rule := range rules {
+		if !rule.matches(declared) {
+			continue
+		}
+		for _, path := range rule.paths {
+			declared.Object = copyField(declared.Object, actual.Object, path).(map[string]interface{})
+		}
+		for _, entry := range actual.GetManagedFields() {
+			if entry.FieldsV1 == nil || !slices.Contains(rule.ManagedFieldsManagers, entry.Manager) {
+				continue
+			}
+			set := &fieldpath.Set{}
+			if err := set.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+				klog.Warningf("Failed to decode the fields of manager %q on %s: %v", entry.Manager, core.GKNN(actual), err)
+				continue
+			}
+			set.Iterate(func(p fieldpath.Path) {
+				declared.Object = copyManagedField(declared.Object, actual.Object, p).(map[string]interface{})
+			})
+		}
+	}
+	return Diff{Declared: declared, Actual: d.Actual}, nil
+}
+
+// jsonPath is a parsed JSONPath, which supports field names, quoted keys,
+// list indexes and wildcards.
+type jsonPath []pathSegment
+
+type pathSegment struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+func parseJSONPath(path string) (jsonPath, error) {
+	if !strings.HasPrefix(path, ".") {
+		return nil, errors.New("must start with '.'")
+	}
+	var result jsonPath
+	for rest := path; rest != ""; {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[") + 1
+			if end == 0 {
+				end = len(rest)
+			}
+			name := rest[1:end]
+			switch name {
+			case "":
+				return nil, errors.New("empty field name")
+			case "*":
+				result = append(result, pathSegment{wildcard: true})
+			default:
+				result = append(result, pathSegment{key: name})
+			}
+			rest = rest[end:]
+		case '[':
+			segment, n, err := parseBracket(rest)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, segment)
+			rest = rest[n:]
+		default:
+			return nil, fmt.Errorf("unexpected %q", rest[0])
+		}
+	}
+	return result, nil
+}
+
+// parseBracket parses the bracket segment at the start of s, and returns the
+// number of parsed characters.
+func parseBracket(s string) (pathSegment, int, error) {
+	if len(s) > 1 && (s[1] == '\'' || s[1] == '"') {
+		end := strings.IndexByte(s[2:], s[1]) + 2
+		if end == 1 || end+1 >= len(s) || s[end+1] != ']' {
+			return pathSegment{}, 0, errors.New("unterminated quoted key")
+		}
+		return pathSegment{key: s[2:end]}, end + 2, nil
+	}
+	end := strings.IndexByte(s, ']')
+	if end == -1 {
+		return pathSegment{}, 0, errors.New("missing ']'")
+	}
+	content := s[1:end]
+	if content == "*" {
+		return pathSegment{wildcard: true}, end + 1, nil
+	}
+	index, err := strconv.Atoi(content)
+	if err != nil || index < 0 {
+		return pathSegment{}, 0, fmt.Errorf("invalid list index %q", content)
+	}
+	return pathSegment{index: index, isIndex: true}, end + 1, nil
+}
+
+// copyField sets the fields at path in declared to their value in actual, or
+// removes them if they are not in actual, and returns declared. Only the
+// fields which are in declared are set.
+func copyField(declared, actual interface{}, path jsonPath) interface{} {
+	if len(path) == 0 {
+		return declared
+	}
+	segment, rest := path[0], path[1:]
+	switch n := declared.(type) {
+	case map[string]interface{}:
+		if segment.isIndex {
+			return n
+		}
+		actualMap, _ := actual.(map[string]interface{})
+		for key, value := range n {
+			if !segment.wildcard && key != segment.key {
+				continue
+			}
+			actualValue, found := actualMap[key]
+			switch {
+			case len(rest) > 0:
+				n[key] = copyField(value, actualValue, rest)
+			case found:
+				n[key] = runtime.DeepCopyJSONValue(actualValue)
+			default:
+				delete(n, key)
+			}
+		}
+		return n
+	case []interface{}:
+		if !segment.isIndex && !segment.wildcard {
+			return n
+		}
+		actualList, _ := actual.([]interface{})
+		result := make([]interface{}, 0, len(n))
+		for i, item := range n {
+			var actualItem interface{}
+			found := i < len(actualList)
+			if found {
+				actualItem = actualList[i]
+			}
+			switch {
+			case !segment.wildcard && i != segment.index:
+				result = append(result, item)
+			case len(rest) > 0:
+				result = append(result, copyField(item, actualItem, rest))
+			case found:
+				result = append(result, runtime.DeepCopyJSONValue(actualItem))
+			}
+		}
+		return result
+	default:
+		return declared
+	}
+}
+
+// copyManagedField sets the field at the managed fields path p in declared to
+// its value in actual, and returns declared. The field is not set if it is
+// not in declared.
+func copyManagedField(declared, actual interface{}, p fieldpath.Path) interface{} {
+	if len(p) == 0 {
+		return declared
+	}
+	element, rest := p[0], p[1:]
+	switch n := declared.(type) {
+	case map[string]interface{}:
+		if element.FieldName == nil {
+			return n
+		}
+		value, found := n[*element.FieldName]
+		if !found {
+			return n
+		}
+		actualMap, _ := actual.(map[string]interface{})
+		actualValue, actualFound := actualMap[*element.FieldName]
+		switch {
+		case len(rest) > 0:
+			n[*element.FieldName] = copyManagedField(value, actualValue, rest)
+		case actualFound:
+			n[*element.FieldName] = runtime.DeepCopyJSONValue(actualValue)
+		default:
+			delete(n, *element.FieldName)
+		}
+		return n
+	case []interface{}:
+		actualList, _ := actual.([]interface{})
+		var actualItem interface{}
+		actualFound := false
+		for i, item := range actualList {
+			if matchesListElement(element, i, item) {
+				actualItem, actualFound = item, true
+				break
+			}
+		}
+		result := make([]interface{}, 0, len(n))
+		for i, item := range n {
+			switch {
+			case !matchesListElement(element, i, item):
+				result = append(result, item)
+			case len(rest) > 0:
+				result = append(result, copyManagedField(item, actualItem, rest))
+			case actualFound:
+				result = append(result, runtime.DeepCopyJSONValue(actualItem))
+			}
+		}
+		return result
+	default:
+		return declared
+	}
+}
+
+// matchesListElement returns true if the list item at index i is the one
+// selected by the managed fields path element.
+func matchesListElement(element fieldpath.PathElement, i int, item interface{}) bool {
+	switch {
+	case element.Index != nil:
+		return *element.Index == i
+	case element.Value != nil:
+		return value.Equals(value.NewValueInterface(item), *element.Value)
+	case element.Key != nil:
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for _, field := range *element.Key {
+			v, found := m[field.Name]
+			if !found || !value.Equals(value.NewValueInterface(v), field.Value) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/kinds"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestNewIgnoreRules(t *testing.T) {
+	testCases := map[string]struct {
+		ignores []v1beta1.IgnoreDifference
+		wantErr string
+	}{
+		"valid rules": {
+			ignores: []v1beta1.IgnoreDifference{
+				{Group: "apps", Kind: "Deployment", JSONPaths: []string{
+					".spec.replicas",
+					".metadata.annotations['example.com/hash']",
+					`.metadata.labels["app"]`,
+					".spec.template.spec.containers[*].image",
+					".spec.template.spec.containers[0].*",
+				}},
+				{Kind: "ConfigMap", ManagedFieldsManagers: []string{"kube-controller-manager"}},
+			},
+		},
+		"missing kind": {
+			ignores: []v1beta1.IgnoreDifference{{Group: "apps", JSONPaths: []string{".spec.replicas"}}},
+			wantErr: "ignoreDifferences[0]: kind must be specified",
+		},
+		"missing fields": {
+			ignores: []v1beta1.IgnoreDifference{{Group: "apps", Kind: "Deployment"}},
+			wantErr: "ignoreDifferences[0]: either jsonPaths or managedFieldsManagers must be specified",
+		},
+		"path without leading dot": {
+			ignores: []v1beta1.IgnoreDifference{{Kind: "ConfigMap", JSONPaths: []string{"data"}}},
+			wantErr: `ignoreDifferences[0]: invalid JSONPath "data": must start with '.'`,
+		},
+		"empty field name": {
+			ignores: []v1beta1.IgnoreDifference{{Kind: "ConfigMap", JSONPaths: []string{".data..key"}}},
+			wantErr: `ignoreDifferences[0]: invalid JSONPath ".data..key": empty field name`,
+		},
+		"unterminated quoted key": {
+			ignores: []v1beta1.IgnoreDifference{{Kind: "ConfigMap", JSONPaths: []string{".data['key"}}},
+			wantErr: `ignoreDifferences[0]: invalid JSONPath ".data['key": unterminated quoted key`,
+		},
+		"invalid list index": {
+			ignores: []v1beta1.IgnoreDifference{{Kind: "Pod", JSONPaths: []string{".spec.containers[first]"}}},
+			wantErr: `ignoreDifferences[0]: invalid JSONPath ".spec.containers[first]": invalid list index "first"`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewIgnoreRules(tc.ignores)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestIgnoringDifferences(t *testing.T) {
+	newDeployment := func(replicas int64, image string, opts ...core.MetaMutator) *unstructured.Unstructured {
+		u := k8sobjects.UnstructuredObject(kinds.Deployment(), append(opts, core.Name("app"), core.Namespace("prod"))...)
+		u.Object["spec"] = map[string]interface{}{
+			"replicas": replicas,
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "app", "image": image},
+						map[string]interface{}{"name": "sidecar", "image": "sidecar:v1"},
+					},
+				},
+			},
+		}
+		return u
+	}
+	managedBy := func(manager, fields string) core.MetaMutator {
+		return func(o client.Object) {
+			o.SetManagedFields(append(o.GetManagedFields(), metav1.ManagedFieldsEntry{
+				Manager:    manager,
+				Operation:  metav1.ManagedFieldsOperationUpdate,
+				FieldsType: "FieldsV1",
+				FieldsV1:   &metav1.FieldsV1{Raw: []byte(fields)},
+			}))
+		}
+	}
+
+	testCases := map[string]struct {
+		ignores  []v1beta1.IgnoreDifference
+		declared *unstructured.Unstructured
+		actual   *unstructured.Unstructured
+		want     *unstructured.Unstructured
+	}{
+		"JSONPath takes the value on the cluster": {
+			ignores: []v1beta1.IgnoreDifference{
+				{Group: "apps", Kind: "Deployment", JSONPaths: []string{".spec.replicas"}},
+			},
+			declared: newDeployment(1, "app:v1"),
+			actual:   newDeployment(3, "app:v2"),
+			want:     newDeployment(3, "app:v1"),
+		},
+		"JSONPath with a wildcard": {
+			ignores: []v1beta1.IgnoreDifference{
+				{Group: "apps", Kind: "Deployment", JSONPaths: []string{".spec.template.spec.containers[*].image"}},
+			},
+			declared: newDeployment(1, "app:v1"),
+			actual:   newDeployment(3, "app:v2"),
+			want:     newDeployment(1, "app:v2"),
+		},
+		"JSONPath with a quoted key removed on the cluster": {
+			ignores: []v1beta1.IgnoreDifference{
+				{Group: "apps", Kind: "Deployment", JSONPaths: []string{".metadata.annotations['example.com/hash']"}},
+			},
+			declared: newDeployment(1, "app:v1", core.Annotation("example.com/hash", "abc")),
+			actual:   newDeployment(1, "app:v1"),
+			want:     newDeployment(1, "app:v1", core.Annotations(map[string]string{})),
+		},
+		"fields owned by an ignored manager take the value on the cluster": {
+			ignores: []v1beta1.IgnoreDifference{
+				{Group: "apps", Kind: "Deployment", ManagedFieldsManagers: []string{"kube-controller-manager"}},
+			},
+			declared: newDeployment(1, "app:v1"),
+			actual: newDeployment(3, "app:v2", managedBy("kube-controller-manager",
+				`{"f:spec":{"f:replicas":{},"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"app\"}":{"f:image":{}}}}}}}`)),
+			want: newDeployment(3, "app:v2"),
+		},
+		"fields owned by other managers are not ignored": {
+			ignores: []v1beta1.IgnoreDifference{
+				{Group: "apps", Kind: "Deployment", ManagedFieldsManagers: []string{"kube-controller-manager"}},
+			},
+			declared: newDeployment(1, "app:v1"),
+			actual:   newDeployment(3, "app:v2", managedBy("kubectl-edit", `{"f:spec":{"f:replicas":{}}}`)),
+			want:     newDeployment(1, "app:v1"),
+		},
+		"rule selecting another name": {
+			ignores: []v1beta1.IgnoreDifference{
+				{Group: "apps", Kind: "Deployment", Name: "other", JSONPaths: []string{".spec.replicas"}},
+			},
+			declared: newDeployment(1, "app:v1"),
+			actual:   newDeployment(3, "app:v1"),
+			want:     newDeployment(1, "app:v1"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rules, err := NewIgnoreRules(tc.ignores)
+			require.NoError(t, err)
+			d := Diff{Declared: tc.declared, Actual: tc.actual}
+			got, statusErr := d.IgnoringDifferences(rules)
+			require.NoError(t, statusErr)
+			assert.Equal(t, tc.want.Object, got.Declared.(*unstructured.Unstructured).Object)
+			// The original declared object is not modified.
+			assert.Equal(t, int64(1), tc.declared.Object["spec"].(map[string]interface{})["replicas"])
+		})
+	}
+}
+
+func TestIgnoreRulesStrip(t *testing.T) {
+	rules, err := NewIgnoreRules([]v1beta1.IgnoreDifference{
+		{Kind: "ConfigMap", Namespace: "prod", JSONPaths: []string{".data.generated", ".metadata.labels.*"}},
+	})
+	require.NoError(t, err)
+
+	obj := k8sobjects.UnstructuredObject(kinds.ConfigMap(), core.Name("settings"), core.Namespace("prod"), core.Label("app", "settings"))
+	obj.Object["data"] = map[string]interface{}{"generated": "abc", "static": "def"}
+	rules.Strip(obj)
+	assert.Equal(t, map[string]interface{}{"static": "def"}, obj.Object["data"])
+	assert.Empty(t, obj.GetLabels())
+
+	other := k8sobjects.UnstructuredObject(kinds.ConfigMap(), core.Name("settings"), core.Namespace("dev"))
+	other.Object["data"] = map[string]interface{}{"generated": "abc"}
+	rules.Strip(other)
+	assert.Equal(t, map[string]interface{}{"generated": "abc"}, other.Object["data"])
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"bytes"
+	"fmt"
+)
+
+const (
+	// signatureHeader is the commit header holding the signature.
+	signatureHeader = "gpgsig"
+	// sha256SignatureHeader is the commit header holding the signature of the
+	// SHA-256 form of the commit, in SHA-256 repositories or repositories
+	// converted between the object formats.
+	sha256SignatureHeader = "gpgsig-sha256"
+)
+
+// Commit is a parsed commit object.
+type Commit struct {
+	// Hash is the object name of the commit.
+	Hash string
+	// Signature is the armored signature of the commit, if any.
+	Signature []byte
+	// SHA256Signature is the armored signature of the SHA-256 form of the
+	// commit, if any. It can't be verified, as only SHA-1 repositories are
+	// supported.
+	SHA256Signature []byte
+	// Payload is the content of the commit object, without the signature
+	// headers. This is the data covered by the signature.
+	Payload []byte
+}
+
+// ReadCommit reads and parses the commit with the specified hash.
+func (r *Repository) ReadCommit(hash string) (*Commit, error) {
+	objType, data, err := r.ReadObject(hash)
+	if err != nil {
+		return nil, err
+	}
+	if objType != CommitObject {
+		return nil, fmt.Errorf("object %s is a %s, not a commit", hash, objType)
+	}
+	commit := parseCommit(data)
+	commit.Hash = hash
+	return commit, nil
+}
+
+// parseCommit splits the signature out of the raw commit object.
+//
+// Headers are separated from the commit message by an empty line. Multi-line
+// header values, like the signature, continue on lines starting with a
+// single space.
+func parseCommit(data []byte) *Commit {
+	commit := &Commit{}
+	var payload bytes.Buffer
+	rest := data
+	// signature is the signature the continuation lines are appended to,
+	// while in a signature header.
+	var signature *[]byte
+	for len(rest) > 0 {
+		end := len(rest)
+		if i := bytes.IndexByte(rest, '\n'); i >= 0 {
+			end = i + 1
+		}
+		line := rest[:end]
+		rest = rest[end:]
+		if len(bytes.TrimSuffix(line, []byte{'\n'})) == 0 {
+			// End of the headers. The rest is the commit message.
+			payload.Write(line)
+			payload.Write(rest)
+			break
+		}
+		switch {
+		case signature != nil && line[0] == ' ':
+			*signature = append(*signature, line[1:]...)
+			continue
+		case bytes.HasPrefix(line, []byte(signatureHeader+" ")):
+			signature = &commit.Signature
+			*signature = append(*signature, line[len(signatureHeader)+1:]...)
+			continue
+		case bytes.HasPrefix(line, []byte(sha256SignatureHeader+" ")):
+			signature = &commit.SHA256Signature
+			*signature = append(*signature, line[len(sha256SignatureHeader)+1:]...)
+			continue
+		}
+		signature = nil
+		payload.Write(line)
+	}
+	commit.Payload = payload.Bytes()
+	return commit
+}
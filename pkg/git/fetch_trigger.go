@@ -0,0 +1,107 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+)
+
+const (
+	// FetchTriggerDir is the directory shared by the reconciler and the
+	// git-sync containers, which holds the fetch trigger file.
+	FetchTriggerDir = "/fetch-trigger"
+	// FetchTriggerFile is the file touched by the reconciler to ask the
+	// git-sync containers to fetch.
+	FetchTriggerFile = FetchTriggerDir + "/fetch"
+)
+
+// FetchToucher asks the git-sync containers in the reconciler Pod to fetch, by
+// touching a file in a volume shared with them. Each git-sync container runs
+// under the git-sync-launcher, which watches the file and signals git-sync
+// when it changes. Unlike signaling git-sync directly, this needs neither a
+// shared process namespace, nor extra capabilities.
+type FetchToucher struct {
+	// Path of the trigger file. Default: FetchTriggerFile.
+	Path string
+	// Now returns the current time. Default: time.Now.
+	Now func() time.Time
+}
+
+// TriggerFetch updates the modification time of the trigger file, creating it
+// if it does not exist.
+func (t *FetchToucher) TriggerFetch() error {
+	path := t.Path
+	if path == "" {
+		path = FetchTriggerFile
+	}
+	now := time.Now
+	if t.Now != nil {
+		now = t.Now
+	}
+	// The content is only written for debugging. The watchers only compare
+	// the modification time.
+	content := []byte(now().UTC().Format(time.RFC3339Nano) + "\n")
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("touching the fetch trigger file: %w", err)
+	}
+	return nil
+}
+
+// FetchTriggerWatcher detects the changes of the trigger file.
+type FetchTriggerWatcher struct {
+	path string
+	last time.Time
+}
+
+// NewFetchTriggerWatcher returns a watcher of the trigger file at the given
+// path. Only the changes made after it is created are detected.
+func NewFetchTriggerWatcher(path string) (*FetchTriggerWatcher, error) {
+	last, err := modTime(path)
+	if err != nil {
+		return nil, err
+	}
+	return &FetchTriggerWatcher{path: path, last: last}, nil
+}
+
+// Triggered returns true if the trigger file was created or modified since the
+// previous call.
+func (w *FetchTriggerWatcher) Triggered() (bool, error) {
+	current, err := modTime(w.path)
+	if err != nil {
+		return false, err
+	}
+	if current.Equal(w.last) {
+		return false, nil
+	}
+	w.last = current
+	return true, nil
+}
+
+// modTime returns the modification time of the file, or the zero time if it
+// does not exist yet.
+func modTime(path string) (time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, fmt.Errorf("reading the fetch trigger file: %w", err)
+	}
+	return info.ModTime(), nil
+}
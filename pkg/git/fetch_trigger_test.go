@@ -0,0 +1,53 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchTrigger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fetch")
+	toucher := &FetchToucher{Path: path}
+	watcher, err := NewFetchTriggerWatcher(path)
+	require.NoError(t, err)
+
+	triggered, err := watcher.Triggered()
+	require.NoError(t, err)
+	assert.False(t, triggered, "the trigger file does not exist yet")
+
+	require.NoError(t, toucher.TriggerFetch())
+	triggered, err = watcher.Triggered()
+	require.NoError(t, err)
+	assert.True(t, triggered, "creating the trigger file triggers a fetch")
+
+	triggered, err = watcher.Triggered()
+	require.NoError(t, err)
+	assert.False(t, triggered, "each change triggers a single fetch")
+
+	// The modification time is set explicitly, so the test does not depend on
+	// the resolution of the file system timestamps.
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(path, later, later))
+	triggered, err = watcher.Triggered()
+	require.NoError(t, err)
+	assert.True(t, triggered, "touching the trigger file triggers a fetch")
+}
@@ -0,0 +1,228 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The git-sync settings read to authenticate like git-sync. They are set on the
+// git-sync container by the reconciler-manager.
+const (
+	gitSyncUsername          = "GITSYNC_USERNAME"
+	gitSyncPassword          = "GITSYNC_PASSWORD"
+	gitSyncAskpassURL        = "GITSYNC_ASKPASS_URL"
+	gitSyncSSH               = "GITSYNC_SSH"
+	gitSyncSSHKnownHosts     = "GITSYNC_SSH_KNOWN_HOSTS"
+	gitSyncCookieFile        = "GITSYNC_COOKIE_FILE"
+	gitSyncGitHubBaseURL     = "GITSYNC_GITHUB_BASE_URL"
+	gitSyncGitHubPrivateKey  = "GITSYNC_GITHUB_APP_PRIVATE_KEY"
+	gitSyncGitHubClientID    = "GITSYNC_GITHUB_APP_CLIENT_ID"
+	gitSyncGitHubAppID       = "GITSYNC_GITHUB_APP_APPLICATION_ID"
+	gitSyncGitHubInstallID   = "GITSYNC_GITHUB_APP_INSTALLATION_ID"
+	gitSSLCAInfo             = "GIT_SSL_CAINFO"
+	gitSSLNoVerify           = "GIT_SSL_NO_VERIFY"
+	gitSyncSSHKeyFile        = "/etc/git-secret/ssh"
+	gitSyncKnownHostsFile    = "/etc/git-secret/known_hosts"
+	gitSyncCookieFilePath    = "/etc/git-secret/cookie_file"
+	defaultGitHubBaseURL     = "https://api.github.com/"
+	gitHubAppTokenUsername   = "x-access-token"
+	gitHubAppJWTLifetime     = 10 * time.Minute
+	gitHubAppJWTClockSkew    = time.Minute
+	askpassResponseMaxLength = 64 << 10
+)
+
+// GitSyncRemoteAuth returns the credentials git-sync uses to fetch from the
+// remote repository, read from the environment and the files of the git-sync
+// container. It supports the same auth types as git-sync: static credentials,
+// the askpass URL of the gcenode-askpass-sidecar, GitHub App installation
+// tokens, SSH keys and cookie files. The HTTPS proxy is read from the
+// environment when connecting.
+func GitSyncRemoteAuth(ctx context.Context, getenv func(string) string) (RemoteAuth, error) {
+	auth := RemoteAuth{
+		Username: getenv(gitSyncUsername),
+		Password: getenv(gitSyncPassword),
+	}
+	var err error
+	switch {
+	case getenv(gitSyncAskpassURL) != "":
+		auth.Username, auth.Password, err = askpassCredentials(ctx, getenv(gitSyncAskpassURL))
+	case getenv(gitSyncGitHubPrivateKey) != "":
+		auth.Username = gitHubAppTokenUsername
+		auth.Password, err = gitHubAppToken(ctx, getenv)
+	case isTrue(getenv(gitSyncSSH)):
+		auth.SSHKey, err = os.ReadFile(gitSyncSSHKeyFile)
+		if err == nil && isTrue(getenv(gitSyncSSHKnownHosts)) {
+			auth.KnownHosts, err = os.ReadFile(gitSyncKnownHostsFile)
+		}
+	case isTrue(getenv(gitSyncCookieFile)):
+		auth.Cookies, err = os.ReadFile(gitSyncCookieFilePath)
+	}
+	if err != nil {
+		return auth, err
+	}
+	if caFile := getenv(gitSSLCAInfo); caFile != "" {
+		if auth.CACert, err = os.ReadFile(caFile); err != nil {
+			return auth, err
+		}
+	}
+	auth.InsecureSkipTLSVerify = isTrue(getenv(gitSSLNoVerify))
+	return auth, nil
+}
+
+func isTrue(value string) bool {
+	b, err := strconv.ParseBool(value)
+	return err == nil && b
+}
+
+// askpassCredentials returns the username and password served by an askpass
+// URL, formatted as `username=<username>\npassword=<password>`.
+func askpassCredentials(ctx context.Context, askpassURL string) (string, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, askpassURL, nil)
+	if err != nil {
+		return "", "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("calling the askpass URL: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("calling the askpass URL: unexpected HTTP status: %s", resp.Status)
+	}
+	var username, password string
+	scanner := bufio.NewScanner(io.LimitReader(resp.Body, askpassResponseMaxLength))
+	for scanner.Scan() {
+		key, value, _ := strings.Cut(scanner.Text(), "=")
+		switch key {
+		case "username":
+			username = value
+		case "password":
+			password = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", "", fmt.Errorf("reading the askpass response: %w", err)
+	}
+	return username, password, nil
+}
+
+// gitHubAppToken returns an installation access token of the GitHub App.
+// https://docs.github.com/en/apps/creating-github-apps/authenticating-with-a-github-app/authenticating-as-a-github-app-installation
+func gitHubAppToken(ctx context.Context, getenv func(string) string) (string, error) {
+	issuer := getenv(gitSyncGitHubClientID)
+	if issuer == "" {
+		issuer = getenv(gitSyncGitHubAppID)
+	}
+	installationID := getenv(gitSyncGitHubInstallID)
+	if issuer == "" || installationID == "" {
+		return "", errors.New("the GitHub App client or application ID, and the installation ID are required")
+	}
+	jwt, err := gitHubAppJWT(issuer, []byte(getenv(gitSyncGitHubPrivateKey)), time.Now())
+	if err != nil {
+		return "", err
+	}
+	baseURL := getenv(gitSyncGitHubBaseURL)
+	if baseURL == "" {
+		baseURL = defaultGitHubBaseURL
+	}
+	endpoint := strings.TrimSuffix(baseURL, "/") + "/app/installations/" + installationID + "/access_tokens"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("requesting a GitHub App installation token: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("requesting a GitHub App installation token: unexpected HTTP status: %s", resp.Status)
+	}
+	var token struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("decoding the GitHub App installation token: %w", err)
+	}
+	return token.Token, nil
+}
+
+// gitHubAppJWT returns the JSON Web Token authenticating as the GitHub App,
+// signed with its private key.
+func gitHubAppJWT(issuer string, privateKey []byte, now time.Time) (string, error) {
+	block, _ := pem.Decode(privateKey)
+	if block == nil {
+		return "", errors.New("invalid GitHub App private key: no PEM data found")
+	}
+	var key *rsa.PrivateKey
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err == nil {
+		var ok bool
+		if key, ok = parsed.(*rsa.PrivateKey); !ok {
+			return "", errors.New("invalid GitHub App private key: not an RSA key")
+		}
+	} else if key, err = x509.ParsePKCS1PrivateKey(block.Bytes); err != nil {
+		return "", fmt.Errorf("invalid GitHub App private key: %w", err)
+	}
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]any{
+		"iat": now.Add(-gitHubAppJWTClockSkew).Unix(),
+		"exp": now.Add(gitHubAppJWTLifetime - gitHubAppJWTClockSkew).Unix(),
+		"iss": issuer,
+	})
+	if err != nil {
+		return "", err
+	}
+	var token bytes.Buffer
+	token.WriteString(base64.RawURLEncoding.EncodeToString(header))
+	token.WriteByte('.')
+	token.WriteString(base64.RawURLEncoding.EncodeToString(claims))
+	digest := sha256.Sum256(token.Bytes())
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	token.WriteByte('.')
+	token.WriteString(base64.RawURLEncoding.EncodeToString(signature))
+	return token.String(), nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitSyncRemoteAuth(t *testing.T) {
+	askpass := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("username=sa@example.iam.gserviceaccount.com\npassword=access-token"))
+	}))
+	defer askpass.Close()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	github := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/app/installations/42/access_tokens" {
+			http.NotFound(w, r)
+			return
+		}
+		// The JWT must be signed with the App private key, and issued by the
+		// App.
+		jwt := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		parts := strings.Split(jwt, ".")
+		require.Len(t, parts, 3)
+		signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+		require.NoError(t, err)
+		digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		if rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature) != nil {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+		claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+		require.NoError(t, err)
+		var issuer struct {
+			Iss string `json:"iss"`
+		}
+		require.NoError(t, json.Unmarshal(claims, &issuer))
+		assert.Equal(t, "client-id", issuer.Iss)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"token":"installation-token"}`))
+	}))
+	defer github.Close()
+
+	testCases := map[string]struct {
+		env     map[string]string
+		want    RemoteAuth
+		wantErr string
+	}{
+		"none": {
+			env:  map[string]string{},
+			want: RemoteAuth{},
+		},
+		"token": {
+			env:  map[string]string{gitSyncUsername: "user", gitSyncPassword: "token"},
+			want: RemoteAuth{Username: "user", Password: "token"},
+		},
+		"askpass": {
+			env:  map[string]string{gitSyncAskpassURL: askpass.URL},
+			want: RemoteAuth{Username: "sa@example.iam.gserviceaccount.com", Password: "access-token"},
+		},
+		"github app": {
+			env: map[string]string{
+				gitSyncGitHubPrivateKey: string(privateKey),
+				gitSyncGitHubClientID:   "client-id",
+				gitSyncGitHubInstallID:  "42",
+				gitSyncGitHubBaseURL:    github.URL,
+			},
+			want: RemoteAuth{Username: gitHubAppTokenUsername, Password: "installation-token"},
+		},
+		"github app without installation ID": {
+			env: map[string]string{
+				gitSyncGitHubPrivateKey: string(privateKey),
+				gitSyncGitHubClientID:   "client-id",
+			},
+			wantErr: "the installation ID are required",
+		},
+		"no SSL verify": {
+			env:  map[string]string{gitSSLNoVerify: "true"},
+			want: RemoteAuth{InsecureSkipTLSVerify: true},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := GitSyncRemoteAuth(context.Background(), func(key string) string {
+				return tc.env[key]
+			})
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestMatchingCookies(t *testing.T) {
+	cookieFile := "# Netscape HTTP Cookie File\n" +
+		".example.com\tTRUE\t/\tTRUE\t2147483647\to\tgit-token\n" +
+		"#HttpOnly_source.example.com\tFALSE\t/repos\tTRUE\t2147483647\tsession\tabc\n" +
+		"other.com\tFALSE\t/\tFALSE\t2147483647\tother\tnope\n"
+	u, err := url.Parse("https://source.example.com/repos/app/info/refs")
+	require.NoError(t, err)
+	var got []string
+	for _, cookie := range matchingCookies([]byte(cookieFile), u) {
+		got = append(got, cookie.Name+"="+cookie.Value)
+	}
+	assert.Equal(t, []string{"o=git-token", "session=abc"}, got)
+}
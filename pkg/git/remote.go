@@ -0,0 +1,372 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	uploadPackService = "git-upload-pack"
+	tagRefPrefix      = "refs/tags/"
+	peeledSuffix      = "^{}"
+	defaultSSHUser    = "git"
+	defaultSSHPort    = "22"
+	remoteTimeout     = 30 * time.Second
+)
+
+// RemoteAuth holds the credentials used to list the refs of a remote
+// repository.
+type RemoteAuth struct {
+	// Username and Password authenticate HTTP(S) requests, if set.
+	Username string
+	Password string
+	// SSHKey is the private key authenticating SSH connections.
+	SSHKey []byte
+	// KnownHosts verifies the SSH host keys, if set.
+	KnownHosts []byte
+	// CACert verifies the HTTPS server certificate, if set.
+	CACert []byte
+	// InsecureSkipTLSVerify disables the verification of the HTTPS server
+	// certificate.
+	InsecureSkipTLSVerify bool
+	// Cookies is the content of a Netscape cookie file, whose matching
+	// cookies are sent with HTTP(S) requests, if set.
+	Cookies []byte
+}
+
+// ListRemoteTags returns the tags of the remote repository, like
+// `git ls-remote --tags`. HTTP(S) and SSH URLs are supported.
+func ListRemoteTags(ctx context.Context, repo string, auth RemoteAuth) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, remoteTimeout)
+	defer cancel()
+	var refs []string
+	var err error
+	if strings.HasPrefix(repo, "http://") || strings.HasPrefix(repo, "https://") {
+		refs, err = listHTTPRefs(ctx, repo, auth)
+	} else {
+		refs, err = listSSHRefs(ctx, repo, auth)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("listing the tags of %s: %w", repo, err)
+	}
+	var tags []string
+	for _, ref := range refs {
+		tag, found := strings.CutPrefix(ref, tagRefPrefix)
+		if !found {
+			continue
+		}
+		// Annotated tags are listed twice, with and without the peeled suffix.
+		tag = strings.TrimSuffix(tag, peeledSuffix)
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+// listHTTPRefs lists the refs advertised by the smart HTTP protocol.
+// https://git-scm.com/docs/http-protocol#_smart_clients
+func listHTTPRefs(ctx context.Context, repo string, auth RemoteAuth) ([]string, error) {
+	endpoint := strings.TrimSuffix(repo, "/") + "/info/refs?service=" + uploadPackService
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if auth.Username != "" || auth.Password != "" {
+		req.SetBasicAuth(auth.Username, auth.Password)
+	}
+	for _, cookie := range matchingCookies(auth.Cookies, req.URL) {
+		req.AddCookie(cookie)
+	}
+	httpClient := http.DefaultClient
+	if len(auth.CACert) > 0 || auth.InsecureSkipTLSVerify {
+		tlsConfig := &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: auth.InsecureSkipTLSVerify, //nolint:gosec // explicitly requested, like GIT_SSL_NO_VERIFY
+		}
+		if len(auth.CACert) > 0 {
+			tlsConfig.RootCAs = x509.NewCertPool()
+			if !tlsConfig.RootCAs.AppendCertsFromPEM(auth.CACert) {
+				return nil, errors.New("invalid CA certificate")
+			}
+		}
+		// The cloned transport keeps reading the proxy from the environment.
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		httpClient = &http.Client{Transport: transport}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status: %s", resp.Status)
+	}
+	r := bufio.NewReader(resp.Body)
+	// The smart HTTP response starts with the service announcement.
+	line, err := readPktLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(line, []byte("# service="+uploadPackService)) {
+		return nil, errors.New("the server does not support the smart HTTP protocol")
+	}
+	if line, err = readPktLine(r); err != nil {
+		return nil, err
+	} else if line != nil {
+		return nil, errors.New("malformed service announcement")
+	}
+	return readRefAdvertisement(r)
+}
+
+// matchingCookies returns the cookies of a Netscape cookie file, as used by
+// `git config http.cookieFile`, which match the URL. Expiration is ignored.
+func matchingCookies(cookieFile []byte, u *url.URL) []*http.Cookie {
+	var cookies []*http.Cookie
+	for _, line := range strings.Split(string(cookieFile), "\n") {
+		line = strings.TrimSpace(strings.TrimPrefix(line, "#HttpOnly_"))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// domain, include subdomains, path, secure, expiration, name, value
+		fields := strings.Split(line, "\t")
+		if len(fields) != 7 {
+			continue
+		}
+		domain := strings.TrimPrefix(fields[0], ".")
+		host := u.Hostname()
+		includeSubdomains := strings.EqualFold(fields[1], "TRUE") || strings.HasPrefix(fields[0], ".")
+		if host != domain && !(includeSubdomains && strings.HasSuffix(host, "."+domain)) {
+			continue
+		}
+		if !strings.HasPrefix(u.Path, fields[2]) {
+			continue
+		}
+		if strings.EqualFold(fields[3], "TRUE") && u.Scheme != "https" {
+			continue
+		}
+		cookies = append(cookies, &http.Cookie{Name: fields[5], Value: fields[6]})
+	}
+	return cookies
+}
+
+// listSSHRefs lists the refs advertised by `git-upload-pack` over SSH.
+func listSSHRefs(ctx context.Context, repo string, auth RemoteAuth) ([]string, error) {
+	user, host, port, path, err := parseSSHURL(repo)
+	if err != nil {
+		return nil, err
+	}
+	if len(auth.SSHKey) == 0 {
+		return nil, errors.New("missing SSH key")
+	}
+	signer, err := ssh.ParsePrivateKey(auth.SSHKey)
+	if err != nil {
+		return nil, fmt.Errorf("parsing SSH key: %w", err)
+	}
+	hostKeyCallback := ssh.InsecureIgnoreHostKey() //nolint:gosec // host keys are only verified with known_hosts, like git-sync
+	if len(auth.KnownHosts) > 0 {
+		hostKeyCallback, err = knownHostsCallback(auth.KnownHosts)
+		if err != nil {
+			return nil, err
+		}
+	}
+	addr := net.JoinHostPort(host, port)
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, addr, &ssh.ClientConfig{
+		User:            user,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: hostKeyCallback,
+	})
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	client := ssh.NewClient(sshConn, chans, reqs)
+	defer func() {
+		_ = client.Close()
+	}()
+	session, err := client.NewSession()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = session.Close()
+	}()
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := session.Start(fmt.Sprintf("%s '%s'", uploadPackService, strings.ReplaceAll(path, "'", `'\''`))); err != nil {
+		return nil, err
+	}
+	refs, err := readRefAdvertisement(bufio.NewReader(stdout))
+	if err != nil {
+		return nil, err
+	}
+	// A flush packet tells git-upload-pack the client doesn't want anything.
+	_, _ = stdin.Write([]byte("0000"))
+	return refs, nil
+}
+
+// parseSSHURL returns the user, host, port and path of an SSH repository URL,
+// either `ssh://[user@]host[:port]/path` or `[user@]host:path`.
+func parseSSHURL(repo string) (user, host, port, path string, err error) {
+	user, port = defaultSSHUser, defaultSSHPort
+	if strings.HasPrefix(repo, "ssh://") {
+		u, err := url.Parse(repo)
+		if err != nil {
+			return "", "", "", "", err
+		}
+		if u.User != nil && u.User.Username() != "" {
+			user = u.User.Username()
+		}
+		if u.Port() != "" {
+			port = u.Port()
+		}
+		return user, u.Hostname(), port, u.Path, nil
+	}
+	hostPart, path, found := strings.Cut(repo, ":")
+	if !found || strings.Contains(hostPart, "/") || strings.HasPrefix(path, "//") {
+		return "", "", "", "", fmt.Errorf("unsupported repository URL %q", repo)
+	}
+	host = hostPart
+	if u, h, found := strings.Cut(hostPart, "@"); found {
+		user, host = u, h
+	}
+	return user, host, port, path, nil
+}
+
+// knownHostsCallback returns a HostKeyCallback accepting the host keys listed
+// in the known_hosts content. Hashed host names are not supported.
+func knownHostsCallback(knownHosts []byte) (ssh.HostKeyCallback, error) {
+	keys := map[string][]ssh.PublicKey{}
+	rest := knownHosts
+	for len(bytes.TrimSpace(rest)) > 0 {
+		_, hosts, key, _, next, err := ssh.ParseKnownHosts(rest)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("parsing known_hosts: %w", err)
+		}
+		for _, h := range hosts {
+			keys[h] = append(keys[h], key)
+		}
+		rest = next
+	}
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		host, port, err := net.SplitHostPort(hostname)
+		if err != nil {
+			host, port = hostname, defaultSSHPort
+		}
+		names := []string{host}
+		if port != defaultSSHPort {
+			names = []string{"[" + host + "]:" + port}
+		}
+		if tcpAddr, ok := remote.(*net.TCPAddr); ok {
+			ip := tcpAddr.IP.String()
+			if port != defaultSSHPort {
+				ip = "[" + ip + "]:" + port
+			}
+			names = append(names, ip)
+		}
+		for _, name := range names {
+			for _, known := range keys[name] {
+				if bytes.Equal(known.Marshal(), key.Marshal()) {
+					return nil
+				}
+			}
+		}
+		return fmt.Errorf("host key of %s not found in known_hosts", hostname)
+	}, nil
+}
+
+// readRefAdvertisement returns the refs of a reference advertisement, which
+// lists one `<hash> <ref>` per packet line, until a flush packet. The first
+// line also lists the server capabilities after a NUL byte.
+// https://git-scm.com/docs/pack-protocol#_reference_discovery
+func readRefAdvertisement(r *bufio.Reader) ([]string, error) {
+	var refs []string
+	for {
+		line, err := readPktLine(r)
+		if err != nil {
+			return nil, err
+		}
+		if line == nil {
+			return refs, nil
+		}
+		line, _, _ = bytes.Cut(line, []byte{0})
+		_, ref, found := bytes.Cut(bytes.TrimSuffix(line, []byte("\n")), []byte(" "))
+		if !found {
+			return nil, fmt.Errorf("malformed ref advertisement: %q", line)
+		}
+		refs = append(refs, string(ref))
+	}
+}
+
+// readPktLine reads a packet line, and returns nil for a flush packet.
+// https://git-scm.com/docs/protocol-common#_pkt_line_format
+func readPktLine(r *bufio.Reader) ([]byte, error) {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("reading packet line: %w", err)
+	}
+	length, err := strconv.ParseUint(string(header), 16, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid packet line length %q", header)
+	}
+	if length == 0 {
+		return nil, nil
+	}
+	if length < 4 {
+		return nil, fmt.Errorf("invalid packet line length %q", header)
+	}
+	line := make([]byte, length-4)
+	if _, err := io.ReadFull(r, line); err != nil {
+		return nil, fmt.Errorf("reading packet line: %w", err)
+	}
+	return line, nil
+}
@@ -0,0 +1,478 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Object types, as stored in loose objects and pack files.
+const (
+	CommitObject = "commit"
+	TreeObject   = "tree"
+	BlobObject   = "blob"
+	TagObject    = "tag"
+)
+
+// pack entry types.
+const (
+	packCommit   = 1
+	packTree     = 2
+	packBlob     = 3
+	packTag      = 4
+	packOfsDelta = 6
+	packRefDelta = 7
+)
+
+// maxDeltaDepth bounds the length of delta chains, to protect against
+// malformed pack files.
+const maxDeltaDepth = 1000
+
+// ErrObjectNotFound is returned when an object is neither stored loose nor in
+// any of the pack files of the repository.
+var ErrObjectNotFound = errors.New("object not found")
+
+// ErrHashMismatch is returned when the content of an object doesn't hash to
+// the name it was looked up by, which means the object database is corrupt or
+// was tampered with.
+var ErrHashMismatch = errors.New("object hash mismatch")
+
+// Repository reads objects from the object database of a git repository.
+//
+// The reconciler containers don't ship a git binary, so the checkout shared
+// by git-sync is read directly from disk. Only SHA-1 repositories are
+// supported.
+type Repository struct {
+	// objectsDir is the absolute path of the objects directory.
+	objectsDir string
+}
+
+// OpenRepository opens the repository the worktree at the specified path was
+// checked out from. The worktree may either contain a .git directory, or a
+// .git file pointing to the git directory, as created by `git worktree add`.
+func OpenRepository(worktree string) (*Repository, error) {
+	gitDir, err := ResolveGitDir(worktree)
+	if err != nil {
+		return nil, err
+	}
+	commonDir := gitDir
+	content, err := os.ReadFile(filepath.Join(gitDir, "commondir"))
+	switch {
+	case err == nil:
+		commonDir = strings.TrimSpace(string(content))
+		if !filepath.IsAbs(commonDir) {
+			commonDir = filepath.Join(gitDir, commonDir)
+		}
+	case !os.IsNotExist(err):
+		return nil, fmt.Errorf("reading commondir of %q: %w", gitDir, err)
+	}
+	format, err := objectFormat(commonDir)
+	if err != nil {
+		return nil, err
+	}
+	if format != sha1ObjectFormat {
+		return nil, fmt.Errorf("the repository of %q uses the %s object format, only %s repositories are supported", worktree, format, sha1ObjectFormat)
+	}
+	objectsDir := filepath.Join(commonDir, "objects")
+	if _, err := os.Stat(objectsDir); err != nil {
+		return nil, fmt.Errorf("locating the objects directory of %q: %w", worktree, err)
+	}
+	return &Repository{objectsDir: objectsDir}, nil
+}
+
+// sha1ObjectFormat is the default object format of git repositories.
+const sha1ObjectFormat = "sha1"
+
+// objectFormat returns the extensions.objectFormat of the repository config,
+// which defaults to sha1.
+func objectFormat(commonDir string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(commonDir, "config"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return sha1ObjectFormat, nil
+		}
+		return "", fmt.Errorf("reading the config of %q: %w", commonDir, err)
+	}
+	section := ""
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			section = strings.ToLower(strings.Trim(line, "[] "))
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if found && section == "extensions" && strings.EqualFold(strings.TrimSpace(key), "objectformat") {
+			return strings.ToLower(strings.TrimSpace(value)), nil
+		}
+	}
+	return sha1ObjectFormat, nil
+}
+
+// ResolveGitDir returns the git directory of the specified worktree, which
+// either contains a .git directory, or a .git file pointing to the git
+// directory, like the worktrees of submodules. The error wraps
+// fs.ErrNotExist if the worktree is not checked out.
+func ResolveGitDir(worktree string) (string, error) {
+	dotGit := filepath.Join(worktree, ".git")
+	info, err := os.Stat(dotGit)
+	if err != nil {
+		return "", fmt.Errorf("locating the git directory of %q: %w", worktree, err)
+	}
+	if info.IsDir() {
+		return dotGit, nil
+	}
+	content, err := os.ReadFile(dotGit)
+	if err != nil {
+		return "", fmt.Errorf("reading %q: %w", dotGit, err)
+	}
+	gitDir, found := strings.CutPrefix(strings.TrimSpace(string(content)), "gitdir:")
+	if !found {
+		return "", fmt.Errorf("invalid git file %q: missing gitdir", dotGit)
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(worktree, gitDir)
+	}
+	return gitDir, nil
+}
+
+// ReadObject returns the type and the content of the object with the
+// specified hash.
+//
+// The content is hashed and compared with the requested object name before it
+// is returned, so callers can trust that the object is the one they asked for.
+func (r *Repository) ReadObject(hash string) (string, []byte, error) {
+	id, err := parseHash(hash)
+	if err != nil {
+		return "", nil, err
+	}
+	objType, data, err := r.readLooseObject(hash)
+	if errors.Is(err, ErrObjectNotFound) {
+		objType, data, err = r.readPackedObject(id)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	if sum := objectSum(objType, data); !bytes.Equal(sum[:], id) {
+		return "", nil, fmt.Errorf("%s: %w: content hashes to %x", hash, ErrHashMismatch, sum)
+	}
+	return objType, data, nil
+}
+
+// objectSum returns the SHA-1 object name of an object with the specified type
+// and content.
+func objectSum(objType string, data []byte) [sha1.Size]byte {
+	h := sha1.New()
+	fmt.Fprintf(h, "%s %d\x00", objType, len(data))
+	h.Write(data)
+	var sum [sha1.Size]byte
+	copy(sum[:], h.Sum(nil))
+	return sum
+}
+
+// parseHash validates and decodes a hex encoded SHA-1 object name.
+func parseHash(hash string) ([]byte, error) {
+	id, err := hex.DecodeString(hash)
+	if err != nil || len(id) != 20 {
+		return nil, fmt.Errorf("invalid object name %q", hash)
+	}
+	return id, nil
+}
+
+func (r *Repository) readLooseObject(hash string) (string, []byte, error) {
+	f, err := os.Open(filepath.Join(r.objectsDir, hash[:2], hash[2:]))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil, fmt.Errorf("%s: %w", hash, ErrObjectNotFound)
+		}
+		return "", nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	zr, err := zlib.NewReader(f)
+	if err != nil {
+		return "", nil, fmt.Errorf("reading loose object %s: %w", hash, err)
+	}
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		return "", nil, fmt.Errorf("reading loose object %s: %w", hash, err)
+	}
+	header, data, found := bytes.Cut(raw, []byte{0})
+	if !found {
+		return "", nil, fmt.Errorf("invalid loose object %s: missing header", hash)
+	}
+	objType, sizeStr, found := strings.Cut(string(header), " ")
+	if !found {
+		return "", nil, fmt.Errorf("invalid loose object %s: malformed header", hash)
+	}
+	size, err := strconv.Atoi(sizeStr)
+	if err != nil || size != len(data) {
+		return "", nil, fmt.Errorf("invalid loose object %s: size mismatch", hash)
+	}
+	return objType, data, nil
+}
+
+func (r *Repository) readPackedObject(id []byte) (string, []byte, error) {
+	indexes, err := filepath.Glob(filepath.Join(r.objectsDir, "pack", "*.idx"))
+	if err != nil {
+		return "", nil, err
+	}
+	// Sort for deterministic lookups.
+	sort.Strings(indexes)
+	for _, idxPath := range indexes {
+		offset, found, err := findInIndex(idxPath, id)
+		if err != nil {
+			return "", nil, err
+		}
+		if !found {
+			continue
+		}
+		packPath := strings.TrimSuffix(idxPath, ".idx") + ".pack"
+		return r.readPackEntry(packPath, offset, 0)
+	}
+	return "", nil, fmt.Errorf("%x: %w", id, ErrObjectNotFound)
+}
+
+// findInIndex looks up the offset of an object in a version 2 pack index.
+func findInIndex(idxPath string, id []byte) (int64, bool, error) {
+	idx, err := os.ReadFile(idxPath)
+	if err != nil {
+		return 0, false, err
+	}
+	const headerLen = 8
+	const fanoutLen = 256 * 4
+	if len(idx) < headerLen+fanoutLen || !bytes.Equal(idx[:4], []byte{0xff, 't', 'O', 'c'}) ||
+		binary.BigEndian.Uint32(idx[4:8]) != 2 {
+		return 0, false, fmt.Errorf("unsupported pack index %q: only version 2 is supported", idxPath)
+	}
+	fanout := idx[headerLen : headerLen+fanoutLen]
+	count := int(binary.BigEndian.Uint32(fanout[255*4:]))
+	namesStart := headerLen + fanoutLen
+	crcStart := namesStart + count*20
+	offsetsStart := crcStart + count*4
+	largeStart := offsetsStart + count*4
+	if len(idx) < largeStart {
+		return 0, false, fmt.Errorf("invalid pack index %q: truncated", idxPath)
+	}
+	lo := 0
+	if id[0] > 0 {
+		lo = int(binary.BigEndian.Uint32(fanout[(int(id[0])-1)*4:]))
+	}
+	hi := int(binary.BigEndian.Uint32(fanout[int(id[0])*4:]))
+	if lo > hi || hi > count {
+		return 0, false, fmt.Errorf("invalid pack index %q: malformed fanout table", idxPath)
+	}
+	i := lo + sort.Search(hi-lo, func(i int) bool {
+		name := idx[namesStart+(lo+i)*20 : namesStart+(lo+i+1)*20]
+		return bytes.Compare(name, id) >= 0
+	})
+	if i >= hi || !bytes.Equal(idx[namesStart+i*20:namesStart+(i+1)*20], id) {
+		return 0, false, nil
+	}
+	offset := binary.BigEndian.Uint32(idx[offsetsStart+i*4:])
+	if offset&0x80000000 == 0 {
+		return int64(offset), true, nil
+	}
+	large := largeStart + int(offset&0x7fffffff)*8
+	if len(idx) < large+8 {
+		return 0, false, fmt.Errorf("invalid pack index %q: truncated", idxPath)
+	}
+	return int64(binary.BigEndian.Uint64(idx[large:])), true, nil
+}
+
+// readPackEntry reads the object stored at the specified offset of a pack
+// file, resolving deltas if necessary.
+func (r *Repository) readPackEntry(packPath string, offset int64, depth int) (string, []byte, error) {
+	if depth > maxDeltaDepth {
+		return "", nil, fmt.Errorf("invalid pack %q: delta chain too long", packPath)
+	}
+	f, err := os.Open(packPath)
+	if err != nil {
+		return "", nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	br := bufio.NewReader(io.NewSectionReader(f, offset, 1<<62))
+	c, err := br.ReadByte()
+	if err != nil {
+		return "", nil, fmt.Errorf("reading pack %q at offset %d: %w", packPath, offset, err)
+	}
+	entryType := (c >> 4) & 0x7
+	size := uint64(c & 0x0f)
+	for shift := 4; c&0x80 != 0; shift += 7 {
+		if c, err = br.ReadByte(); err != nil {
+			return "", nil, fmt.Errorf("reading pack %q at offset %d: %w", packPath, offset, err)
+		}
+		size |= uint64(c&0x7f) << shift
+	}
+
+	var baseType string
+	var base []byte
+	switch entryType {
+	case packOfsDelta:
+		c, err = br.ReadByte()
+		if err != nil {
+			return "", nil, fmt.Errorf("reading pack %q at offset %d: %w", packPath, offset, err)
+		}
+		rel := int64(c & 0x7f)
+		for c&0x80 != 0 {
+			if c, err = br.ReadByte(); err != nil {
+				return "", nil, fmt.Errorf("reading pack %q at offset %d: %w", packPath, offset, err)
+			}
+			rel = ((rel + 1) << 7) | int64(c&0x7f)
+		}
+		if rel <= 0 || rel > offset {
+			return "", nil, fmt.Errorf("invalid pack %q: bad delta base offset at %d", packPath, offset)
+		}
+		baseType, base, err = r.readPackEntry(packPath, offset-rel, depth+1)
+		if err != nil {
+			return "", nil, err
+		}
+	case packRefDelta:
+		baseID := make([]byte, 20)
+		if _, err := io.ReadFull(br, baseID); err != nil {
+			return "", nil, fmt.Errorf("reading pack %q at offset %d: %w", packPath, offset, err)
+		}
+		baseType, base, err = r.ReadObject(hex.EncodeToString(baseID))
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	zr, err := zlib.NewReader(br)
+	if err != nil {
+		return "", nil, fmt.Errorf("reading pack %q at offset %d: %w", packPath, offset, err)
+	}
+	data, err := io.ReadAll(io.LimitReader(zr, int64(size)+1))
+	if err != nil {
+		return "", nil, fmt.Errorf("reading pack %q at offset %d: %w", packPath, offset, err)
+	}
+	if uint64(len(data)) != size {
+		return "", nil, fmt.Errorf("invalid pack %q: size mismatch at offset %d", packPath, offset)
+	}
+
+	switch entryType {
+	case packCommit:
+		return CommitObject, data, nil
+	case packTree:
+		return TreeObject, data, nil
+	case packBlob:
+		return BlobObject, data, nil
+	case packTag:
+		return TagObject, data, nil
+	case packOfsDelta, packRefDelta:
+		result, err := applyDelta(base, data)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid pack %q: delta at offset %d: %w", packPath, offset, err)
+		}
+		return baseType, result, nil
+	default:
+		return "", nil, fmt.Errorf("invalid pack %q: unknown entry type %d at offset %d", packPath, entryType, offset)
+	}
+}
+
+// applyDelta applies a git delta to the base object.
+func applyDelta(base, delta []byte) ([]byte, error) {
+	srcSize, delta, err := readDeltaSize(delta)
+	if err != nil {
+		return nil, err
+	}
+	if srcSize != uint64(len(base)) {
+		return nil, errors.New("base size mismatch")
+	}
+	dstSize, delta, err := readDeltaSize(delta)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]byte, 0, dstSize)
+	for len(delta) > 0 {
+		op := delta[0]
+		delta = delta[1:]
+		switch {
+		case op&0x80 != 0:
+			// Copy from the base object.
+			var offset, size uint64
+			for i := 0; i < 4; i++ {
+				if op&(1<<i) != 0 {
+					if len(delta) == 0 {
+						return nil, errors.New("truncated copy instruction")
+					}
+					offset |= uint64(delta[0]) << (8 * i)
+					delta = delta[1:]
+				}
+			}
+			for i := 0; i < 3; i++ {
+				if op&(0x10<<i) != 0 {
+					if len(delta) == 0 {
+						return nil, errors.New("truncated copy instruction")
+					}
+					size |= uint64(delta[0]) << (8 * i)
+					delta = delta[1:]
+				}
+			}
+			if size == 0 {
+				size = 0x10000
+			}
+			if offset+size > uint64(len(base)) {
+				return nil, errors.New("copy instruction out of bounds")
+			}
+			result = append(result, base[offset:offset+size]...)
+		case op != 0:
+			// Insert the following op bytes.
+			if int(op) > len(delta) {
+				return nil, errors.New("truncated insert instruction")
+			}
+			result = append(result, delta[:op]...)
+			delta = delta[op:]
+		default:
+			return nil, errors.New("reserved instruction")
+		}
+	}
+	if uint64(len(result)) != dstSize {
+		return nil, errors.New("result size mismatch")
+	}
+	return result, nil
+}
+
+// readDeltaSize reads a size encoded in a delta header.
+func readDeltaSize(delta []byte) (uint64, []byte, error) {
+	var size uint64
+	for shift := 0; ; shift += 7 {
+		if len(delta) == 0 {
+			return 0, nil, errors.New("truncated delta header")
+		}
+		c := delta[0]
+		delta = delta[1:]
+		size |= uint64(c&0x7f) << shift
+		if c&0x80 == 0 {
+			return size, delta, nil
+		}
+	}
+}
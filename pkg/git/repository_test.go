@@ -0,0 +1,387 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// objectHash returns the object name of the object.
+func objectHash(objType string, data []byte) string {
+	sum := sha1.Sum(append([]byte(fmt.Sprintf("%s %d\x00", objType, len(data))), data...))
+	return hex.EncodeToString(sum[:])
+}
+
+func zlibCompress(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	_, err := zw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+// writeLooseObject stores the object in the objects directory and returns its
+// name.
+func writeLooseObject(t *testing.T, objectsDir, objType string, data []byte) string {
+	t.Helper()
+	hash := objectHash(objType, data)
+	raw := append([]byte(fmt.Sprintf("%s %d\x00", objType, len(data))), data...)
+	dir := filepath.Join(objectsDir, hash[:2])
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, hash[2:]), zlibCompress(t, raw), 0644))
+	return hash
+}
+
+// packEntry is an entry of a test pack file.
+type packEntry struct {
+	// hash is the name of the resolved object.
+	hash string
+	// entryType is the pack entry type.
+	entryType byte
+	// baseIndex is the index of the delta base, for OFS deltas.
+	baseIndex int
+	// data is the object content, or the delta for OFS deltas.
+	data []byte
+}
+
+// writePack stores the entries in a version 2 pack and index.
+func writePack(t *testing.T, objectsDir string, entries []packEntry) {
+	t.Helper()
+	var pack bytes.Buffer
+	pack.WriteString("PACK")
+	require.NoError(t, binary.Write(&pack, binary.BigEndian, uint32(2)))
+	require.NoError(t, binary.Write(&pack, binary.BigEndian, uint32(len(entries))))
+	offsets := make([]int64, len(entries))
+	for i, e := range entries {
+		offsets[i] = int64(pack.Len())
+		size := len(e.data)
+		c := e.entryType<<4 | byte(size&0x0f)
+		size >>= 4
+		for size > 0 {
+			pack.WriteByte(c | 0x80)
+			c = byte(size & 0x7f)
+			size >>= 7
+		}
+		pack.WriteByte(c)
+		if e.entryType == packOfsDelta {
+			rel := offsets[i] - offsets[e.baseIndex]
+			encoded := []byte{byte(rel & 0x7f)}
+			for rel >>= 7; rel > 0; rel >>= 7 {
+				rel--
+				encoded = append([]byte{byte(0x80 | rel&0x7f)}, encoded...)
+			}
+			pack.Write(encoded)
+		}
+		pack.Write(zlibCompress(t, e.data))
+	}
+	packSum := sha1.Sum(pack.Bytes())
+	pack.Write(packSum[:])
+
+	type indexEntry struct {
+		id     []byte
+		offset int64
+	}
+	var sorted []indexEntry
+	for i, e := range entries {
+		id, err := hex.DecodeString(e.hash)
+		require.NoError(t, err)
+		sorted = append(sorted, indexEntry{id: id, offset: offsets[i]})
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].id, sorted[j].id) < 0
+	})
+	var idx bytes.Buffer
+	idx.Write([]byte{0xff, 't', 'O', 'c'})
+	require.NoError(t, binary.Write(&idx, binary.BigEndian, uint32(2)))
+	for b := 0; b < 256; b++ {
+		count := 0
+		for _, e := range sorted {
+			if int(e.id[0]) <= b {
+				count++
+			}
+		}
+		require.NoError(t, binary.Write(&idx, binary.BigEndian, uint32(count)))
+	}
+	for _, e := range sorted {
+		idx.Write(e.id)
+	}
+	for range sorted {
+		require.NoError(t, binary.Write(&idx, binary.BigEndian, uint32(0)))
+	}
+	for _, e := range sorted {
+		require.NoError(t, binary.Write(&idx, binary.BigEndian, uint32(e.offset)))
+	}
+	idx.Write(packSum[:])
+	idxSum := sha1.Sum(idx.Bytes())
+	idx.Write(idxSum[:])
+
+	packDir := filepath.Join(objectsDir, "pack")
+	require.NoError(t, os.MkdirAll(packDir, 0755))
+	name := "pack-" + hex.EncodeToString(packSum[:])
+	require.NoError(t, os.WriteFile(filepath.Join(packDir, name+".pack"), pack.Bytes(), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(packDir, name+".idx"), idx.Bytes(), 0644))
+}
+
+// newWorktree creates a repository with a linked worktree, laid out like the
+// checkouts of git-sync, and returns the worktree and objects directories.
+func newWorktree(t *testing.T) (string, string) {
+	t.Helper()
+	root := t.TempDir()
+	gitDir := filepath.Join(root, ".git")
+	objectsDir := filepath.Join(gitDir, "objects")
+	worktreeGitDir := filepath.Join(gitDir, "worktrees", "abc123")
+	worktree := filepath.Join(root, ".worktrees", "abc123")
+	require.NoError(t, os.MkdirAll(objectsDir, 0755))
+	require.NoError(t, os.MkdirAll(worktreeGitDir, 0755))
+	require.NoError(t, os.MkdirAll(worktree, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(worktreeGitDir, "commondir"), []byte("../..\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(worktree, ".git"), []byte("gitdir: "+worktreeGitDir+"\n"), 0644))
+	return worktree, objectsDir
+}
+
+func TestRepositoryReadObject(t *testing.T) {
+	worktree, objectsDir := newWorktree(t)
+
+	looseData := []byte("loose blob\n")
+	looseHash := writeLooseObject(t, objectsDir, BlobObject, looseData)
+
+	commitData := []byte("tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"author Nomos <nomos@example.com> 1700000000 +0000\n" +
+		"committer Nomos <nomos@example.com> 1700000000 +0000\n\npacked commit\n")
+	baseData := []byte("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: foo\n")
+	deltaResult := []byte("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: bar\n")
+	// Copy everything but the namespace name from the base, then insert the
+	// new name.
+	copyLen := len(baseData) - len("foo\n")
+	delta := []byte{byte(len(baseData)), byte(len(deltaResult)),
+		0x80 | 0x10, byte(copyLen),
+		4, 'b', 'a', 'r', '\n'}
+	writePack(t, objectsDir, []packEntry{
+		{hash: objectHash(CommitObject, commitData), entryType: packCommit, data: commitData},
+		{hash: objectHash(BlobObject, baseData), entryType: packBlob, data: baseData},
+		{hash: objectHash(BlobObject, deltaResult), entryType: packOfsDelta, baseIndex: 1, data: delta},
+	})
+
+	// Store a loose object under the name of another object, as if the object
+	// database had been tampered with.
+	tamperedHash := objectHash(BlobObject, []byte("trusted blob\n"))
+	tamperedRaw := []byte("blob 15\x00malicious blob\n")
+	require.NoError(t, os.MkdirAll(filepath.Join(objectsDir, tamperedHash[:2]), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(objectsDir, tamperedHash[:2], tamperedHash[2:]),
+		zlibCompress(t, tamperedRaw), 0644))
+
+	repo, err := OpenRepository(worktree)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		hash     string
+		wantType string
+		wantData []byte
+		wantErr  error
+	}{
+		"loose object": {
+			hash:     looseHash,
+			wantType: BlobObject,
+			wantData: looseData,
+		},
+		"packed object": {
+			hash:     objectHash(CommitObject, commitData),
+			wantType: CommitObject,
+			wantData: commitData,
+		},
+		"packed delta base": {
+			hash:     objectHash(BlobObject, baseData),
+			wantType: BlobObject,
+			wantData: baseData,
+		},
+		"packed delta": {
+			hash:     objectHash(BlobObject, deltaResult),
+			wantType: BlobObject,
+			wantData: deltaResult,
+		},
+		"missing object": {
+			hash:    objectHash(BlobObject, []byte("missing")),
+			wantErr: ErrObjectNotFound,
+		},
+		"tampered object": {
+			hash:    tamperedHash,
+			wantErr: ErrHashMismatch,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			objType, data, err := repo.ReadObject(tc.hash)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantType, objType)
+			assert.Equal(t, tc.wantData, data)
+		})
+	}
+}
+
+func TestOpenRepositoryObjectFormat(t *testing.T) {
+	testCases := map[string]struct {
+		config  string
+		wantErr string
+	}{
+		"no config": {},
+		"default object format": {
+			config: "[core]\n\trepositoryformatversion = 0\n",
+		},
+		"SHA-1 object format": {
+			config: "[core]\n\trepositoryformatversion = 1\n[extensions]\n\tobjectformat = sha1\n",
+		},
+		"SHA-256 object format": {
+			config:  "[core]\n\trepositoryformatversion = 1\n[extensions]\n\tobjectFormat = sha256\n",
+			wantErr: "uses the sha256 object format, only sha1 repositories are supported",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			worktree, objectsDir := newWorktree(t)
+			if tc.config != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(objectsDir), "config"), []byte(tc.config), 0644))
+			}
+			_, err := OpenRepository(worktree)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestResolveGitDir(t *testing.T) {
+	testCases := map[string]struct {
+		dotGit      map[string]string
+		dotGitDir   bool
+		wantGitDir  string
+		wantErr     string
+		wantMissing bool
+	}{
+		"git directory": {
+			dotGitDir:  true,
+			wantGitDir: ".git",
+		},
+		"git file with a relative path": {
+			dotGit:     map[string]string{".git": "gitdir: ../.git/modules/lib\n"},
+			wantGitDir: "../.git/modules/lib",
+		},
+		"git file without a space": {
+			dotGit:     map[string]string{".git": "gitdir:../.git/modules/lib\n"},
+			wantGitDir: "../.git/modules/lib",
+		},
+		"invalid git file": {
+			dotGit:  map[string]string{".git": "invalid\n"},
+			wantErr: "missing gitdir",
+		},
+		"not checked out": {
+			wantMissing: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			worktree := filepath.Join(t.TempDir(), "lib")
+			require.NoError(t, os.MkdirAll(worktree, 0755))
+			if tc.dotGitDir {
+				require.NoError(t, os.Mkdir(filepath.Join(worktree, ".git"), 0755))
+			}
+			for name, content := range tc.dotGit {
+				require.NoError(t, os.WriteFile(filepath.Join(worktree, name), []byte(content), 0644))
+			}
+			gitDir, err := ResolveGitDir(worktree)
+			switch {
+			case tc.wantMissing:
+				assert.ErrorIs(t, err, fs.ErrNotExist)
+			case tc.wantErr != "":
+				assert.ErrorContains(t, err, tc.wantErr)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, filepath.Join(worktree, tc.wantGitDir), gitDir)
+			}
+		})
+	}
+}
+
+func TestParseCommit(t *testing.T) {
+	headers := "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"author Nomos <nomos@example.com> 1700000000 +0000\n" +
+		"committer Nomos <nomos@example.com> 1700000000 +0000\n"
+	message := "\nAdd namespace\n\n gpgsig in the message body\n"
+	signed := headers +
+		"gpgsig -----BEGIN SSH SIGNATURE-----\n" +
+		" U1NIU0lH\n" +
+		" -----END SSH SIGNATURE-----\n" +
+		message
+	sha256Signature := "gpgsig-sha256 -----BEGIN SSH SIGNATURE-----\n" +
+		" U0hBMjU2\n" +
+		" -----END SSH SIGNATURE-----\n"
+
+	testCases := map[string]struct {
+		data                string
+		wantSignature       string
+		wantSHA256Signature string
+		wantPayload         string
+	}{
+		"signed commit": {
+			data:          signed,
+			wantSignature: "-----BEGIN SSH SIGNATURE-----\nU1NIU0lH\n-----END SSH SIGNATURE-----\n",
+			wantPayload:   headers + message,
+		},
+		"signed commit with a SHA-256 signature": {
+			data:                strings.Replace(signed, message, sha256Signature+message, 1),
+			wantSignature:       "-----BEGIN SSH SIGNATURE-----\nU1NIU0lH\n-----END SSH SIGNATURE-----\n",
+			wantSHA256Signature: "-----BEGIN SSH SIGNATURE-----\nU0hBMjU2\n-----END SSH SIGNATURE-----\n",
+			wantPayload:         headers + message,
+		},
+		"commit with a SHA-256 signature only": {
+			data:                headers + sha256Signature + message,
+			wantSHA256Signature: "-----BEGIN SSH SIGNATURE-----\nU0hBMjU2\n-----END SSH SIGNATURE-----\n",
+			wantPayload:         headers + message,
+		},
+		"unsigned commit": {
+			data:        headers + message,
+			wantPayload: headers + message,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			commit := parseCommit([]byte(tc.data))
+			assert.Equal(t, tc.wantSignature, string(commit.Signature))
+			assert.Equal(t, tc.wantSHA256Signature, string(commit.SHA256Signature))
+			assert.Equal(t, tc.wantPayload, string(commit.Payload))
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	semverrange "github.com/Masterminds/semver/v3"
+)
+
+// constraintOperators are the characters which make a revision a semantic
+// version constraint. `~`, `^`, `*` and spaces are not allowed in Git refs, and
+// a ref starting with `<`, `>`, `=` or `!` is unlikely enough.
+const constraintOperators = "~^* "
+
+// IsRevisionConstraint returns true if the revision is a semantic version
+// constraint, like `>=1.4.0 <2.0.0` or `~1.4`, rather than a ref or commit.
+func IsRevisionConstraint(revision string) bool {
+	if !strings.ContainsAny(revision, constraintOperators) &&
+		!strings.HasPrefix(revision, "<") && !strings.HasPrefix(revision, ">") &&
+		!strings.HasPrefix(revision, "=") && !strings.HasPrefix(revision, "!=") {
+		return false
+	}
+	_, err := semverrange.NewConstraint(revision)
+	return err == nil
+}
+
+// MatchesConstraint returns true if the tag is a semantic version matching the
+// constraint.
+func MatchesConstraint(constraint, tag string) bool {
+	c, err := semverrange.NewConstraint(constraint)
+	if err != nil {
+		return false
+	}
+	v, err := semverrange.NewVersion(tag)
+	if err != nil {
+		return false
+	}
+	return c.Check(v)
+}
+
+// ResolveTag returns the highest tag matching the semantic version constraint.
+// Tags which are not semantic versions, with or without a `v` prefix, are
+// ignored.
+func ResolveTag(constraint string, tags []string) (string, error) {
+	c, err := semverrange.NewConstraint(constraint)
+	if err != nil {
+		return "", fmt.Errorf("invalid revision constraint %q: %w", constraint, err)
+	}
+	var resolved string
+	var highest *semverrange.Version
+	for _, tag := range tags {
+		v, err := semverrange.NewVersion(tag)
+		if err != nil || !c.Check(v) {
+			continue
+		}
+		if highest == nil || v.GreaterThan(highest) {
+			resolved = tag
+			highest = v
+		}
+	}
+	if highest == nil {
+		return "", fmt.Errorf("no tag matches the revision constraint %q", constraint)
+	}
+	return resolved, nil
+}
+
+// ResolvedRevisionFile is the name of the file, in the repository root shared
+// by the git-sync and reconciler containers, where the git-sync-launcher
+// records the tag resolved from the revision constraint.
+const ResolvedRevisionFile = "resolved-revision"
+
+// ResolveRemoteTag returns the highest tag of the remote repository matching
+// the semantic version constraint. The tags are listed with the credentials of
+// the git-sync container.
+func ResolveRemoteTag(ctx context.Context, repo, constraint string, getenv func(string) string) (string, error) {
+	auth, err := GitSyncRemoteAuth(ctx, getenv)
+	if err != nil {
+		return "", fmt.Errorf("reading the credentials of %s: %w", repo, err)
+	}
+	tags, err := ListRemoteTags(ctx, repo, auth)
+	if err != nil {
+		return "", err
+	}
+	return ResolveTag(constraint, tags)
+}
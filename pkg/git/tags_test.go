@@ -0,0 +1,147 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRevisionConstraint(t *testing.T) {
+	testCases := map[string]bool{
+		"":                 false,
+		"HEAD":             false,
+		"main":             false,
+		"v1.4.0":           false,
+		"1.4.0":            false,
+		"refs/tags/v1.4.0": false,
+		"1.x":              false,
+		"9f3b1c2d":         false,
+		">=1.4.0 <2.0.0":   true,
+		">=1.4.0":          true,
+		"~1.4":             true,
+		"^1.4.0":           true,
+		"1.*":              true,
+		"1.0.0 - 1.6.5":    true,
+		"=1.4.0":           true,
+		"!=1.4.0":          true,
+		"<3":               true,
+		"> foo":            false,
+	}
+	for revision, want := range testCases {
+		t.Run(revision, func(t *testing.T) {
+			assert.Equal(t, want, IsRevisionConstraint(revision))
+		})
+	}
+}
+
+func TestResolveTag(t *testing.T) {
+	tags := []string{"v1.3.9", "v1.4.0", "v1.4.2", "v1.5.0-rc.1", "1.9.0", "v2.0.0", "latest", "release-1"}
+	testCases := map[string]struct {
+		constraint string
+		want       string
+		wantErr    string
+	}{
+		"range": {
+			constraint: ">=1.4.0 <2.0.0",
+			want:       "1.9.0",
+		},
+		"patch releases": {
+			constraint: "~1.4",
+			want:       "v1.4.2",
+		},
+		"prereleases with a prerelease constraint": {
+			constraint: ">=1.5.0-0 <1.6.0-0",
+			want:       "v1.5.0-rc.1",
+		},
+		"no match": {
+			constraint: ">=3.0.0",
+			wantErr:    `no tag matches the revision constraint ">=3.0.0"`,
+		},
+		"invalid constraint": {
+			constraint: ">= foo",
+			wantErr:    `invalid revision constraint ">= foo"`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ResolveTag(tc.constraint, tags)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func pktLine(s string) string {
+	return fmt.Sprintf("%04x%s", len(s)+4, s)
+}
+
+func TestListRemoteTags_HTTP(t *testing.T) {
+	advertisement := pktLine("# service=git-upload-pack\n") + "0000" +
+		pktLine("1111111111111111111111111111111111111111 HEAD\x00multi_ack side-band-64k\n") +
+		pktLine("1111111111111111111111111111111111111111 refs/heads/main\n") +
+		pktLine("2222222222222222222222222222222222222222 refs/tags/v1.4.0\n") +
+		pktLine("3333333333333333333333333333333333333333 refs/tags/v1.4.0^{}\n") +
+		pktLine("4444444444444444444444444444444444444444 refs/tags/v1.5.0\n") +
+		"0000"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, _ := r.BasicAuth()
+		if user != "user" || password != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/org/repo.git/info/refs" || r.URL.Query().Get("service") != uploadPackService {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(advertisement))
+	}))
+	defer server.Close()
+
+	tags, err := ListRemoteTags(context.Background(), server.URL+"/org/repo.git", RemoteAuth{Username: "user", Password: "token"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1.4.0", "v1.5.0"}, tags)
+
+	_, err = ListRemoteTags(context.Background(), server.URL+"/org/repo.git", RemoteAuth{})
+	assert.ErrorContains(t, err, "401 Unauthorized")
+}
+
+func TestParseSSHURL(t *testing.T) {
+	testCases := map[string][4]string{
+		"git@github.com:org/repo.git":            {"git", "github.com", "22", "org/repo.git"},
+		"example.com:repo":                       {"git", "example.com", "22", "repo"},
+		"ssh://deploy@git.example.com:2222/repo": {"deploy", "git.example.com", "2222", "/repo"},
+		"ssh://git.example.com/org/repo.git":     {"git", "git.example.com", "22", "/org/repo.git"},
+	}
+	for repo, want := range testCases {
+		t.Run(repo, func(t *testing.T) {
+			user, host, port, path, err := parseSSHURL(repo)
+			require.NoError(t, err)
+			assert.Equal(t, want, [4]string{user, host, port, path})
+		})
+	}
+	_, _, _, _, err := parseSSHURL("file:///tmp/repo")
+	assert.ErrorContains(t, err, "unsupported repository URL")
+}
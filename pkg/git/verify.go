@@ -0,0 +1,273 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"golang.org/x/crypto/ssh"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
+	"kpt.dev/configsync/pkg/status"
+)
+
+const (
+	pgpSignaturePrefix = "-----BEGIN PGP SIGNATURE-----"
+	sshSignaturePrefix = "-----BEGIN SSH SIGNATURE-----"
+	pgpPublicKeyPrefix = "-----BEGIN PGP PUBLIC KEY BLOCK-----"
+
+	// sshSigMagic is the preamble of SSH signatures.
+	// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig
+	sshSigMagic = "SSHSIG"
+	// sshSigNamespace is the namespace git uses to sign commits with SSH keys.
+	sshSigNamespace = "git"
+)
+
+// TrustedKeys holds the public keys allowed to sign commits.
+type TrustedKeys struct {
+	// PGPKeyRing contains the trusted GPG public keys.
+	PGPKeyRing openpgp.EntityList
+	// SSHKeys contains the trusted SSH public keys.
+	SSHKeys []ssh.PublicKey
+}
+
+// Empty returns true if there are no trusted keys.
+func (k *TrustedKeys) Empty() bool {
+	return len(k.PGPKeyRing) == 0 && len(k.SSHKeys) == 0
+}
+
+// LoadTrustedKeys reads the trusted keys from the files in the specified
+// directory, usually a mounted Secret. Each file may either contain an
+// ASCII-armored GPG public keyring, or SSH public keys in the authorized_keys
+// or allowed_signers format.
+func LoadTrustedKeys(dir string) (*TrustedKeys, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading the trusted keys directory %q: %w", dir, err)
+	}
+	keys := &TrustedKeys{}
+	for _, entry := range entries {
+		// Skip the hidden files and directories created by the kubelet when
+		// mounting Secrets.
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading the trusted keys file %q: %w", path, err)
+		}
+		if info.IsDir() {
+			continue
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading the trusted keys file %q: %w", path, err)
+		}
+		if bytes.Contains(content, []byte(pgpPublicKeyPrefix)) {
+			keyRing, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(content))
+			if err != nil {
+				return nil, fmt.Errorf("parsing the GPG keyring %q: %w", entry.Name(), err)
+			}
+			keys.PGPKeyRing = append(keys.PGPKeyRing, keyRing...)
+			continue
+		}
+		sshKeys, err := parseSSHKeys(content)
+		if err != nil {
+			return nil, fmt.Errorf("parsing the SSH keys %q: %w", entry.Name(), err)
+		}
+		keys.SSHKeys = append(keys.SSHKeys, sshKeys...)
+	}
+	if keys.Empty() {
+		return nil, fmt.Errorf("no trusted keys found in %q", dir)
+	}
+	return keys, nil
+}
+
+// parseSSHKeys parses SSH public keys, one per line. Empty lines and comments
+// are ignored.
+func parseSSHKeys(content []byte) ([]ssh.PublicKey, error) {
+	var keys []ssh.PublicKey
+	for _, line := range bytes.Split(content, []byte{'\n'}) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+		key, _, _, _, err := ssh.ParseAuthorizedKey(line)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// VerifyCommit verifies that the commit is signed by one of the trusted keys.
+// It returns the identity of the signer.
+func VerifyCommit(commit *Commit, keys *TrustedKeys) (string, error) {
+	signature := string(commit.Signature)
+	switch {
+	case len(signature) == 0 && len(commit.SHA256Signature) > 0:
+		return "", fmt.Errorf("commit %s is only signed in the %s header, for SHA-256 repositories, which are not supported", commit.Hash, sha256SignatureHeader)
+	case len(signature) == 0:
+		return "", fmt.Errorf("commit %s is not signed", commit.Hash)
+	case strings.HasPrefix(signature, pgpSignaturePrefix):
+		if len(keys.PGPKeyRing) == 0 {
+			return "", fmt.Errorf("commit %s is signed with GPG, but no trusted GPG keys are configured", commit.Hash)
+		}
+		signer, err := openpgp.CheckArmoredDetachedSignature(keys.PGPKeyRing,
+			bytes.NewReader(commit.Payload), strings.NewReader(signature), nil)
+		if err != nil {
+			return "", fmt.Errorf("verifying the GPG signature of commit %s: %w", commit.Hash, err)
+		}
+		return fmt.Sprintf("%X", signer.PrimaryKey.Fingerprint), nil
+	case strings.HasPrefix(signature, sshSignaturePrefix):
+		if len(keys.SSHKeys) == 0 {
+			return "", fmt.Errorf("commit %s is signed with SSH, but no trusted SSH keys are configured", commit.Hash)
+		}
+		signer, err := verifySSHSignature(commit.Payload, commit.Signature, keys.SSHKeys)
+		if err != nil {
+			return "", fmt.Errorf("verifying the SSH signature of commit %s: %w", commit.Hash, err)
+		}
+		return ssh.FingerprintSHA256(signer), nil
+	default:
+		return "", fmt.Errorf("commit %s has an unsupported signature type", commit.Hash)
+	}
+}
+
+// sshSignature is the SSH signature blob, following the magic preamble.
+type sshSignature struct {
+	Version       uint32
+	PublicKey     []byte
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Signature     []byte
+}
+
+// sshSignedData is the data signed by SSH signatures, following the magic
+// preamble.
+type sshSignedData struct {
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Hash          []byte
+}
+
+// verifySSHSignature verifies an armored SSH signature of the message, and
+// returns the trusted key that made it.
+func verifySSHSignature(message, armored []byte, trusted []ssh.PublicKey) (ssh.PublicKey, error) {
+	block, _ := pem.Decode(armored)
+	if block == nil || block.Type != "SSH SIGNATURE" {
+		return nil, errors.New("malformed signature")
+	}
+	blob, found := bytes.CutPrefix(block.Bytes, []byte(sshSigMagic))
+	if !found {
+		return nil, errors.New("malformed signature: missing preamble")
+	}
+	sig := sshSignature{}
+	if err := ssh.Unmarshal(blob, &sig); err != nil {
+		return nil, fmt.Errorf("malformed signature: %w", err)
+	}
+	if sig.Version != 1 {
+		return nil, fmt.Errorf("unsupported signature version %d", sig.Version)
+	}
+	if sig.Namespace != sshSigNamespace {
+		return nil, fmt.Errorf("unexpected signature namespace %q", sig.Namespace)
+	}
+	signer, err := ssh.ParsePublicKey(sig.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("malformed signature public key: %w", err)
+	}
+	if !containsKey(trusted, signer) {
+		return nil, fmt.Errorf("signing key %s is not trusted", ssh.FingerprintSHA256(signer))
+	}
+	var h hash.Hash
+	switch sig.HashAlgorithm {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return nil, fmt.Errorf("unsupported signature hash algorithm %q", sig.HashAlgorithm)
+	}
+	h.Write(message)
+	signed := append([]byte(sshSigMagic), ssh.Marshal(sshSignedData{
+		Namespace:     sig.Namespace,
+		HashAlgorithm: sig.HashAlgorithm,
+		Hash:          h.Sum(nil),
+	})...)
+	sshSig := &ssh.Signature{}
+	if err := ssh.Unmarshal(sig.Signature, sshSig); err != nil {
+		return nil, fmt.Errorf("malformed signature: %w", err)
+	}
+	if err := signer.Verify(signed, sshSig); err != nil {
+		return nil, err
+	}
+	return signer, nil
+}
+
+func containsKey(keys []ssh.PublicKey, key ssh.PublicKey) bool {
+	for _, k := range keys {
+		if bytes.Equal(k.Marshal(), key.Marshal()) {
+			return true
+		}
+	}
+	return false
+}
+
+// CommitVerifier verifies the signature of the commits checked out by
+// git-sync.
+type CommitVerifier struct {
+	// KeysDir is the directory containing the trusted public keys.
+	// The keys are reloaded for every verification, to pick up Secret updates.
+	KeysDir string
+}
+
+// Verify checks that the commit checked out at the source directory is signed
+// by a trusted key.
+func (v *CommitVerifier) Verify(sourceDir cmpath.Absolute, commit string) status.Error {
+	keys, err := LoadTrustedKeys(v.KeysDir)
+	if err != nil {
+		return status.SourceVerificationError(err)
+	}
+	repo, err := OpenRepository(sourceDir.OSPath())
+	if err != nil {
+		return status.SourceError.Wrap(err).Build()
+	}
+	c, err := repo.ReadCommit(commit)
+	if err != nil {
+		if errors.Is(err, ErrHashMismatch) {
+			return status.SourceVerificationError(err)
+		}
+		return status.SourceError.Wrap(err).Build()
+	}
+	signer, err := VerifyCommit(c, keys)
+	if err != nil {
+		return status.SourceVerificationError(err)
+	}
+	klog.Infof("Verified the signature of commit %s, signed by %s", commit, signer)
+	return nil
+}
@@ -0,0 +1,230 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
+	"kpt.dev/configsync/pkg/status"
+)
+
+const testCommitHeaders = "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+	"author Nomos <nomos@example.com> 1700000000 +0000\n" +
+	"committer Nomos <nomos@example.com> 1700000000 +0000\n"
+
+const testCommitMessage = "\nAdd namespace\n"
+
+// signedCommit returns a commit object with the armored signature in the
+// gpgsig header.
+func signedCommit(signature []byte) []byte {
+	lines := strings.Split(strings.TrimSuffix(string(signature), "\n"), "\n")
+	return []byte(testCommitHeaders + "gpgsig " + strings.Join(lines, "\n ") + "\n" + testCommitMessage)
+}
+
+// sshSign creates an armored SSH signature of the message.
+func sshSign(t *testing.T, signer ssh.Signer, namespace string, message []byte) []byte {
+	t.Helper()
+	h := sha512.Sum512(message)
+	signed := append([]byte(sshSigMagic), ssh.Marshal(sshSignedData{
+		Namespace:     namespace,
+		HashAlgorithm: "sha512",
+		Hash:          h[:],
+	})...)
+	sig, err := signer.Sign(rand.Reader, signed)
+	require.NoError(t, err)
+	blob := append([]byte(sshSigMagic), ssh.Marshal(sshSignature{
+		Version:       1,
+		PublicKey:     signer.PublicKey().Marshal(),
+		Namespace:     namespace,
+		HashAlgorithm: "sha512",
+		Signature:     ssh.Marshal(sig),
+	})...)
+	return pem.EncodeToMemory(&pem.Block{Type: "SSH SIGNATURE", Bytes: blob})
+}
+
+func newSSHSigner(t *testing.T) ssh.Signer {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(priv)
+	require.NoError(t, err)
+	return signer
+}
+
+func newPGPEntity(t *testing.T, algorithm packet.PublicKeyAlgorithm) *openpgp.Entity {
+	t.Helper()
+	entity, err := openpgp.NewEntity("Nomos", "", "nomos@example.com", &packet.Config{Algorithm: algorithm})
+	require.NoError(t, err)
+	return entity
+}
+
+func pgpSign(t *testing.T, entity *openpgp.Entity, message []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, openpgp.ArmoredDetachSign(&buf, entity, bytes.NewReader(message), nil))
+	buf.WriteString("\n")
+	return buf.Bytes()
+}
+
+func pgpPublicKey(t *testing.T, entity *openpgp.Entity) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestVerifyCommit(t *testing.T) {
+	payload := []byte(testCommitHeaders + testCommitMessage)
+	trustedSSH := newSSHSigner(t)
+	untrustedSSH := newSSHSigner(t)
+	trustedPGP := newPGPEntity(t, packet.PubKeyAlgoRSA)
+	trustedEd25519PGP := newPGPEntity(t, packet.PubKeyAlgoEdDSA)
+	untrustedPGP := newPGPEntity(t, packet.PubKeyAlgoRSA)
+	keys := &TrustedKeys{
+		PGPKeyRing: openpgp.EntityList{trustedPGP, trustedEd25519PGP},
+		SSHKeys:    []ssh.PublicKey{trustedSSH.PublicKey()},
+	}
+
+	testCases := map[string]struct {
+		data    []byte
+		keys    *TrustedKeys
+		wantErr string
+	}{
+		"SSH signature by a trusted key": {
+			data: signedCommit(sshSign(t, trustedSSH, sshSigNamespace, payload)),
+			keys: keys,
+		},
+		"SSH signature by an untrusted key": {
+			data:    signedCommit(sshSign(t, untrustedSSH, sshSigNamespace, payload)),
+			keys:    keys,
+			wantErr: "is not trusted",
+		},
+		"SSH signature with the wrong namespace": {
+			data:    signedCommit(sshSign(t, trustedSSH, "file", payload)),
+			keys:    keys,
+			wantErr: "unexpected signature namespace",
+		},
+		"SSH signature of a different payload": {
+			data:    signedCommit(sshSign(t, trustedSSH, sshSigNamespace, []byte("tampered"))),
+			keys:    keys,
+			wantErr: "verifying the SSH signature",
+		},
+		"SSH signature without trusted SSH keys": {
+			data:    signedCommit(sshSign(t, trustedSSH, sshSigNamespace, payload)),
+			keys:    &TrustedKeys{PGPKeyRing: openpgp.EntityList{trustedPGP}},
+			wantErr: "no trusted SSH keys are configured",
+		},
+		"GPG signature by a trusted key": {
+			data: signedCommit(pgpSign(t, trustedPGP, payload)),
+			keys: keys,
+		},
+		"GPG signature by a trusted ed25519 key": {
+			data: signedCommit(pgpSign(t, trustedEd25519PGP, payload)),
+			keys: keys,
+		},
+		"GPG signature along with a SHA-256 signature": {
+			data: []byte(strings.Replace(string(signedCommit(pgpSign(t, trustedPGP, payload))), testCommitMessage,
+				"gpgsig-sha256 -----BEGIN PGP SIGNATURE-----\n -----END PGP SIGNATURE-----\n"+testCommitMessage, 1)),
+			keys: keys,
+		},
+		"SHA-256 signature only": {
+			data:    []byte(testCommitHeaders + "gpgsig-sha256 -----BEGIN PGP SIGNATURE-----\n -----END PGP SIGNATURE-----\n" + testCommitMessage),
+			keys:    keys,
+			wantErr: "SHA-256 repositories, which are not supported",
+		},
+		"GPG signature by an untrusted key": {
+			data:    signedCommit(pgpSign(t, untrustedPGP, payload)),
+			keys:    keys,
+			wantErr: "verifying the GPG signature",
+		},
+		"unsigned commit": {
+			data:    payload,
+			keys:    keys,
+			wantErr: "is not signed",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			commit := parseCommit(tc.data)
+			_, err := VerifyCommit(commit, tc.keys)
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLoadTrustedKeys(t *testing.T) {
+	sshSigner := newSSHSigner(t)
+	entity := newPGPEntity(t, packet.PubKeyAlgoRSA)
+
+	dir := t.TempDir()
+	authorizedKey := ssh.MarshalAuthorizedKey(sshSigner.PublicKey())
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ssh"),
+		append([]byte("# trusted signers\nnomos@example.com "), authorizedKey...), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "gpg"), pgpPublicKey(t, entity), 0644))
+	// Secret volumes contain hidden files, which must be skipped.
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "..data"), 0755))
+
+	keys, err := LoadTrustedKeys(dir)
+	require.NoError(t, err)
+	require.Len(t, keys.SSHKeys, 1)
+	assert.Equal(t, sshSigner.PublicKey().Marshal(), keys.SSHKeys[0].Marshal())
+	require.Len(t, keys.PGPKeyRing, 1)
+	assert.Equal(t, entity.PrimaryKey.Fingerprint, keys.PGPKeyRing[0].PrimaryKey.Fingerprint)
+
+	_, err = LoadTrustedKeys(t.TempDir())
+	assert.ErrorContains(t, err, "no trusted keys found")
+}
+
+func TestCommitVerifierVerify(t *testing.T) {
+	worktree, objectsDir := newWorktree(t)
+	signer := newSSHSigner(t)
+	payload := []byte(testCommitHeaders + testCommitMessage)
+	signedHash := writeLooseObject(t, objectsDir, CommitObject, signedCommit(sshSign(t, signer, sshSigNamespace, payload)))
+	unsignedHash := writeLooseObject(t, objectsDir, CommitObject, payload)
+
+	keysDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(keysDir, "ssh"), ssh.MarshalAuthorizedKey(signer.PublicKey()), 0644))
+	verifier := &CommitVerifier{KeysDir: keysDir}
+	sourceDir := cmpath.Absolute(worktree)
+
+	assert.NoError(t, verifier.Verify(sourceDir, signedHash))
+
+	err := verifier.Verify(sourceDir, unsignedHash)
+	require.Error(t, err)
+	assert.Equal(t, status.SourceVerificationErrorCode, err.Code())
+}
@@ -30,6 +30,7 @@ import (
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/auth"
+	"kpt.dev/configsync/pkg/provenance"
 	"kpt.dev/configsync/pkg/util"
 	"sigs.k8s.io/kustomize/kyaml/filesys"
 	"sigs.k8s.io/kustomize/kyaml/kio"
@@ -65,16 +66,24 @@ type Hydrator struct {
 	ValuesFileApplyStrategy string
 	CACertFilePath          string
 	CredentialProvider      auth.CredentialProvider
+	VerificationKeysDir     string
+	VerificationIdentity    string
+	VerificationIssuer      string
 }
 
-func (h *Hydrator) templateArgs(ctx context.Context, destDir string) ([]string, error) {
+// templateArgs returns the arguments of `helm template`. The chart is rendered
+// from the local archive at chartPath if set, otherwise it is fetched from the
+// repository.
+func (h *Hydrator) templateArgs(ctx context.Context, destDir, chartPath string) ([]string, error) {
 	args := []string{"template"}
 	var err error
 
 	if h.ReleaseName != "" {
 		args = append(args, h.ReleaseName)
 	}
-	if h.isOCI() {
+	if chartPath != "" {
+		args = append(args, chartPath)
+	} else if h.isOCI() {
 		args = append(args, h.Repo+"/"+h.Chart)
 	} else {
 		args = append(args, h.Chart)
@@ -89,7 +98,7 @@ func (h *Hydrator) templateArgs(ctx context.Context, destDir string) ([]string,
 	} else {
 		args = append(args, "--namespace", configsync.DefaultHelmReleaseNamespace)
 	}
-	if h.Version != "" {
+	if h.Version != "" && chartPath == "" {
 		args = append(args, "--version", h.Version)
 	}
 	args, err = h.appendValuesArgs(args)
@@ -286,8 +295,16 @@ func (h *Hydrator) HelmTemplate(ctx context.Context) error {
 
 	// for "latest" tag, we always re-fetch and re-sync
 	if h.Version != "latest" && oldDir == destDir {
-		klog.Infof("no update required with the same helm chart version %q", h.Version)
-		return nil
+		verification, err := provenance.ReadChartVerification(destDir)
+		if err != nil {
+			return err
+		}
+		// Re-render the chart if verification was enabled or disabled since
+		// it was rendered.
+		if (verification != nil) == h.verify() {
+			klog.Infof("no update required with the same helm chart version %q", h.Version)
+			return nil
+		}
 	}
 
 	if !loggedIn {
@@ -296,7 +313,27 @@ func (h *Hydrator) HelmTemplate(ctx context.Context) error {
 		}
 	}
 
-	args, err := h.templateArgs(ctx, destDir)
+	// Verified charts are pulled and verified first, then rendered from the
+	// verified archive, so that the rendered chart can't differ from it.
+	var chartPath string
+	var verification *provenance.ChartVerification
+	if h.verify() {
+		pullDir, err := os.MkdirTemp("", "helm-pull-")
+		if err != nil {
+			return fmt.Errorf("failed to create the chart download directory: %w", err)
+		}
+		defer func() {
+			if err := os.RemoveAll(pullDir); err != nil {
+				klog.Warningf("failed to remove the chart download directory %q: %v", pullDir, err)
+			}
+		}()
+		chartPath, verification, err = h.pullAndVerify(ctx, pullDir)
+		if err != nil {
+			return err
+		}
+	}
+
+	args, err := h.templateArgs(ctx, destDir, chartPath)
 	if err != nil {
 		return err
 	}
@@ -310,14 +347,44 @@ func (h *Hydrator) HelmTemplate(ctx context.Context) error {
 		return fmt.Errorf("failed to create chart directory: %w", err)
 	}
 
+	if err := provenance.WriteChartVerification(destDir, verification); err != nil {
+		return err
+	}
+
 	if err := h.setDeployNamespace(destDir); err != nil {
 		return fmt.Errorf("failed to set the deploy namespace: %w", err)
 	}
 
 	klog.Infof("successfully rendered the helm chart: %s", string(out))
+	if oldDir == destDir {
+		// The chart was rendered again in place, so there is no previous
+		// directory to remove.
+		oldDir = ""
+	}
 	return util.UpdateSymlink(h.HydrateRoot, linkPath, destDir, oldDir)
 }
 
+// RenderChart renders the local chart in chartDir to destDir. The values files
+// from the source repository are applied before ValuesFilePaths, so the values
+// files from ConfigMaps take precedence.
+func (h *Hydrator) RenderChart(ctx context.Context, chartDir string, valuesFiles []string, destDir string) error {
+	hc := *h
+	hc.ValuesFilePaths = append(append([]string{}, valuesFiles...), h.ValuesFilePaths...)
+	args, err := hc.templateArgs(ctx, destDir, chartDir)
+	if err != nil {
+		return err
+	}
+	out, err := hc.helm(ctx, args...)
+	if err != nil {
+		return fmt.Errorf("rendering helm chart: %w", err)
+	}
+	if err := hc.setDeployNamespace(destDir); err != nil {
+		return fmt.Errorf("failed to set the deploy namespace: %w", err)
+	}
+	klog.Infof("successfully rendered the helm chart: %s", string(out))
+	return nil
+}
+
 func (h *Hydrator) isOCI() bool {
 	return strings.HasPrefix(h.Repo, "oci://")
 }
@@ -0,0 +1,244 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/oci"
+	"kpt.dev/configsync/pkg/provenance"
+	"kpt.dev/configsync/pkg/status"
+)
+
+// keyringFile is the name of the keyring passed to `helm pull --keyring`,
+// in the chart download directory.
+const keyringFile = "keyring.gpg"
+
+var (
+	// ociDigestPattern matches the digest printed by `helm pull` for OCI
+	// charts.
+	ociDigestPattern = regexp.MustCompile(`(?m)^Digest: (sha256:[a-f0-9]{64})\s*$`)
+	// signedByPattern matches the identities of the signer printed by
+	// `helm pull --verify`.
+	signedByPattern = regexp.MustCompile(`(?m)^Signed by: (.+?)\s*$`)
+	// fingerprintPattern matches the fingerprint of the signing key printed by
+	// `helm pull --verify`.
+	fingerprintPattern = regexp.MustCompile(`(?m)^Using Key With Fingerprint: ([A-F0-9]+)\s*$`)
+	// chartHashPattern matches the checksum of the chart archive printed by
+	// `helm pull --verify`.
+	chartHashPattern = regexp.MustCompile(`(?m)^Chart Hash Verified: (sha256:[a-f0-9]{64})\s*$`)
+	// verificationFailurePattern matches the errors of `helm pull --verify`
+	// when the provenance file is missing, not signed by a trusted key, or
+	// doesn't match the archive.
+	verificationFailurePattern = regexp.MustCompile(`openpgp: |sum does not match|provenance`)
+)
+
+// verify returns whether the chart must be verified before it is rendered.
+func (h *Hydrator) verify() bool {
+	return h.VerificationKeysDir != ""
+}
+
+func (h *Hydrator) pullArgs(ctx context.Context, destDir string) ([]string, error) {
+	if h.isOCI() {
+		return []string{"pull", h.Repo + "/" + h.Chart, "--version", h.Version, "--destination", destDir}, nil
+	}
+	// Provenance files are verified by Helm itself.
+	keyring := filepath.Join(destDir, keyringFile)
+	if err := writeKeyRing(h.VerificationKeysDir, keyring); err != nil {
+		return nil, err
+	}
+	return h.appendAuthArgs(ctx, []string{"pull", h.Chart, "--repo", h.Repo, "--version", h.Version,
+		"--verify", "--keyring", keyring, "--destination", destDir})
+}
+
+// pullAndVerify downloads the chart archive into the directory, and checks
+// that it is signed by a trusted signer. It returns the path of the verified
+// archive.
+func (h *Hydrator) pullAndVerify(ctx context.Context, dir string) (string, *provenance.ChartVerification, error) {
+	args, err := h.pullArgs(ctx, dir)
+	if err != nil {
+		return "", nil, err
+	}
+	out, err := h.helm(ctx, args...)
+	if err != nil {
+		if !h.isOCI() && verificationFailurePattern.Match(out) {
+			return "", nil, status.SourceVerificationError(fmt.Errorf("failed to verify chart %s version %s: %w", h.Chart, h.Version, err))
+		}
+		return "", nil, fmt.Errorf("pulling helm chart: %w", err)
+	}
+	archives, err := filepath.Glob(filepath.Join(dir, "*.tgz"))
+	if err != nil {
+		return "", nil, err
+	}
+	if len(archives) != 1 {
+		return "", nil, fmt.Errorf("expected one chart archive after pulling chart %s, found %d", h.Chart, len(archives))
+	}
+	chartPath := archives[0]
+
+	var verification *provenance.ChartVerification
+	if h.isOCI() {
+		verification, err = h.verifyImage(out)
+	} else {
+		verification, err = parseVerification(out)
+	}
+	if err != nil {
+		return "", nil, status.SourceVerificationError(fmt.Errorf("failed to verify chart %s version %s: %w", h.Chart, h.Version, err))
+	}
+	klog.Infof("Verified chart %s version %s with digest %q, signed by %s", h.Chart, h.Version, verification.Digest, verification.Signer)
+	return chartPath, verification, nil
+}
+
+// parseVerification returns the verification result printed by
+// `helm pull --verify`.
+func parseVerification(pullOutput []byte) (*provenance.ChartVerification, error) {
+	hash := chartHashPattern.FindSubmatch(pullOutput)
+	fingerprint := fingerprintPattern.FindSubmatch(pullOutput)
+	if hash == nil || fingerprint == nil {
+		return nil, fmt.Errorf("failed to find the verification result in the output of `helm pull`: %s", string(pullOutput))
+	}
+	signer := string(fingerprint[1])
+	var names []string
+	for _, match := range signedByPattern.FindAllSubmatch(pullOutput, -1) {
+		names = append(names, string(match[1]))
+	}
+	if len(names) > 0 {
+		// Helm prints the identities in random order.
+		sort.Strings(names)
+		signer = fmt.Sprintf("%s (%s)", names[0], signer)
+	}
+	return &provenance.ChartVerification{Digest: string(hash[1]), Signer: signer}, nil
+}
+
+// verifyImage checks the cosign signatures of the chart image pulled by
+// `helm pull`, whose output holds the image digest.
+func (h *Hydrator) verifyImage(pullOutput []byte) (*provenance.ChartVerification, error) {
+	match := ociDigestPattern.FindSubmatch(pullOutput)
+	if match == nil {
+		return nil, fmt.Errorf("failed to find the chart digest in the output of `helm pull`: %s", string(pullOutput))
+	}
+	digest, err := v1.NewHash(string(match[1]))
+	if err != nil {
+		return nil, err
+	}
+	// OCI tags don't allow "+", which Helm replaces with "_".
+	tag := strings.ReplaceAll(h.Version, "+", "_")
+	ref, err := name.ParseReference(strings.TrimPrefix(h.Repo, "oci://") + "/" + h.Chart + ":" + tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse reference to chart %s: %w", h.Chart, err)
+	}
+	options := []remote.Option{remote.WithAuth(h.registryAuthenticator())}
+	if h.CACertFilePath != "" {
+		transport, err := caCertTransport(h.CACertFilePath)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, remote.WithTransport(transport))
+	}
+	verifier := &oci.Verifier{
+		KeysDir:  h.VerificationKeysDir,
+		Identity: h.VerificationIdentity,
+		Issuer:   h.VerificationIssuer,
+	}
+	signer, err := verifier.Verify(ref, digest, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &provenance.ChartVerification{Digest: digest.String(), Signer: signer}, nil
+}
+
+// registryAuthenticator returns the authenticator used to fetch the cosign
+// signatures of OCI charts.
+func (h *Hydrator) registryAuthenticator() authn.Authenticator {
+	switch h.Auth {
+	case configsync.AuthToken:
+		return &authn.Basic{Username: h.UserName, Password: h.Password}
+	case configsync.AuthGCPServiceAccount, configsync.AuthK8sServiceAccount, configsync.AuthGCENode:
+		return &oci.CredentialAuthenticator{CredentialProvider: h.CredentialProvider}
+	default:
+		return authn.Anonymous
+	}
+}
+
+// caCertTransport returns an HTTP transport trusting the CA certificate, in
+// addition to the system roots.
+func caCertTransport(caCertFilePath string) (http.RoundTripper, error) {
+	caCert, err := os.ReadFile(caCertFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading the CA certificate: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("no certificates found in %q", caCertFilePath)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	return transport, nil
+}
+
+// writeKeyRing concatenates the binary GPG keyrings from the files in the
+// specified directory, usually a mounted Secret, into the keyring file passed
+// to `helm pull --keyring`, which only accepts a single keyring.
+func writeKeyRing(dir, keyring string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("reading the keyring directory %q: %w", dir, err)
+	}
+	var content []byte
+	for _, entry := range entries {
+		// Skip the hidden files and directories created by the kubelet when
+		// mounting Secrets.
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("reading the keyring file %q: %w", path, err)
+		}
+		if info.IsDir() {
+			continue
+		}
+		keys, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading the keyring file %q: %w", path, err)
+		}
+		content = append(content, keys...)
+	}
+	if len(content) == 0 {
+		return fmt.Errorf("no trusted keys found in %q", dir)
+	}
+	if err := os.WriteFile(keyring, content, 0600); err != nil {
+		return fmt.Errorf("writing the keyring: %w", err)
+	}
+	return nil
+}
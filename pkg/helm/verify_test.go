@@ -0,0 +1,86 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/provenance"
+)
+
+const testChartHash = "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+
+func TestParseVerification(t *testing.T) {
+	testCases := map[string]struct {
+		output  string
+		want    *provenance.ChartVerification
+		wantErr string
+	}{
+		"signer with identities": {
+			output: "Signed by: Nomos <nomos@example.com>\n" +
+				"Signed by: CI <ci@example.com>\n" +
+				"Using Key With Fingerprint: 0123456789ABCDEF0123456789ABCDEF01234567\n" +
+				"Chart Hash Verified: " + testChartHash + "\n",
+			want: &provenance.ChartVerification{
+				Digest: testChartHash,
+				Signer: "CI <ci@example.com> (0123456789ABCDEF0123456789ABCDEF01234567)",
+			},
+		},
+		"signer without identity": {
+			output: "Using Key With Fingerprint: 0123456789ABCDEF0123456789ABCDEF01234567\n" +
+				"Chart Hash Verified: " + testChartHash + "\n",
+			want: &provenance.ChartVerification{
+				Digest: testChartHash,
+				Signer: "0123456789ABCDEF0123456789ABCDEF01234567",
+			},
+		},
+		"not verified": {
+			output:  "Pulled: my-chart-1.0.0.tgz\n",
+			wantErr: "failed to find the verification result",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseVerification([]byte(tc.output))
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestWriteKeyRing(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.gpg"), []byte("first keyring"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.gpg"), []byte("second keyring"), 0644))
+	// Secret volumes contain hidden files, which must be skipped.
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "..data"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".hidden"), []byte("hidden"), 0644))
+
+	keyring := filepath.Join(t.TempDir(), keyringFile)
+	require.NoError(t, writeKeyRing(dir, keyring))
+	content, err := os.ReadFile(keyring)
+	require.NoError(t, err)
+	assert.Equal(t, "first keyringsecond keyring", string(content))
+
+	assert.ErrorContains(t, writeKeyRing(t.TempDir(), keyring), "no trusted keys found")
+}
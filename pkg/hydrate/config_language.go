@@ -0,0 +1,271 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/cuecontext"
+	cueerrors "cuelang.org/go/cue/errors"
+	"cuelang.org/go/cue/load"
+	"cuelang.org/go/cue/parser"
+	"github.com/google/go-jsonnet"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Jsonnet is the name of the Jsonnet renderer.
+	Jsonnet = "jsonnet"
+	// Cue is the name of the CUE renderer.
+	Cue = "cue"
+	// JsonnetMainFile is the Jsonnet file evaluated to render the sync
+	// directory.
+	JsonnetMainFile = "main.jsonnet"
+	// CueModDir is the directory of the CUE module rendered from the sync
+	// directory.
+	CueModDir = "cue.mod"
+)
+
+// jsonnetRenderer evaluates main.jsonnet in the sync directory, with the sync
+// directory as the library search path. Jsonnet is evaluated in-process, so
+// no binary is needed in the image. The imports must not resolve outside of
+// the sync directory.
+type jsonnetRenderer struct{}
+
+func (jsonnetRenderer) Name() string { return Jsonnet }
+
+func (jsonnetRenderer) Detect(dir string) (bool, error) {
+	return hasMarker(dir, JsonnetMainFile, false)
+}
+
+func (jsonnetRenderer) Render(input, output string) HydrationError {
+	return renderJSON(input, output, Jsonnet, func() ([]byte, error) {
+		vm := jsonnet.MakeVM()
+		vm.Importer(&rootedImporter{
+			root:     input,
+			jpaths:   []string{input},
+			importer: &jsonnet.FileImporter{JPaths: []string{input}},
+		})
+		out, err := vm.EvaluateFile(filepath.Join(input, JsonnetMainFile))
+		return []byte(out), err
+	})
+}
+
+// rootedImporter is a Jsonnet importer which rejects the imports resolving
+// outside of the root directory, once the symlinks are evaluated. The import
+// is looked up like the FileImporter does, and checked before it is read.
+type rootedImporter struct {
+	root     string
+	jpaths   []string
+	importer jsonnet.Importer
+}
+
+var _ jsonnet.Importer = &rootedImporter{}
+
+// Import implements jsonnet.Importer.
+func (i *rootedImporter) Import(importedFrom, importedPath string) (jsonnet.Contents, string, error) {
+	dir, _ := filepath.Split(importedFrom)
+	dirs := []string{dir}
+	for j := len(i.jpaths) - 1; j >= 0; j-- {
+		dirs = append(dirs, i.jpaths[j])
+	}
+	for _, dir := range dirs {
+		p := importedPath
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(dir, p)
+		}
+		if _, err := os.Stat(p); err != nil {
+			continue
+		}
+		if _, err := resolveInRoot(i.root, p); err != nil {
+			return jsonnet.Contents{}, "", fmt.Errorf("couldn't open import %q: %w", importedPath, err)
+		}
+		break
+	}
+	return i.importer.Import(importedFrom, importedPath)
+}
+
+// resolveInRoot evaluates the symlinks of the path, and returns an error if
+// it is outside of the root directory.
+func resolveInRoot(root, p string) (string, error) {
+	resolvedRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", err
+	}
+	resolved, err := filepath.EvalSymlinks(p)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(resolvedRoot, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside of the source directory", p)
+	}
+	return resolved, nil
+}
+
+// cueRenderer exports the CUE package of the module in the sync directory,
+// like `cue export --out json`. CUE is evaluated in-process, so no binary is
+// needed in the image.
+type cueRenderer struct{}
+
+func (cueRenderer) Name() string { return Cue }
+
+func (cueRenderer) Detect(dir string) (bool, error) {
+	return hasMarker(dir, CueModDir, true)
+}
+
+func (cueRenderer) Render(input, output string) HydrationError {
+	return renderJSON(input, output, Cue, func() ([]byte, error) {
+		out, err := cueExport(input)
+		if err != nil {
+			return nil, errors.New(strings.TrimSpace(cueerrors.Details(err, nil)))
+		}
+		return out, nil
+	})
+}
+
+// cueExport evaluates the CUE package in the directory, and returns its
+// concrete value as JSON. The files of the package and of its imports must
+// not resolve outside of the directory.
+func cueExport(dir string) ([]byte, error) {
+	instances := load.Instances([]string{"."}, &load.Config{
+		Dir: dir,
+		ParseFile: func(name string, src interface{}, cfg parser.Config) (*ast.File, error) {
+			if _, err := resolveInRoot(dir, name); err != nil {
+				return nil, err
+			}
+			return parser.ParseFile(name, src, cfg)
+		},
+	})
+	if len(instances) != 1 {
+		return nil, fmt.Errorf("expected a single CUE package, found %d", len(instances))
+	}
+	if err := instances[0].Err; err != nil {
+		return nil, err
+	}
+	value := cuecontext.New().BuildInstance(instances[0])
+	if err := value.Err(); err != nil {
+		return nil, err
+	}
+	if err := value.Validate(cue.Concrete(true)); err != nil {
+		return nil, err
+	}
+	return value.MarshalJSON()
+}
+
+// renderJSON evaluates the configs in the input directory with the render
+// function, and writes the Kubernetes objects of its JSON output to the output
+// directory.
+func renderJSON(input, output, name string, render func() ([]byte, error)) HydrationError {
+	if err := os.RemoveAll(output); err != nil {
+		return NewInternalError(err)
+	}
+	if err := os.MkdirAll(output, 0755); err != nil {
+		return NewInternalError(fmt.Errorf("unable to make directory: %s: %w", output, err))
+	}
+
+	out, err := render()
+	if err != nil {
+		renderErr := fmt.Errorf("failed to render %s in %s: %w", name, input, err)
+		mustDeleteOutput(renderErr, output)
+		return NewActionableError(renderErr)
+	}
+	if err := writeManifests(output, out); err != nil {
+		renderErr := fmt.Errorf("invalid output of %s in %s: %w", name, input, err)
+		mustDeleteOutput(renderErr, output)
+		return NewActionableError(renderErr)
+	}
+	return nil
+}
+
+// writeManifests writes the Kubernetes objects in the JSON value to the output
+// directory, one file per object, named like the output of `kustomize build`.
+// The objects are looked up in arrays, List objects and the fields of other
+// JSON objects, so the configs can be laid out freely.
+func writeManifests(output string, data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	var objects []map[string]interface{}
+	collectObjects(value, &objects)
+	for _, obj := range objects {
+		content, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		file := filepath.Join(output, manifestFileName(obj))
+		if _, err := os.Stat(file); err == nil {
+			return fmt.Errorf("duplicate object %s", strings.TrimSuffix(filepath.Base(file), ".yaml"))
+		}
+		if err := os.WriteFile(file, content, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectObjects appends the Kubernetes objects in the JSON value to objects.
+func collectObjects(value interface{}, objects *[]map[string]interface{}) {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			collectObjects(item, objects)
+		}
+	case map[string]interface{}:
+		_, hasAPIVersion := v["apiVersion"].(string)
+		kind, hasKind := v["kind"].(string)
+		if hasAPIVersion && hasKind {
+			if items, ok := v["items"].([]interface{}); ok && strings.HasSuffix(kind, "List") {
+				collectObjects(items, objects)
+				return
+			}
+			*objects = append(*objects, v)
+			return
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			collectObjects(v[k], objects)
+		}
+	}
+}
+
+// manifestFileName returns the file name of the object, in the format of
+// `kustomize build --output`, prefixed with the namespace if set.
+func manifestFileName(obj map[string]interface{}) string {
+	var parts []string
+	metadata, _ := obj["metadata"].(map[string]interface{})
+	if ns, _ := metadata["namespace"].(string); ns != "" {
+		parts = append(parts, ns)
+	}
+	parts = append(parts, strings.Split(obj["apiVersion"].(string), "/")...)
+	parts = append(parts, obj["kind"].(string))
+	if name, _ := metadata["name"].(string); name != "" {
+		parts = append(parts, name)
+	}
+	return strings.ToLower(strings.Join(parts, "_")) + ".yaml"
+}
@@ -69,6 +69,28 @@ type Hydrator struct {
 	RehydratePeriod time.Duration
 	// ReconcilerName is the name of the reconciler.
 	ReconcilerName string
+	// ChartRenderer renders the Helm chart at the root of the sync directory.
+	ChartRenderer ChartRenderer
+	// ChartValuesFiles are the paths to the values files of the Helm chart,
+	// relative to the sync directory.
+	ChartValuesFiles []string
+	// ExtraInputs are the absolute paths to the rendering inputs outside of
+	// the source, like the values files mounted from ConfigMaps. Their content
+	// is part of the hash of the rendering inputs.
+	ExtraInputs []string
+	// Hermetic rejects the kustomizations which fetch remote bases or Helm
+	// charts at render time.
+	Hermetic bool
+	// AllowExecFunctions allows the Kptfile pipelines to run exec functions.
+	AllowExecFunctions bool
+	// KptFunctionsDir is the directory of the exec function binaries shipped
+	// in the image, the only ones the Kptfile pipelines may run. Defaults to
+	// DefaultKptFunctionsDir.
+	KptFunctionsDir string
+	// Renderers are the rendering tools, in order of precedence. The first
+	// one detected in the sync directory renders it. Defaults to Kustomize,
+	// Helm, Jsonnet, CUE and Kptfile pipelines.
+	Renderers []Renderer
 }
 
 // Run runs the hydration process periodically.
@@ -128,30 +150,73 @@ func (h *Hydrator) Run(ctx context.Context) {
 	}
 }
 
-// runHydrate runs `kustomize build` on the source configs.
+// runHydrate renders the source configs with the renderer detected in the
+// sync directory, and falls back to the Kptfile function pipelines. The
+// previously hydrated configs are reused if the rendering inputs are unchanged.
 func (h *Hydrator) runHydrate(sourceCommit string, syncPath cmpath.Absolute) HydrationError {
 	newHydratedDir := h.HydratedRoot.Join(cmpath.RelativeOS(sourceCommit))
 	dest := newHydratedDir.Join(h.SyncDir).OSPath()
 
 	osSyncPath := syncPath.OSPath()
-	if err := kustomizeBuild(osSyncPath, dest, true); err != nil {
-		return err
+	hash, err := h.sourceRenderHash(osSyncPath)
+	if err != nil {
+		klog.Warningf("unable to compute the hash of the rendering inputs of %s, the render cache is disabled: %v", osSyncPath, err)
+	}
+	cachedDir := h.cachedHydratedDir(hash)
+	if cachedDir == "" {
+		var r Renderer = h.kptRenderer()
+		detected, err := h.renderer(osSyncPath)
+		if err != nil {
+			return NewInternalError(fmt.Errorf("unable to check if rendering is needed for the source directory: %s: %w", osSyncPath, err))
+		}
+		if detected != nil {
+			r = detected
+		}
+		if err := r.Render(osSyncPath, dest); err != nil {
+			return err
+		}
 	}
 
 	newCommit, err := ComputeCommit(h.sourcePath())
 	if err != nil {
 		return NewTransientError(err)
 	} else if sourceCommit != newCommit {
-		return NewTransientError(fmt.Errorf("source commit changed while running Kustomize build, was %s, now %s. It will be retried in the next sync", sourceCommit, newCommit))
+		return NewTransientError(fmt.Errorf("source commit changed while rendering, was %s, now %s. It will be retried in the next sync", sourceCommit, newCommit))
 	}
 
+	if cachedDir != "" && cachedDir != newHydratedDir.OSPath() {
+		// Link the previously hydrated configs into the directory of the new
+		// commit, so that the reconciler reads the commit from the symlink.
+		// The linked directory is left in place until the symlink is updated.
+		if err := os.RemoveAll(newHydratedDir.OSPath()); err != nil {
+			return NewInternalError(err)
+		}
+		if err := linkTree(cachedDir, newHydratedDir.OSPath()); err != nil {
+			return NewInternalError(fmt.Errorf("unable to reuse the hydrated directory %s: %w", cachedDir, err))
+		}
+	}
 	if err := updateSymlink(h.HydratedRoot.OSPath(), h.HydratedLink, newHydratedDir.OSPath()); err != nil {
 		return NewInternalError(fmt.Errorf("unable to update the symbolic link to %s: %w", newHydratedDir.OSPath(), err))
 	}
+	h.updateRenderCache(sourceCommit, hash, cachedDir != "")
+	if cachedDir != "" {
+		klog.Infof("Skipped rendering %s for commit %s: the rendering inputs are unchanged", osSyncPath, sourceCommit)
+		return nil
+	}
 	klog.Infof("Successfully rendered %s for commit %s", osSyncPath, sourceCommit)
 	return nil
 }
 
+// sourceRenderHash returns the hash of the rendering inputs of the sync
+// directory, within the source directory of the commit.
+func (h *Hydrator) sourceRenderHash(syncPath string) (string, error) {
+	sourceDir, err := h.sourcePath().EvalSymlinks()
+	if err != nil {
+		return "", err
+	}
+	return h.renderHash(sourceDir.OSPath(), syncPath)
+}
+
 // ComputeCommit returns the computed commit from given sourceDir, or error
 // if the sourceDir fails symbolic link evaluation
 func ComputeCommit(sourceDir cmpath.Absolute) (string, error) {
@@ -172,11 +237,11 @@ func (h *Hydrator) sourcePath() cmpath.Absolute {
 // hydrate renders the source git repo to hydrated configs.
 func (h *Hydrator) hydrate(sourceCommit string, syncPath cmpath.Absolute) HydrationError {
 	osSyncPath := syncPath.OSPath()
-	hydrate, err := needsKustomize(osSyncPath)
+	r, err := h.renderer(osSyncPath)
 	if err != nil {
 		return NewInternalError(fmt.Errorf("unable to check if rendering is needed for the source directory: %s: %w", osSyncPath, err))
 	}
-	if !hydrate {
+	if r == nil {
 		found, err := hasKustomizeSubdir(osSyncPath)
 		if err != nil {
 			return NewInternalError(err)
@@ -186,7 +251,7 @@ func (h *Hydrator) hydrate(sourceCommit string, syncPath cmpath.Absolute) Hydrat
 				"To fix, either add kustomization.yaml in the sync directory to trigger the rendering process, "+
 				"or remove kustomizaiton.yaml from all sub directories to skip rendering.", osSyncPath))
 		}
-		klog.V(5).Infof("no rendering is needed because of no Kustomization config file in the source configs with commit %s", sourceCommit)
+		klog.V(5).Infof("no rendering is needed because of no Kustomization config file, Helm chart, Jsonnet, CUE module or Kptfile pipeline in the source configs with commit %s", sourceCommit)
 		if err := os.RemoveAll(h.HydratedRoot.OSPath()); err != nil {
 			return NewInternalError(err)
 		}
@@ -331,6 +396,9 @@ func exportError(commit, root, errorFile string, hydrationError HydrationError)
 		Code:  hydrationError.Code(),
 		Error: hydrationError.Error(),
 	}
+	if pipelineErr, ok := hydrationError.(PipelineError); ok {
+		payload.Results = pipelineErr.Results
+	}
 
 	jb, err := json.Marshal(payload)
 	if err != nil {
@@ -369,6 +437,11 @@ func SourceCommitAndSyncPathWithRetry(backoff wait.Backoff, sourceType configsyn
 		commit, syncPath, err = SourceCommitAndSyncPath(sourceType, sourcePath, syncDir, reconcilerName)
 		return err
 	})
+	// Errors already identified by the *-sync container, like verification
+	// failures, are exposed as is.
+	if statusErr, ok := err.(status.Error); ok {
+		return commit, syncPath, statusErr
+	}
 	// If a retriable error can't be addressed with retry, it is identified as a
 	// source error, and will be exposed in the R*Sync status.
 	return commit, syncPath, status.SourceError.Wrap(err).Build()
@@ -397,6 +470,8 @@ func SourceCommitAndSyncPath(sourceType configsync.SourceType, sourcePath cmpath
 		containerName = reconcilermanager.GitSync
 	case configsync.HelmSource:
 		containerName = reconcilermanager.HelmSync
+	case configsync.ArchiveSource:
+		containerName = reconcilermanager.ArchiveSync
 	}
 
 	content, err := os.ReadFile(errFilePath)
@@ -415,6 +490,10 @@ func SourceCommitAndSyncPath(sourceType configsync.SourceType, sourcePath cmpath
 	case err == nil && len(content) != 0:
 		// The source error file exists, which indicates the *-sync container is
 		// ready, so return the error directly without retry.
+		if status.IsSourceVerificationErrorMessage(string(content)) {
+			return "", "", status.SourceVerificationErrorFromMessage(
+				fmt.Sprintf("error in the %s container", containerName), string(content))
+		}
 		return "", "", fmt.Errorf("error in the %s container: %s", containerName, string(content))
 	default:
 		// The sourceRoot directory exists, but the source error file doesn't exist.
@@ -15,9 +15,11 @@
 package hydrate
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -27,6 +29,7 @@ import (
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
 	ft "kpt.dev/configsync/pkg/importer/filesystem/filesystemtest"
+	"kpt.dev/configsync/pkg/status"
 	"kpt.dev/configsync/pkg/testing/testerrors"
 )
 
@@ -50,6 +53,7 @@ func TestSourceCommitAndDirWithRetry(t *testing.T) {
 		errFileContent       string
 		expectedSourceCommit string
 		expectedErrMsg       string
+		expectedErrCode      string
 	}{
 		{
 			name:                 "source root directory isn't created within the retry cap",
@@ -82,11 +86,20 @@ func TestSourceCommitAndDirWithRetry(t *testing.T) {
 			expectedErrMsg: "is empty. Please check git-sync logs for more info",
 		},
 		{
-			name:           "error file exists with non-empty content",
-			retryCap:       100 * time.Millisecond,
-			errFileExists:  true,
-			errFileContent: "git-sync error",
-			expectedErrMsg: "git-sync error",
+			name:            "error file exists with non-empty content",
+			retryCap:        100 * time.Millisecond,
+			errFileExists:   true,
+			errFileContent:  "git-sync error",
+			expectedErrMsg:  "git-sync error",
+			expectedErrCode: status.SourceErrorCode,
+		},
+		{
+			name:            "error file exists with a verification error",
+			retryCap:        100 * time.Millisecond,
+			errFileExists:   true,
+			errFileContent:  status.SourceVerificationError(errors.New("image is not signed")).Error(),
+			expectedErrMsg:  "image is not signed",
+			expectedErrCode: status.SourceVerificationErrorCode,
 		},
 		{
 			name:           "sync directory doesn't exist",
@@ -183,6 +196,12 @@ func TestSourceCommitAndDirWithRetry(t *testing.T) {
 			} else {
 				assert.NotNil(t, err)
 				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				if tc.expectedErrCode != "" {
+					assert.Equal(t, tc.expectedErrCode, err.Code())
+					// The error code must not be repeated when the error
+					// reported by the *-sync container already has one.
+					assert.Equal(t, 1, strings.Count(err.Error(), "KNV"+tc.expectedErrCode), err.Error())
+				}
 			}
 
 			// Block and wait for the goroutine to complete.
@@ -205,7 +224,7 @@ func TestRunHydrate(t *testing.T) {
 		{
 			name:      "Run hydrate when source commit is changed",
 			commit:    differentCommit,
-			wantedErr: NewTransientError(fmt.Errorf("source commit changed while running Kustomize build, was %s, now %s. It will be retried in the next sync", originCommit, differentCommit)),
+			wantedErr: NewTransientError(fmt.Errorf("source commit changed while rendering, was %s, now %s. It will be retried in the next sync", originCommit, differentCommit)),
 		},
 	}
 
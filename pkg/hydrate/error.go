@@ -14,7 +14,12 @@
 
 package hydrate
 
-import "kpt.dev/configsync/pkg/status"
+import (
+	"fmt"
+	"strings"
+
+	"kpt.dev/configsync/pkg/status"
+)
 
 // HydrationError is a wrapper of the error in the hydration process with the error code.
 type HydrationError interface {
@@ -68,10 +73,48 @@ func (e TransientError) Code() string {
 	return status.TransientErrorCode
 }
 
+// FunctionResult is an error returned by a function of a Kptfile pipeline.
+type FunctionResult struct {
+	// Function is the name of the function.
+	Function string
+	// Message is the message of the error.
+	Message string
+	// Path is the slash-delimited path of the file the error is about,
+	// relative to the sync directory.
+	Path string
+}
+
+// String returns the message of the error, prefixed with the function name.
+func (r FunctionResult) String() string {
+	return fmt.Sprintf("function %q: %s", r.Function, r.Message)
+}
+
+// PipelineError represents the user actionable errors returned by the
+// functions of a Kptfile pipeline.
+type PipelineError struct {
+	ActionableError
+	// Results are the errors returned by the functions.
+	Results []FunctionResult
+}
+
+// NewPipelineError returns the wrapper of the function errors.
+func NewPipelineError(results []FunctionResult) PipelineError {
+	msgs := make([]string, len(results))
+	for i, r := range results {
+		msgs[i] = fmt.Sprintf("%s: %s", r.Path, r)
+	}
+	return PipelineError{
+		ActionableError: NewActionableError(fmt.Errorf("failed to render the Kptfile pipeline:\n%s", strings.Join(msgs, "\n"))),
+		Results:         results,
+	}
+}
+
 // HydrationErrorPayload is the payload of the hydration error in the error file.
 type HydrationErrorPayload struct {
 	// Code is the error code to indicate if it is a user error or an internal error.
 	Code string
 	// Error is the message of the hydration error.
 	Error string
+	// Results are the errors returned by the functions of a Kptfile pipeline.
+	Results []FunctionResult `json:",omitempty"`
 }
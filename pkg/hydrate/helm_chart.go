@@ -0,0 +1,70 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ChartFile is the file name of the Helm chart metadata.
+const ChartFile = "Chart.yaml"
+
+// ChartRenderer renders a Helm chart stored in the source repository.
+type ChartRenderer interface {
+	// RenderChart renders the chart in chartDir with the values files, in
+	// order of precedence, and writes the manifests to destDir.
+	RenderChart(ctx context.Context, chartDir string, valuesFiles []string, destDir string) error
+}
+
+// needsHelmChart checks if there is a Helm chart at the root of the directory.
+func needsHelmChart(dir string) (bool, error) {
+	return hasMarker(dir, ChartFile, false)
+}
+
+// chartRender renders the Helm chart in the input directory to the output
+// directory, with the values files in ChartValuesFiles.
+func (h *Hydrator) chartRender(input, output string) HydrationError {
+	if h.ChartRenderer == nil {
+		return NewInternalError(fmt.Errorf("unable to render the Helm chart in %s: no chart renderer is configured", input))
+	}
+	valuesFiles := make([]string, 0, len(h.ChartValuesFiles))
+	for _, f := range h.ChartValuesFiles {
+		valuesFile := filepath.Join(input, filepath.FromSlash(f))
+		rel, err := filepath.Rel(input, valuesFile)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return NewActionableError(fmt.Errorf("values file %q is outside of the sync directory %s", f, input))
+		}
+		if _, err := os.Stat(valuesFile); err != nil {
+			return NewActionableError(fmt.Errorf("unable to read the values file %q of the Helm chart in %s: %w", f, input, err))
+		}
+		valuesFiles = append(valuesFiles, valuesFile)
+	}
+
+	if err := os.RemoveAll(output); err != nil {
+		return NewInternalError(err)
+	}
+	if err := os.MkdirAll(output, 0755); err != nil {
+		return NewInternalError(err)
+	}
+	if err := h.ChartRenderer.RenderChart(context.Background(), input, valuesFiles, output); err != nil {
+		mustDeleteOutput(err, output)
+		return NewActionableError(fmt.Errorf("failed to render the Helm chart in %s: %w", input, err))
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/status"
+)
+
+// fakeChartRenderer records the values files and writes a manifest to the
+// destination directory.
+type fakeChartRenderer struct {
+	valuesFiles []string
+	err         error
+}
+
+func (r *fakeChartRenderer) RenderChart(_ context.Context, _ string, valuesFiles []string, destDir string) error {
+	r.valuesFiles = valuesFiles
+	if r.err != nil {
+		return r.err
+	}
+	return os.WriteFile(filepath.Join(destDir, "ns.yaml"), []byte(namespace), 0644)
+}
+
+func TestChartRender(t *testing.T) {
+	testCases := map[string]struct {
+		valuesFiles     []string
+		renderer        *fakeChartRenderer
+		wantValuesFiles []string
+		wantErr         string
+	}{
+		"values files": {
+			valuesFiles:     []string{"values.yaml", "env/prod.yaml"},
+			renderer:        &fakeChartRenderer{},
+			wantValuesFiles: []string{"values.yaml", "env/prod.yaml"},
+		},
+		"values file outside of the sync directory": {
+			valuesFiles: []string{"../values.yaml"},
+			renderer:    &fakeChartRenderer{},
+			wantErr:     `values file "../values.yaml" is outside of the sync directory`,
+		},
+		"missing values file": {
+			valuesFiles: []string{"env/dev.yaml"},
+			renderer:    &fakeChartRenderer{},
+			wantErr:     `unable to read the values file "env/dev.yaml"`,
+		},
+		"rendering error": {
+			renderer: &fakeChartRenderer{err: errors.New("template: web/templates/deploy.yaml:3: unexpected EOF")},
+			wantErr:  "failed to render the Helm chart",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			input := t.TempDir()
+			writeFiles(t, input, map[string]string{
+				ChartFile:       "apiVersion: v2\nname: web\nversion: 0.1.0\n",
+				"values.yaml":   "replicas: 1\n",
+				"env/prod.yaml": "replicas: 3\n",
+			})
+			output := filepath.Join(t.TempDir(), "rendered")
+			h := &Hydrator{ChartRenderer: tc.renderer, ChartValuesFiles: tc.valuesFiles}
+
+			r, err := h.renderer(input)
+			require.NoError(t, err)
+			require.Equal(t, Helm, r.Name())
+
+			hydrationErr := h.chartRender(input, output)
+			if tc.wantErr != "" {
+				require.Error(t, hydrationErr)
+				assert.Contains(t, hydrationErr.Error(), tc.wantErr)
+				assert.Equal(t, status.ActionableHydrationErrorCode, hydrationErr.Code())
+				_, err := os.Stat(output)
+				assert.True(t, os.IsNotExist(err), "the output should be removed")
+				return
+			}
+			require.NoError(t, hydrationErr)
+			var want []string
+			for _, f := range tc.wantValuesFiles {
+				want = append(want, filepath.Join(input, f))
+			}
+			assert.Equal(t, want, tc.renderer.valuesFiles)
+			_, err = os.Stat(filepath.Join(output, "ns.yaml"))
+			assert.NoError(t, err)
+		})
+	}
+}
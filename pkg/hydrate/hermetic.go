@@ -0,0 +1,163 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/kustomize/api/resource"
+	"sigs.k8s.io/kustomize/api/types"
+)
+
+// defaultChartHome is the directory of the Helm charts used by kustomize,
+// relative to the kustomization root, when helmGlobals.chartHome is not set.
+const defaultChartHome = "charts"
+
+// RemoteSource is a source fetched by kustomize at render time.
+type RemoteSource struct {
+	// Kustomization is the path to the kustomization file which references
+	// the remote source.
+	Kustomization string
+	// Field is the field of the kustomization which references the remote
+	// source.
+	Field string
+	// URL is the remote source, as declared in the kustomization.
+	URL string
+}
+
+// String returns the remote source with its location.
+func (r RemoteSource) String() string {
+	return fmt.Sprintf("%s: %s: %s", r.Kustomization, r.Field, r.URL)
+}
+
+// FindRemoteSources returns the remote bases and the Helm charts downloaded
+// from a repository by the kustomization in the directory, and by the local
+// bases and components it references, recursively.
+func FindRemoteSources(dir string) ([]RemoteSource, error) {
+	var result []RemoteSource
+	visited := map[string]bool{}
+	var walk func(dir string) error
+	walk = func(dir string) error {
+		if visited[dir] {
+			return nil
+		}
+		visited[dir] = true
+		kustFile, k, err := readKustomization(dir)
+		if err != nil || k == nil {
+			return err
+		}
+		refs := map[string][]string{
+			"resources":  k.Resources,
+			"components": k.Components,
+		}
+		for _, field := range []string{"resources", "components"} {
+			for _, ref := range refs[field] {
+				if isRemoteBase(ref) {
+					result = append(result, RemoteSource{Kustomization: kustFile, Field: field, URL: ref})
+					continue
+				}
+				path := filepath.Join(dir, filepath.FromSlash(ref))
+				if fi, err := os.Stat(path); err == nil && fi.IsDir() {
+					if err := walk(path); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		chartHome := defaultChartHome
+		if k.HelmGlobals != nil && k.HelmGlobals.ChartHome != "" {
+			chartHome = k.HelmGlobals.ChartHome
+		}
+		for _, chart := range k.HelmCharts {
+			if chart.Repo == "" || hasLocalChart(filepath.Join(dir, filepath.FromSlash(chartHome)), chart) {
+				continue
+			}
+			result = append(result, RemoteSource{
+				Kustomization: kustFile,
+				Field:         "helmCharts",
+				URL:           strings.TrimSuffix(chart.Repo, "/") + "/" + chart.Name,
+			})
+		}
+		return nil
+	}
+	if err := walk(filepath.Clean(dir)); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// readKustomization reads the kustomization file in the directory. It returns
+// a nil Kustomization if there is none.
+func readKustomization(dir string) (string, *types.Kustomization, error) {
+	for _, name := range validKustomizationFiles {
+		file := filepath.Join(dir, name)
+		content, err := os.ReadFile(file)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return "", nil, err
+		}
+		k := &types.Kustomization{}
+		if err := k.Unmarshal(content); err != nil {
+			return "", nil, fmt.Errorf("%s: %w", file, err)
+		}
+		k.FixKustomization()
+		return file, k, nil
+	}
+	return "", nil, nil
+}
+
+// isRemoteBase checks if the resource is fetched from a remote repository.
+func isRemoteBase(ref string) bool {
+	return (&resource.Origin{}).Append(ref).Repo != ""
+}
+
+// hasLocalChart checks if the chart has already been pulled to the chart home,
+// in which case kustomize doesn't download it.
+func hasLocalChart(chartHome string, chart types.HelmChart) bool {
+	paths := []string{filepath.Join(chartHome, chart.Name)}
+	if chart.Version != "" {
+		paths = append(paths, filepath.Join(chartHome, chart.Name+"-"+chart.Version, chart.Name))
+	}
+	for _, path := range paths {
+		if _, err := os.Stat(filepath.Join(path, ChartFile)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// validateHermetic returns an actionable error if the kustomization in the
+// directory fetches remote sources, which are not allowed in hermetic mode.
+func validateHermetic(dir string) HydrationError {
+	remoteSources, err := FindRemoteSources(dir)
+	if err != nil {
+		return NewActionableError(fmt.Errorf("unable to check the remote sources of the kustomization in %s: %w", dir, err))
+	}
+	if len(remoteSources) == 0 {
+		return nil
+	}
+	var lines []string
+	for _, r := range remoteSources {
+		lines = append(lines, r.String())
+	}
+	return NewActionableError(fmt.Errorf("remote sources are not allowed with hermetic rendering, "+
+		"vendor them in the repository with `nomos vendor` or disable spec.override.rendering.hermetic:\n%s",
+		strings.Join(lines, "\n")))
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/status"
+)
+
+func TestFindRemoteSources(t *testing.T) {
+	testCases := map[string]struct {
+		files map[string]string
+		want  []RemoteSource
+	}{
+		"local bases only": {
+			files: map[string]string{
+				"kustomization.yaml":         "resources:\n- ../base\n- ns.yaml\n",
+				"../base/kustomization.yaml": "resources:\n- deploy.yaml\n",
+			},
+		},
+		"remote bases": {
+			files: map[string]string{
+				"kustomization.yaml": "resources:\n- https://github.com/org/repo//base?ref=v1.0.0\n- ns.yaml\n" +
+					"components:\n- github.com/org/components/logging?ref=main\n",
+			},
+			want: []RemoteSource{
+				{Kustomization: "kustomization.yaml", Field: "resources", URL: "https://github.com/org/repo//base?ref=v1.0.0"},
+				{Kustomization: "kustomization.yaml", Field: "components", URL: "github.com/org/components/logging?ref=main"},
+			},
+		},
+		"remote base of a local base": {
+			files: map[string]string{
+				"kustomization.yaml":         "resources:\n- ../base\n",
+				"../base/kustomization.yaml": "bases:\n- git@github.com:org/repo.git//base?ref=v1\n",
+			},
+			want: []RemoteSource{
+				{Kustomization: "../base/kustomization.yaml", Field: "resources", URL: "git@github.com:org/repo.git//base?ref=v1"},
+			},
+		},
+		"Helm charts": {
+			files: map[string]string{
+				"kustomization.yaml": "helmCharts:\n" +
+					"- name: remote\n  repo: https://charts.example.com/\n  version: 1.0.0\n" +
+					"- name: pulled\n  repo: https://charts.example.com\n  version: 2.0.0\n" +
+					"- name: local\n",
+				"charts/pulled-2.0.0/pulled/Chart.yaml": "name: pulled\n",
+				"charts/local/Chart.yaml":               "name: local\n",
+			},
+			want: []RemoteSource{
+				{Kustomization: "kustomization.yaml", Field: "helmCharts", URL: "https://charts.example.com/remote"},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			root := t.TempDir()
+			dir := filepath.Join(root, "configs")
+			writeFiles(t, dir, tc.files)
+			got, err := FindRemoteSources(dir)
+			require.NoError(t, err)
+			var want []RemoteSource
+			for _, r := range tc.want {
+				r.Kustomization = filepath.Join(dir, r.Kustomization)
+				want = append(want, r)
+			}
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestKustomizeRendererHermetic(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"kustomization.yaml": "resources:\n- https://github.com/org/repo//base?ref=v1.0.0\n",
+	})
+	output := filepath.Join(t.TempDir(), "rendered")
+	err := kustomizeRenderer{hermetic: true}.Render(dir, output)
+	require.Error(t, err)
+	assert.Equal(t, status.ActionableHydrationErrorCode, err.Code())
+	assert.Contains(t, err.Error(), "remote sources are not allowed with hermetic rendering")
+	assert.Contains(t, err.Error(), "resources: https://github.com/org/repo//base?ref=v1.0.0")
+}
@@ -0,0 +1,491 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/kustomize/kyaml/fn/runtime/runtimeutil"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+const (
+	// Kptfile is the name of the file declaring a kpt package and its
+	// function pipeline.
+	Kptfile = "Kptfile"
+	// Kpt is the name of the renderer of the Kptfile function pipelines.
+	Kpt = "kpt"
+	// DefaultKptFunctionsDir is the directory of the exec function binaries
+	// shipped in the hydration-controller image. Only these binaries are run.
+	DefaultKptFunctionsDir = "/kpt-functions"
+
+	wasmExtension = ".wasm"
+	kptfileKind   = "Kptfile"
+
+	resultSeverityError = "error"
+)
+
+// kptfile is the subset of the kpt.dev/v1 Kptfile used to render a package.
+type kptfile struct {
+	APIVersion string   `yaml:"apiVersion,omitempty"`
+	Kind       string   `yaml:"kind,omitempty"`
+	Pipeline   pipeline `yaml:"pipeline,omitempty"`
+}
+
+// pipeline is the list of functions which render a kpt package.
+type pipeline struct {
+	// Mutators are run in order, each receiving the output of the previous one.
+	Mutators []function `yaml:"mutators,omitempty"`
+	// Validators are run in order after the mutators, and must not change the
+	// resources.
+	Validators []function `yaml:"validators,omitempty"`
+}
+
+// function is a KRM function of a Kptfile pipeline.
+type function struct {
+	Name       string            `yaml:"name,omitempty"`
+	Image      string            `yaml:"image,omitempty"`
+	Exec       string            `yaml:"exec,omitempty"`
+	ConfigPath string            `yaml:"configPath,omitempty"`
+	ConfigMap  map[string]string `yaml:"configMap,omitempty"`
+	Selectors  []interface{}     `yaml:"selectors,omitempty"`
+	Exclude    []interface{}     `yaml:"exclude,omitempty"`
+}
+
+// String returns the name of the function, or its exec path or image when it
+// is not named.
+func (f function) String() string {
+	switch {
+	case f.Name != "":
+		return f.Name
+	case f.Exec != "":
+		return f.Exec
+	default:
+		return f.Image
+	}
+}
+
+// functionResult is a result of the ResourceList returned by a function.
+type functionResult struct {
+	Message     string `yaml:"message,omitempty"`
+	Severity    string `yaml:"severity,omitempty"`
+	ResourceRef *struct {
+		APIVersion string `yaml:"apiVersion,omitempty"`
+		Kind       string `yaml:"kind,omitempty"`
+		Name       string `yaml:"name,omitempty"`
+		Namespace  string `yaml:"namespace,omitempty"`
+	} `yaml:"resourceRef,omitempty"`
+	Field *struct {
+		Path string `yaml:"path,omitempty"`
+	} `yaml:"field,omitempty"`
+	File *struct {
+		Path string `yaml:"path,omitempty"`
+	} `yaml:"file,omitempty"`
+}
+
+// HasKptPipeline checks if the file is a Kptfile which declares a function
+// pipeline. A Kptfile which cannot be parsed is considered to declare one, so
+// that the error is reported by the rendering process.
+func HasKptPipeline(file string) bool {
+	if filepath.Base(file) != Kptfile {
+		return false
+	}
+	kf, err := readKptfile(file)
+	if err != nil {
+		return true
+	}
+	return len(kf.Pipeline.Mutators) > 0 || len(kf.Pipeline.Validators) > 0
+}
+
+// needsKptPipeline checks if there is a Kptfile with a function pipeline
+// under the directory or any of its subdirectories.
+func needsKptPipeline(dir string) (bool, error) {
+	packages, err := kptPackages(dir)
+	return len(packages) > 0, err
+}
+
+// kptPackages returns the slash-delimited paths, relative to dir, of the
+// directories with a Kptfile declaring a function pipeline. The subpackages
+// come before their parent package, as they are rendered first.
+func kptPackages(dir string) ([]string, error) {
+	var packages []string
+	err := filepath.WalkDir(dir, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !HasKptPipeline(p) {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, filepath.Dir(p))
+		if err != nil {
+			return err
+		}
+		packages = append(packages, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to traverse the directory: %s: %w", dir, err)
+	}
+	sort.SliceStable(packages, func(i, j int) bool {
+		return packageDepth(packages[i]) > packageDepth(packages[j])
+	})
+	return packages, nil
+}
+
+func packageDepth(pkg string) int {
+	if pkg == "." {
+		return 0
+	}
+	return strings.Count(pkg, "/") + 1
+}
+
+// readKptfile parses the Kptfile.
+func readKptfile(file string) (*kptfile, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	kf := &kptfile{}
+	if err := yaml.Unmarshal(content, kf); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", file, err)
+	}
+	if kf.Kind != kptfileKind {
+		return nil, fmt.Errorf("unable to parse %s: expected kind %s, got %q", file, kptfileKind, kf.Kind)
+	}
+	return kf, nil
+}
+
+// kptRender runs the function pipelines of the kpt packages under input, and
+// writes the rendered configs to output.
+func (r kptRenderer) kptRender(input, output string) HydrationError {
+	if _, err := os.Stat(output); err == nil {
+		mustDeleteOutput(err, output)
+	}
+	if err := os.MkdirAll(output, os.FileMode(0755)); err != nil {
+		return NewInternalError(fmt.Errorf("unable to make directory: %s: %w", output, err))
+	}
+
+	if hydrationErr := r.runKptPipelines(input, output); hydrationErr != nil {
+		mustDeleteOutput(hydrationErr, output)
+		return hydrationErr
+	}
+	return nil
+}
+
+// runKptPipelines renders the kpt packages under input, subpackages first.
+// Each pipeline only receives the resources of its package, including the
+// already rendered resources of its subpackages.
+func (r kptRenderer) runKptPipelines(input, output string) HydrationError {
+	packages, err := kptPackages(input)
+	if err != nil {
+		return NewInternalError(err)
+	}
+	nodes, err := kio.LocalPackageReader{
+		PackagePath:    input,
+		MatchFilesGlob: kio.DefaultMatch,
+	}.Read()
+	if err != nil {
+		return NewActionableError(fmt.Errorf("unable to read the configs in %s: %w", input, err))
+	}
+
+	for _, pkg := range packages {
+		kf, err := readKptfile(filepath.Join(input, filepath.FromSlash(pkg), Kptfile))
+		if err != nil {
+			return NewActionableError(err)
+		}
+		var inPackage, outOfPackage []*yaml.RNode
+		for _, node := range nodes {
+			if inKptPackage(pkg, nodePath(node)) {
+				inPackage = append(inPackage, node)
+			} else {
+				outOfPackage = append(outOfPackage, node)
+			}
+		}
+		p := &pipelineRunner{
+			input:        input,
+			pkg:          pkg,
+			pipeline:     kf.Pipeline,
+			allowExec:    r.allowExec,
+			functionsDir: r.functionsDir,
+		}
+		rendered, hydrationErr := p.run(inPackage)
+		if hydrationErr != nil {
+			return hydrationErr
+		}
+		nodes = append(outOfPackage, rendered...)
+	}
+
+	if err := (kio.LocalPackageWriter{PackagePath: output}).Write(nodes); err != nil {
+		return NewActionableError(fmt.Errorf("unable to write the rendered configs to %s: %w", output, err))
+	}
+	return nil
+}
+
+// inKptPackage checks if the slash-delimited path is under the package.
+func inKptPackage(pkg, p string) bool {
+	return pkg == "." || strings.HasPrefix(p, pkg+"/")
+}
+
+// nodePath returns the path of the file the resource was read from, relative
+// to the sync directory.
+func nodePath(node *yaml.RNode) string {
+	p, _, err := kioutil.GetFileAnnotations(node)
+	if err != nil {
+		return ""
+	}
+	return p
+}
+
+// pipelineRunner runs the function pipeline of a kpt package.
+type pipelineRunner struct {
+	// input is the absolute path of the sync directory.
+	input string
+	// pkg is the slash-delimited path of the package, relative to input.
+	pkg      string
+	pipeline pipeline
+	// allowExec enables the exec functions.
+	allowExec bool
+	// functionsDir is the directory of the exec function binaries which are
+	// allowed to run.
+	functionsDir string
+}
+
+// run runs the mutators then the validators on the resources of the package,
+// and returns the mutated resources. It stops at the first function which
+// fails or returns a result with the error severity.
+func (p *pipelineRunner) run(nodes []*yaml.RNode) ([]*yaml.RNode, HydrationError) {
+	var err HydrationError
+	for _, fn := range p.pipeline.Mutators {
+		nodes, err = p.runFunction(fn, nodes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, fn := range p.pipeline.Validators {
+		// Validators run on copies, so that their output is discarded.
+		copies := make([]*yaml.RNode, len(nodes))
+		for i, node := range nodes {
+			copies[i] = node.Copy()
+		}
+		if _, err = p.runFunction(fn, copies); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// runFunction runs the function with the ResourceList of the resources.
+func (p *pipelineRunner) runFunction(fn function, nodes []*yaml.RNode) ([]*yaml.RNode, HydrationError) {
+	kptfilePath := path.Join(p.pkg, Kptfile)
+	fail := func(err error) HydrationError {
+		return NewPipelineError([]FunctionResult{{Function: fn.String(), Message: err.Error(), Path: kptfilePath}})
+	}
+	if len(fn.Selectors) > 0 || len(fn.Exclude) > 0 {
+		return nil, fail(fmt.Errorf("function selectors and exclusions are not supported"))
+	}
+	run, err := p.functionRun(fn)
+	if err != nil {
+		return nil, fail(err)
+	}
+	config, err := p.functionConfig(fn, nodes)
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	filter := &runtimeutil.FunctionFilter{
+		Run:            run,
+		FunctionConfig: config,
+		GlobalScope:    true,
+		DeferFailure:   true,
+	}
+	output, err := filter.Filter(nodes)
+	if err != nil {
+		return nil, fail(fmt.Errorf("unable to read the function output: %w", err))
+	}
+
+	results, err := parseFunctionResults(filter.Results)
+	if err != nil {
+		return nil, fail(err)
+	}
+	var errs []FunctionResult
+	for _, result := range results {
+		resultPath := p.resultPath(result, nodes)
+		if result.Severity != resultSeverityError {
+			klog.Infof("kpt function %s returned a result with severity %q for %s: %s", fn, result.Severity, resultPath, result.Message)
+			continue
+		}
+		message := result.Message
+		if result.Field != nil && result.Field.Path != "" {
+			message = fmt.Sprintf("%s (field: %s)", message, result.Field.Path)
+		}
+		errs = append(errs, FunctionResult{Function: fn.String(), Message: message, Path: resultPath})
+	}
+	if exit := filter.GetExit(); exit != nil && len(errs) == 0 {
+		// The function failed without explaining why in its results.
+		errs = append(errs, FunctionResult{Function: fn.String(), Message: exit.Error(), Path: kptfilePath})
+	}
+	if len(errs) > 0 {
+		return nil, NewPipelineError(errs)
+	}
+	return output, nil
+}
+
+// functionRun returns the implementation of the function, which executes its
+// exec binary or runs its WASM module. Container images are not supported, as
+// there is no container runtime in the hydration-controller.
+func (p *pipelineRunner) functionRun(fn function) (func(io.Reader, io.Writer) error, error) {
+	args := strings.Fields(fn.Exec)
+	if len(args) == 0 {
+		if fn.Image != "" {
+			return nil, fmt.Errorf("functions running a container image are not supported, specify the exec path of the function instead")
+		}
+		return nil, fmt.Errorf("the exec path of the function must be specified")
+	}
+	if strings.HasSuffix(args[0], wasmExtension) {
+		return p.wasmRun(fn, args[0], args[1:])
+	}
+	if !p.allowExec {
+		return nil, fmt.Errorf("exec functions are disabled, set spec.override.rendering.allowExecFunctions to true to run them")
+	}
+	name, err := p.execPath(args[0])
+	if err != nil {
+		return nil, err
+	}
+	args = args[1:]
+	pkgDir := filepath.Join(p.input, filepath.FromSlash(p.pkg))
+	return func(r io.Reader, w io.Writer) error {
+		var stderr bytes.Buffer
+		cmd := exec.Command(name, args...)
+		cmd.Dir = pkgDir
+		cmd.Stdin = r
+		cmd.Stdout = w
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("failed to run %s: %w, stderr: %s", fn.Exec, err, strings.TrimSpace(stderr.String()))
+		}
+		return nil
+	}, nil
+}
+
+// execPath returns the path of the binary of an exec function. Only the
+// binaries in the functions directory are allowed, so the exec path must be
+// either the name of one of them or its absolute path. The binaries of the
+// repository are never run.
+func (p *pipelineRunner) execPath(exec string) (string, error) {
+	name := exec
+	if filepath.IsAbs(exec) {
+		if filepath.Dir(filepath.Clean(exec)) != filepath.Clean(p.functionsDir) {
+			return "", fmt.Errorf("the exec function %s is not in the functions directory %s of the hydration-controller image", exec, p.functionsDir)
+		}
+		name = filepath.Base(exec)
+	} else if strings.ContainsRune(exec, '/') || exec == "." || exec == ".." {
+		return "", fmt.Errorf("the exec function %s must be a binary of the functions directory %s of the hydration-controller image, binaries of the repository are not run", exec, p.functionsDir)
+	}
+	execPath := filepath.Join(p.functionsDir, name)
+	fi, err := os.Stat(execPath)
+	if err != nil || !fi.Mode().IsRegular() {
+		return "", fmt.Errorf("the exec function %s is not found in the functions directory %s of the hydration-controller image", exec, p.functionsDir)
+	}
+	return execPath, nil
+}
+
+// functionConfig returns the functionConfig of the ResourceList, either the
+// resource at configPath in the package, or a ConfigMap with the configMap
+// data.
+func (p *pipelineRunner) functionConfig(fn function, nodes []*yaml.RNode) (*yaml.RNode, error) {
+	switch {
+	case fn.ConfigPath != "" && len(fn.ConfigMap) > 0:
+		return nil, fmt.Errorf("only one of configPath and configMap can be specified")
+	case fn.ConfigPath != "":
+		configPath := path.Join(p.pkg, fn.ConfigPath)
+		for _, node := range nodes {
+			if nodePath(node) == configPath {
+				return node, nil
+			}
+		}
+		return nil, fmt.Errorf("the function config %s is not found in the package", fn.ConfigPath)
+	case len(fn.ConfigMap) > 0:
+		config, err := yaml.FromMap(map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name": "function-input",
+				"annotations": map[string]interface{}{
+					"config.kubernetes.io/local-config": "true",
+				},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		config.SetDataMap(fn.ConfigMap)
+		return config, nil
+	default:
+		return nil, nil
+	}
+}
+
+// resultPath returns the path of the file the result is about, relative to
+// the sync directory. It defaults to the Kptfile of the package.
+func (p *pipelineRunner) resultPath(result functionResult, nodes []*yaml.RNode) string {
+	if result.File != nil && result.File.Path != "" {
+		return path.Clean(result.File.Path)
+	}
+	if ref := result.ResourceRef; ref != nil {
+		for _, node := range nodes {
+			if node.GetApiVersion() == ref.APIVersion && node.GetKind() == ref.Kind &&
+				node.GetName() == ref.Name && node.GetNamespace() == ref.Namespace {
+				if p := nodePath(node); p != "" {
+					return p
+				}
+			}
+		}
+	}
+	return path.Join(p.pkg, Kptfile)
+}
+
+// parseFunctionResults parses the results of the ResourceList returned by a
+// function.
+func parseFunctionResults(results *yaml.RNode) ([]functionResult, error) {
+	if results.IsNilOrEmpty() {
+		return nil, nil
+	}
+	content, err := results.String()
+	if err != nil {
+		return nil, err
+	}
+	var parsed []functionResult
+	if err := yaml.Unmarshal([]byte(content), &parsed); err != nil {
+		return nil, fmt.Errorf("unable to parse the function results: %w", err)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,425 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// setEnvFn is an exec function which sets the env label from its
+	// functionConfig on the resources.
+	setEnvFn = `#!/bin/sh
+input=$(cat)
+env=$(echo "${input}" | sed -n 's/^ *env: *//p' | tail -n 1)
+echo "${input}" | sed -e "s/^\( *\)app: web$/\1app: web\n\1env: ${env}/"
+`
+	// rejectFn is an exec function which rejects the Deployment.
+	rejectFn = `#!/bin/sh
+cat >/dev/null
+cat <<EOF
+apiVersion: config.kubernetes.io/v1
+kind: ResourceList
+items: []
+results:
+- message: too many replicas
+  severity: error
+  resourceRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: web
+  field:
+    path: spec.replicas
+- message: consider adding resource limits
+  severity: warning
+  file:
+    path: app/deploy.yaml
+EOF
+exit 1
+`
+	// crashFn is an exec function which fails without any result.
+	crashFn = `#!/bin/sh
+echo "unable to reach the policy server" >&2
+exit 2
+`
+
+	deployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    app: web
+spec:
+  replicas: 5
+`
+	namespace = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: web
+  labels:
+    app: web
+`
+)
+
+// buildWasm compiles the WASM function of the testdata directory, and returns
+// an empty module when the go tool is not available.
+func buildWasm(t *testing.T, name string) string {
+	t.Helper()
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		return ""
+	}
+	module := filepath.Join(t.TempDir(), name+wasmExtension)
+	cmd := exec.Command(goTool, "build", "-o", module, ".")
+	cmd.Dir = filepath.Join("testdata", "wasm", name)
+	cmd.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm", "CGO_ENABLED=0")
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	wasm, err := os.ReadFile(module)
+	require.NoError(t, err)
+	return string(wasm)
+}
+
+func TestKptRender(t *testing.T) {
+	setEnvWasm := buildWasm(t, "set-env")
+	// The functions directory of the image.
+	functionsDir := t.TempDir()
+	writeFiles(t, functionsDir, map[string]string{
+		"set-env.sh":   setEnvFn,
+		"reject.sh":    rejectFn,
+		"crash.sh":     crashFn,
+		"set-env.wasm": setEnvWasm,
+	})
+	for _, fn := range []string{"set-env.sh", "reject.sh", "crash.sh"} {
+		require.NoError(t, os.Chmod(filepath.Join(functionsDir, fn), 0755))
+	}
+	testCases := map[string]struct {
+		files        map[string]string
+		disallowExec bool
+		wasm         bool
+		want         map[string]string
+		dontWant     map[string]string
+		wantErr      string
+		wantPaths    []string
+	}{
+		"mutator with configMap": {
+			files: map[string]string{
+				"Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: root\n" +
+					"pipeline:\n  mutators:\n  - exec: set-env.sh\n    configMap:\n      env: prod\n",
+				"app/deploy.yaml": deployment,
+				"ns.yaml":         namespace,
+			},
+			want: map[string]string{
+				"app/deploy.yaml": "env: prod",
+				"ns.yaml":         "env: prod",
+			},
+		},
+		"subpackage with configPath": {
+			files: map[string]string{
+				"app/Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: app\n" +
+					"pipeline:\n  mutators:\n  - exec: set-env.sh\n    configPath: env.yaml\n",
+				"app/env.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: env\n" +
+					"  annotations:\n    config.kubernetes.io/local-config: \"true\"\ndata:\n  env: dev\n",
+				"app/deploy.yaml": deployment,
+				"ns.yaml":         namespace,
+			},
+			want: map[string]string{
+				"app/deploy.yaml": "env: dev",
+			},
+			dontWant: map[string]string{
+				"ns.yaml": "env:",
+			},
+		},
+		"subpackages are rendered before the validators of their parent": {
+			files: map[string]string{
+				"Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: root\n" +
+					"pipeline:\n  validators:\n  - exec: reject.sh\n    name: reject\n",
+				"app/Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: app\n" +
+					"pipeline:\n  mutators:\n  - exec: set-env.sh\n    configPath: env.yaml\n",
+				"app/env.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: env\n" +
+					"  annotations:\n    config.kubernetes.io/local-config: \"true\"\ndata:\n  env: dev\n",
+				"app/deploy.yaml": deployment,
+				"ns.yaml":         namespace,
+			},
+			wantErr:   `function "reject": too many replicas (field: spec.replicas)`,
+			wantPaths: []string{"app/deploy.yaml"},
+		},
+		"validator result without path": {
+			files: map[string]string{
+				"Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: root\n" +
+					"pipeline:\n  validators:\n  - exec: crash.sh\n",
+				"ns.yaml": namespace,
+			},
+			wantErr:   "unable to reach the policy server",
+			wantPaths: []string{"Kptfile"},
+		},
+		"exec functions are disabled": {
+			files: map[string]string{
+				"Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: root\n" +
+					"pipeline:\n  mutators:\n  - exec: set-env.sh\n",
+				"ns.yaml": namespace,
+			},
+			disallowExec: true,
+			wantErr:      "exec functions are disabled",
+			wantPaths:    []string{"Kptfile"},
+		},
+		"binary of the repository": {
+			files: map[string]string{
+				"Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: root\n" +
+					"pipeline:\n  mutators:\n  - exec: ./fn/set-env.sh\n",
+				"fn/set-env.sh": setEnvFn,
+				"ns.yaml":       namespace,
+			},
+			wantErr:   "binaries of the repository are not run",
+			wantPaths: []string{"Kptfile"},
+		},
+		"absolute path outside of the functions directory": {
+			files: map[string]string{
+				"Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: root\n" +
+					"pipeline:\n  mutators:\n  - exec: /bin/sh\n",
+				"ns.yaml": namespace,
+			},
+			wantErr:   "is not in the functions directory",
+			wantPaths: []string{"Kptfile"},
+		},
+		"function not shipped in the image": {
+			files: map[string]string{
+				"Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: root\n" +
+					"pipeline:\n  mutators:\n  - exec: set-labels\n",
+				"ns.yaml": namespace,
+			},
+			wantErr:   "the exec function set-labels is not found in the functions directory",
+			wantPaths: []string{"Kptfile"},
+		},
+		"WASM function of the repository": {
+			files: map[string]string{
+				"app/Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: app\n" +
+					"pipeline:\n  mutators:\n  - exec: ../fn/set-env.wasm\n    configMap:\n      env: prod\n",
+				"fn/set-env.wasm": setEnvWasm,
+				"app/deploy.yaml": deployment,
+				"ns.yaml":         namespace,
+			},
+			disallowExec: true,
+			wasm:         true,
+			want: map[string]string{
+				"app/deploy.yaml": "env: prod",
+			},
+			dontWant: map[string]string{
+				"ns.yaml": "env: prod",
+			},
+		},
+		"WASM function of the image": {
+			files: map[string]string{
+				"Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: root\n" +
+					"pipeline:\n  mutators:\n  - exec: " + filepath.Join(functionsDir, "set-env.wasm") + "\n    configMap:\n      env: dev\n",
+				"ns.yaml": namespace,
+			},
+			disallowExec: true,
+			wasm:         true,
+			want: map[string]string{
+				"ns.yaml": "env: dev",
+			},
+		},
+		"WASM function outside of the source directory": {
+			files: map[string]string{
+				"Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: root\n" +
+					"pipeline:\n  mutators:\n  - exec: ../set-env.wasm\n",
+				"ns.yaml": namespace,
+			},
+			wantErr:   "is not found in the package",
+			wantPaths: []string{"Kptfile"},
+		},
+		"WASM function which is not a module": {
+			files: map[string]string{
+				"Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: root\n" +
+					"pipeline:\n  mutators:\n  - exec: set-env.wasm\n",
+				"set-env.wasm": setEnvFn,
+				"ns.yaml":      namespace,
+			},
+			wantErr:   "failed to run set-env.wasm",
+			wantPaths: []string{"Kptfile"},
+		},
+		"container image": {
+			files: map[string]string{
+				"Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: root\n" +
+					"pipeline:\n  mutators:\n  - image: gcr.io/kpt-fn/set-labels:v0.2\n",
+				"ns.yaml": namespace,
+			},
+			wantErr:   "functions running a container image are not supported",
+			wantPaths: []string{"Kptfile"},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if tc.wasm && setEnvWasm == "" {
+				t.Skip("the go tool is required to build the WASM function")
+			}
+			input := t.TempDir()
+			writeFiles(t, input, tc.files)
+			for p := range tc.files {
+				if filepath.Ext(p) == ".sh" {
+					require.NoError(t, os.Chmod(filepath.Join(input, p), 0755))
+				}
+			}
+			output := filepath.Join(t.TempDir(), "rendered")
+
+			found, err := needsKptPipeline(input)
+			require.NoError(t, err)
+			require.True(t, found)
+
+			r := kptRenderer{allowExec: !tc.disallowExec, functionsDir: functionsDir}
+			hydrationErr := r.Render(input, output)
+			if tc.wantErr != "" {
+				require.Error(t, hydrationErr)
+				assert.Contains(t, hydrationErr.Error(), tc.wantErr)
+				pipelineErr, ok := hydrationErr.(PipelineError)
+				require.True(t, ok, "expected a PipelineError, got %T", hydrationErr)
+				var paths []string
+				for _, r := range pipelineErr.Results {
+					paths = append(paths, r.Path)
+				}
+				assert.Equal(t, tc.wantPaths, paths)
+				_, err := os.Stat(output)
+				assert.True(t, os.IsNotExist(err), "the output should be removed")
+				return
+			}
+			require.NoError(t, hydrationErr)
+			for p, want := range tc.want {
+				content, err := os.ReadFile(filepath.Join(output, p))
+				require.NoError(t, err)
+				assert.Contains(t, string(content), want)
+				assert.NotContains(t, string(content), "config.kubernetes.io/")
+			}
+			for p, dontWant := range tc.dontWant {
+				content, err := os.ReadFile(filepath.Join(output, p))
+				require.NoError(t, err)
+				assert.NotContains(t, string(content), dontWant)
+			}
+		})
+	}
+}
+
+func TestExecPath(t *testing.T) {
+	functionsDir := t.TempDir()
+	writeFiles(t, functionsDir, map[string]string{"set-labels": "#!/bin/sh\n"})
+	require.NoError(t, os.Mkdir(filepath.Join(functionsDir, "lib"), 0755))
+	p := &pipelineRunner{input: t.TempDir(), pkg: ".", allowExec: true, functionsDir: functionsDir}
+
+	testCases := map[string]struct {
+		exec    string
+		want    string
+		wantErr string
+	}{
+		"name": {
+			exec: "set-labels",
+			want: filepath.Join(functionsDir, "set-labels"),
+		},
+		"absolute path": {
+			exec: filepath.Join(functionsDir, "set-labels"),
+			want: filepath.Join(functionsDir, "set-labels"),
+		},
+		"absolute path escaping the functions directory": {
+			exec:    filepath.Join(functionsDir, "..", "set-labels"),
+			wantErr: "is not in the functions directory",
+		},
+		"relative path": {
+			exec:    "../set-labels",
+			wantErr: "binaries of the repository are not run",
+		},
+		"directory": {
+			exec:    "lib",
+			wantErr: "is not found in the functions directory",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := p.execPath(tc.exec)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestWasmPath(t *testing.T) {
+	functionsDir := t.TempDir()
+	outside := t.TempDir()
+	input := filepath.Join(t.TempDir(), "repo")
+	writeFiles(t, outside, map[string]string{"fn.wasm": ""})
+	writeFiles(t, input, map[string]string{"fn/set-env.wasm": "", "app/Kptfile": ""})
+	require.NoError(t, os.Symlink(filepath.Join(outside, "fn.wasm"), filepath.Join(input, "fn", "link.wasm")))
+	p := &pipelineRunner{input: input, pkg: "app", functionsDir: functionsDir}
+
+	testCases := map[string]struct {
+		module  string
+		want    string
+		wantErr string
+	}{
+		"relative path": {
+			module: "../fn/set-env.wasm",
+			want:   filepath.Join(input, "fn", "set-env.wasm"),
+		},
+		"absolute path": {
+			module: filepath.Join(functionsDir, "set-env.wasm"),
+			want:   filepath.Join(functionsDir, "set-env.wasm"),
+		},
+		"absolute path outside of the functions directory": {
+			module:  filepath.Join(outside, "fn.wasm"),
+			wantErr: "is not in the functions directory",
+		},
+		"relative path escaping the source directory": {
+			module:  filepath.Join("..", "..", "..", filepath.Base(outside), "fn.wasm"),
+			wantErr: "is outside of the source directory",
+		},
+		"symlink escaping the source directory": {
+			module:  "../fn/link.wasm",
+			wantErr: "is outside of the source directory",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := p.wasmPath(tc.module)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestHasKptPipeline(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"pipeline/Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\npipeline:\n  validators:\n  - exec: ./validate\n",
+		"package/Kptfile":  "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: package\n",
+		"invalid/Kptfile":  "kind: [",
+		"ns.yaml":          namespace,
+	})
+	assert.True(t, HasKptPipeline(filepath.Join(dir, "pipeline", "Kptfile")))
+	assert.False(t, HasKptPipeline(filepath.Join(dir, "package", "Kptfile")))
+	assert.True(t, HasKptPipeline(filepath.Join(dir, "invalid", "Kptfile")))
+	assert.False(t, HasKptPipeline(filepath.Join(dir, "ns.yaml")))
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"github.com/tetratelabs/wazero/sys"
+)
+
+const (
+	// wasmFunctionTimeout is how long a WASM function may run.
+	wasmFunctionTimeout = 5 * time.Minute
+	// wasmMemoryLimitPages limits the memory of a WASM function to 512MiB, in
+	// pages of 64KiB.
+	wasmMemoryLimitPages = 8192
+)
+
+// wasmPath returns the path of the module of a WASM function. The module is
+// either in the functions directory, when the path is absolute, or in the
+// repository, relative to the package. Modules of the repository must not be
+// outside of the source directory, once the symlinks are evaluated.
+func (p *pipelineRunner) wasmPath(module string) (string, error) {
+	if filepath.IsAbs(module) {
+		if filepath.Dir(filepath.Clean(module)) != filepath.Clean(p.functionsDir) {
+			return "", fmt.Errorf("the WASM function %s is not in the functions directory %s of the hydration-controller image", module, p.functionsDir)
+		}
+		return module, nil
+	}
+	modulePath := filepath.Join(p.input, filepath.FromSlash(p.pkg), module)
+	if _, err := os.Stat(modulePath); err != nil {
+		return "", fmt.Errorf("the WASM function %s is not found in the package %s", module, p.pkg)
+	}
+	modulePath, err := resolveInRoot(p.input, modulePath)
+	if err != nil {
+		return "", fmt.Errorf("the WASM function %s is invalid: %w", module, err)
+	}
+	return modulePath, nil
+}
+
+// wasmRun returns the implementation of a WASM function, which runs the WASI
+// module with the ResourceList on stdin and stdout, like an exec function.
+// The module runs in a sandbox, without access to the filesystem, the network
+// or the environment, and with limited memory and time, so modules of the
+// repository are run, unlike exec functions.
+func (p *pipelineRunner) wasmRun(fn function, module string, args []string) (func(io.Reader, io.Writer) error, error) {
+	modulePath, err := p.wasmPath(module)
+	if err != nil {
+		return nil, err
+	}
+	wasm, err := os.ReadFile(modulePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the WASM function %s: %w", module, err)
+	}
+	return func(r io.Reader, w io.Writer) error {
+		ctx, cancel := context.WithTimeout(context.Background(), wasmFunctionTimeout)
+		defer cancel()
+		runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+			WithMemoryLimitPages(wasmMemoryLimitPages).
+			WithCloseOnContextDone(true))
+		defer func() {
+			_ = runtime.Close(ctx)
+		}()
+		if _, err := wasi_snapshot_preview1.Instantiate(ctx, runtime); err != nil {
+			return fmt.Errorf("failed to instantiate WASI for %s: %w", fn.Exec, err)
+		}
+		var stderr bytes.Buffer
+		config := wazero.NewModuleConfig().
+			WithName(filepath.Base(module)).
+			WithArgs(append([]string{filepath.Base(module)}, args...)...).
+			WithStdin(r).
+			WithStdout(w).
+			WithStderr(&stderr)
+		_, err := runtime.InstantiateWithConfig(ctx, wasm, config)
+		var exitErr *sys.ExitError
+		if err == nil || (errors.As(err, &exitErr) && exitErr.ExitCode() == 0) {
+			return nil
+		}
+		return fmt.Errorf("failed to run %s: %w, stderr: %s", fn.Exec, err, strings.TrimSpace(stderr.String()))
+	}, nil
+}
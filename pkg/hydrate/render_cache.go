@@ -0,0 +1,297 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// RenderHashFile is the file name of the hash of the rendering inputs of
+	// the hydrated configs linked under the hydrated root.
+	RenderHashFile = "render-hash"
+	// RenderCacheHitFile is the file name of the commit whose hydrated configs
+	// were reused from the previous commit, because the rendering inputs were
+	// unchanged.
+	RenderCacheHitFile = "render-cache-hit"
+)
+
+// jsonnetImportRegex matches the paths imported by a Jsonnet file.
+var jsonnetImportRegex = regexp.MustCompile(`\b(?:import|importstr|importbin)\s+["']([^"']+)["']`)
+
+// renderInputs collects the local files the rendering of a sync directory
+// depends on: the sync directory itself, and the files and directories
+// referenced from the Kustomization config files, Kptfiles and Jsonnet files
+// it contains, recursively. References outside of the source root are ignored.
+type renderInputs struct {
+	sourceRoot string
+	// files maps the paths of the input files relative to sourceRoot to their
+	// absolute paths.
+	files map[string]string
+	// visited are the absolute paths of the files and directories already
+	// collected.
+	visited map[string]bool
+	// remote is true if a Kustomization config file references a remote
+	// base or Helm chart, which is fetched at render time.
+	remote bool
+}
+
+// add collects the file, or the files under the directory.
+func (ri *renderInputs) add(path string) error {
+	if ri.visited[path] {
+		return nil
+	}
+	rel, err := filepath.Rel(ri.sourceRoot, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil
+	}
+	return filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ri.visited[p] {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		ri.visited[p] = true
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(ri.sourceRoot, p)
+		if err != nil {
+			return err
+		}
+		ri.files[filepath.ToSlash(rel)] = p
+		return ri.addReferences(p)
+	})
+}
+
+// addReferences collects the local paths referenced from the file.
+func (ri *renderInputs) addReferences(file string) error {
+	name := filepath.Base(file)
+	var refs []string
+	switch {
+	case HasKustomization(name) || name == Kptfile:
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		var value interface{}
+		if err := yaml.Unmarshal(content, &value); err != nil {
+			// The renderer reports the invalid file.
+			return nil
+		}
+		refs = stringValues(value, nil)
+	case strings.HasSuffix(name, ".jsonnet") || strings.HasSuffix(name, ".libsonnet"):
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		for _, m := range jsonnetImportRegex.FindAllStringSubmatch(string(content), -1) {
+			refs = append(refs, m[1])
+		}
+	}
+	dir := filepath.Dir(file)
+	for _, ref := range refs {
+		if isRemoteRef(ref) {
+			if HasKustomization(name) {
+				ri.remote = true
+			}
+			continue
+		}
+		if filepath.IsAbs(ref) {
+			continue
+		}
+		// Generator files may be specified as `key=path`.
+		if i := strings.Index(ref, "="); i >= 0 {
+			ref = ref[i+1:]
+		}
+		path := filepath.Join(dir, filepath.FromSlash(ref))
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		if err := ri.add(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stringValues appends the string scalars in the value to values.
+func stringValues(value interface{}, values []string) []string {
+	switch v := value.(type) {
+	case string:
+		values = append(values, v)
+	case []interface{}:
+		for _, item := range v {
+			values = stringValues(item, values)
+		}
+	case map[string]interface{}:
+		for _, item := range v {
+			values = stringValues(item, values)
+		}
+	}
+	return values
+}
+
+// renderHash returns a hash of the content of the rendering inputs of the sync
+// directory, under the source root, and of the extra inputs. It returns an
+// empty hash, which bypasses the render cache, if the rendering fetches remote
+// sources, as their content is unknown until they are fetched.
+func (h *Hydrator) renderHash(sourceRoot, syncPath string) (string, error) {
+	// The sync directory is part of the hash, so that the same configs in
+	// another directory are rendered again.
+	syncRel, err := filepath.Rel(sourceRoot, syncPath)
+	if err != nil {
+		return "", err
+	}
+	if syncRel == ".." || strings.HasPrefix(syncRel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("the sync directory %s is outside of the source directory %s", syncPath, sourceRoot)
+	}
+	ri := &renderInputs{
+		sourceRoot: sourceRoot,
+		files:      map[string]string{},
+		visited:    map[string]bool{},
+	}
+	if err := ri.add(syncPath); err != nil {
+		return "", err
+	}
+	if ri.remote {
+		klog.V(3).Infof("the render cache is bypassed for %s: the rendering fetches remote sources", syncPath)
+		return "", nil
+	}
+	rels := make([]string, 0, len(ri.files))
+	for rel := range ri.files {
+		rels = append(rels, rel)
+	}
+	sort.Strings(rels)
+	hash := sha256.New()
+	write := func(name, path string) error {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(hash, "%s\x00%d\x00", name, len(content))
+		hash.Write(content)
+		return nil
+	}
+	fmt.Fprintf(hash, "%s\x00", filepath.ToSlash(syncRel))
+	for _, rel := range rels {
+		if err := write(rel, ri.files[rel]); err != nil {
+			return "", err
+		}
+	}
+	for _, path := range h.ExtraInputs {
+		if err := write(path, path); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// cachedHydratedDir returns the linked hydrated directory if it was rendered
+// from inputs with the same hash, otherwise an empty string.
+func (h *Hydrator) cachedHydratedDir(hash string) string {
+	if hash == "" {
+		return ""
+	}
+	prevHash, err := os.ReadFile(filepath.Join(h.HydratedRoot.OSPath(), RenderHashFile))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			klog.Warningf("unable to read the render hash file: %v", err)
+		}
+		return ""
+	}
+	if string(prevHash) != hash {
+		return ""
+	}
+	dir, err := filepath.EvalSymlinks(filepath.Join(h.HydratedRoot.OSPath(), h.HydratedLink))
+	if err != nil {
+		return ""
+	}
+	return dir
+}
+
+// updateRenderCache records the hash of the rendering inputs of the linked
+// hydrated directory, and whether it was reused for the commit. Failures only
+// disable the cache for the next commit, so they are logged.
+func (h *Hydrator) updateRenderCache(commit, hash string, reused bool) {
+	hashFile := filepath.Join(h.HydratedRoot.OSPath(), RenderHashFile)
+	cacheHitFile := filepath.Join(h.HydratedRoot.OSPath(), RenderCacheHitFile)
+	if hash == "" {
+		if err := os.RemoveAll(hashFile); err != nil {
+			klog.Warningf("unable to remove the render hash file: %v", err)
+		}
+	} else if err := os.WriteFile(hashFile, []byte(hash), 0644); err != nil {
+		klog.Warningf("unable to write the render hash file: %v", err)
+	}
+	if !reused {
+		if err := os.RemoveAll(cacheHitFile); err != nil {
+			klog.Warningf("unable to remove the render cache hit file: %v", err)
+		}
+	} else if err := os.WriteFile(cacheHitFile, []byte(commit), 0644); err != nil {
+		klog.Warningf("unable to write the render cache hit file: %v", err)
+	}
+}
+
+// linkTree recreates the directory tree of src at dst, with hard links to the
+// files of src, so that src is left unchanged. The files are copied if they
+// can't be linked.
+func linkTree(src, dst string) error {
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, 0755)
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		}
+		if err := os.Link(p, target); err == nil {
+			return nil
+		}
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, content, 0644)
+	})
+}
@@ -0,0 +1,234 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
+)
+
+// countingRenderer copies the namespace manifest to the output directory and
+// counts the renderings.
+type countingRenderer struct {
+	count int
+}
+
+func (r *countingRenderer) Name() string { return "counting" }
+
+func (r *countingRenderer) Detect(string) (bool, error) { return true, nil }
+
+func (r *countingRenderer) Render(input, output string) HydrationError {
+	r.count++
+	content, err := os.ReadFile(filepath.Join(input, "ns.yaml"))
+	if err != nil {
+		return NewActionableError(err)
+	}
+	if err := os.MkdirAll(output, 0755); err != nil {
+		return NewInternalError(err)
+	}
+	if err := os.WriteFile(filepath.Join(output, "ns.yaml"), content, 0644); err != nil {
+		return NewInternalError(err)
+	}
+	return nil
+}
+
+func TestRenderHash(t *testing.T) {
+	base := map[string]string{
+		"configs/kustomization.yaml":     "resources:\n- ../base\n- ns.yaml\nconfigMapGenerator:\n- name: env\n  files:\n  - env=../env/prod.env\n",
+		"configs/ns.yaml":                namespace,
+		"base/kustomization.yaml":        "resources:\n- deploy.yaml\n",
+		"base/deploy.yaml":               deployment,
+		"env/prod.env":                   "ENV=prod\n",
+		"jsonnet/main.jsonnet":           "local lib = import '../lib/web.libsonnet';\n[lib.ns]\n",
+		"lib/web.libsonnet":              "{ ns: {} }\n",
+		"unrelated/kustomization.yaml":   "resources: []\n",
+		"configs/.git/objects/something": "binary",
+	}
+	hash := func(t *testing.T, changes map[string]string, syncDir string) string {
+		t.Helper()
+		root := t.TempDir()
+		files := map[string]string{}
+		for p, c := range base {
+			files[p] = c
+		}
+		for p, c := range changes {
+			files[p] = c
+		}
+		writeFiles(t, root, files)
+		h, err := (&Hydrator{}).renderHash(root, filepath.Join(root, syncDir))
+		require.NoError(t, err)
+		return h
+	}
+	want := hash(t, nil, "configs")
+
+	testCases := map[string]struct {
+		changes  map[string]string
+		syncDir  string
+		wantSame bool
+	}{
+		"unrelated directory": {
+			changes:  map[string]string{"unrelated/kustomization.yaml": "resources:\n- cm.yaml\n"},
+			wantSame: true,
+		},
+		"git directory": {
+			changes:  map[string]string{"configs/.git/objects/something": "changed"},
+			wantSame: true,
+		},
+		"sync directory": {
+			changes: map[string]string{"configs/ns.yaml": deployment},
+		},
+		"new file in the sync directory": {
+			changes: map[string]string{"configs/cm.yaml": "kind: ConfigMap\n"},
+		},
+		"local base": {
+			changes: map[string]string{"base/deploy.yaml": namespace},
+		},
+		"generator file": {
+			changes: map[string]string{"env/prod.env": "ENV=dev\n"},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := hash(t, tc.changes, "configs")
+			if tc.wantSame {
+				assert.Equal(t, want, got)
+			} else {
+				assert.NotEqual(t, want, got)
+			}
+		})
+	}
+
+	t.Run("Jsonnet import", func(t *testing.T) {
+		jsonnetHash := hash(t, nil, "jsonnet")
+		assert.NotEqual(t, jsonnetHash, hash(t, map[string]string{"lib/web.libsonnet": "{ ns: { kind: 'Namespace' } }\n"}, "jsonnet"))
+	})
+
+	t.Run("remote sources bypass the cache", func(t *testing.T) {
+		for _, ref := range []string{
+			"https://github.com/example/configs//base?ref=v1",
+			"github.com/example/configs/base?ref=v1",
+			"git@github.com:example/configs.git",
+		} {
+			assert.Empty(t, hash(t, map[string]string{"configs/kustomization.yaml": "resources:\n- " + ref + "\n- ns.yaml\n"}, "configs"), ref)
+		}
+		assert.Empty(t, hash(t, map[string]string{
+			"configs/kustomization.yaml": "helmCharts:\n- name: web\n  repo: https://charts.example.com\n  version: 1.0.0\n",
+		}, "configs"))
+		// URLs in Kptfiles aren't fetched by the rendering.
+		assert.NotEmpty(t, hash(t, map[string]string{
+			"configs/Kptfile": "apiVersion: kpt.dev/v1\nkind: Kptfile\nupstream:\n  git:\n    repo: https://github.com/example/configs\n",
+		}, "configs"))
+	})
+
+	t.Run("extra inputs", func(t *testing.T) {
+		root := t.TempDir()
+		writeFiles(t, root, map[string]string{"configs/ns.yaml": namespace, "values.yaml": "replicas: 1\n"})
+		h := &Hydrator{ExtraInputs: []string{filepath.Join(root, "values.yaml")}}
+		before, err := h.renderHash(root, filepath.Join(root, "configs"))
+		require.NoError(t, err)
+		writeFiles(t, root, map[string]string{"values.yaml": "replicas: 3\n"})
+		after, err := h.renderHash(root, filepath.Join(root, "configs"))
+		require.NoError(t, err)
+		assert.NotEqual(t, before, after)
+	})
+}
+
+func TestRunHydrateRenderCache(t *testing.T) {
+	root := t.TempDir()
+	renderer := &countingRenderer{}
+	h := &Hydrator{
+		SourceRoot:   cmpath.Absolute(filepath.Join(root, "source")),
+		SourceLink:   "rev",
+		HydratedRoot: cmpath.Absolute(filepath.Join(root, "hydrated")),
+		HydratedLink: "rev",
+		SyncDir:      "configs",
+		Renderers:    []Renderer{renderer},
+	}
+	require.NoError(t, os.MkdirAll(h.HydratedRoot.OSPath(), 0755))
+	checkout := func(commit string, files map[string]string) cmpath.Absolute {
+		t.Helper()
+		commitDir := filepath.Join(h.SourceRoot.OSPath(), commit)
+		writeFiles(t, commitDir, files)
+		link := filepath.Join(h.SourceRoot.OSPath(), h.SourceLink)
+		require.NoError(t, os.RemoveAll(link))
+		require.NoError(t, os.Symlink(commitDir, link))
+		return cmpath.Absolute(filepath.Join(commitDir, "configs"))
+	}
+	hydratedCommit := func() string {
+		t.Helper()
+		dir, err := filepath.EvalSymlinks(filepath.Join(h.HydratedRoot.OSPath(), h.HydratedLink))
+		require.NoError(t, err)
+		return filepath.Base(dir)
+	}
+	cacheHit := func() string {
+		t.Helper()
+		commit, err := ExtractCommit(filepath.Join(h.HydratedRoot.OSPath(), RenderCacheHitFile))
+		require.NoError(t, err)
+		return commit
+	}
+
+	// The first commit is rendered.
+	syncPath := checkout("commit1", map[string]string{"configs/ns.yaml": namespace, "docs/README.md": "v1"})
+	require.NoError(t, h.runHydrate("commit1", syncPath))
+	assert.Equal(t, 1, renderer.count)
+	assert.Equal(t, "commit1", hydratedCommit())
+	assert.Empty(t, cacheHit())
+
+	// An unrelated change reuses the rendered configs.
+	syncPath = checkout("commit2", map[string]string{"configs/ns.yaml": namespace, "docs/README.md": "v2"})
+	require.NoError(t, h.runHydrate("commit2", syncPath))
+	assert.Equal(t, 1, renderer.count)
+	assert.Equal(t, "commit2", hydratedCommit())
+	assert.Equal(t, "commit2", cacheHit())
+	content, err := os.ReadFile(filepath.Join(h.HydratedRoot.OSPath(), h.HydratedLink, "configs", "ns.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, namespace, string(content))
+	_, err = os.Stat(filepath.Join(h.HydratedRoot.OSPath(), "commit1"))
+	assert.True(t, os.IsNotExist(err), "the previous hydrated directory should be removed after the symlink is updated")
+
+	// A change in the sync directory is rendered again.
+	syncPath = checkout("commit3", map[string]string{"configs/ns.yaml": deployment})
+	require.NoError(t, h.runHydrate("commit3", syncPath))
+	assert.Equal(t, 2, renderer.count)
+	assert.Equal(t, "commit3", hydratedCommit())
+	assert.Empty(t, cacheHit())
+}
+
+func TestLinkTree(t *testing.T) {
+	src := t.TempDir()
+	writeFiles(t, src, map[string]string{"configs/ns.yaml": namespace, "configs/app/deploy.yaml": deployment})
+	require.NoError(t, os.Symlink("ns.yaml", filepath.Join(src, "configs", "link.yaml")))
+	dst := filepath.Join(t.TempDir(), "commit2")
+
+	require.NoError(t, linkTree(src, dst))
+	for p, want := range map[string]string{
+		"configs/ns.yaml":         namespace,
+		"configs/app/deploy.yaml": deployment,
+		"configs/link.yaml":       namespace,
+	} {
+		content, err := os.ReadFile(filepath.Join(dst, filepath.FromSlash(p)))
+		require.NoError(t, err)
+		assert.Equal(t, want, string(content))
+		// The source directory, still linked by the reconciler, is unchanged.
+		content, err = os.ReadFile(filepath.Join(src, filepath.FromSlash(p)))
+		require.NoError(t, err)
+		assert.Equal(t, want, string(content))
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Renderer renders the configs in the sync directory to the hydrated
+// directory.
+type Renderer interface {
+	// Name returns the name of the rendering tool.
+	Name() string
+	// Detect checks if the configs in the directory must be rendered with the
+	// tool.
+	Detect(dir string) (bool, error)
+	// Render renders the configs in the input directory to the output
+	// directory. The output directory is removed on error.
+	Render(input, output string) HydrationError
+}
+
+// renderers returns the renderers of the Hydrator, in order of precedence.
+// A Kustomization config file takes precedence over a Helm chart, which takes
+// precedence over Jsonnet, CUE, and finally Kptfile pipelines.
+func (h *Hydrator) renderers() []Renderer {
+	if h.Renderers != nil {
+		return h.Renderers
+	}
+	return []Renderer{
+		kustomizeRenderer{hermetic: h.Hermetic},
+		helmChartRenderer{hydrator: h},
+		jsonnetRenderer{},
+		cueRenderer{},
+		h.kptRenderer(),
+	}
+}
+
+// renderer returns the renderer of the configs in the directory, or nil if no
+// rendering is needed.
+func (h *Hydrator) renderer(dir string) (Renderer, error) {
+	for _, r := range h.renderers() {
+		found, err := r.Detect(dir)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+// HasRendererMarker checks if the file marks the sync directory for rendering
+// with a Helm chart, Jsonnet or CUE.
+func HasRendererMarker(file, syncDir string) bool {
+	dir := filepath.Dir(file)
+	syncDir = filepath.Clean(syncDir)
+	switch {
+	case dir == syncDir:
+		base := filepath.Base(file)
+		return base == ChartFile || base == JsonnetMainFile
+	case filepath.Base(dir) == CueModDir:
+		return filepath.Dir(dir) == syncDir
+	default:
+		return false
+	}
+}
+
+// kustomizeRenderer runs `kustomize build`. In hermetic mode, the
+// kustomizations must not fetch remote sources.
+type kustomizeRenderer struct {
+	hermetic bool
+}
+
+func (kustomizeRenderer) Name() string { return Kustomize }
+
+func (kustomizeRenderer) Detect(dir string) (bool, error) { return needsKustomize(dir) }
+
+func (r kustomizeRenderer) Render(input, output string) HydrationError {
+	if r.hermetic {
+		if err := validateHermetic(input); err != nil {
+			return err
+		}
+	}
+	return kustomizeBuild(input, output, true)
+}
+
+type helmChartRenderer struct {
+	hydrator *Hydrator
+}
+
+func (helmChartRenderer) Name() string { return Helm }
+
+func (helmChartRenderer) Detect(dir string) (bool, error) { return needsHelmChart(dir) }
+
+func (r helmChartRenderer) Render(input, output string) HydrationError {
+	return r.hydrator.chartRender(input, output)
+}
+
+type kptRenderer struct {
+	allowExec    bool
+	functionsDir string
+}
+
+// kptRenderer returns the renderer of the Kptfile function pipelines, which
+// only runs the exec functions of the image when they are allowed.
+func (h *Hydrator) kptRenderer() kptRenderer {
+	functionsDir := h.KptFunctionsDir
+	if functionsDir == "" {
+		functionsDir = DefaultKptFunctionsDir
+	}
+	return kptRenderer{allowExec: h.AllowExecFunctions, functionsDir: functionsDir}
+}
+
+func (kptRenderer) Name() string { return Kpt }
+
+func (kptRenderer) Detect(dir string) (bool, error) { return needsKptPipeline(dir) }
+
+func (r kptRenderer) Render(input, output string) HydrationError {
+	return r.kptRender(input, output)
+}
+
+// hasMarker checks if the directory contains the marker file, or the marker
+// directory if isDir is true.
+func hasMarker(dir, marker string, isDir bool) (bool, error) {
+	fi, err := os.Stat(filepath.Join(dir, marker))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("unable to check %s in the directory: %s: %w", marker, dir, err)
+	}
+	return fi.IsDir() == isDir, nil
+}
@@ -0,0 +1,240 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderer(t *testing.T) {
+	testCases := map[string]struct {
+		files map[string]string
+		want  string
+	}{
+		"kustomization takes precedence over the Helm chart": {
+			files: map[string]string{"kustomization.yaml": "resources: []\n", ChartFile: "name: web\n"},
+			want:  Kustomize,
+		},
+		"Helm chart takes precedence over Jsonnet": {
+			files: map[string]string{ChartFile: "name: web\n", JsonnetMainFile: "[]\n"},
+			want:  Helm,
+		},
+		"Jsonnet takes precedence over CUE": {
+			files: map[string]string{JsonnetMainFile: "[]\n", "cue.mod/module.cue": "module: \"example.com/web\"\n"},
+			want:  Jsonnet,
+		},
+		"CUE takes precedence over the Kptfile pipeline": {
+			files: map[string]string{"cue.mod/module.cue": "module: \"example.com/web\"\n", "Kptfile": "pipeline:\n  mutators:\n  - exec: ./fn\n"},
+			want:  Cue,
+		},
+		"Kptfile pipeline": {
+			files: map[string]string{"Kptfile": "pipeline:\n  mutators:\n  - exec: ./fn\n"},
+			want:  Kpt,
+		},
+		"markers in a subdirectory": {
+			files: map[string]string{"web/" + ChartFile: "name: web\n", "lib/" + JsonnetMainFile: "[]\n", "ns.yaml": namespace},
+			want:  "",
+		},
+		"cue.mod file": {
+			files: map[string]string{CueModDir: "module: \"example.com/web\"\n"},
+			want:  "",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tc.files)
+			r, err := (&Hydrator{}).renderer(dir)
+			require.NoError(t, err)
+			if tc.want == "" {
+				assert.Nil(t, r)
+				return
+			}
+			require.NotNil(t, r)
+			assert.Equal(t, tc.want, r.Name())
+		})
+	}
+}
+
+func TestHasRendererMarker(t *testing.T) {
+	syncDir := filepath.Join("repo", "configs")
+	assert.True(t, HasRendererMarker(filepath.Join(syncDir, ChartFile), syncDir+"/"))
+	assert.True(t, HasRendererMarker(filepath.Join(syncDir, JsonnetMainFile), syncDir))
+	assert.True(t, HasRendererMarker(filepath.Join(syncDir, CueModDir, "module.cue"), syncDir))
+	assert.False(t, HasRendererMarker(filepath.Join(syncDir, "web", ChartFile), syncDir))
+	assert.False(t, HasRendererMarker(filepath.Join(syncDir, "web", CueModDir, "module.cue"), syncDir))
+	assert.False(t, HasRendererMarker(filepath.Join(syncDir, "ns.yaml"), syncDir))
+}
+
+func TestConfigLanguageRender(t *testing.T) {
+	// The files outside of the source directory, which must not be imported.
+	base := t.TempDir()
+	outside := filepath.Join(base, "outside")
+	writeFiles(t, outside, map[string]string{
+		"secret.libsonnet": "{name: 'secret'}\n",
+		"secret.cue":       "package web\n\nsecret: {apiVersion: \"v1\", kind: \"Secret\", metadata: name: \"secret\"}\n",
+		"lib/secret.cue":   "package lib\n\nname: \"secret\"\n",
+	})
+	cueModule := "module: \"example.com/web\"\nlanguage: version: \"v0.14.0\"\n"
+	testCases := map[string]struct {
+		renderer  Renderer
+		files     map[string]string
+		symlinks  map[string]string
+		wantFiles []string
+		wantErr   string
+	}{
+		"Jsonnet array with library": {
+			renderer: jsonnetRenderer{},
+			files: map[string]string{
+				JsonnetMainFile: `local lib = import 'lib/web.libsonnet';
+[{apiVersion: 'v1', kind: 'Namespace', metadata: {name: lib.name}}, lib.deployment]
+`,
+				"lib/web.libsonnet": `{
+  name: 'web',
+  deployment: {apiVersion: 'apps/v1', kind: 'Deployment', metadata: {name: $.name, namespace: $.name}},
+}
+`,
+			},
+			wantFiles: []string{"v1_namespace_web.yaml", "web_apps_v1_deployment_web.yaml"},
+		},
+		"CUE fields and List": {
+			renderer: cueRenderer{},
+			files: map[string]string{
+				"cue.mod/module.cue": "module: \"example.com/web\"\nlanguage: version: \"v0.14.0\"\n",
+				"web.cue": `package web
+
+#Namespace: {apiVersion: "v1", kind: "Namespace", metadata: name: string}
+namespace: #Namespace & {metadata: name: "web"}
+roles: {apiVersion: "v1", kind: "List", items: [
+	{apiVersion: "rbac.authorization.k8s.io/v1", kind: "ClusterRole", metadata: name: "viewer"},
+]}
+replicas: 3
+`,
+			},
+			wantFiles: []string{"rbac.authorization.k8s.io_v1_clusterrole_viewer.yaml", "v1_namespace_web.yaml"},
+		},
+		"Jsonnet evaluation error": {
+			renderer: jsonnetRenderer{},
+			files:    map[string]string{JsonnetMainFile: "{replicas: replica}\n"},
+			wantErr:  "Unknown variable: replica",
+		},
+		"CUE incomplete value": {
+			renderer: cueRenderer{},
+			files: map[string]string{
+				"cue.mod/module.cue": "module: \"example.com/web\"\nlanguage: version: \"v0.14.0\"\n",
+				"web.cue":            "package web\n\nnamespace: {apiVersion: \"v1\", kind: \"Namespace\", metadata: name: string}\n",
+			},
+			wantErr: "namespace.metadata.name: incomplete value string",
+		},
+		"Jsonnet absolute import": {
+			renderer: jsonnetRenderer{},
+			files: map[string]string{
+				JsonnetMainFile: "local lib = import '" + filepath.Join(outside, "secret.libsonnet") + "';\n[{apiVersion: 'v1', kind: 'Namespace', metadata: {name: lib.name}}]\n",
+			},
+			wantErr: "is outside of the source directory",
+		},
+		"Jsonnet import of the parent directory": {
+			renderer: jsonnetRenderer{},
+			files: map[string]string{
+				JsonnetMainFile: "local lib = import '../outside/secret.libsonnet';\n[{apiVersion: 'v1', kind: 'Namespace', metadata: {name: lib.name}}]\n",
+			},
+			wantErr: "is outside of the source directory",
+		},
+		"Jsonnet import through a symlink": {
+			renderer: jsonnetRenderer{},
+			files: map[string]string{
+				JsonnetMainFile: "local lib = import 'lib/secret.libsonnet';\n[{apiVersion: 'v1', kind: 'Namespace', metadata: {name: lib.name}}]\n",
+			},
+			symlinks: map[string]string{"lib": outside},
+			wantErr:  "is outside of the source directory",
+		},
+		"CUE absolute import": {
+			renderer: cueRenderer{},
+			files: map[string]string{
+				"cue.mod/module.cue": cueModule,
+				"web.cue":            "package web\n\nimport \"" + filepath.Join(outside, "lib") + "\"\n\nnamespace: {apiVersion: \"v1\", kind: \"Namespace\", metadata: name: lib.name}\n",
+			},
+			wantErr: "no location found for package",
+		},
+		"CUE import of the parent directory": {
+			renderer: cueRenderer{},
+			files: map[string]string{
+				"cue.mod/module.cue": cueModule,
+				"web.cue":            "package web\n\nimport \"../outside/lib\"\n\nnamespace: {apiVersion: \"v1\", kind: \"Namespace\", metadata: name: lib.name}\n",
+			},
+			wantErr: "relative import paths not allowed",
+		},
+		"CUE import through a symlink": {
+			renderer: cueRenderer{},
+			files: map[string]string{
+				"cue.mod/module.cue": cueModule,
+				"web.cue":            "package web\n\nimport \"example.com/web/lib\"\n\nnamespace: {apiVersion: \"v1\", kind: \"Namespace\", metadata: name: lib.name}\n",
+			},
+			symlinks: map[string]string{"lib": filepath.Join(outside, "lib")},
+			wantErr:  "is outside of the source directory",
+		},
+		"CUE file through a symlink": {
+			renderer: cueRenderer{},
+			files: map[string]string{
+				"cue.mod/module.cue": cueModule,
+				"web.cue":            "package web\n\nnamespace: {apiVersion: \"v1\", kind: \"Namespace\", metadata: name: \"web\"}\n",
+			},
+			symlinks: map[string]string{"secret.cue": filepath.Join(outside, "secret.cue")},
+			wantErr:  "is outside of the source directory",
+		},
+		"duplicate objects": {
+			renderer: jsonnetRenderer{},
+			files: map[string]string{
+				JsonnetMainFile: "local ns = {apiVersion: 'v1', kind: 'Namespace', metadata: {name: 'web'}};\n[ns, ns]\n",
+			},
+			wantErr: "duplicate object v1_namespace_web",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			input, err := os.MkdirTemp(base, "source")
+			require.NoError(t, err)
+			writeFiles(t, input, tc.files)
+			for p, target := range tc.symlinks {
+				require.NoError(t, os.Symlink(target, filepath.Join(input, p)))
+			}
+			output := filepath.Join(t.TempDir(), "rendered")
+
+			hydrationErr := tc.renderer.Render(input, output)
+			if tc.wantErr != "" {
+				require.Error(t, hydrationErr)
+				assert.Contains(t, hydrationErr.Error(), tc.wantErr)
+				_, err := os.Stat(output)
+				assert.True(t, os.IsNotExist(err), "the output should be removed")
+				return
+			}
+			require.NoError(t, hydrationErr)
+			entries, err := os.ReadDir(output)
+			require.NoError(t, err)
+			var files []string
+			for _, e := range entries {
+				files = append(files, e.Name())
+			}
+			sort.Strings(files)
+			assert.Equal(t, tc.wantFiles, files)
+		})
+	}
+}
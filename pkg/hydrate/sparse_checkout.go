@@ -0,0 +1,129 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// kustomizationRefs are the fields of a Kustomization which reference other
+// files or directories.
+type kustomizationRefs struct {
+	Resources  []string `json:"resources,omitempty"`
+	Bases      []string `json:"bases,omitempty"`
+	Components []string `json:"components,omitempty"`
+}
+
+// SparseCheckoutPaths returns the paths, relative to the repository root,
+// which must be checked out to sync the syncDir: the syncDir itself, and the
+// local files and directories referenced by its Kustomizations, recursively.
+// Remote references and references outside the repository are skipped.
+func SparseCheckoutPaths(repoRoot, syncDir string) ([]string, error) {
+	syncDir = path.Clean(filepath.ToSlash(syncDir))
+	paths := []string{syncDir}
+	visited := map[string]bool{}
+	var visit func(dir string) error
+	visit = func(dir string) error {
+		if visited[dir] {
+			return nil
+		}
+		visited[dir] = true
+		refs, err := readKustomizationRefs(filepath.Join(repoRoot, filepath.FromSlash(dir)))
+		if err != nil {
+			return err
+		}
+		for _, ref := range refs {
+			if isRemoteRef(ref) {
+				continue
+			}
+			p := path.Join(dir, ref)
+			if p == ".." || strings.HasPrefix(p, "../") {
+				// Outside of the repository.
+				continue
+			}
+			if !slices.ContainsFunc(paths, func(dir string) bool { return isWithin(p, dir) }) {
+				paths = append(paths, p)
+			}
+			if fi, err := os.Stat(filepath.Join(repoRoot, filepath.FromSlash(p))); err == nil && fi.IsDir() {
+				if err := visit(p); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	err := filepath.WalkDir(filepath.Join(repoRoot, filepath.FromSlash(syncDir)), func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		rel, err := filepath.Rel(repoRoot, p)
+		if err != nil {
+			return err
+		}
+		return visit(filepath.ToSlash(rel))
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(paths)
+	return paths, nil
+}
+
+// readKustomizationRefs returns the references of the Kustomization in the
+// directory, or nil if the directory has no Kustomization.
+func readKustomizationRefs(dir string) ([]string, error) {
+	for _, name := range validKustomizationFiles {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading the Kustomization in %s: %w", dir, err)
+		}
+		refs := &kustomizationRefs{}
+		if err := yaml.Unmarshal(content, refs); err != nil {
+			return nil, fmt.Errorf("parsing the Kustomization in %s: %w", dir, err)
+		}
+		return slices.Concat(refs.Resources, refs.Bases, refs.Components), nil
+	}
+	return nil, nil
+}
+
+// isRemoteRef returns true if the Kustomize reference points to a remote
+// repository or URL, rather than a local path.
+func isRemoteRef(ref string) bool {
+	return strings.Contains(ref, "://") ||
+		strings.HasPrefix(ref, "git@") ||
+		strings.HasPrefix(ref, "github.com/") ||
+		strings.Contains(ref, "?ref=")
+}
+
+// isWithin returns true if the slash path p is dir or is inside dir.
+func isWithin(p, dir string) bool {
+	return dir == "." || p == dir || strings.HasPrefix(p, dir+"/")
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSparseCheckoutPaths(t *testing.T) {
+	testCases := map[string]struct {
+		files   map[string]string
+		syncDir string
+		want    []string
+		wantErr string
+	}{
+		"no kustomization": {
+			files:   map[string]string{"configs/ns.yaml": "kind: Namespace"},
+			syncDir: "configs",
+			want:    []string{"configs"},
+		},
+		"bases outside the sync directory": {
+			files: map[string]string{
+				"overlays/prod/kustomization.yaml":    "resources:\n- ../../base\n- service.yaml\ncomponents:\n- ../../components/monitoring\n",
+				"overlays/prod/service.yaml":          "kind: Service",
+				"base/kustomization.yaml":             "resources:\n- deployment.yaml\n- ../common/rbac.yaml\n",
+				"base/deployment.yaml":                "kind: Deployment",
+				"components/monitoring/Kustomization": "kind: Component",
+				"common/rbac.yaml":                    "kind: Role",
+			},
+			syncDir: "overlays/prod",
+			want:    []string{"base", "common/rbac.yaml", "components/monitoring", "overlays/prod"},
+		},
+		"kustomization in a subdirectory of the sync directory": {
+			files: map[string]string{
+				"clusters/a/app/kustomization.yml": "bases:\n- ../../../shared\n",
+				"shared/kustomization.yaml":        "resources:\n- cm.yaml\n",
+			},
+			syncDir: "clusters/a",
+			want:    []string{"clusters/a", "shared"},
+		},
+		"remote and out of repository references are skipped": {
+			files: map[string]string{
+				"app/kustomization.yaml": "resources:\n- https://example.com/app.yaml\n- github.com/example/repo/base?ref=v1\n- git@example.com:org/repo\n- ../../outside\n",
+			},
+			syncDir: "app",
+			want:    []string{"app"},
+		},
+		"cyclic references": {
+			files: map[string]string{
+				"a/kustomization.yaml": "resources:\n- ../b\n",
+				"b/kustomization.yaml": "resources:\n- ../a\n",
+			},
+			syncDir: "a",
+			want:    []string{"a", "b"},
+		},
+		"invalid kustomization": {
+			files:   map[string]string{"app/kustomization.yaml": "resources: {"},
+			syncDir: "app",
+			wantErr: "parsing the Kustomization",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			root := t.TempDir()
+			for p, content := range tc.files {
+				p = filepath.Join(root, filepath.FromSlash(p))
+				require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+				require.NoError(t, os.WriteFile(p, []byte(content), 0644))
+			}
+			got, err := SparseCheckoutPaths(root, tc.syncDir)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/klog/v2"
+	gitutil "kpt.dev/configsync/pkg/git"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
+)
+
+const (
+	// gitModulesFile is the file which declares the Git submodules.
+	gitModulesFile = ".gitmodules"
+	// gitRefPrefix prefixes the ref of a symbolic HEAD.
+	gitRefPrefix = "ref:"
+)
+
+// Submodule is a Git submodule checked out with a commit.
+type Submodule struct {
+	// Path is the slash path of the submodule within the repository.
+	Path string
+	// Commit is the commit hash the submodule is resolved to.
+	Commit string
+}
+
+// ResolveSubmodules returns the Git submodules checked out in sourceDir, and
+// their own submodules, recursively, with the commits they are resolved to.
+//
+// The submodules which can't be resolved are only an error if they overlap
+// with syncDir, the relative path of the sync directory: the others are
+// ignored, since they are not synced.
+func ResolveSubmodules(sourceDir cmpath.Absolute, syncDir cmpath.Relative) ([]Submodule, error) {
+	dir, err := sourceDir.EvalSymlinks()
+	if err != nil {
+		return nil, fmt.Errorf("unable to evaluate the symbolic link of sourceDir %s: %w", dir, err)
+	}
+	submodules, err := resolveSubmodules(dir.OSPath(), "", path.Clean(syncDir.SlashPath()))
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve the submodules of commit %s: %w", filepath.Base(dir.OSPath()), err)
+	}
+	return submodules, nil
+}
+
+// resolveSubmodules returns the submodules checked out in the worktree, and
+// their own submodules, recursively. Submodules which are declared but not
+// checked out, for example because git-sync skips them, are ignored.
+// The Git metadata is read directly, so the git binary is not required.
+func resolveSubmodules(worktree, prefix, syncDir string) ([]Submodule, error) {
+	content, err := os.ReadFile(filepath.Join(worktree, gitModulesFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", gitModulesFile, err)
+	}
+	var result []Submodule
+	for _, p := range submodulePaths(content) {
+		subPath := path.Join(prefix, p)
+		dir := filepath.Join(worktree, filepath.FromSlash(p))
+		submodules, err := resolveSubmodule(dir, subPath, syncDir)
+		if err != nil {
+			if !overlaps(subPath, syncDir) {
+				klog.Warningf("Ignoring submodule %s, outside of the sync directory: %v", subPath, err)
+				continue
+			}
+			return nil, err
+		}
+		result = append(result, submodules...)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Path < result[j].Path
+	})
+	return result, nil
+}
+
+// resolveSubmodule returns the submodule checked out in dir, if any, along
+// with its own submodules.
+func resolveSubmodule(dir, subPath, syncDir string) ([]Submodule, error) {
+	gitDir, err := gitutil.ResolveGitDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	commit, err := resolveHead(gitDir)
+	if err != nil {
+		return nil, fmt.Errorf("resolving the commit of submodule %s: %w", subPath, err)
+	}
+	nested, err := resolveSubmodules(dir, subPath, syncDir)
+	if err != nil {
+		return nil, err
+	}
+	return append([]Submodule{{Path: subPath, Commit: commit}}, nested...), nil
+}
+
+// overlaps returns true if one of the slash paths contains the other one.
+func overlaps(a, b string) bool {
+	return a == b || b == "." || a == "." ||
+		strings.HasPrefix(b, a+"/") || strings.HasPrefix(a, b+"/")
+}
+
+// submodulePaths returns the paths of the submodules declared in the content
+// of a .gitmodules file.
+func submodulePaths(content []byte) []string {
+	var result []string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	inSubmodule := false
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			inSubmodule = strings.HasPrefix(line, "[submodule ")
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if inSubmodule && found && strings.TrimSpace(key) == "path" {
+			result = append(result, path.Clean(strings.Trim(strings.TrimSpace(value), `"`)))
+		}
+	}
+	return result
+}
+
+// resolveHead returns the commit hash of the HEAD of the Git directory.
+func resolveHead(gitDir string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return "", err
+	}
+	head := strings.TrimSpace(string(content))
+	ref, found := strings.CutPrefix(head, gitRefPrefix)
+	if !found {
+		// Submodules are usually checked out with a detached HEAD.
+		return head, nil
+	}
+	ref = strings.TrimSpace(ref)
+	content, err = os.ReadFile(filepath.Join(gitDir, filepath.FromSlash(ref)))
+	if err == nil {
+		return strings.TrimSpace(string(content)), nil
+	}
+	if !os.IsNotExist(err) {
+		return "", err
+	}
+	packedRefs, err := os.ReadFile(filepath.Join(gitDir, "packed-refs"))
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	for _, line := range strings.Split(string(packedRefs), "\n") {
+		if commit, name, found := strings.Cut(strings.TrimSpace(line), " "); found && name == ref {
+			return commit, nil
+		}
+	}
+	return "", fmt.Errorf("ref %s not found", ref)
+}
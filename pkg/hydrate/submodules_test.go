@@ -0,0 +1,124 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
+)
+
+const (
+	libCommit    = "1111111111111111111111111111111111111111"
+	nestedCommit = "2222222222222222222222222222222222222222"
+	refCommit    = "3333333333333333333333333333333333333333"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for p, content := range files {
+		p = filepath.Join(root, filepath.FromSlash(p))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0644))
+	}
+}
+
+func TestResolveSubmodules(t *testing.T) {
+	unresolvedLib := map[string]string{
+		".gitmodules":   "[submodule \"lib\"]\n\tpath = lib\n",
+		"lib/.git/HEAD": "ref: refs/heads/main\n",
+	}
+	testCases := map[string]struct {
+		files   map[string]string
+		syncDir string
+		want    []Submodule
+		wantErr string
+	}{
+		"no submodules": {
+			files: map[string]string{"configs/ns.yaml": "kind: Namespace"},
+		},
+		"submodules are not checked out": {
+			files: map[string]string{
+				".gitmodules": "[submodule \"lib\"]\n\tpath = lib\n\turl = https://example.com/lib\n",
+			},
+		},
+		"recursive submodules": {
+			files: map[string]string{
+				".gitmodules": "[submodule \"lib\"]\n\tpath = lib\n\turl = https://example.com/lib\n" +
+					"[submodule \"bundles\"]\n\tpath = \"third_party/bundles\"\n\turl = https://example.com/bundles\n",
+				"lib/.git":                             "gitdir: ../.git/modules/lib\n",
+				".git/modules/lib/HEAD":                libCommit + "\n",
+				"lib/.gitmodules":                      "[submodule \"nested\"]\n\tpath = nested\n\turl = https://example.com/nested\n",
+				"lib/nested/.git":                      "gitdir: ../../.git/modules/lib/modules/nested\n",
+				".git/modules/lib/modules/nested/HEAD": nestedCommit + "\n",
+				"third_party/bundles/.git/HEAD":        "ref: refs/heads/main\n",
+				"third_party/bundles/.git/packed-refs": "# pack-refs with: peeled\n" + refCommit + " refs/heads/main\n",
+			},
+			want: []Submodule{
+				{Path: "lib", Commit: libCommit},
+				{Path: "lib/nested", Commit: nestedCommit},
+				{Path: "third_party/bundles", Commit: refCommit},
+			},
+		},
+		"unresolved ref": {
+			files:   unresolvedLib,
+			wantErr: "ref refs/heads/main not found",
+		},
+		"unresolved ref in the sync directory": {
+			files:   unresolvedLib,
+			syncDir: "lib/configs",
+			wantErr: "ref refs/heads/main not found",
+		},
+		"unresolved ref outside of the sync directory": {
+			files:   unresolvedLib,
+			syncDir: "configs",
+		},
+		"invalid git file outside of the sync directory": {
+			files: map[string]string{
+				".gitmodules":                      "[submodule \"lib\"]\n\tpath = lib\n[submodule \"bundles\"]\n\tpath = configs/bundles\n",
+				"lib/.git":                         "invalid\n",
+				"configs/bundles/.git/HEAD":        "ref: refs/heads/main\n",
+				"configs/bundles/.git/packed-refs": refCommit + " refs/heads/main\n",
+			},
+			syncDir: "configs",
+			want:    []Submodule{{Path: "configs/bundles", Commit: refCommit}},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			root := t.TempDir()
+			commitDir := filepath.Join(root, originCommit)
+			writeFiles(t, commitDir, tc.files)
+			link := filepath.Join(root, "rev")
+			require.NoError(t, os.Symlink(commitDir, link))
+
+			syncDir := tc.syncDir
+			if syncDir == "" {
+				syncDir = "."
+			}
+			submodules, err := ResolveSubmodules(cmpath.Absolute(link), cmpath.RelativeSlash(syncDir))
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, submodules)
+		})
+	}
+}
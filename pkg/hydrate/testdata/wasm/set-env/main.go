@@ -0,0 +1,52 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command set-env is a WASM function, built with GOOS=wasip1 GOARCH=wasm,
+// which sets the env label from its functionConfig on the resources.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+func main() {
+	input, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if _, err := os.ReadDir("/"); err == nil {
+		fmt.Fprintln(os.Stderr, "the filesystem should not be accessible")
+		os.Exit(1)
+	}
+	lines := strings.Split(string(input), "\n")
+	env := ""
+	for _, line := range lines {
+		if value, found := strings.CutPrefix(strings.TrimSpace(line), "env: "); found {
+			env = value
+		}
+	}
+	var output []string
+	for _, line := range lines {
+		output = append(output, line)
+		if strings.TrimSpace(line) == "app: web" {
+			indent := line[:len(line)-len(strings.TrimLeft(line, " "))]
+			output = append(output, indent+"env: "+env)
+		}
+	}
+	fmt.Print(strings.Join(output, "\n"))
+}
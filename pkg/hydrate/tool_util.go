@@ -259,6 +259,17 @@ func ValidateAndRunKustomize(sourcePath string) (cmpath.Absolute, error) {
 // ValidateHydrateFlags validates the hydrate and vet flags.
 // It returns the absolute path of the source directory, if hydration is needed, and errors.
 func ValidateHydrateFlags(sourceFormat configsync.SourceFormat) (cmpath.Absolute, bool, error) {
+	switch flags.OutputFormat {
+	case flags.OutputYAML, flags.OutputJSON: // do nothing
+	default:
+		return "", false, fmt.Errorf("format argument must be %q or %q", flags.OutputYAML, flags.OutputJSON)
+	}
+	return ValidateSourceDir(sourceFormat)
+}
+
+// ValidateSourceDir validates the source directory of the --path flag.
+// It returns the absolute path of the source directory, if hydration is needed, and errors.
+func ValidateSourceDir(sourceFormat configsync.SourceFormat) (cmpath.Absolute, bool, error) {
 	abs, err := filepath.Abs(flags.Path)
 	if err != nil {
 		return "", false, err
@@ -272,12 +283,6 @@ func ValidateHydrateFlags(sourceFormat configsync.SourceFormat) (cmpath.Absolute
 		return "", false, err
 	}
 
-	switch flags.OutputFormat {
-	case flags.OutputYAML, flags.OutputJSON: // do nothing
-	default:
-		return "", false, fmt.Errorf("format argument must be %q or %q", flags.OutputYAML, flags.OutputJSON)
-	}
-
 	needsKustomize, err := needsKustomize(abs)
 	if err != nil {
 		return "", false, fmt.Errorf("unable to check if Kustomize is needed for the source directory: %s: %w", abs, err)
@@ -297,9 +302,10 @@ func ValidateOptions(ctx context.Context, rootDir cmpath.Absolute, apiServerTime
 	var serverResourcer discovery.ServerResourcer = discovery.NoOpServerResourcer{}
 
 	options.Scheme = core.Scheme
+	options.OpenAPISchemas = discovery.NoOpenAPISchemas
 
 	if !flags.SkipAPIServer {
-		cfg, err := restconfig.NewRestConfig(apiServerTimeout)
+		cfg, err := restconfig.NewContextRestConfig(apiServerTimeout, flags.Context)
 		if err != nil {
 			return options, apiServerCheckError(err, "failed to create rest config")
 		}
@@ -316,6 +322,7 @@ func ValidateOptions(ctx context.Context, rootDir cmpath.Absolute, apiServerTime
 			return options, err
 		}
 		serverResourcer = dc
+		options.OpenAPISchemas = discovery.OpenAPIV3Schemas(dc.OpenAPIV3())
 		options.Converter, err = declared.NewValueConverter(dc)
 		if err != nil {
 			return options, err
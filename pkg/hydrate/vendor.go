@@ -0,0 +1,356 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/kustomize/api/resource"
+	"sigs.k8s.io/kustomize/api/types"
+	kyaml "sigs.k8s.io/kustomize/kyaml/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DefaultVendorDir is the directory of the vendored sources, relative to
+	// the vendored directory.
+	DefaultVendorDir = "vendor"
+	// VendorLockFile is the file recording the vendored sources, in the
+	// vendor directory.
+	VendorLockFile = "vendor-lock.yaml"
+)
+
+// VendoredSource is a remote source of a kustomization vendored locally.
+type VendoredSource struct {
+	// URL is the remote source, as declared in the kustomization.
+	URL string `json:"url"`
+	// Commit is the commit of the repository the remote base is pinned to.
+	Commit string `json:"commit,omitempty"`
+	// Version is the version of the vendored Helm chart.
+	Version string `json:"version,omitempty"`
+	// Path is the path to the vendored source, relative to the vendored
+	// directory.
+	Path string `json:"path"`
+}
+
+// Vendorer rewrites the remote sources of the kustomizations in a directory
+// into local copies.
+type Vendorer struct {
+	// Root is the directory whose kustomizations are vendored.
+	Root string
+	// VendorDir is the directory of the vendored remote bases, relative to
+	// Root.
+	VendorDir string
+	// FetchRepo fetches the ref of the repository to the directory, and
+	// returns the commit it resolves to. The directory must not contain the
+	// repository metadata.
+	FetchRepo func(ctx context.Context, repo, ref, dir string) (string, error)
+	// PullChart pulls the Helm chart from its repository and extracts it to
+	// the directory.
+	PullChart func(ctx context.Context, chart types.HelmChart, dir string) error
+
+	// repos maps the repository and ref of the fetched remote bases to their
+	// vendored directory and commit.
+	repos map[string]fetchedRepo
+	// lock are the vendored sources.
+	lock []VendoredSource
+}
+
+type fetchedRepo struct {
+	dir    string
+	commit string
+}
+
+// Vendor vendors the remote bases and the Helm charts downloaded by the
+// kustomizations under Root, including the kustomizations of the vendored
+// bases. Remote bases are copied under VendorDir at the commit they resolve
+// to, and Helm charts are pulled into the chart home of their kustomization.
+// It returns the vendored sources, which are also recorded in the lock file.
+func (v *Vendorer) Vendor(ctx context.Context) ([]VendoredSource, error) {
+	if v.VendorDir == "" {
+		v.VendorDir = DefaultVendorDir
+	}
+	if v.FetchRepo == nil {
+		v.FetchRepo = gitFetch
+	}
+	if v.PullChart == nil {
+		v.PullChart = helmPull
+	}
+	v.repos = map[string]fetchedRepo{}
+	v.lock = nil
+
+	dirs, err := kustomizationDirs(v.Root)
+	if err != nil {
+		return nil, err
+	}
+	for len(dirs) > 0 {
+		dir := dirs[0]
+		dirs = dirs[1:]
+		vendored, err := v.vendorKustomization(ctx, dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, vendoredDir := range vendored {
+			nested, err := kustomizationDirs(vendoredDir)
+			if err != nil {
+				return nil, err
+			}
+			dirs = append(dirs, nested...)
+		}
+	}
+	if len(v.lock) == 0 {
+		return nil, nil
+	}
+	return v.lock, v.writeLock()
+}
+
+// vendorKustomization vendors the remote sources of the kustomization in the
+// directory, and returns the directories of the newly fetched repositories.
+func (v *Vendorer) vendorKustomization(ctx context.Context, dir string) ([]string, error) {
+	kustFile, k, err := readKustomization(dir)
+	if err != nil || k == nil {
+		return nil, err
+	}
+	node, err := kyaml.ReadFile(kustFile)
+	if err != nil {
+		return nil, err
+	}
+	var fetched []string
+	changed := false
+	for _, field := range []string{"resources", "components", "bases"} {
+		seq := node.Field(field)
+		if seq == nil {
+			continue
+		}
+		elements, err := seq.Value.Elements()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", kustFile, field, err)
+		}
+		for _, el := range elements {
+			url := el.YNode().Value
+			if !isRemoteBase(url) {
+				continue
+			}
+			origin := (&resource.Origin{}).Append(url)
+			key := origin.Repo + "?ref=" + origin.Ref
+			repo, found := v.repos[key]
+			if !found {
+				repo, err = v.fetch(ctx, origin.Repo, origin.Ref)
+				if err != nil {
+					return nil, fmt.Errorf("%s: unable to vendor %s: %w", kustFile, url, err)
+				}
+				v.repos[key] = repo
+				fetched = append(fetched, repo.dir)
+			}
+			target := filepath.Join(repo.dir, filepath.FromSlash(origin.Path))
+			rel, err := filepath.Rel(dir, target)
+			if err != nil {
+				return nil, err
+			}
+			el.YNode().Value = filepath.ToSlash(rel)
+			changed = true
+			v.record(VendoredSource{URL: url, Commit: repo.commit, Path: target})
+		}
+	}
+	if changed {
+		if err := kyaml.WriteFile(node, kustFile); err != nil {
+			return nil, err
+		}
+	}
+
+	chartHome := defaultChartHome
+	if k.HelmGlobals != nil && k.HelmGlobals.ChartHome != "" {
+		chartHome = k.HelmGlobals.ChartHome
+	}
+	chartHome = filepath.Join(dir, filepath.FromSlash(chartHome))
+	for _, chart := range k.HelmCharts {
+		if chart.Repo == "" || hasLocalChart(chartHome, chart) {
+			continue
+		}
+		chartDir := chartHome
+		if chart.Version != "" {
+			chartDir = filepath.Join(chartHome, chart.Name+"-"+chart.Version)
+		}
+		if err := os.MkdirAll(chartDir, 0755); err != nil {
+			return nil, err
+		}
+		if err := v.PullChart(ctx, chart, chartDir); err != nil {
+			return nil, fmt.Errorf("%s: unable to vendor the Helm chart %s from %s: %w", kustFile, chart.Name, chart.Repo, err)
+		}
+		v.record(VendoredSource{
+			URL:     strings.TrimSuffix(chart.Repo, "/") + "/" + chart.Name,
+			Version: chart.Version,
+			Path:    filepath.Join(chartDir, chart.Name),
+		})
+	}
+	return fetched, nil
+}
+
+// fetch fetches the ref of the repository into the vendor directory, named
+// after the repository and the commit.
+func (v *Vendorer) fetch(ctx context.Context, repo, ref string) (fetchedRepo, error) {
+	vendorRoot := filepath.Join(v.Root, v.VendorDir)
+	if err := os.MkdirAll(vendorRoot, 0755); err != nil {
+		return fetchedRepo{}, err
+	}
+	tmpDir, err := os.MkdirTemp(vendorRoot, ".fetch-")
+	if err != nil {
+		return fetchedRepo{}, err
+	}
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+	commit, err := v.FetchRepo(ctx, repo, ref, tmpDir)
+	if err != nil {
+		return fetchedRepo{}, err
+	}
+	dir := filepath.Join(vendorRoot, filepath.FromSlash(repoDirName(repo))+"@"+commit)
+	if err := os.RemoveAll(dir); err != nil {
+		return fetchedRepo{}, err
+	}
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return fetchedRepo{}, err
+	}
+	if err := os.Rename(tmpDir, dir); err != nil {
+		return fetchedRepo{}, err
+	}
+	return fetchedRepo{dir: dir, commit: commit}, nil
+}
+
+// record adds the vendored source to the lock, with a path relative to Root.
+func (v *Vendorer) record(source VendoredSource) {
+	if rel, err := filepath.Rel(v.Root, source.Path); err == nil {
+		source.Path = filepath.ToSlash(rel)
+	}
+	v.lock = append(v.lock, source)
+}
+
+// writeLock writes the vendored sources to the lock file.
+func (v *Vendorer) writeLock() error {
+	sort.SliceStable(v.lock, func(i, j int) bool { return v.lock[i].Path < v.lock[j].Path })
+	content, err := yaml.Marshal(map[string]interface{}{"sources": v.lock})
+	if err != nil {
+		return err
+	}
+	lockFile := filepath.Join(v.Root, v.VendorDir, VendorLockFile)
+	if err := os.MkdirAll(filepath.Dir(lockFile), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(lockFile, content, 0644)
+}
+
+// kustomizationDirs returns the directories with a kustomization file under
+// the root, in lexical order.
+func kustomizationDirs(root string) ([]string, error) {
+	var dirs []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if HasKustomization(d.Name()) {
+			dirs = append(dirs, filepath.Dir(path))
+		}
+		return nil
+	})
+	return dirs, err
+}
+
+// repoDirName returns the directory name of the repository, made of its host
+// and path, like `github.com/org/repo`.
+func repoDirName(repo string) string {
+	name := repo
+	if i := strings.Index(name, "://"); i >= 0 {
+		name = name[i+len("://"):]
+	}
+	if i := strings.Index(name, "@"); i >= 0 && i < strings.IndexAny(name+"/", "/:") {
+		// Remove the user of `git@github.com:org/repo`.
+		name = name[i+1:]
+	}
+	name = strings.ReplaceAll(name, ":", "/")
+	name = strings.TrimSuffix(strings.Trim(name, "/"), ".git")
+	var parts []string
+	for _, p := range strings.Split(name, "/") {
+		if p != "" && p != "." && p != ".." {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// gitFetch fetches the ref of the repository with git, and removes the
+// repository metadata.
+func gitFetch(ctx context.Context, repo, ref, dir string) (string, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"fetch", "--quiet", "--depth=1", repo, ref},
+		{"checkout", "--quiet", "FETCH_HEAD"},
+	} {
+		if _, err := git(ctx, dir, args...); err != nil {
+			return "", err
+		}
+	}
+	commit, err := git(ctx, dir, "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	if err := os.RemoveAll(filepath.Join(dir, ".git")); err != nil {
+		return "", err
+	}
+	return commit, nil
+}
+
+func git(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %s: %w", strings.Join(args, " "), strings.TrimSpace(string(out)), err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// helmPull pulls the Helm chart and extracts it to the directory.
+func helmPull(ctx context.Context, chart types.HelmChart, dir string) error {
+	args := []string{"pull", "--untar", "--untardir", dir}
+	if strings.HasPrefix(chart.Repo, "oci://") {
+		args = append(args, strings.TrimSuffix(chart.Repo, "/")+"/"+chart.Name)
+	} else {
+		args = append(args, chart.Name, "--repo", chart.Repo)
+	}
+	if chart.Version != "" {
+		args = append(args, "--version", chart.Version)
+	}
+	out, err := exec.CommandContext(ctx, Helm, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("helm %s: %s: %w", strings.Join(args, " "), strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/api/types"
+)
+
+func TestVendor(t *testing.T) {
+	// repos are the files of the remote repositories, by repository and ref.
+	repos := map[string]map[string]string{
+		"https://github.com/org/app?ref=v1.0.0": {
+			"base/kustomization.yaml": "resources:\n- deploy.yaml\n- https://github.com/org/common//rbac?ref=main\n",
+			"base/deploy.yaml":        deployment,
+		},
+		"https://github.com/org/common?ref=main": {
+			"rbac/kustomization.yaml": "resources:\n- role.yaml\n",
+			"rbac/role.yaml":          "kind: Role\n",
+		},
+	}
+	commits := map[string]string{
+		"https://github.com/org/app?ref=v1.0.0":  "1111111",
+		"https://github.com/org/common?ref=main": "2222222",
+	}
+	var fetches []string
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"kustomization.yaml": "# The app\nresources:\n- https://github.com/org/app//base?ref=v1.0.0\n- ns.yaml\n" +
+			"helmCharts:\n- name: web\n  repo: https://charts.example.com\n  version: 1.2.3\n",
+		"ns.yaml":                         namespace,
+		"overlay/kustomization.yaml":      "resources:\n- https://github.com/org/app//base?ref=v1.0.0\n",
+		".github/kustomization.yaml":      "resources:\n- https://github.com/org/ignored\n",
+		"local/kustomization.yaml":        "resources:\n- ../ns.yaml\n",
+		"local/charts/db/Chart.yaml":      "name: db\n",
+		"local/charts/db/values.yaml":     "",
+		"local/charts/db/templates/.keep": "",
+	})
+	v := &Vendorer{
+		Root: root,
+		FetchRepo: func(_ context.Context, repo, ref, dir string) (string, error) {
+			key := repo + "?ref=" + ref
+			fetches = append(fetches, key)
+			writeFiles(t, dir, repos[key])
+			return commits[key], nil
+		},
+		PullChart: func(_ context.Context, chart types.HelmChart, dir string) error {
+			writeFiles(t, dir, map[string]string{chart.Name + "/Chart.yaml": "name: " + chart.Name + "\nversion: " + chart.Version + "\n"})
+			return nil
+		},
+	}
+	sources, err := v.Vendor(context.Background())
+	require.NoError(t, err)
+
+	// Each repository is fetched once, including the remote bases of the
+	// vendored bases.
+	assert.Equal(t, []string{
+		"https://github.com/org/app?ref=v1.0.0",
+		"https://github.com/org/common?ref=main",
+	}, fetches)
+	assert.Equal(t, []VendoredSource{
+		{URL: "https://charts.example.com/web", Version: "1.2.3", Path: "charts/web-1.2.3/web"},
+		{URL: "https://github.com/org/app//base?ref=v1.0.0", Commit: "1111111", Path: "vendor/github.com/org/app@1111111/base"},
+		{URL: "https://github.com/org/app//base?ref=v1.0.0", Commit: "1111111", Path: "vendor/github.com/org/app@1111111/base"},
+		{URL: "https://github.com/org/common//rbac?ref=main", Commit: "2222222", Path: "vendor/github.com/org/common@2222222/rbac"},
+	}, sources)
+
+	content, err := os.ReadFile(filepath.Join(root, "kustomization.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "# The app\nresources:\n- vendor/github.com/org/app@1111111/base\n- ns.yaml\n"+
+		"helmCharts:\n- name: web\n  repo: https://charts.example.com\n  version: 1.2.3\n", string(content))
+	content, err = os.ReadFile(filepath.Join(root, "overlay", "kustomization.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "resources:\n- ../vendor/github.com/org/app@1111111/base\n", string(content))
+	content, err = os.ReadFile(filepath.Join(root, "vendor", "github.com", "org", "app@1111111", "base", "kustomization.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "resources:\n- deploy.yaml\n- ../../common@2222222/rbac\n", string(content))
+	_, err = os.Stat(filepath.Join(root, "vendor", VendorLockFile))
+	assert.NoError(t, err)
+
+	// The vendored kustomizations are hermetic.
+	remoteSources, err := FindRemoteSources(root)
+	require.NoError(t, err)
+	assert.Empty(t, remoteSources)
+	remoteSources, err = FindRemoteSources(filepath.Join(root, "overlay"))
+	require.NoError(t, err)
+	assert.Empty(t, remoteSources)
+}
+
+func TestGitFetch(t *testing.T) {
+	repo := t.TempDir()
+	writeFiles(t, repo, map[string]string{"base/kustomization.yaml": "resources: []\n"})
+	for _, args := range [][]string{
+		{"init", "--quiet", "--initial-branch=main"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "init"},
+		{"tag", "v1"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	want, err := git(context.Background(), repo, "rev-parse", "HEAD")
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	commit, err := gitFetch(context.Background(), "file://"+repo, "v1", dir)
+	require.NoError(t, err)
+	assert.Equal(t, want, commit)
+	_, err = os.Stat(filepath.Join(dir, "base", "kustomization.yaml"))
+	assert.NoError(t, err)
+	_, err = os.Stat(filepath.Join(dir, ".git"))
+	assert.True(t, os.IsNotExist(err), "the repository metadata should be removed")
+}
+
+func TestRepoDirName(t *testing.T) {
+	testCases := map[string]string{
+		"https://github.com/org/repo.git":  "github.com/org/repo",
+		"git@github.com:org/repo.git":      "github.com/org/repo",
+		"ssh://git@gitlab.com/group/sub/r": "gitlab.com/group/sub/r",
+		"file:///tmp/../repos/app":         "tmp/repos/app",
+	}
+	for repo, want := range testCases {
+		assert.Equal(t, want, repoDirName(repo), repo)
+	}
+}
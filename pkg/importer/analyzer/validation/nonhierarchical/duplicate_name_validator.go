@@ -15,6 +15,8 @@
 package nonhierarchical
 
 import (
+	"strings"
+
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"kpt.dev/configsync/pkg/status"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -63,3 +65,13 @@ func SelectorMetadataNameCollisionError(kind string, name string, duplicates ...
 			kind, len(duplicates), kind, name).
 		BuildWithResources(duplicates...)
 }
+
+// SourceCollisionError reports that an object is declared in more than one
+// source of a RootSync.
+func SourceCollisionError(gk schema.GroupKind, namespace string, name string, sources []string, duplicates ...client.Object) status.Error {
+	return nameCollisionErrorBuilder.
+		Sprintf("Configs MUST NOT be declared in more than one source of a RootSync. "+
+			"Found %d configs of GroupKind %q in Namespace %q named %q in sources %s. Delete the duplicates from all but one source to fix:",
+			len(duplicates), gk.String(), namespace, name, strings.Join(sources, ", ")).
+		BuildWithResources(duplicates...)
+}
@@ -0,0 +1,37 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nonhierarchical
+
+import (
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IllegalHookAnnotationErrorCode is the error code for IllegalHookAnnotationError.
+const IllegalHookAnnotationErrorCode = "1075"
+
+var illegalHookAnnotationError = status.NewErrorBuilder(IllegalHookAnnotationErrorCode)
+
+// IllegalHookAnnotationError represents an illegal hook annotation, either
+// because of its value or because the object is not a Job or a Pod.
+// Error implements error.
+func IllegalHookAnnotationError(resource client.Object, value string) status.Error {
+	return illegalHookAnnotationError.
+		Sprintf("Config has invalid hook annotation %s=%s. The annotation may only be set on a Job or a Pod, "+
+			"and the value must be one of %q, %q, or %q.",
+			metadata.HookAnnotationKey, value, metadata.HookPreSync, metadata.HookPostSync, metadata.HookSyncFail).
+		BuildWithResources(resource)
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nonhierarchical
+
+import (
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IllegalSyncWaveAnnotationErrorCode is the error code for IllegalSyncWaveAnnotationError.
+const IllegalSyncWaveAnnotationErrorCode = "1074"
+
+var illegalSyncWaveAnnotationError = status.NewErrorBuilder(IllegalSyncWaveAnnotationErrorCode)
+
+// IllegalSyncWaveAnnotationError represents an illegal sync-wave annotation value.
+// Error implements error.
+func IllegalSyncWaveAnnotationError(resource client.Object, value string) status.Error {
+	return illegalSyncWaveAnnotationError.
+		Sprintf("Config has invalid sync-wave annotation %s=%s. If set, the value must be an integer.",
+			metadata.SyncWaveAnnotationKey, value).
+		BuildWithResources(resource)
+}
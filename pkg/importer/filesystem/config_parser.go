@@ -33,6 +33,10 @@ type ConfigParser interface {
 	// ReadClusterNamesFromSelector returns the list of cluster names specified in
 	// the `cluster-name-selector` annotation.
 	ReadClusterNamesFromSelector(filePaths reader.FilePaths) ([]string, status.MultiError)
+
+	// ReadLocalConfig returns the objects marked as local configuration, which
+	// are skipped by Parse.
+	ReadLocalConfig(filePaths reader.FilePaths) ([]ast.FileObject, status.MultiError)
 }
 
 // AsCoreObjects converts a slice of FileObjects to a slice of client.Objects.
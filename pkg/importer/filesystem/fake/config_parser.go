@@ -68,4 +68,9 @@ func (p *ConfigParser) ReadClusterNamesFromSelector(_ reader.FilePaths) ([]strin
 	return nil, nil
 }
 
+// ReadLocalConfig fakes filesystem.ConfigParser.ReadLocalConfig
+func (p *ConfigParser) ReadLocalConfig(_ reader.FilePaths) ([]ast.FileObject, status.MultiError) {
+	return nil, nil
+}
+
 var _ filesystem.ConfigParser = &ConfigParser{}
@@ -87,6 +87,12 @@ func (p *Parser) ReadClusterRegistryResources(filePaths reader.FilePaths, format
 	return p.reader.Read(filePaths)
 }
 
+// ReadLocalConfig returns the objects marked as local configuration, which are
+// skipped by Parse.
+func (p *Parser) ReadLocalConfig(filePaths reader.FilePaths) ([]ast.FileObject, status.MultiError) {
+	return reader.ReadLocalConfig(filePaths)
+}
+
 // ReadClusterNamesFromSelector returns the list of cluster names specified in the `cluster-name-selector` annotation.
 func (p *Parser) ReadClusterNamesFromSelector(filePaths reader.FilePaths) ([]string, status.MultiError) {
 	var clusters []string
@@ -30,6 +30,32 @@ import (
 )
 
 func parseFile(path string) ([]*unstructured.Unstructured, error) {
+	unstructureds, err := decodeFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return filterLocalConfigUnstructured(unstructureds), nil
+}
+
+// parseLocalConfigFile returns the objects in the file which are marked as
+// local configuration.
+func parseLocalConfigFile(path string) ([]*unstructured.Unstructured, error) {
+	unstructureds, err := decodeFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var result []*unstructured.Unstructured
+	for _, u := range unstructureds {
+		if isLocalConfig(u) {
+			result = append(result, u)
+		}
+	}
+	return result, nil
+}
+
+// decodeFile decodes the objects in the file, including the objects marked as
+// local configuration.
+func decodeFile(path string) ([]*unstructured.Unstructured, error) {
 	if !filepath.IsAbs(path) {
 		return nil, errors.New("attempted to read relative path")
 	}
@@ -43,7 +69,7 @@ func parseFile(path string) ([]*unstructured.Unstructured, error) {
 			importer.Metrics.Violations.Inc()
 			return nil, err
 		}
-		return parseYAMLFile(contents)
+		return decodeYAMLFile(contents)
 	case ".json":
 		contents, err := os.ReadFile(path)
 		if err != nil {
@@ -52,7 +78,7 @@ func parseFile(path string) ([]*unstructured.Unstructured, error) {
 			importer.Metrics.Violations.Inc()
 			return nil, err
 		}
-		return parseJSONFile(contents)
+		return decodeJSONFile(contents)
 	default:
 		return nil, nil
 	}
@@ -71,7 +97,17 @@ func isEmptyYAMLDocument(document string) bool {
 	return true
 }
 
-// parseYAMLFile parses a byte array as a YAML document stream.
+// parseYAMLFile parses a byte array as a YAML document stream, skipping the
+// objects marked as local configuration.
+func parseYAMLFile(contents []byte) ([]*unstructured.Unstructured, error) {
+	unstructureds, err := decodeYAMLFile(contents)
+	if err != nil {
+		return nil, err
+	}
+	return filterLocalConfigUnstructured(unstructureds), nil
+}
+
+// decodeYAMLFile decodes a byte array as a YAML document stream.
 // Each document, if not empty, is decoded into an Unstructured object.
 //
 // According to the YAML spec, `---` is the directive end marker, indicating the
@@ -88,7 +124,7 @@ func isEmptyYAMLDocument(document string) bool {
 //
 // YAML Spec for document streams:
 // https://yaml.org/spec/1.2.2/#document-stream-productions
-func parseYAMLFile(contents []byte) ([]*unstructured.Unstructured, error) {
+func decodeYAMLFile(contents []byte) ([]*unstructured.Unstructured, error) {
 	// We have to manually split documents with the YAML separator since by default
 	// yaml.Unmarshal only unmarshalls the first document, but a file may contain multiple.
 	var result []*unstructured.Unstructured
@@ -109,10 +145,20 @@ func parseYAMLFile(contents []byte) ([]*unstructured.Unstructured, error) {
 		}
 		result = append(result, &u)
 	}
-	return filterLocalConfigUnstructured(result), nil
+	return result, nil
 }
 
+// parseJSONFile parses a byte array as a JSON object, skipping the object if
+// it is marked as local configuration.
 func parseJSONFile(contents []byte) ([]*unstructured.Unstructured, error) {
+	unstructureds, err := decodeJSONFile(contents)
+	if err != nil {
+		return nil, err
+	}
+	return filterLocalConfigUnstructured(unstructureds), nil
+}
+
+func decodeJSONFile(contents []byte) ([]*unstructured.Unstructured, error) {
 	if len(contents) == 0 {
 		// While an empty files is not valid JSON, Kubernetes allows empty JSON
 		// files when applying multiple files.
@@ -126,7 +172,7 @@ func parseJSONFile(contents []byte) ([]*unstructured.Unstructured, error) {
 	if err != nil {
 		return nil, err
 	}
-	return filterLocalConfigUnstructured([]*unstructured.Unstructured{&u}), nil
+	return []*unstructured.Unstructured{&u}, nil
 }
 
 func hasStatusField(u runtime.Unstructured) bool {
@@ -143,11 +189,17 @@ func hasStatusField(u runtime.Unstructured) bool {
 func filterLocalConfigUnstructured(unstructureds []*unstructured.Unstructured) []*unstructured.Unstructured {
 	var result []*unstructured.Unstructured
 	for _, u := range unstructureds {
-		annoVal, found := u.GetAnnotations()[metadata.LocalConfigAnnotationKey]
-		if found && annoVal != metadata.NoLocalConfigAnnoVal {
+		if isLocalConfig(u) {
 			continue
 		}
 		result = append(result, u)
 	}
 	return result
 }
+
+// isLocalConfig returns true if the object is marked as local configuration,
+// which is not applied to the cluster.
+func isLocalConfig(u *unstructured.Unstructured) bool {
+	annoVal, found := u.GetAnnotations()[metadata.LocalConfigAnnotationKey]
+	return found && annoVal != metadata.NoLocalConfigAnnoVal
+}
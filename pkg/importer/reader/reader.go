@@ -58,11 +58,24 @@ type File struct{}
 
 var _ Reader = &File{}
 
+// Read implements Reader.
 func (r *File) Read(filePaths FilePaths) ([]ast.FileObject, status.MultiError) {
+	return readFiles(filePaths, parseFile)
+}
+
+// ReadLocalConfig returns the list of FileObjects in the passed files which
+// are marked as local configuration, and so are skipped by Read. Local
+// configuration is never applied, but may configure how the other objects are
+// validated.
+func ReadLocalConfig(filePaths FilePaths) ([]ast.FileObject, status.MultiError) {
+	return readFiles(filePaths, parseLocalConfigFile)
+}
+
+func readFiles(filePaths FilePaths, parse func(path string) ([]*unstructured.Unstructured, error)) ([]ast.FileObject, status.MultiError) {
 	var objs []ast.FileObject
 	var errs status.MultiError
 	for _, f := range filePaths.Files {
-		newObjs, err := r.read(filePaths.RootDir, filePaths.PolicyDir, f)
+		newObjs, err := read(filePaths.RootDir, filePaths.PolicyDir, f, parse)
 		if err != nil {
 			errs = status.Append(errs, err)
 			continue
@@ -75,8 +88,7 @@ func (r *File) Read(filePaths FilePaths) ([]ast.FileObject, status.MultiError) {
 	return objs, nil
 }
 
-// Read implements Reader.
-func (r *File) read(rootDir cmpath.Absolute, policyDir cmpath.Relative, file cmpath.Absolute) ([]ast.FileObject, status.MultiError) {
+func read(rootDir cmpath.Absolute, policyDir cmpath.Relative, file cmpath.Absolute, parse func(path string) ([]*unstructured.Unstructured, error)) ([]ast.FileObject, status.MultiError) {
 	splitPath := strings.Split(file.OSPath(), "/")
 	for _, pathPiece := range splitPath {
 		if pathPiece == ".github" || pathPiece == ".gitlab" || pathPiece == ".gitlab-ci.yml" {
@@ -85,7 +97,7 @@ func (r *File) read(rootDir cmpath.Absolute, policyDir cmpath.Relative, file cmp
 		}
 	}
 
-	unstructureds, err := parseFile(file.OSPath())
+	unstructureds, err := parse(file.OSPath())
 	if err != nil {
 		return nil, status.PathWrapError(err, file.OSPath())
 	}
@@ -0,0 +1,77 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/core"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// HookPhase is the type used to identify value enums to use with the hook
+// annotation.
+type HookPhase string
+
+// String returns the string value of the HookPhase.
+// Implements the Stringer interface.
+func (p HookPhase) String() string {
+	return string(p)
+}
+
+const (
+	// HookAnnotationKey is the annotation key set by Config Sync users on a
+	// Job or Pod to run it as a sync hook, instead of managing it.
+	// Hooks are not tracked in the inventory. They are re-created by the
+	// applier for each commit, at the phase of the sync specified by the
+	// annotation value, with the SyncKindLabel, SyncNameLabel and
+	// SyncNamespaceLabel labels of their RSync. The hooks which are no longer
+	// declared are deleted, using these labels.
+	HookAnnotationKey = configsync.ConfigSyncPrefix + "hook"
+	// HookCommitAnnotationKey is the annotation key set by the applier on the
+	// hooks it runs, to the commit they were run for. The hooks of a commit
+	// are not run again, even after the reconciler restarts.
+	HookCommitAnnotationKey = configsync.ConfigSyncPrefix + "hook-commit"
+	// HookPreSync hooks run before the objects are applied. The declared
+	// Namespaces of the pre-sync hooks are applied first, if they do not exist.
+	// If a pre-sync hook fails, the objects are not applied.
+	HookPreSync HookPhase = "pre-sync"
+	// HookPostSync hooks run after the objects are applied without errors.
+	HookPostSync HookPhase = "post-sync"
+	// HookSyncFail hooks run when a pre-sync hook fails, or when the objects
+	// fail to apply.
+	HookSyncFail HookPhase = "sync-fail"
+)
+
+// HookPhases are the valid values of the hook annotation, in the order they
+// may run.
+var HookPhases = []HookPhase{HookPreSync, HookPostSync, HookSyncFail}
+
+// IsHook returns true if the object has the hook annotation, with any value.
+func IsHook(obj client.Object) bool {
+	_, found := obj.GetAnnotations()[HookAnnotationKey]
+	return found
+}
+
+// HasHookPhase returns true if the hook annotation is set to the specified
+// phase.
+func HasHookPhase(obj client.Object, phase HookPhase) bool {
+	return core.GetAnnotation(obj, HookAnnotationKey) == phase.String()
+}
+
+// WithHookPhase returns a MetaMutator that sets the hook annotation on an
+// Object.
+func WithHookPhase(phase HookPhase) core.MetaMutator {
+	return core.Annotation(HookAnnotationKey, phase.String())
+}
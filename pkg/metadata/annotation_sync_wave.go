@@ -0,0 +1,47 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"strconv"
+
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/core"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SyncWaveAnnotationKey is the annotation key set by Config Sync users on a
+// managed resource to group it into a sync wave. The value must be an integer.
+// The waves are applied in ascending order, and the objects of each wave must
+// be reconciled before the next wave is applied.
+// Objects without the annotation are in wave 0.
+const SyncWaveAnnotationKey = configsync.ConfigSyncPrefix + "sync-wave"
+
+// GetSyncWave returns the sync wave of the object, or 0 if the object does
+// not have the sync-wave annotation.
+// Returns an error if the annotation value is not an integer.
+func GetSyncWave(obj client.Object) (int, error) {
+	value, found := obj.GetAnnotations()[SyncWaveAnnotationKey]
+	if !found {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// WithSyncWave returns a MetaMutator that sets the sync-wave annotation on an
+// Object.
+func WithSyncWave(wave int) core.MetaMutator {
+	return core.Annotation(SyncWaveAnnotationKey, strconv.Itoa(wave))
+}
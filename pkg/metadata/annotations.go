@@ -201,6 +201,16 @@ const KustomizeOrigin = "config.kubernetes.io/origin"
 // FleetWorkloadIdentityCredentials is the key for the credentials file of the Fleet Workload Identity.
 const FleetWorkloadIdentityCredentials = "config.kubernetes.io/fleet-workload-identity"
 
+// SparseCheckoutPatterns is the key for the patterns of the git-sync sparse
+// checkout file, set on the reconciler Pod template.
+const SparseCheckoutPatterns = configsync.ConfigSyncPrefix + "sparse-checkout"
+
+// SourceChangeAnnotationKey is the annotation key set on a RootSync or RepoSync
+// by the source webhook receiver of the reconciler-manager, when a change is
+// pushed to the source. The value is the pushed commit, or the time the change
+// was received if the commit is unknown.
+const SourceChangeAnnotationKey = configsync.ConfigSyncPrefix + "source-change"
+
 // StatusMode is the type used to identify value enums to use with the
 // `configsync.gke.io/status` annotation.
 type StatusMode string
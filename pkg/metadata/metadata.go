@@ -56,6 +56,8 @@ var sourceAnnotations = map[string]bool{
 	ManagementModeAnnotationKey:            true,
 	LifecycleMutationAnnotation:            true,
 	DeletionPropagationPolicyAnnotationKey: true,
+	SyncWaveAnnotationKey:                  true,
+	HookAnnotationKey:                      true,
 }
 
 // IsSourceAnnotation returns true if the annotation is a ConfigSync source
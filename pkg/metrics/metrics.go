@@ -45,6 +45,8 @@ const (
 	ResourceConflictsName = "resource_conflicts_total"
 	// InternalErrorsName is the name of internal error count metric
 	InternalErrorsName = "internal_errors_total"
+	// DriftedResourcesName is the name of drifted resource count metric
+	DriftedResourcesName = "drifted_resources"
 )
 
 var (
@@ -134,4 +136,10 @@ var (
 		InternalErrorsName,
 		"The number of internal errors triggered by Config Sync",
 		stats.UnitDimensionless)
+
+	// DriftedResources metric measures the number of managed resources which drifted from their declared state.
+	DriftedResources = stats.Int64(
+		DriftedResourcesName,
+		"The number of managed resources which drifted from their declared state",
+		stats.UnitDimensionless)
 )
@@ -164,6 +164,12 @@ func RecordResourceConflict(ctx context.Context, commit string) {
 	record(tagCtx, measurement)
 }
 
+// RecordDriftedResources produces a measurement for the DriftedResources view.
+func RecordDriftedResources(ctx context.Context, numResources int) {
+	measurement := DriftedResources.M(int64(numResources))
+	record(ctx, measurement)
+}
+
 // RecordInternalError produces measurements for the InternalErrors view.
 func RecordInternalError(ctx context.Context, source string) {
 	tagCtx, _ := tag.New(ctx, tag.Upsert(KeyInternalErrorSource, source))
@@ -63,5 +63,6 @@ func RegisterReconcilerMetricsViews() error {
 		ResourceConflictsView,
 		InternalErrorsView,
 		PipelineErrorView,
+		DriftedResourcesView,
 	)
 }
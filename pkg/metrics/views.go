@@ -153,4 +153,12 @@ var (
 		TagKeys:     []tag.Key{KeyInternalErrorSource},
 		Aggregation: view.Count(),
 	}
+
+	// DriftedResourcesView aggregates the DriftedResources metric measurements.
+	DriftedResourcesView = &view.View{
+		Name:        DriftedResourcesName,
+		Measure:     DriftedResources,
+		Description: "The current number of managed resources which drifted from their declared state",
+		Aggregation: view.LastValue(),
+	}
 )
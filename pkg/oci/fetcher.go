@@ -28,6 +28,8 @@ import (
 	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/provenance"
+	"kpt.dev/configsync/pkg/status"
 	"kpt.dev/configsync/pkg/util"
 )
 
@@ -48,11 +50,29 @@ func HasDigest(imageName string) bool {
 type Fetcher struct {
 	// Authenticator is used to authenticate with the OCI repository.
 	Authenticator authn.Authenticator
+	// Verifier verifies the signatures of the image before it is extracted.
+	// Verification is skipped when nil.
+	Verifier *Verifier
+	// TagPolicy selects the tag of the image among the tags of the image
+	// repository. The image is pulled as specified when nil.
+	TagPolicy *TagPolicy
 }
 
 // FetchPackage fetches the package from the OCI repository and write it to the destination.
 func (f *Fetcher) FetchPackage(ctx context.Context, imageName, ociRoot, rev string) error {
-	image, err := PullImage(imageName, remote.WithContext(ctx), remote.WithAuth(f.Authenticator))
+	options := []remote.Option{remote.WithContext(ctx), remote.WithAuth(f.Authenticator)}
+	if f.TagPolicy != nil {
+		repo, err := name.NewRepository(imageName)
+		if err != nil {
+			return fmt.Errorf("failed to parse repository %q: %v", imageName, err)
+		}
+		tag, err := f.TagPolicy.Resolve(ctx, repo, options...)
+		if err != nil {
+			return err
+		}
+		imageName = repo.Tag(tag).String()
+	}
+	image, err := PullImage(imageName, options...)
 	if err != nil {
 		return err
 	}
@@ -63,7 +83,23 @@ func (f *Fetcher) FetchPackage(ctx context.Context, imageName, ociRoot, rev stri
 		return fmt.Errorf("failed to calculate image digest: %w", err)
 	}
 
+	// Verify the image on every fetch, even if the digest is unchanged, so
+	// that revoked signers stop the sync.
+	if f.Verifier != nil {
+		ref, err := name.ParseReference(imageName)
+		if err != nil {
+			return fmt.Errorf("failed to parse reference %q: %v", imageName, err)
+		}
+		if _, err := f.Verifier.Verify(ref, imageDigestHash, options...); err != nil {
+			return status.SourceVerificationError(fmt.Errorf("failed to verify image %s: %w", imageName, err))
+		}
+	}
+
 	destDir := filepath.Join(ociRoot, imageDigestHash.Hex)
+	resolution := provenance.ImageResolution{
+		Tag:    imageTag(imageName),
+		Digest: imageDigestHash.String(),
+	}
 
 	linkPath := filepath.Join(ociRoot, rev)
 	oldDir, err := filepath.EvalSymlinks(linkPath)
@@ -72,7 +108,8 @@ func (f *Fetcher) FetchPackage(ctx context.Context, imageName, ociRoot, rev stri
 	}
 	if oldDir == destDir {
 		klog.Infof("no update required with the same image digest hash %q", imageDigestHash)
-		return nil
+		// The same image may have been pushed with a new tag.
+		return provenance.WriteImageResolution(destDir, resolution)
 	}
 
 	if _, err = os.Stat(destDir); os.IsNotExist(err) {
@@ -89,8 +126,31 @@ func (f *Fetcher) FetchPackage(ctx context.Context, imageName, ociRoot, rev stri
 		return fmt.Errorf("failed to extract the image and write to the directory %q: %w", destDir, err)
 	}
 
+	// Record the resolution before the symlink is updated, so the reconciler
+	// never reads the extracted image without it.
+	if err := provenance.WriteImageResolution(destDir, resolution); err != nil {
+		return err
+	}
+
 	klog.Infof("pulled image digest %q", imageDigestHash)
-	return util.UpdateSymlink(ociRoot, linkPath, destDir, oldDir)
+	if err := util.UpdateSymlink(ociRoot, linkPath, destDir, oldDir); err != nil {
+		return err
+	}
+	if oldDir != "" {
+		if err := os.Remove(provenance.ImageResolutionPath(oldDir)); err != nil && !os.IsNotExist(err) {
+			klog.Warningf("unable to remove the previous image resolution: %v", err)
+		}
+	}
+	return nil
+}
+
+// imageTag returns the tag of the image, or an empty string if the image is
+// pulled by digest.
+func imageTag(imageName string) string {
+	if tag, err := name.NewTag(imageName); err == nil {
+		return tag.TagStr()
+	}
+	return ""
 }
 
 // PullImage pulls image from source using provided options for auth credentials
@@ -0,0 +1,206 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	semverrange "github.com/Masterminds/semver/v3"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"k8s.io/klog/v2"
+)
+
+// createdAnnotation is the OCI annotation recording the creation time of an
+// image, which takes precedence over the creation time of the image config.
+const createdAnnotation = "org.opencontainers.image.created"
+
+// maxLatestCandidates is the maximum number of tags the latest tag policy
+// compares. The creation time of each tag is read from its manifest and config,
+// so the regex must narrow the candidates.
+const maxLatestCandidates = 50
+
+// cosignTagPattern matches the tags cosign uses to attach signatures,
+// attestations and SBOMs to an image, which are never synced.
+var cosignTagPattern = regexp.MustCompile(`^sha256-[0-9a-f]{64}\.(sig|att|sbom)$`)
+
+// TagPolicy selects the tag of the image to pull, among the tags of the image
+// repository.
+type TagPolicy struct {
+	// Semver is a semantic version constraint. The highest matching tag is
+	// selected.
+	Semver string
+	// Regex is a regular expression the tags must match to be selected.
+	Regex string
+	// Latest selects the most recently created image, among the tags matching
+	// Regex.
+	Latest bool
+}
+
+// Validate returns an error if the policy is invalid.
+func (p *TagPolicy) Validate() error {
+	if (p.Semver == "") == !p.Latest {
+		return errors.New("exactly one of the semver and latest tag policies must be set")
+	}
+	if p.Latest && p.Regex == "" {
+		return errors.New("the latest tag policy requires a regex")
+	}
+	if p.Semver != "" {
+		if _, err := semverrange.NewConstraint(p.Semver); err != nil {
+			return fmt.Errorf("invalid semver tag policy %q: %w", p.Semver, err)
+		}
+	}
+	if p.Regex != "" {
+		if _, err := regexp.Compile(p.Regex); err != nil {
+			return fmt.Errorf("invalid regex tag policy %q: %w", p.Regex, err)
+		}
+	}
+	return nil
+}
+
+// Resolve lists the tags of the repository, and returns the tag selected by
+// the policy.
+func (p *TagPolicy) Resolve(ctx context.Context, repo name.Repository, options ...remote.Option) (string, error) {
+	if err := p.Validate(); err != nil {
+		return "", err
+	}
+	tags, err := remote.ListWithContext(ctx, repo, options...)
+	if err != nil {
+		return "", fmt.Errorf("failed to list the tags of %s: %w", repo, err)
+	}
+	candidates, err := p.filter(tags)
+	if err != nil {
+		return "", err
+	}
+	var tag string
+	if p.Latest {
+		tag, err = latestTag(ctx, repo, candidates, options...)
+	} else {
+		tag, err = highestTag(p.Semver, candidates)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the tag of %s: %w", repo, err)
+	}
+	klog.Infof("resolved tag %q of %s", tag, repo)
+	return tag, nil
+}
+
+// filter returns the tags matching the regular expression of the policy,
+// excluding the tags attached by cosign.
+func (p *TagPolicy) filter(tags []string) ([]string, error) {
+	var re *regexp.Regexp
+	if p.Regex != "" {
+		var err error
+		if re, err = regexp.Compile(p.Regex); err != nil {
+			return nil, fmt.Errorf("invalid regex tag policy %q: %w", p.Regex, err)
+		}
+	}
+	var result []string
+	for _, tag := range tags {
+		if cosignTagPattern.MatchString(tag) {
+			continue
+		}
+		if re != nil && !re.MatchString(tag) {
+			continue
+		}
+		result = append(result, tag)
+	}
+	return result, nil
+}
+
+// highestTag returns the highest tag matching the semantic version constraint.
+func highestTag(constraint string, tags []string) (string, error) {
+	c, err := semverrange.NewConstraint(constraint)
+	if err != nil {
+		return "", fmt.Errorf("invalid semver tag policy %q: %w", constraint, err)
+	}
+	var resolved string
+	var highest *semverrange.Version
+	for _, tag := range tags {
+		v, err := semverrange.NewVersion(tag)
+		if err != nil || !c.Check(v) {
+			continue
+		}
+		if highest == nil || v.GreaterThan(highest) {
+			resolved = tag
+			highest = v
+		}
+	}
+	if highest == nil {
+		return "", fmt.Errorf("no tag matches the semver tag policy %q", constraint)
+	}
+	return resolved, nil
+}
+
+// latestTag returns the tag of the most recently created image. Registries
+// don't expose when a tag was pushed, so the creation time is read from the
+// image annotations, or from the image config. Tags whose creation time can't
+// be read are skipped.
+func latestTag(ctx context.Context, repo name.Repository, tags []string, options ...remote.Option) (string, error) {
+	if len(tags) > maxLatestCandidates {
+		return "", fmt.Errorf("%d tags match the latest tag policy, more than the maximum of %d: narrow the regex",
+			len(tags), maxLatestCandidates)
+	}
+	options = append(options[:len(options):len(options)], remote.WithContext(ctx))
+	var resolved string
+	var latest time.Time
+	var lastErr error
+	for _, tag := range tags {
+		created, err := createdTime(repo.Tag(tag), options...)
+		if err != nil {
+			klog.Warningf("skipping tag %q of %s: %v", tag, repo, err)
+			lastErr = err
+			continue
+		}
+		if resolved == "" || created.After(latest) {
+			resolved = tag
+			latest = created
+		}
+	}
+	if resolved == "" {
+		if lastErr != nil {
+			return "", fmt.Errorf("failed to read the creation time of the tags matching the latest tag policy: %w", lastErr)
+		}
+		return "", errors.New("no tag matches the latest tag policy")
+	}
+	return resolved, nil
+}
+
+// createdTime returns the creation time of the image.
+func createdTime(ref name.Reference, options ...remote.Option) (time.Time, error) {
+	image, err := remote.Image(ref, options...)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to pull image %s: %w", ref, err)
+	}
+	manifest, err := image.Manifest()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read the manifest of %s: %w", ref, err)
+	}
+	if created, ok := manifest.Annotations[createdAnnotation]; ok {
+		if t, err := time.Parse(time.RFC3339, strings.TrimSpace(created)); err == nil {
+			return t, nil
+		}
+	}
+	config, err := image.ConfigFile()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read the config of %s: %w", ref, err)
+	}
+	return config.Created.Time, nil
+}
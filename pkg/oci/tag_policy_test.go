@@ -0,0 +1,175 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/provenance"
+)
+
+// pushTaggedImage pushes an image with a single file, created at the
+// specified time, and returns its digest.
+func pushTaggedImage(t *testing.T, repo name.Repository, tag, content string, created time.Time) v1.Hash {
+	t.Helper()
+	layer := static.NewLayer(tarFile(t, "ns.yaml", content), types.DockerUncompressedLayer)
+	image, err := mutate.AppendLayers(empty.Image, layer)
+	require.NoError(t, err)
+	image, err = mutate.CreatedAt(image, v1.Time{Time: created})
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(repo.Tag(tag), image))
+	digest, err := image.Digest()
+	require.NoError(t, err)
+	return digest
+}
+
+// pushBrokenImage pushes an image whose config blob is deleted, so its creation
+// time can't be read.
+func pushBrokenImage(t *testing.T, repo name.Repository, tag string) {
+	t.Helper()
+	pushTaggedImage(t, repo, tag, tag, time.Now())
+	image, err := remote.Image(repo.Tag(tag))
+	require.NoError(t, err)
+	config, err := image.ConfigName()
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodDelete,
+		fmt.Sprintf("http://%s/v2/%s/blobs/%s", repo.RegistryStr(), repo.RepositoryStr(), config), nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+}
+
+func TestTagPolicyResolve(t *testing.T) {
+	repo := newTestRegistry(t)
+	now := time.Now().UTC().Truncate(time.Second)
+	pushTaggedImage(t, repo, "v1.3.0", "v1.3.0", now.Add(-5*time.Hour))
+	pushTaggedImage(t, repo, "v1.4.2", "v1.4.2", now.Add(-4*time.Hour))
+	pushTaggedImage(t, repo, "v1.5.0", "v1.5.0", now.Add(-3*time.Hour))
+	pushTaggedImage(t, repo, "v2.0.0", "v2.0.0", now.Add(-2*time.Hour))
+	digest := pushTaggedImage(t, repo, "main-1a2b3c", "main-1a2b3c", now.Add(-1*time.Hour))
+	pushLayers(t, repo, digest, signatureTagSuffix)
+	pushBrokenImage(t, repo, "main-broken")
+
+	testCases := map[string]struct {
+		policy  TagPolicy
+		want    string
+		wantErr string
+	}{
+		"semver range": {
+			policy: TagPolicy{Semver: ">=1.4.0 <2.0.0"},
+			want:   "v1.5.0",
+		},
+		"semver with regex": {
+			policy: TagPolicy{Semver: ">=1.0.0", Regex: `^v1\.`},
+			want:   "v1.5.0",
+		},
+		"latest": {
+			policy: TagPolicy{Latest: true, Regex: `^v`},
+			want:   "v2.0.0",
+		},
+		"latest skips the tags which fail": {
+			policy: TagPolicy{Latest: true, Regex: `^main-`},
+			want:   "main-1a2b3c",
+		},
+		"latest with only failing tags": {
+			policy:  TagPolicy{Latest: true, Regex: `^main-broken$`},
+			wantErr: "failed to read the creation time of the tags matching the latest tag policy",
+		},
+		"latest without regex": {
+			policy:  TagPolicy{Latest: true},
+			wantErr: "the latest tag policy requires a regex",
+		},
+		"no semver match": {
+			policy:  TagPolicy{Semver: ">=3.0.0"},
+			wantErr: `no tag matches the semver tag policy ">=3.0.0"`,
+		},
+		"no latest match": {
+			policy:  TagPolicy{Latest: true, Regex: `^release-`},
+			wantErr: "no tag matches the latest tag policy",
+		},
+		"semver and latest": {
+			policy:  TagPolicy{Semver: "~1.4", Latest: true},
+			wantErr: "exactly one of the semver and latest tag policies must be set",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.policy.Resolve(context.Background(), repo)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestFetchPackageWithTagPolicy(t *testing.T) {
+	repo := newTestRegistry(t)
+	now := time.Now().UTC().Truncate(time.Second)
+	pushTaggedImage(t, repo, "v1.4.0", "v1.4.0", now)
+	fetcher := &Fetcher{
+		Authenticator: authn.Anonymous,
+		TagPolicy:     &TagPolicy{Semver: "~1.4"},
+	}
+	ociRoot := t.TempDir()
+	require.NoError(t, fetcher.FetchPackage(context.Background(), repo.String(), ociRoot, "rev"))
+
+	// A new artifact matching the policy is pulled on the next fetch, and the
+	// resolution of the previous artifact is removed with it.
+	oldDir, err := filepath.EvalSymlinks(filepath.Join(ociRoot, "rev"))
+	require.NoError(t, err)
+	digest := pushTaggedImage(t, repo, "v1.4.1", "v1.4.1", now)
+	require.NoError(t, fetcher.FetchPackage(context.Background(), repo.String(), ociRoot, "rev"))
+	content, err := os.ReadFile(filepath.Join(ociRoot, "rev", "ns.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "v1.4.1", string(content))
+
+	resolution, err := provenance.ReadImageResolution(filepath.Join(ociRoot, digest.Hex))
+	require.NoError(t, err)
+	assert.Equal(t, &provenance.ImageResolution{Tag: "v1.4.1", Digest: digest.String()}, resolution)
+	_, err = os.Stat(provenance.ImageResolutionPath(oldDir))
+	assert.True(t, os.IsNotExist(err), "the previous image resolution should be removed")
+}
+
+func TestLatestTagMaxCandidates(t *testing.T) {
+	repo, err := name.NewRepository("registry.example.com/configs")
+	require.NoError(t, err)
+	tags := make([]string, maxLatestCandidates+1)
+	for i := range tags {
+		tags[i] = fmt.Sprintf("main-%d", i)
+	}
+	_, err = latestTag(context.Background(), repo, tags)
+	assert.ErrorContains(t, err, "51 tags match the latest tag policy, more than the maximum of 50: narrow the regex")
+}
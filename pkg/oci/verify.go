@@ -0,0 +1,583 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/sigstore/sigstore-go/pkg/bundle"
+	"github.com/sigstore/sigstore-go/pkg/root"
+	"github.com/sigstore/sigstore-go/pkg/tlog"
+	"github.com/sigstore/sigstore-go/pkg/verify"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// signatureAnnotation is the layer annotation holding the base64-encoded
+	// signature of a cosign simple signing payload.
+	signatureAnnotation = "dev.cosignproject.cosign/signature"
+	// certificateAnnotation is the layer annotation holding the PEM-encoded
+	// signing certificate of keyless signatures.
+	certificateAnnotation = "dev.sigstore.cosign/certificate"
+	// bundleAnnotation is the layer annotation holding the Rekor bundle, which
+	// proves when the signature was recorded in the transparency log.
+	bundleAnnotation = "dev.sigstore.cosign/bundle"
+
+	// signatureTagSuffix is the suffix of the tag cosign stores signatures at.
+	signatureTagSuffix = ".sig"
+	// attestationTagSuffix is the suffix of the tag cosign stores
+	// attestations at.
+	attestationTagSuffix = ".att"
+
+	// rekorPublicKeyFile is the name of the trusted keys file holding the
+	// Rekor public keys, for keyless verification.
+	rekorPublicKeyFile = "rekor.pub"
+	// ctLogPublicKeyFile is the name of the trusted keys file holding the
+	// public keys of the certificate transparency logs, which sign the
+	// timestamps embedded in Fulcio certificates, for keyless verification.
+	ctLogPublicKeyFile = "ctfe.pub"
+)
+
+// Verifier verifies the cosign signatures and attestations of images.
+//
+// Signatures are verified either with trusted public keys, or keyless, with a
+// Fulcio certificate issued to the expected identity. Keyless signing
+// certificates must be recorded in a certificate transparency log, and keyless
+// signatures in the Rekor transparency log while the certificate was valid.
+// The verification itself is done by sigstore-go.
+type Verifier struct {
+	// KeysDir is the directory containing the trusted material.
+	// The material is reloaded for every verification, to pick up Secret
+	// updates.
+	KeysDir string
+	// Identity is the expected subject alternative name of keyless signing
+	// certificates. Keyless verification is used when it is set.
+	Identity string
+	// Issuer is the expected OIDC issuer of keyless signing certificates.
+	Issuer string
+	// Attestations is the list of in-toto predicate types that must be
+	// attested by a trusted signer.
+	Attestations []string
+}
+
+// keyless returns whether keyless verification is used.
+func (v *Verifier) keyless() bool {
+	return v.Identity != ""
+}
+
+// Verify checks that the image with the specified digest is signed by a
+// trusted signer, and that it has all the required attestations.
+// It returns the identity of the signer.
+func (v *Verifier) Verify(ref name.Reference, digest v1.Hash, options ...remote.Option) (string, error) {
+	trust, err := loadTrustRoot(v.KeysDir, v.keyless())
+	if err != nil {
+		return "", err
+	}
+	signer, err := v.verifySignatures(trust, ref, digest, options...)
+	if err != nil {
+		return "", err
+	}
+	if len(v.Attestations) > 0 {
+		if err := v.verifyAttestations(trust, ref, digest, options...); err != nil {
+			return "", err
+		}
+	}
+	klog.Infof("Verified the signature of image digest %q, signed by %s", digest, signer)
+	return signer, nil
+}
+
+// trustRoot is the trusted material used to verify signatures.
+type trustRoot struct {
+	// publicKeys are the trusted public keys, by fingerprint, for key-based
+	// verification.
+	publicKeys map[string]crypto.PublicKey
+	// material is the trusted material of sigstore-go: the public keys for
+	// key-based verification, or the Fulcio certificates and the keys of the
+	// transparency logs for keyless verification.
+	material root.TrustedMaterial
+}
+
+// loadTrustRoot reads the PEM-encoded public keys and certificates from the
+// files in the specified directory, usually a mounted Secret.
+func loadTrustRoot(dir string, keyless bool) (*trustRoot, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading the trusted keys directory %q: %w", dir, err)
+	}
+	var publicKeys, rekorKeys, ctLogKeys []crypto.PublicKey
+	var roots, intermediates []*x509.Certificate
+	for _, entry := range entries {
+		// Skip the hidden files and directories created by the kubelet when
+		// mounting Secrets.
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading the trusted keys file %q: %w", path, err)
+		}
+		if info.IsDir() {
+			continue
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading the trusted keys file %q: %w", path, err)
+		}
+		for block, rest := pem.Decode(content); block != nil; block, rest = pem.Decode(rest) {
+			switch block.Type {
+			case "PUBLIC KEY":
+				key, err := x509.ParsePKIXPublicKey(block.Bytes)
+				if err != nil {
+					return nil, fmt.Errorf("parsing the public key %q: %w", entry.Name(), err)
+				}
+				switch entry.Name() {
+				case rekorPublicKeyFile:
+					rekorKeys = append(rekorKeys, key)
+				case ctLogPublicKeyFile:
+					ctLogKeys = append(ctLogKeys, key)
+				default:
+					publicKeys = append(publicKeys, key)
+				}
+			case "CERTIFICATE":
+				cert, err := x509.ParseCertificate(block.Bytes)
+				if err != nil {
+					return nil, fmt.Errorf("parsing the certificate %q: %w", entry.Name(), err)
+				}
+				if bytes.Equal(cert.RawIssuer, cert.RawSubject) {
+					roots = append(roots, cert)
+				} else {
+					intermediates = append(intermediates, cert)
+				}
+			}
+		}
+	}
+	switch {
+	case keyless && len(roots) == 0:
+		return nil, fmt.Errorf("no trusted root certificates found in %q", dir)
+	case keyless && len(rekorKeys) == 0:
+		return nil, fmt.Errorf("no Rekor public key found in %q", filepath.Join(dir, rekorPublicKeyFile))
+	case keyless && len(ctLogKeys) == 0:
+		return nil, fmt.Errorf("no certificate transparency log public key found in %q", filepath.Join(dir, ctLogPublicKeyFile))
+	case !keyless && len(publicKeys) == 0:
+		return nil, fmt.Errorf("no trusted public keys found in %q", dir)
+	}
+
+	if !keyless {
+		trust := &trustRoot{publicKeys: make(map[string]crypto.PublicKey)}
+		verifiers := make(map[string]*root.ExpiringKey)
+		for _, key := range publicKeys {
+			verifier, err := signature.LoadVerifier(key, crypto.SHA256)
+			if err != nil {
+				return nil, fmt.Errorf("loading the public key: %w", err)
+			}
+			fingerprint := keyFingerprint(key)
+			trust.publicKeys[fingerprint] = key
+			verifiers[fingerprint] = root.NewExpiringKey(verifier, time.Time{}, time.Time{})
+		}
+		trust.material = root.NewTrustedPublicKeyMaterialFromMapping(verifiers)
+		return trust, nil
+	}
+
+	var authorities []root.CertificateAuthority
+	for _, cert := range roots {
+		authorities = append(authorities, &root.FulcioCertificateAuthority{Root: cert, Intermediates: intermediates})
+	}
+	rekorLogs, err := transparencyLogs(rekorKeys)
+	if err != nil {
+		return nil, err
+	}
+	ctLogs, err := transparencyLogs(ctLogKeys)
+	if err != nil {
+		return nil, err
+	}
+	material, err := root.NewTrustedRoot(root.TrustedRootMediaType01, authorities, ctLogs, nil, rekorLogs)
+	if err != nil {
+		return nil, err
+	}
+	return &trustRoot{material: material}, nil
+}
+
+// transparencyLogs returns the transparency logs with the public keys, by log
+// ID, which is the hex-encoded SHA-256 digest of the DER-encoded public key.
+func transparencyLogs(keys []crypto.PublicKey) (map[string]*root.TransparencyLog, error) {
+	logs := make(map[string]*root.TransparencyLog)
+	for _, key := range keys {
+		der, err := x509.MarshalPKIXPublicKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("encoding the transparency log public key: %w", err)
+		}
+		id := sha256.Sum256(der)
+		logs[hex.EncodeToString(id[:])] = &root.TransparencyLog{
+			ID:                id[:],
+			PublicKey:         key,
+			HashFunc:          crypto.SHA256,
+			SignatureHashFunc: crypto.SHA256,
+			// The keys are trusted for as long as they are in the Secret.
+			ValidityPeriodStart: time.Unix(0, 0),
+		}
+	}
+	return logs, nil
+}
+
+// simpleSigningPayload is the payload signed by `cosign sign`.
+type simpleSigningPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// verifySignatures checks that at least one of the signatures of the image is
+// valid, and returns the signer.
+func (v *Verifier) verifySignatures(trust *trustRoot, ref name.Reference, digest v1.Hash, options ...remote.Option) (string, error) {
+	layers, err := fetchLayers(ref, digest, signatureTagSuffix, options...)
+	if err != nil {
+		return "", fmt.Errorf("fetching signatures: %w", err)
+	}
+	if len(layers) == 0 {
+		return "", fmt.Errorf("no signatures found for image digest %q", digest)
+	}
+	var errs []error
+	for _, l := range layers {
+		sig, err := base64.StdEncoding.DecodeString(l.annotations[signatureAnnotation])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("malformed signature: %w", err))
+			continue
+		}
+		payloadDigest := sha256.Sum256(l.content)
+		content := bundle.NewMessageSignature(payloadDigest[:], "SHA2_256", sig)
+		signer, err := v.verifySigner(trust, content, l.annotations, func() verify.ArtifactPolicyOption {
+			return verify.WithArtifact(bytes.NewReader(l.content))
+		})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		payload := simpleSigningPayload{}
+		if err := json.Unmarshal(l.content, &payload); err != nil {
+			errs = append(errs, fmt.Errorf("malformed signature payload: %w", err))
+			continue
+		}
+		if payload.Critical.Image.DockerManifestDigest != digest.String() {
+			errs = append(errs, fmt.Errorf("signature payload is for image digest %q", payload.Critical.Image.DockerManifestDigest))
+			continue
+		}
+		return signer, nil
+	}
+	return "", fmt.Errorf("no valid signatures found for image digest %q: %w", digest, errors.Join(errs...))
+}
+
+// inTotoStatement is the payload of cosign attestations.
+type inTotoStatement struct {
+	PredicateType string `json:"predicateType"`
+}
+
+// verifyAttestations checks that each of the required predicate types is
+// attested for the image by a trusted signer.
+func (v *Verifier) verifyAttestations(trust *trustRoot, ref name.Reference, digest v1.Hash, options ...remote.Option) error {
+	layers, err := fetchLayers(ref, digest, attestationTagSuffix, options...)
+	if err != nil {
+		return fmt.Errorf("fetching attestations: %w", err)
+	}
+	subject, err := hex.DecodeString(digest.Hex)
+	if err != nil {
+		return fmt.Errorf("malformed image digest %q: %w", digest, err)
+	}
+	attested := map[string]bool{}
+	for _, l := range layers {
+		envelope := &dsse.Envelope{}
+		if err := json.Unmarshal(l.content, envelope); err != nil {
+			klog.Warningf("Skipping malformed attestation of image digest %q: %v", digest, err)
+			continue
+		}
+		payload, err := envelope.DecodeB64Payload()
+		if err != nil {
+			klog.Warningf("Skipping malformed attestation of image digest %q: %v", digest, err)
+			continue
+		}
+		statement := inTotoStatement{}
+		if err := json.Unmarshal(payload, &statement); err != nil {
+			klog.Warningf("Skipping malformed attestation of image digest %q: %v", digest, err)
+			continue
+		}
+		// The subjects of the statement are checked against the image digest.
+		_, err = v.verifySigner(trust, &bundle.Envelope{Envelope: envelope}, l.annotations, func() verify.ArtifactPolicyOption {
+			return verify.WithArtifactDigest(digest.Algorithm, subject)
+		})
+		if err != nil {
+			klog.Warningf("Skipping unverified %q attestation of image digest %q: %v", statement.PredicateType, digest, err)
+			continue
+		}
+		attested[statement.PredicateType] = true
+	}
+	var missing []string
+	for _, predicateType := range v.Attestations {
+		if !attested[predicateType] {
+			missing = append(missing, predicateType)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("no valid attestations found for image digest %q with predicate types %s", digest, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// verifySigner verifies the signature of the artifact, and returns the
+// identity of the signer. The artifact policy is built for every attempt, as
+// the artifact readers can only be read once.
+func (v *Verifier) verifySigner(trust *trustRoot, content verify.SignatureContent, annotations map[string]string, artifact func() verify.ArtifactPolicyOption) (string, error) {
+	if !v.keyless() {
+		verifier, err := verify.NewSignedEntityVerifier(trust.material, verify.WithCurrentTime())
+		if err != nil {
+			return "", err
+		}
+		for fingerprint := range trust.publicKeys {
+			entity := &signedEntity{signature: content, verification: &publicKey{hint: fingerprint}}
+			if _, err := verifier.Verify(entity, verify.NewPolicy(artifact(), verify.WithKey())); err == nil {
+				return fingerprint, nil
+			}
+		}
+		return "", errors.New("signature is not signed by any of the trusted public keys")
+	}
+
+	block, _ := pem.Decode([]byte(annotations[certificateAnnotation]))
+	if block == nil {
+		return "", errors.New("keyless signature without signing certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("malformed signing certificate: %w", err)
+	}
+	entry, err := rekorEntry(annotations[bundleAnnotation])
+	if err != nil {
+		return "", err
+	}
+	verifier, err := verify.NewSignedEntityVerifier(trust.material,
+		verify.WithSignedCertificateTimestamps(1),
+		verify.WithTransparencyLog(1),
+		verify.WithIntegratedTimestamps(1))
+	if err != nil {
+		return "", err
+	}
+	identity, err := verify.NewShortCertificateIdentity(v.Issuer, "", v.Identity, "")
+	if err != nil {
+		return "", err
+	}
+	entity := &signedEntity{
+		signature:    content,
+		verification: bundle.NewCertificate(cert),
+		entries:      []*tlog.Entry{entry},
+	}
+	if _, err := verifier.Verify(entity, verify.NewPolicy(artifact(), verify.WithCertificateIdentity(identity))); err != nil {
+		return "", fmt.Errorf("verifying the keyless signature: %w", err)
+	}
+	return v.Identity, nil
+}
+
+// keyFingerprint returns the SHA-256 fingerprint of the DER-encoded public key.
+func keyFingerprint(key crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "unknown public key"
+	}
+	sum := sha256.Sum256(der)
+	return "SHA256:" + hex.EncodeToString(sum[:])
+}
+
+// rekorBundle is the proof that an entry was recorded in the Rekor
+// transparency log, as stored by cosign. Base64 encoded fields are decoded by
+// the JSON decoder.
+type rekorBundle struct {
+	SignedEntryTimestamp []byte `json:"SignedEntryTimestamp"`
+	Payload              struct {
+		Body           []byte `json:"body"`
+		IntegratedTime int64  `json:"integratedTime"`
+		LogIndex       int64  `json:"logIndex"`
+		LogID          string `json:"logID"`
+	} `json:"Payload"`
+}
+
+// rekorEntry returns the transparency log entry of the Rekor bundle
+// annotation. The entry is verified along with the signature, which it must
+// record.
+func rekorEntry(annotation string) (*tlog.Entry, error) {
+	if annotation == "" {
+		return nil, errors.New("keyless signature without Rekor bundle")
+	}
+	b := rekorBundle{}
+	if err := json.Unmarshal([]byte(annotation), &b); err != nil {
+		return nil, fmt.Errorf("malformed Rekor bundle: %w", err)
+	}
+	logID, err := hex.DecodeString(b.Payload.LogID)
+	if err != nil {
+		return nil, fmt.Errorf("malformed Rekor bundle: %w", err)
+	}
+	entry, err := tlog.NewEntry(b.Payload.Body, b.Payload.IntegratedTime, b.Payload.LogIndex, logID, b.SignedEntryTimestamp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("malformed Rekor entry: %w", err)
+	}
+	return entry, nil
+}
+
+// signedEntity is a cosign signature or attestation, along with the signing
+// certificate or public key and the Rekor entry recording it.
+type signedEntity struct {
+	signature    verify.SignatureContent
+	verification verify.VerificationContent
+	entries      []*tlog.Entry
+}
+
+var _ verify.SignedEntity = &signedEntity{}
+
+// HasInclusionPromise returns whether the entity has a Rekor entry, as cosign
+// bundles prove the inclusion with a signed entry timestamp.
+func (e *signedEntity) HasInclusionPromise() bool {
+	return len(e.entries) > 0
+}
+
+// HasInclusionProof returns false, as cosign bundles have no inclusion proof.
+func (e *signedEntity) HasInclusionProof() bool {
+	return false
+}
+
+// VerificationContent returns the signing certificate or public key.
+func (e *signedEntity) VerificationContent() (verify.VerificationContent, error) {
+	return e.verification, nil
+}
+
+// SignatureContent returns the signature.
+func (e *signedEntity) SignatureContent() (verify.SignatureContent, error) {
+	return e.signature, nil
+}
+
+// Timestamps returns no timestamps, as cosign signatures are timestamped by
+// the Rekor entry.
+func (e *signedEntity) Timestamps() ([][]byte, error) {
+	return nil, nil
+}
+
+// TlogEntries returns the Rekor entries recording the signature.
+func (e *signedEntity) TlogEntries() ([]*tlog.Entry, error) {
+	return e.entries, nil
+}
+
+// publicKey is the verification content of key-based signatures. The hint is
+// the fingerprint of the trusted public key.
+type publicKey struct {
+	hint string
+}
+
+var _ verify.VerificationContent = &publicKey{}
+
+// Hint returns the fingerprint of the public key.
+func (k *publicKey) Hint() string {
+	return k.hint
+}
+
+// CompareKey returns whether the key is the trusted public key.
+func (k *publicKey) CompareKey(key any, material root.TrustedMaterial) bool {
+	verifier, err := material.PublicKeyVerifier(k.hint)
+	if err != nil {
+		return false
+	}
+	trusted, err := verifier.PublicKey()
+	if err != nil {
+		return false
+	}
+	equaler, ok := key.(interface{ Equal(crypto.PublicKey) bool })
+	return ok && equaler.Equal(trusted)
+}
+
+// ValidAtTime returns whether the trusted public key is valid at the time.
+func (k *publicKey) ValidAtTime(t time.Time, material root.TrustedMaterial) bool {
+	verifier, err := material.PublicKeyVerifier(k.hint)
+	return err == nil && verifier.ValidAtTime(t)
+}
+
+// Certificate returns nil, as key-based signatures have no certificate.
+func (k *publicKey) Certificate() *x509.Certificate {
+	return nil
+}
+
+// PublicKey returns the public key.
+func (k *publicKey) PublicKey() verify.PublicKeyProvider {
+	return k
+}
+
+// signatureLayer is a layer of a cosign signature or attestation image.
+type signatureLayer struct {
+	content     []byte
+	annotations map[string]string
+}
+
+// fetchLayers fetches the layers of the image cosign attached to the image
+// with the specified digest, using the tag with the specified suffix.
+// It returns no layers if the image doesn't exist.
+func fetchLayers(ref name.Reference, digest v1.Hash, suffix string, options ...remote.Option) ([]signatureLayer, error) {
+	tag := ref.Context().Tag(fmt.Sprintf("%s-%s%s", digest.Algorithm, digest.Hex, suffix))
+	image, err := remote.Image(tag, options...)
+	if err != nil {
+		var terr *transport.Error
+		if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	manifest, err := image.Manifest()
+	if err != nil {
+		return nil, err
+	}
+	var layers []signatureLayer
+	for _, desc := range manifest.Layers {
+		layer, err := image.LayerByDigest(desc.Digest)
+		if err != nil {
+			return nil, err
+		}
+		rc, err := layer.Compressed()
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, signatureLayer{content: content, annotations: desc.Annotations})
+	}
+	return layers, nil
+}
@@ -0,0 +1,681 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	ct "github.com/google/certificate-transparency-go"
+	cttls "github.com/google/certificate-transparency-go/tls"
+	ctx509 "github.com/google/certificate-transparency-go/x509"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/status"
+)
+
+const (
+	testIdentity       = "ci@example.com"
+	testIssuer         = "https://accounts.example.com"
+	testPredicateType  = "https://slsa.dev/provenance/v1"
+	simpleSigningType  = "application/vnd.dev.cosign.simplesigning.v1+json"
+	dsseEnvelopeType   = "application/vnd.dsse.envelope.v1+json"
+	inTotoPayloadType  = "application/vnd.in-toto+json"
+	testRepositoryName = "configs"
+
+	// publicGoodIdentity and publicGoodIssuer are the identity which signed
+	// the attestation in testdata with the public-good Sigstore instance.
+	publicGoodIdentity = "cody@soyland.com"
+	publicGoodIssuer   = "https://github.com/login/oauth"
+)
+
+// oidIssuerV2 is the Fulcio certificate extension holding the DER-encoded
+// OIDC issuer.
+var oidIssuerV2 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+
+// newTestRegistry starts an in-memory registry and returns the repository to
+// push test images to.
+func newTestRegistry(t *testing.T) name.Repository {
+	t.Helper()
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(server.Close)
+	repo, err := name.NewRepository(strings.TrimPrefix(server.URL, "http://")+"/"+testRepositoryName, name.Insecure)
+	require.NoError(t, err)
+	return repo
+}
+
+// pushImage pushes an image with a single file, and returns its digest.
+func pushImage(t *testing.T, repo name.Repository, content string) v1.Hash {
+	t.Helper()
+	layer := static.NewLayer(tarFile(t, "ns.yaml", content), types.DockerUncompressedLayer)
+	image, err := mutate.AppendLayers(empty.Image, layer)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(repo.Tag("v1"), image))
+	digest, err := image.Digest()
+	require.NoError(t, err)
+	return digest
+}
+
+// tarFile returns a tar archive with a single file.
+func tarFile(t *testing.T, name, content string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}))
+	_, err := tw.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	return buf.Bytes()
+}
+
+// pushLayers attaches an image with the layers to the image with the digest,
+// using the tag cosign uses for the suffix.
+func pushLayers(t *testing.T, repo name.Repository, digest v1.Hash, suffix string, layers ...mutate.Addendum) {
+	t.Helper()
+	image := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	image, err := mutate.Append(image, layers...)
+	require.NoError(t, err)
+	tag := repo.Tag(fmt.Sprintf("%s-%s%s", digest.Algorithm, digest.Hex, suffix))
+	require.NoError(t, remote.Write(tag, image))
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func sign(t *testing.T, key crypto.Signer, message []byte) []byte {
+	t.Helper()
+	digest := sha256.Sum256(message)
+	sig, err := key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	require.NoError(t, err)
+	return sig
+}
+
+func publicKeyPEM(t *testing.T, key crypto.Signer) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func writeKeys(t *testing.T, files map[string][]byte) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), content, 0644))
+	}
+	return dir
+}
+
+func signaturePayload(digest v1.Hash) []byte {
+	return []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"%s"},"image":{"docker-manifest-digest":"%s"},"type":"cosign container image signature"},"optional":null}`,
+		testRepositoryName, digest))
+}
+
+// newSignatureLayer returns a cosign signature of the image with the digest.
+func newSignatureLayer(t *testing.T, key crypto.Signer, digest v1.Hash, annotations map[string]string) mutate.Addendum {
+	t.Helper()
+	payload := signaturePayload(digest)
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[signatureAnnotation] = base64.StdEncoding.EncodeToString(sign(t, key, payload))
+	return mutate.Addendum{
+		Layer:       static.NewLayer(payload, simpleSigningType),
+		Annotations: annotations,
+	}
+}
+
+// newAttestationLayer returns a cosign attestation of the image with the digest.
+func newAttestationLayer(t *testing.T, key crypto.Signer, digest v1.Hash, predicateType string) mutate.Addendum {
+	t.Helper()
+	statement := fmt.Sprintf(`{"_type":"https://in-toto.io/Statement/v0.1","predicateType":"%s","subject":[{"name":"%s","digest":{"%s":"%s"}}],"predicate":{}}`,
+		predicateType, testRepositoryName, digest.Algorithm, digest.Hex)
+	envelope, err := json.Marshal(map[string]interface{}{
+		"payloadType": inTotoPayloadType,
+		"payload":     []byte(statement),
+		"signatures": []map[string]interface{}{{
+			"sig": sign(t, key, dsse.PAE(inTotoPayloadType, []byte(statement))),
+		}},
+	})
+	require.NoError(t, err)
+	return mutate.Addendum{
+		Layer:       static.NewLayer(envelope, dsseEnvelopeType),
+		Annotations: map[string]string{"predicateType": predicateType},
+	}
+}
+
+func TestVerifierKeyBased(t *testing.T) {
+	repo := newTestRegistry(t)
+	trusted := newKey(t)
+	untrusted := newKey(t)
+	keysDir := writeKeys(t, map[string][]byte{"cosign.pub": publicKeyPEM(t, trusted)})
+
+	testCases := map[string]struct {
+		signatures   func(digest v1.Hash) []mutate.Addendum
+		attestations func(digest v1.Hash) []mutate.Addendum
+		required     []string
+		wantErr      string
+	}{
+		"signed by a trusted key": {
+			signatures: func(digest v1.Hash) []mutate.Addendum {
+				return []mutate.Addendum{newSignatureLayer(t, trusted, digest, nil)}
+			},
+		},
+		"one of the signatures is by a trusted key": {
+			signatures: func(digest v1.Hash) []mutate.Addendum {
+				return []mutate.Addendum{
+					newSignatureLayer(t, untrusted, digest, nil),
+					newSignatureLayer(t, trusted, digest, nil),
+				}
+			},
+		},
+		"signed by an untrusted key": {
+			signatures: func(digest v1.Hash) []mutate.Addendum {
+				return []mutate.Addendum{newSignatureLayer(t, untrusted, digest, nil)}
+			},
+			wantErr: "not signed by any of the trusted public keys",
+		},
+		"signature of another image": {
+			signatures: func(v1.Hash) []mutate.Addendum {
+				other := v1.Hash{Algorithm: "sha256", Hex: strings.Repeat("0", 64)}
+				return []mutate.Addendum{newSignatureLayer(t, trusted, other, nil)}
+			},
+			wantErr: "signature payload is for image digest",
+		},
+		"unsigned": {
+			wantErr: "no signatures found",
+		},
+		"attested by a trusted key": {
+			signatures: func(digest v1.Hash) []mutate.Addendum {
+				return []mutate.Addendum{newSignatureLayer(t, trusted, digest, nil)}
+			},
+			attestations: func(digest v1.Hash) []mutate.Addendum {
+				return []mutate.Addendum{newAttestationLayer(t, trusted, digest, testPredicateType)}
+			},
+			required: []string{testPredicateType},
+		},
+		"attested by an untrusted key": {
+			signatures: func(digest v1.Hash) []mutate.Addendum {
+				return []mutate.Addendum{newSignatureLayer(t, trusted, digest, nil)}
+			},
+			attestations: func(digest v1.Hash) []mutate.Addendum {
+				return []mutate.Addendum{newAttestationLayer(t, untrusted, digest, testPredicateType)}
+			},
+			required: []string{testPredicateType},
+			wantErr:  "no valid attestations found",
+		},
+		"missing attestation": {
+			signatures: func(digest v1.Hash) []mutate.Addendum {
+				return []mutate.Addendum{newSignatureLayer(t, trusted, digest, nil)}
+			},
+			attestations: func(digest v1.Hash) []mutate.Addendum {
+				return []mutate.Addendum{newAttestationLayer(t, trusted, digest, "https://spdx.dev/Document")}
+			},
+			required: []string{testPredicateType},
+			wantErr:  testPredicateType,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// Push a different image for each test case, so that signatures
+			// don't leak between test cases.
+			digest := pushImage(t, repo, name)
+			if tc.signatures != nil {
+				pushLayers(t, repo, digest, signatureTagSuffix, tc.signatures(digest)...)
+			}
+			if tc.attestations != nil {
+				pushLayers(t, repo, digest, attestationTagSuffix, tc.attestations(digest)...)
+			}
+			verifier := &Verifier{KeysDir: keysDir, Attestations: tc.required}
+			signer, err := verifier.Verify(repo.Tag("v1"), digest)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, keyFingerprint(trusted.Public()), signer)
+			}
+		})
+	}
+}
+
+// fulcio is a test certificate authority issuing keyless signing certificates.
+type fulcio struct {
+	key  *ecdsa.PrivateKey
+	cert *x509.Certificate
+	// ctLog is the key of the certificate transparency log signing the
+	// timestamps embedded in the issued certificates. No timestamp is
+	// embedded if it is nil.
+	ctLog crypto.Signer
+}
+
+func newFulcio(t *testing.T, ctLog crypto.Signer) *fulcio {
+	t.Helper()
+	key := newKey(t)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-fulcio"},
+		NotBefore:             time.Now().Add(-24 * time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &fulcio{key: key, cert: cert, ctLog: ctLog}
+}
+
+func (f *fulcio) rootPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: f.cert.Raw})
+}
+
+// issue returns a PEM-encoded signing certificate for the identity, which was
+// valid for ten minutes an hour ago, like short-lived Fulcio certificates.
+func (f *fulcio) issue(t *testing.T, key crypto.Signer, identity, issuer string) []byte {
+	t.Helper()
+	issuerExt, err := asn1.Marshal(issuer)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:    big.NewInt(2),
+		NotBefore:       time.Now().Add(-time.Hour),
+		NotAfter:        time.Now().Add(-50 * time.Minute),
+		EmailAddresses:  []string{identity},
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		ExtraExtensions: []pkix.Extension{{Id: oidIssuerV2, Value: issuerExt}},
+	}
+	if f.ctLog != nil {
+		template.ExtraExtensions = append(template.ExtraExtensions, f.sctExtension(t, template, key))
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, f.cert, key.Public(), f.key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+// sctExtension returns the extension embedding the timestamp signed by the
+// certificate transparency log for the certificate. Like in Fulcio, the log
+// signs the precertificate, which has a poison extension instead.
+func (f *fulcio) sctExtension(t *testing.T, template *x509.Certificate, key crypto.Signer) pkix.Extension {
+	t.Helper()
+	precert := *template
+	precert.ExtraExtensions = append(slices.Clone(template.ExtraExtensions),
+		pkix.Extension{Id: asn1.ObjectIdentifier(ctx509.OIDExtensionCTPoison), Critical: true, Value: asn1.NullBytes})
+	der, err := x509.CreateCertificate(rand.Reader, &precert, f.cert, key.Public(), f.key)
+	require.NoError(t, err)
+	parsed, err := ctx509.ParseCertificate(der)
+	require.NoError(t, err)
+	tbs, err := ctx509.BuildPrecertTBS(parsed.RawTBSCertificate, nil)
+	require.NoError(t, err)
+
+	sct := ct.SignedCertificateTimestamp{
+		SCTVersion: ct.V1,
+		LogID:      ct.LogID{KeyID: logID(t, f.ctLog)},
+		Timestamp:  uint64(template.NotBefore.UnixMilli()),
+	}
+	input, err := ct.SerializeSCTSignatureInput(sct, ct.LogEntry{Leaf: ct.MerkleTreeLeaf{
+		Version:  ct.V1,
+		LeafType: ct.TimestampedEntryLeafType,
+		TimestampedEntry: &ct.TimestampedEntry{
+			Timestamp: sct.Timestamp,
+			EntryType: ct.PrecertLogEntryType,
+			PrecertEntry: &ct.PreCert{
+				IssuerKeyHash:  sha256.Sum256(f.cert.RawSubjectPublicKeyInfo),
+				TBSCertificate: tbs,
+			},
+		},
+	}})
+	require.NoError(t, err)
+	sct.Signature = ct.DigitallySigned{
+		Algorithm: cttls.SignatureAndHashAlgorithm{Hash: cttls.SHA256, Signature: cttls.ECDSA},
+		Signature: sign(t, f.ctLog, input),
+	}
+	serialized, err := cttls.Marshal(sct)
+	require.NoError(t, err)
+	list, err := cttls.Marshal(ctx509.SignedCertificateTimestampList{SCTList: []ctx509.SerializedSCT{{Val: serialized}}})
+	require.NoError(t, err)
+	value, err := asn1.Marshal(list)
+	require.NoError(t, err)
+	return pkix.Extension{Id: asn1.ObjectIdentifier(ctx509.OIDExtensionCTSCT), Value: value}
+}
+
+// logID returns the ID of the transparency log with the key.
+func logID(t *testing.T, key crypto.Signer) [sha256.Size]byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+	return sha256.Sum256(der)
+}
+
+// rekorBundleAnnotation returns a bundle recording the signature of the
+// payload, made with the certificate, in the transparency log at the
+// specified time.
+func rekorBundleAnnotation(t *testing.T, rekorKey crypto.Signer, payload, signature, certificate []byte, integratedTime time.Time) string {
+	t.Helper()
+	payloadDigest := sha256.Sum256(payload)
+	body, err := json.Marshal(map[string]interface{}{
+		"apiVersion": "0.0.1",
+		"kind":       "hashedrekord",
+		"spec": map[string]interface{}{
+			"data": map[string]interface{}{
+				"hash": map[string]string{"algorithm": "sha256", "value": hex.EncodeToString(payloadDigest[:])},
+			},
+			"signature": map[string]interface{}{
+				"content":   signature,
+				"publicKey": map[string]interface{}{"content": certificate},
+			},
+		},
+	})
+	require.NoError(t, err)
+	id := logID(t, rekorKey)
+	entry := map[string]interface{}{
+		"body":           body,
+		"integratedTime": integratedTime.Unix(),
+		"logID":          hex.EncodeToString(id[:]),
+		"logIndex":       1,
+	}
+	// The keys are sorted, so this is the canonical JSON signed by Rekor.
+	canonical, err := json.Marshal(entry)
+	require.NoError(t, err)
+	bundle, err := json.Marshal(map[string]interface{}{
+		"SignedEntryTimestamp": sign(t, rekorKey, canonical),
+		"Payload":              entry,
+	})
+	require.NoError(t, err)
+	return string(bundle)
+}
+
+func TestVerifierKeyless(t *testing.T) {
+	repo := newTestRegistry(t)
+	ctLogKey := newKey(t)
+	ca := newFulcio(t, ctLogKey)
+	rekorKey := newKey(t)
+	signingKey := newKey(t)
+	keysDir := writeKeys(t, map[string][]byte{
+		"fulcio.crt.pem":   ca.rootPEM(),
+		rekorPublicKeyFile: publicKeyPEM(t, rekorKey),
+		ctLogPublicKeyFile: publicKeyPEM(t, ctLogKey),
+	})
+	signedAt := time.Now().Add(-55 * time.Minute)
+
+	otherKey := newKey(t)
+
+	testCases := map[string]struct {
+		certificate []byte
+		bundleTime  time.Time
+		rekorKey    crypto.Signer
+		// otherEntry records another valid signature of the same payload in
+		// the bundle.
+		otherEntry bool
+		wantErr    string
+	}{
+		"signed by the expected identity": {
+			certificate: ca.issue(t, signingKey, testIdentity, testIssuer),
+			bundleTime:  signedAt,
+			rekorKey:    rekorKey,
+		},
+		"signed by another identity": {
+			certificate: ca.issue(t, signingKey, "someone@example.com", testIssuer),
+			bundleTime:  signedAt,
+			rekorKey:    rekorKey,
+			wantErr:     "failed to verify certificate identity",
+		},
+		"identity issued by another issuer": {
+			certificate: ca.issue(t, signingKey, testIdentity, "https://evil.example.com"),
+			bundleTime:  signedAt,
+			rekorKey:    rekorKey,
+			wantErr:     "failed to verify certificate identity",
+		},
+		"certificate by an untrusted CA": {
+			certificate: newFulcio(t, ctLogKey).issue(t, signingKey, testIdentity, testIssuer),
+			bundleTime:  signedAt,
+			rekorKey:    rekorKey,
+			wantErr:     "failed to verify leaf certificate",
+		},
+		"certificate without timestamp": {
+			certificate: (&fulcio{key: ca.key, cert: ca.cert}).issue(t, signingKey, testIdentity, testIssuer),
+			bundleTime:  signedAt,
+			rekorKey:    rekorKey,
+			wantErr:     "failed to verify signed certificate timestamp",
+		},
+		"certificate timestamp by an untrusted log": {
+			certificate: (&fulcio{key: ca.key, cert: ca.cert, ctLog: newKey(t)}).issue(t, signingKey, testIdentity, testIssuer),
+			bundleTime:  signedAt,
+			rekorKey:    rekorKey,
+			wantErr:     "failed to verify signed certificate timestamp",
+		},
+		"recorded after the certificate expired": {
+			certificate: ca.issue(t, signingKey, testIdentity, testIssuer),
+			bundleTime:  time.Now(),
+			rekorKey:    rekorKey,
+			wantErr:     "integrated time outside certificate validity",
+		},
+		"bundle not signed by Rekor": {
+			certificate: ca.issue(t, signingKey, testIdentity, testIssuer),
+			bundleTime:  signedAt,
+			rekorKey:    newKey(t),
+			wantErr:     "not enough verified log entries",
+		},
+		"bundle for another signature of the payload": {
+			certificate: ca.issue(t, signingKey, testIdentity, testIssuer),
+			bundleTime:  signedAt,
+			rekorKey:    rekorKey,
+			otherEntry:  true,
+			wantErr:     "transparency log signature does not match",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			digest := pushImage(t, repo, name)
+			payload := signaturePayload(digest)
+			signature := sign(t, signingKey, payload)
+			entrySignature, entryCertificate := signature, tc.certificate
+			if tc.otherEntry {
+				entrySignature = sign(t, otherKey, payload)
+				entryCertificate = ca.issue(t, otherKey, "someone@example.com", testIssuer)
+			}
+			pushLayers(t, repo, digest, signatureTagSuffix, mutate.Addendum{
+				Layer: static.NewLayer(payload, simpleSigningType),
+				Annotations: map[string]string{
+					signatureAnnotation:   base64.StdEncoding.EncodeToString(signature),
+					certificateAnnotation: string(tc.certificate),
+					bundleAnnotation: rekorBundleAnnotation(t, tc.rekorKey, payload,
+						entrySignature, entryCertificate, tc.bundleTime),
+				},
+			})
+			verifier := &Verifier{KeysDir: keysDir, Identity: testIdentity, Issuer: testIssuer}
+			signer, err := verifier.Verify(repo.Tag("v1"), digest)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, testIdentity, signer)
+			}
+		})
+	}
+}
+
+// TestVerifierPublicGood verifies an attestation of the empty image made by
+// `cosign attest` with the public-good Sigstore instance, taken from the
+// cosign test data. The signing certificate embeds timestamps of the
+// certificate transparency log, and the attestation is recorded in Rekor.
+// testdata/public-good holds the public-good trusted root.
+func TestVerifierPublicGood(t *testing.T) {
+	digest, err := empty.Image.Digest()
+	require.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join("testdata", "attestation.json"))
+	require.NoError(t, err)
+	data, err := os.ReadFile(filepath.Join("testdata", "attestation.annotations.json"))
+	require.NoError(t, err)
+	trust, err := loadTrustRoot(filepath.Join("testdata", "public-good"), true)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		identity string
+		issuer   string
+		digest   v1.Hash
+		modify   func(annotations map[string]string)
+		wantErr  bool
+	}{
+		"attested by the expected identity": {
+			identity: publicGoodIdentity,
+			issuer:   publicGoodIssuer,
+			digest:   digest,
+		},
+		"attested by another identity": {
+			identity: "someone@example.com",
+			issuer:   publicGoodIssuer,
+			digest:   digest,
+			wantErr:  true,
+		},
+		"identity issued by another issuer": {
+			identity: publicGoodIdentity,
+			issuer:   "https://accounts.google.com",
+			digest:   digest,
+			wantErr:  true,
+		},
+		"attestation of another image": {
+			identity: publicGoodIdentity,
+			issuer:   publicGoodIssuer,
+			digest:   v1.Hash{Algorithm: "sha256", Hex: strings.Repeat("0", 64)},
+			wantErr:  true,
+		},
+		"modified Rekor entry": {
+			identity: publicGoodIdentity,
+			issuer:   publicGoodIssuer,
+			digest:   digest,
+			modify: func(annotations map[string]string) {
+				annotations[bundleAnnotation] = strings.Replace(annotations[bundleAnnotation], `"integratedTime":`, `"integratedTime":1`, 1)
+			},
+			wantErr: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			annotations := map[string]string{}
+			require.NoError(t, json.Unmarshal(data, &annotations))
+			if tc.modify != nil {
+				tc.modify(annotations)
+			}
+			repo := newTestRegistry(t)
+			pushLayers(t, repo, tc.digest, attestationTagSuffix,
+				mutate.Addendum{Layer: static.NewLayer(content, dsseEnvelopeType), Annotations: annotations})
+
+			verifier := &Verifier{Identity: tc.identity, Issuer: tc.issuer, Attestations: []string{annotations["predicateType"]}}
+			err := verifier.verifyAttestations(trust, repo.Tag("v1"), tc.digest)
+			if tc.wantErr {
+				assert.ErrorContains(t, err, "no valid attestations found")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLoadTrustRoot(t *testing.T) {
+	key := newKey(t)
+	ca := newFulcio(t, key)
+
+	_, err := loadTrustRoot(writeKeys(t, map[string][]byte{"cosign.pub": publicKeyPEM(t, key)}), false)
+	assert.NoError(t, err)
+
+	_, err = loadTrustRoot(writeKeys(t, map[string][]byte{"fulcio.crt.pem": ca.rootPEM()}), false)
+	assert.ErrorContains(t, err, "no trusted public keys found")
+
+	_, err = loadTrustRoot(writeKeys(t, map[string][]byte{"fulcio.crt.pem": ca.rootPEM()}), true)
+	assert.ErrorContains(t, err, "no Rekor public key found")
+
+	_, err = loadTrustRoot(writeKeys(t, map[string][]byte{rekorPublicKeyFile: publicKeyPEM(t, key)}), true)
+	assert.ErrorContains(t, err, "no trusted root certificates found")
+
+	_, err = loadTrustRoot(writeKeys(t, map[string][]byte{
+		"fulcio.crt.pem":   ca.rootPEM(),
+		rekorPublicKeyFile: publicKeyPEM(t, key),
+	}), true)
+	assert.ErrorContains(t, err, "no certificate transparency log public key found")
+
+	_, err = loadTrustRoot(writeKeys(t, map[string][]byte{
+		"fulcio.crt.pem":   ca.rootPEM(),
+		rekorPublicKeyFile: publicKeyPEM(t, key),
+		ctLogPublicKeyFile: publicKeyPEM(t, key),
+	}), true)
+	assert.NoError(t, err)
+}
+
+func TestFetchPackageWithVerification(t *testing.T) {
+	repo := newTestRegistry(t)
+	trusted := newKey(t)
+	keysDir := writeKeys(t, map[string][]byte{"cosign.pub": publicKeyPEM(t, trusted)})
+	fetcher := &Fetcher{
+		Authenticator: authn.Anonymous,
+		Verifier:      &Verifier{KeysDir: keysDir},
+	}
+	imageName := repo.Tag("v1").String()
+
+	digest := pushImage(t, repo, "kind: Namespace")
+	ociRoot := t.TempDir()
+	err := fetcher.FetchPackage(context.Background(), imageName, ociRoot, "rev")
+	require.Error(t, err)
+	statusErr, ok := err.(status.Error)
+	require.True(t, ok, "expected a status.Error, got %T", err)
+	assert.Equal(t, status.SourceVerificationErrorCode, statusErr.Code())
+	_, err = os.Stat(filepath.Join(ociRoot, digest.Hex))
+	assert.True(t, os.IsNotExist(err), "unverified image should not be extracted")
+
+	pushLayers(t, repo, digest, signatureTagSuffix, newSignatureLayer(t, trusted, digest, nil))
+	require.NoError(t, fetcher.FetchPackage(context.Background(), imageName, ociRoot, "rev"))
+	content, err := os.ReadFile(filepath.Join(ociRoot, "rev", "ns.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "kind: Namespace", string(content))
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DryRunner validates the declared objects against the cluster before they
+// are applied.
+type DryRunner interface {
+	// DryRun validates the objects, and returns an error for each object
+	// which would be rejected by the apply.
+	DryRun(ctx context.Context, objs []*unstructured.Unstructured) status.MultiError
+}
+
+// ServerSideDryRunner dry-runs a server-side apply of the objects, so they are
+// validated by the API server and the admission webhooks without being
+// persisted.
+type ServerSideDryRunner struct {
+	// Client is used to send the dry-run requests.
+	Client client.Client
+}
+
+var _ DryRunner = &ServerSideDryRunner{}
+
+// DryRun implements DryRunner.
+//
+// Objects of a type whose CRD is declared in the same set, and namespaced
+// objects in a Namespace declared in the same set, can't be dry-run before
+// the CRD or the Namespace is applied. Their errors are ignored when the type
+// or the Namespace doesn't exist yet.
+func (d *ServerSideDryRunner) DryRun(ctx context.Context, objs []*unstructured.Unstructured) status.MultiError {
+	declaredCRDs := make(map[schema.GroupKind]bool)
+	declaredNamespaces := make(map[string]bool)
+	for _, obj := range objs {
+		switch obj.GroupVersionKind().GroupKind() {
+		case kinds.CustomResourceDefinition():
+			group, _, _ := unstructured.NestedString(obj.Object, "spec", "group")
+			kind, _, _ := unstructured.NestedString(obj.Object, "spec", "names", "kind")
+			declaredCRDs[schema.GroupKind{Group: group, Kind: kind}] = true
+		case kinds.Namespace().GroupKind():
+			declaredNamespaces[obj.GetName()] = true
+		}
+	}
+
+	var errs status.MultiError
+	for _, obj := range objs {
+		err := d.Client.Patch(ctx, obj.DeepCopy(), client.Apply,
+			client.DryRunAll,
+			client.ForceOwnership,
+			client.FieldOwner(configsync.FieldManager))
+		switch {
+		case err == nil:
+		case declaredCRDs[obj.GroupVersionKind().GroupKind()] && (meta.IsNoMatchError(err) || apierrors.IsNotFound(err)):
+		case declaredNamespaces[obj.GetNamespace()] && apierrors.IsNotFound(err):
+		default:
+			errs = status.Append(errs, status.DryRunError(err, obj))
+		}
+	}
+	return errs
+}
+
+// changedObjects returns the declared objects which are new or changed since
+// the last successful apply, excluding the objects the applier doesn't apply
+// as declared.
+func (u *Updater) changedObjects() []*unstructured.Unstructured {
+	var changed []*unstructured.Unstructured
+	for _, obj := range u.Resources.DeclaredUnstructureds() {
+		if metadata.IsManagementDisabled(obj) {
+			continue
+		}
+		id := core.IDOf(obj)
+		if _, found := u.Resources.GetIgnored(id); found {
+			continue
+		}
+		if applied, found := u.appliedObjects[id]; found && equalIgnoringToken(applied, obj) {
+			continue
+		}
+		changed = append(changed, obj)
+	}
+	return changed
+}
+
+// recordAppliedObjects records the declared objects as successfully applied.
+func (u *Updater) recordAppliedObjects() {
+	u.appliedObjects = make(map[core.ID]*unstructured.Unstructured)
+	for _, obj := range u.Resources.DeclaredUnstructureds() {
+		u.appliedObjects[core.IDOf(obj)] = obj.DeepCopy()
+	}
+}
+
+// equalIgnoringToken compares the objects, ignoring the sync token which is
+// updated for every commit.
+func equalIgnoringToken(a, b *unstructured.Unstructured) bool {
+	a = a.DeepCopy()
+	b = b.DeepCopy()
+	core.RemoveAnnotations(a, metadata.SyncTokenAnnotationKey)
+	core.RemoveAnnotations(b, metadata.SyncTokenAnnotationKey)
+	return equality.Semantic.DeepEqual(a.Object, b.Object)
+}
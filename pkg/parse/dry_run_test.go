@@ -0,0 +1,256 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	applierfake "kpt.dev/configsync/pkg/applier/fake"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/remediator/conflict"
+	remediatorfake "kpt.dev/configsync/pkg/remediator/fake"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/syncer/reconcile"
+	"kpt.dev/configsync/pkg/syncer/reconcile/fight"
+	syncertest "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// dryRunClient returns the configured errors for the dry-run of the objects,
+// and forwards the other requests.
+type dryRunClient struct {
+	client.Client
+	errs    map[core.ID]error
+	patched []core.ID
+}
+
+func (c *dryRunClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	options := &client.PatchOptions{}
+	options.ApplyOptions(opts)
+	if len(options.DryRun) != 1 || options.DryRun[0] != metav1.DryRunAll {
+		return errors.New("not a dry-run")
+	}
+	id := core.IDOf(obj)
+	c.patched = append(c.patched, id)
+	if err, found := c.errs[id]; found {
+		return err
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func toUnstructureds(t *testing.T, objs ...client.Object) []*unstructured.Unstructured {
+	t.Helper()
+	var result []*unstructured.Unstructured
+	for _, obj := range objs {
+		u, err := reconcile.AsUnstructuredSanitized(obj)
+		require.NoError(t, err)
+		result = append(result, u)
+	}
+	return result
+}
+
+func TestServerSideDryRunner(t *testing.T) {
+	anvilGVK := schema.GroupVersionKind{Group: "acme.com", Version: "v1", Kind: "Anvil"}
+	namespace := k8sobjects.NamespaceObject("shipping",
+		core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/shipping/ns.yaml"))
+	configMap := k8sobjects.ConfigMapObject(core.Namespace("shipping"), core.Name("config"),
+		core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/shipping/cm.yaml"))
+	anvil := k8sobjects.Unstructured(anvilGVK, core.Namespace("shipping"), core.Name("anvil"))
+	anvilCRD := k8sobjects.CRDV1UnstructuredForGVK(anvilGVK, apiextensionsv1.NamespaceScoped)
+
+	testCases := map[string]struct {
+		objs    []client.Object
+		errs    map[core.ID]error
+		wantErr []v1beta1.ConfigSyncError
+	}{
+		"valid objects": {
+			objs: []client.Object{namespace, configMap},
+		},
+		"rejected object": {
+			objs: []client.Object{namespace, configMap},
+			errs: map[core.ID]error{
+				core.IDOf(configMap): apierrors.NewBadRequest("admission webhook denied the request"),
+			},
+			wantErr: []v1beta1.ConfigSyncError{{
+				Code: status.DryRunErrorCode,
+				ErrorMessage: "KNV2019: server-side dry-run failed for ConfigMap, shipping/config, none of the objects were applied: " +
+					"admission webhook denied the request\n\n" +
+					"source: namespaces/shipping/cm.yaml\n" +
+					"namespace: shipping\n" +
+					"metadata.name: config\n" +
+					"group:\n" +
+					"version: v1\n" +
+					"kind: ConfigMap\n\n" +
+					"For more information, see https://g.co/cloud/acm-errors#knv2019",
+				Resources: []v1beta1.ResourceRef{{
+					SourcePath: "namespaces/shipping/cm.yaml",
+					Name:       "config",
+					Namespace:  "shipping",
+					GVK:        metav1.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+				}},
+			}},
+		},
+		"object in a declared Namespace": {
+			objs: []client.Object{namespace, configMap},
+			errs: map[core.ID]error{
+				core.IDOf(configMap): apierrors.NewNotFound(kinds.Namespace().GroupVersion().WithResource("namespaces").GroupResource(), "shipping"),
+			},
+		},
+		"object in an undeclared Namespace": {
+			objs: []client.Object{configMap},
+			errs: map[core.ID]error{
+				core.IDOf(configMap): apierrors.NewNotFound(kinds.Namespace().GroupVersion().WithResource("namespaces").GroupResource(), "shipping"),
+			},
+			wantErr: []v1beta1.ConfigSyncError{{Code: status.DryRunErrorCode}},
+		},
+		"object of a declared CRD": {
+			objs: []client.Object{anvilCRD, anvil},
+			errs: map[core.ID]error{
+				core.IDOf(anvil): &meta.NoKindMatchError{GroupKind: anvilGVK.GroupKind(), SearchedVersions: []string{"v1"}},
+			},
+		},
+		"object of an undeclared CRD": {
+			objs: []client.Object{anvil},
+			errs: map[core.ID]error{
+				core.IDOf(anvil): &meta.NoKindMatchError{GroupKind: anvilGVK.GroupKind(), SearchedVersions: []string{"v1"}},
+			},
+			wantErr: []v1beta1.ConfigSyncError{{Code: status.DryRunErrorCode}},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			fakeClient := syncertest.NewClient(t, core.Scheme)
+			c := &dryRunClient{Client: fakeClient, errs: tc.errs}
+			d := &ServerSideDryRunner{Client: c}
+			objs := toUnstructureds(t, tc.objs...)
+
+			errs := status.ToCSE(d.DryRun(context.Background(), objs))
+			require.Len(t, errs, len(tc.wantErr))
+			for i, want := range tc.wantErr {
+				assert.Equal(t, want.Code, errs[i].Code)
+				if want.ErrorMessage != "" {
+					assert.Equal(t, want, errs[i])
+				}
+			}
+			// Every object is dry-run, and none of them is persisted.
+			var ids []core.ID
+			for _, obj := range objs {
+				ids = append(ids, core.IDOf(obj))
+			}
+			assert.Equal(t, ids, c.patched)
+			for _, obj := range objs {
+				if _, found := tc.errs[core.IDOf(obj)]; found {
+					continue
+				}
+				err := fakeClient.Get(context.Background(), client.ObjectKeyFromObject(obj), obj.DeepCopy())
+				assert.True(t, apierrors.IsNotFound(err), "%s should not be persisted: %v", core.IDOf(obj), err)
+			}
+		})
+	}
+}
+
+// fakeDryRunner records the dry-run objects, and returns the configured
+// errors.
+type fakeDryRunner struct {
+	calls [][]core.ID
+	errs  status.MultiError
+}
+
+func (d *fakeDryRunner) DryRun(_ context.Context, objs []*unstructured.Unstructured) status.MultiError {
+	var ids []core.ID
+	for _, obj := range objs {
+		ids = append(ids, core.IDOf(obj))
+	}
+	d.calls = append(d.calls, ids)
+	return d.errs
+}
+
+func TestUpdaterDryRun(t *testing.T) {
+	namespace := k8sobjects.NamespaceObject("shipping")
+	configMap := k8sobjects.ConfigMapObject(core.Namespace("shipping"), core.Name("config"))
+	changedConfigMap := k8sobjects.ConfigMapObject(core.Namespace("shipping"), core.Name("config"), core.Label("changed", "true"))
+	ignored := k8sobjects.ConfigMapObject(core.Namespace("shipping"), core.Name("ignored"),
+		core.Annotation(metadata.LifecycleMutationAnnotation, metadata.IgnoreMutation))
+	dryRunErr := status.DryRunError(errors.New("denied"), configMap)
+
+	newCache := func(commit string, objs ...client.Object) *cacheForCommit {
+		var fileObjs []ast.FileObject
+		for _, obj := range objs {
+			fileObjs = append(fileObjs, k8sobjects.FileObject(obj, "namespaces/shipping/objects.yaml"))
+		}
+		return &cacheForCommit{
+			source: &sourceState{commit: commit},
+			parse:  &parseResult{objsToApply: fileObjs},
+		}
+	}
+	resources := &declared.Resources{}
+	resources.UpdateIgnored(ignored)
+	dryRunner := &fakeDryRunner{errs: dryRunErr}
+	fakeApplier := &applierfake.Applier{
+		ApplyOutputs: []applierfake.ApplierOutputs{{}, {}},
+	}
+	u := &Updater{
+		Scope:          declared.RootScope,
+		Resources:      resources,
+		Remediator:     &remediatorfake.Remediator{},
+		Applier:        fakeApplier,
+		SyncErrorCache: NewSyncErrorCache(conflict.NewHandler(), fight.NewHandler()),
+		DryRunner:      dryRunner,
+	}
+	ctx := context.Background()
+
+	// A failed dry-run blocks the apply, and is reported as a sync error.
+	cache := newCache("abc123", namespace, configMap, ignored)
+	err := u.Update(ctx, cache)
+	require.Error(t, err)
+	assert.Equal(t, 0, fakeApplier.ApplyCalls)
+	assert.False(t, cache.applied)
+	assert.Equal(t, status.ToCSE(dryRunErr), status.ToCSE(u.SyncErrorCache.Errors()))
+	assert.Equal(t, [][]core.ID{{core.IDOf(namespace), core.IDOf(configMap)}}, dryRunner.calls)
+
+	// The retry dry-runs the objects again, and applies them once valid.
+	dryRunner.errs = nil
+	err = u.Update(ctx, cache)
+	require.NoError(t, err)
+	assert.Equal(t, 1, fakeApplier.ApplyCalls)
+	assert.True(t, cache.applied)
+	assert.Nil(t, u.SyncErrorCache.Errors())
+
+	// Only the objects changed since the last apply are dry-run.
+	err = u.Update(ctx, newCache("def456", namespace, changedConfigMap, ignored))
+	require.NoError(t, err)
+	assert.Equal(t, 2, fakeApplier.ApplyCalls)
+	assert.Equal(t, [][]core.ID{
+		{core.IDOf(namespace), core.IDOf(configMap)},
+		{core.IDOf(namespace), core.IDOf(configMap)},
+		{core.IDOf(configMap)},
+	}, dryRunner.calls)
+}
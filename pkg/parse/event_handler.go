@@ -45,6 +45,7 @@ func NewEventHandler(ctx context.Context, r Reconciler, nsControllerState *names
 // - SyncEventType          - Sync from the cache, priming the cache from disk, if necessary.
 // - StatusUpdateEventType  - Update the RSync status with status from the Remediator & NSController.
 // - NamespaceSyncEventType - Sync from the cache, if the NSController requested one.
+// - SourceChangeEventType  - Trigger a fetch of the source, and sync from the cache.
 // - RetrySyncEventType     - Sync from the cache, if one of the following cases is detected:
 //   - Remediator or Reconciler reported a management conflict
 //   - Reconciler requested a retry due to error
@@ -86,6 +87,17 @@ func (s *EventHandler) Handle(event events.Event) events.Result {
 		}
 		runResult = runFn(ctx, triggerNamespaceUpdate)
 
+	case events.SourceChangeEventType:
+		// The source webhook receiver was notified of a change. Fetch now,
+		// instead of waiting for the next poll. The periodic sync picks up the
+		// new commit, if the fetch takes longer than this sync attempt.
+		if opts.FetchTrigger != nil {
+			if err := opts.FetchTrigger.TriggerFetch(); err != nil {
+				klog.Warningf("Failed to trigger a fetch of the source: %v", err)
+			}
+		}
+		runResult = runFn(ctx, triggerSourceChange)
+
 	case events.RetrySyncEventType:
 		// Retry if there was an error, conflict, or any watches need to be updated.
 		var trigger string
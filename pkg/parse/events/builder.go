@@ -40,6 +40,9 @@ type PublishingGroupBuilder struct {
 	NamespaceControllerPeriod time.Duration
 	// RetryBackoff is how long the Parser waits between retries, after an error.
 	RetryBackoff wait.Backoff
+	// SourceChanges signals changes to the source, notified by the source
+	// webhook receiver. Source change events are disabled when nil.
+	SourceChanges <-chan struct{}
 }
 
 // Build a list of Publishers based on the PublishingGroupBuilder config.
@@ -56,6 +59,9 @@ func (t *PublishingGroupBuilder) Build() []Publisher {
 	if t.RetryBackoff.Duration > 0 {
 		publishers = append(publishers, NewRetrySyncPublisher(t.Clock, t.RetryBackoff))
 	}
+	if t.SourceChanges != nil {
+		publishers = append(publishers, NewChannelPublisher(SourceChangeEventType, t.SourceChanges))
+	}
 	if t.StatusUpdatePeriod > 0 {
 		// Status updates are on an independent timer from the sync event, and thus
 		// could run immediately after a sync event. The status updater performs a
@@ -36,4 +36,7 @@ const (
 	// RetrySyncEventType is the EventType for a sync triggered by an error
 	// during a previous sync attempt.
 	RetrySyncEventType EventType = "RetrySyncEvent"
+	// SourceChangeEventType is the EventType for a sync triggered by a change
+	// to the source, notified by the source webhook receiver.
+	SourceChangeEventType EventType = "SourceChangeEvent"
 )
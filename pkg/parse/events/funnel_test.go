@@ -431,6 +431,36 @@ func TestFunnel_Start(t *testing.T) {
 	}
 }
 
+func TestFunnel_Start_SourceChanges(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sourceChanges := make(chan struct{}, 1)
+	expectedEvents := []eventResult{
+		{Event: Event{Type: SourceChangeEventType}},
+		{Event: Event{Type: SourceChangeEventType}},
+	}
+	subscriber := &testSubscriber{
+		T:              t,
+		ExpectedEvents: expectedEvents,
+		StepFunc:       func() { sourceChanges <- struct{}{} },
+		DoneFunc:       cancel,
+	}
+	funnel := &Funnel{
+		Publishers: (&PublishingGroupBuilder{SourceChanges: sourceChanges}).Build(),
+		Subscriber: subscriber,
+	}
+	doneCh := funnel.Start(ctx)
+
+	// Signal a source change, without blocking handler
+	go subscriber.StepFunc()
+
+	// Wait until done
+	<-doneCh
+
+	testutil.AssertEqual(t, []Event{expectedEvents[0].Event, expectedEvents[1].Event}, subscriber.ReceivedEvents)
+}
+
 type eventResult struct {
 	Event  Event
 	Result Result
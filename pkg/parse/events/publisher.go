@@ -204,3 +204,37 @@ func (s *ResetOnRunAttemptPublisher) HandleResult(result Result) {
 		s.timer.Reset(s.Period)
 	}
 }
+
+// NewChannelPublisher constructs a ChannelPublisher that publishes an event
+// each time a signal is received from the channel.
+func NewChannelPublisher(eventType EventType, ch <-chan struct{}) *ChannelPublisher {
+	return &ChannelPublisher{
+		EventType: eventType,
+		Channel:   ch,
+	}
+}
+
+// ChannelPublisher sends an event each time a signal is received from the
+// channel. Unlike the TimeDelayPublisher, events are only sent on demand.
+type ChannelPublisher struct {
+	EventType EventType
+	Channel   <-chan struct{}
+}
+
+// Type of events produced by this publisher.
+func (s *ChannelPublisher) Type() EventType {
+	return s.EventType
+}
+
+// Start returns the signal channel as the event channel.
+func (s *ChannelPublisher) Start(_ context.Context) reflect.Value {
+	return reflect.ValueOf(s.Channel)
+}
+
+// Publish calls the HandleFunc with a new event.
+func (s *ChannelPublisher) Publish(subscriber Subscriber) Result {
+	return subscriber.Handle(Event{Type: s.EventType})
+}
+
+// HandleResult is a no-op.
+func (s *ChannelPublisher) HandleResult(_ Result) {}
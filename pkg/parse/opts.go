@@ -15,11 +15,14 @@
 package parse
 
 import (
+	"context"
 	"time"
 
 	"k8s.io/utils/clock"
 	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/diff"
 	"kpt.dev/configsync/pkg/importer/filesystem"
+	"kpt.dev/configsync/pkg/status"
 	"kpt.dev/configsync/pkg/util/discovery"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -59,6 +62,11 @@ type Options struct {
 	// available on the cluster.
 	DiscoveryClient discovery.ServerResourcer
 
+	// OpenAPISchemas returns the OpenAPI schemas of the types currently
+	// available on the cluster, used to validate the objects in Git.
+	// The objects are not validated against their schemas when nil.
+	OpenAPISchemas discovery.OpenAPISchemasFunc
+
 	// Converter uses the DiscoveryClient to encode the declared fields of
 	// objects in Git.
 	Converter *declared.ValueConverter
@@ -66,6 +74,10 @@ type Options struct {
 	// WebhookEnabled indicates whether the Webhook is currently enabled
 	WebhookEnabled bool
 
+	// IgnoreRules select the fields of the managed objects whose differences
+	// from the declared state are ignored.
+	IgnoreRules diff.IgnoreRules
+
 	// DeclaredResources is the set of valid source objects, managed by the
 	// Updater and shared with the Parser & Remediator.
 	// This is used by the Parser to validate that CRDs can only be removed from
@@ -93,4 +105,28 @@ type ReconcilerOptions struct {
 	// RenderingEnabled indicates whether the hydration-controller is currently
 	// running for this reconciler.
 	RenderingEnabled bool
+
+	// SourceVerifier verifies the fetched source before it is rendered and
+	// synced. Verification is skipped when nil.
+	SourceVerifier SourceVerifier
+
+	// FetchTrigger asks the *-sync containers to fetch the source, when the
+	// source webhook receiver is notified of a change. Only the periodic
+	// fetch is used when nil.
+	FetchTrigger FetchTrigger
+
+	// RolloutGate gates the sync of new commits on the health of a canary
+	// cluster group. New commits are synced without gating when nil.
+	RolloutGate RolloutGate
+
+	// DriftReporter reports the drift recorded by the Remediator, with each
+	// periodic sync status update. No drift is reported when nil.
+	DriftReporter DriftReporter
+}
+
+// DriftReporter reports the drift of the managed objects, when the drift is
+// reported instead of reverted.
+type DriftReporter interface {
+	// Report publishes the current drift.
+	Report(ctx context.Context) status.Error
 }
@@ -18,7 +18,10 @@ import (
 	"context"
 
 	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/filesystem"
+	"kpt.dev/configsync/pkg/importer/reader"
 	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/validate/policy"
 )
 
 // Parser represents a parser that can be pointed at and continuously parse a source.
@@ -26,3 +29,17 @@ type Parser interface {
 	// ParseSource parses the source manifest files and returns the objects.
 	ParseSource(ctx context.Context, state *sourceState) ([]ast.FileObject, status.MultiError)
 }
+
+// loadPolicies reads the ValidationPolicies from the files of the sync
+// directory. If the configurations are rendered, the policies are read from the
+// source files before rendering instead.
+func loadPolicies(parser filesystem.ConfigParser, state *sourceState, filePaths reader.FilePaths) ([]*policy.Policy, status.MultiError) {
+	if state.sourceSyncPath == "" {
+		return policy.Load(parser, filePaths)
+	}
+	return policy.LoadSource(parser, reader.FilePaths{
+		RootDir:   state.sourceSyncPath,
+		PolicyDir: filePaths.PolicyDir,
+		Files:     state.sourceFiles,
+	})
+}
@@ -24,6 +24,7 @@ import (
 	"kpt.dev/configsync/pkg/status"
 	utildiscovery "kpt.dev/configsync/pkg/util/discovery"
 	"kpt.dev/configsync/pkg/validate"
+	"kpt.dev/configsync/pkg/validate/policy"
 )
 
 type repoSyncParser struct {
@@ -49,23 +50,30 @@ func (p *repoSyncParser) ParseSource(ctx context.Context, state *sourceState) ([
 	if err != nil {
 		return nil, err
 	}
+	policies, err := loadPolicies(opts.ConfigParser, state, filePaths)
+	if err != nil {
+		return nil, err
+	}
 
 	options := validate.Options{
-		ClusterName:  opts.ClusterName,
-		SyncName:     opts.SyncName,
-		PolicyDir:    opts.SyncDir,
-		PreviousCRDs: crds,
-		BuildScoper:  builder,
-		Converter:    opts.Converter,
-		Scheme:       opts.Client.Scheme(),
+		ClusterName:    opts.ClusterName,
+		SyncName:       opts.SyncName,
+		PolicyDir:      opts.SyncDir,
+		PreviousCRDs:   crds,
+		BuildScoper:    builder,
+		Converter:      opts.Converter,
+		Scheme:         opts.Client.Scheme(),
+		OpenAPISchemas: opts.OpenAPISchemas,
 		// Namespaces and NamespaceSelectors should not be declared in a namespace repo.
 		// So disable the API call and dynamic mode of NamespaceSelector.
 		AllowAPICall:             false,
 		DynamicNSSelectorEnabled: false,
 		WebhookEnabled:           opts.WebhookEnabled,
+		IgnoreRules:              opts.IgnoreRules,
 		FieldManager:             configsync.FieldManager,
 	}
 	options = OptionsForScope(options, opts.Scope)
+	options.Visitors = append(options.Visitors, policy.Visitor(policies))
 
 	objs, err = validate.Unstructured(ctx, opts.Client, objs, options)
 
@@ -0,0 +1,123 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	corev1 "k8s.io/api/core/v1"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RolloutGate gates the sync of new commits on the health of a canary
+// cluster group.
+type RolloutGate interface {
+	// Check returns an error if the commit must not be synced yet, because
+	// the canary cluster group has not synced it.
+	Check(ctx context.Context, commit string) status.Error
+}
+
+// ConfigMapRolloutGate reads the health of the cluster groups from a shared
+// ConfigMap. The ConfigMap data maps the name of each cluster group to the
+// latest commit that all the clusters of the group have synced.
+type ConfigMapRolloutGate struct {
+	// Client reads the ConfigMap.
+	Client client.Reader
+	// Key is the namespace and name of the ConfigMap.
+	Key client.ObjectKey
+	// CanaryGroup is the name of the canary cluster group.
+	CanaryGroup string
+}
+
+var _ RolloutGate = &ConfigMapRolloutGate{}
+
+// Check implements RolloutGate.
+func (g *ConfigMapRolloutGate) Check(ctx context.Context, commit string) status.Error {
+	cm := &corev1.ConfigMap{}
+	if err := g.Client.Get(ctx, g.Key, cm); err != nil {
+		return rolloutGateError(fmt.Errorf("failed to get the ConfigMap %s: %w", g.Key, err))
+	}
+	return checkCanaryCommit(g.CanaryGroup, cm.Data, commit)
+}
+
+// HTTPRolloutGate reads the health of the cluster groups from an HTTP
+// endpoint. The endpoint serves a JSON object which maps the name of each
+// cluster group to the latest commit that all the clusters of the group have
+// synced.
+type HTTPRolloutGate struct {
+	// Client sends the requests. http.DefaultClient is used when nil.
+	Client *http.Client
+	// URL is the URL of the endpoint.
+	URL string
+	// CanaryGroup is the name of the canary cluster group.
+	CanaryGroup string
+}
+
+var _ RolloutGate = &HTTPRolloutGate{}
+
+// Check implements RolloutGate.
+func (g *HTTPRolloutGate) Check(ctx context.Context, commit string) status.Error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.URL, nil)
+	if err != nil {
+		return rolloutGateError(fmt.Errorf("invalid URL %q: %w", g.URL, err))
+	}
+	c := g.Client
+	if c == nil {
+		c = http.DefaultClient
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return rolloutGateError(fmt.Errorf("failed to get %s: %w", g.URL, err))
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return rolloutGateError(fmt.Errorf("failed to get %s: %s", g.URL, resp.Status))
+	}
+	groups := make(map[string]string)
+	if err := json.NewDecoder(resp.Body).Decode(&groups); err != nil {
+		return rolloutGateError(fmt.Errorf("failed to decode the response of %s: %w", g.URL, err))
+	}
+	return checkCanaryCommit(g.CanaryGroup, groups, commit)
+}
+
+// checkCanaryCommit returns an error unless the canary group synced the
+// commit. The commits must match exactly: a canary commit descending from the
+// commit can't be accepted, because the history newer than the fetched commit
+// is not available. The gate opens once the source is fetched at the canary
+// commit.
+func checkCanaryCommit(canaryGroup string, groups map[string]string, commit string) status.Error {
+	synced, found := groups[canaryGroup]
+	if !found {
+		return rolloutGateError(fmt.Errorf("canary group %q has not reported any synced commit", canaryGroup))
+	}
+	if synced != commit {
+		return rolloutGateError(fmt.Errorf("waiting for canary group %q to sync commit %q: latest synced commit is %q, the commits must match",
+			canaryGroup, commit, synced))
+	}
+	return nil
+}
+
+// rolloutGateError indicates that a commit is not synced yet, because the
+// canary cluster group has not synced it. It is transient, because the gate
+// opens once the canary cluster group reports the commit as synced.
+func rolloutGateError(err error) status.Error {
+	return status.TransientError(fmt.Errorf("rollout gate: %w", err))
+}
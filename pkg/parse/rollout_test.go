@@ -0,0 +1,106 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/status"
+	syncertest "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRolloutGate(t *testing.T) {
+	commit := "abc123"
+	testCases := map[string]struct {
+		groups  map[string]string
+		wantErr bool
+	}{
+		"canary group synced the commit": {
+			groups: map[string]string{"canary": commit, "prod": "def456"},
+		},
+		"canary group synced another commit": {
+			groups:  map[string]string{"canary": "def456"},
+			wantErr: true,
+		},
+		"canary group did not report": {
+			groups:  map[string]string{"prod": commit},
+			wantErr: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run("ConfigMap: "+name, func(t *testing.T) {
+			cm := k8sobjects.ConfigMapObject(core.Namespace("fleet"), core.Name("rollout"))
+			cm.Data = tc.groups
+			gate := &ConfigMapRolloutGate{
+				Client:      syncertest.NewClient(t, core.Scheme, cm),
+				Key:         client.ObjectKey{Namespace: "fleet", Name: "rollout"},
+				CanaryGroup: "canary",
+			}
+			assertRolloutGateErr(t, tc.wantErr, gate.Check(context.Background(), commit))
+		})
+		t.Run("HTTP: "+name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_ = json.NewEncoder(w).Encode(tc.groups)
+			}))
+			defer server.Close()
+			gate := &HTTPRolloutGate{
+				URL:         server.URL,
+				CanaryGroup: "canary",
+			}
+			assertRolloutGateErr(t, tc.wantErr, gate.Check(context.Background(), commit))
+		})
+	}
+
+	t.Run("ConfigMap not found", func(t *testing.T) {
+		gate := &ConfigMapRolloutGate{
+			Client:      syncertest.NewClient(t, core.Scheme),
+			Key:         client.ObjectKey{Namespace: "fleet", Name: "rollout"},
+			CanaryGroup: "canary",
+		}
+		assertRolloutGateErr(t, true, gate.Check(context.Background(), commit))
+	})
+
+	t.Run("HTTP error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		gate := &HTTPRolloutGate{
+			URL:         server.URL,
+			CanaryGroup: "canary",
+		}
+		assertRolloutGateErr(t, true, gate.Check(context.Background(), commit))
+	})
+}
+
+func assertRolloutGateErr(t *testing.T, wantErr bool, err status.Error) {
+	t.Helper()
+	if !wantErr {
+		assert.NoError(t, err)
+		return
+	}
+	if assert.Error(t, err) {
+		// Rollout gate errors are transient, so they don't fail the sync.
+		assert.Equal(t, status.TransientErrorCode, err.Code())
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/hydrate"
 	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/metrics"
 	"kpt.dev/configsync/pkg/reconciler/namespacecontroller"
@@ -139,32 +140,82 @@ func setSourceStatusFields(source *v1beta1.SourceStatus, newStatus *SourceStatus
 	switch newSourceSpec := newStatus.Spec.(type) {
 	case GitSourceSpec:
 		source.Git = &v1beta1.GitStatus{
-			Repo:     newSourceSpec.Repo,
-			Revision: newSourceSpec.Revision,
-			Branch:   newSourceSpec.Branch,
-			Dir:      newSourceSpec.Dir,
+			Repo:               newSourceSpec.Repo,
+			Revision:           newSourceSpec.Revision,
+			RevisionConstraint: newSourceSpec.RevisionConstraint,
+			Branch:             newSourceSpec.Branch,
+			Dir:                newSourceSpec.Dir,
+			SparsePaths:        newSourceSpec.SparsePaths,
+			Submodules:         gitSubmoduleStatuses(newSourceSpec.Submodules),
 		}
 		source.Oci = nil
 		source.Helm = nil
+		source.Archive = nil
 	case OCISourceSpec:
 		source.Oci = &v1beta1.OciStatus{
-			Image: newSourceSpec.Image,
-			Dir:   newSourceSpec.Dir,
+			Image:  newSourceSpec.Image,
+			Dir:    newSourceSpec.Dir,
+			Tag:    newSourceSpec.Tag,
+			Digest: newSourceSpec.Digest,
 		}
 		source.Git = nil
 		source.Helm = nil
+		source.Archive = nil
 	case HelmSourceSpec:
 		source.Helm = &v1beta1.HelmStatus{
 			Repo:    newSourceSpec.Repo,
 			Chart:   newSourceSpec.Chart,
 			Version: newSourceSpec.Version,
+			Digest:  newSourceSpec.Digest,
+			Signer:  newSourceSpec.Signer,
 		}
 		source.Git = nil
 		source.Oci = nil
+		source.Archive = nil
+	case ArchiveSourceSpec:
+		source.Archive = &v1beta1.ArchiveStatus{
+			URL: newSourceSpec.URL,
+			Dir: newSourceSpec.Dir,
+		}
+		source.Git = nil
+		source.Oci = nil
+		source.Helm = nil
 	default:
 		source.Helm = nil
 		source.Git = nil
 		source.Oci = nil
+		source.Archive = nil
+	}
+	source.Sources = nil
+	for _, newSource := range newStatus.Sources {
+		sourceStatus := v1beta1.AdditionalSourceStatus{
+			Name:   newSource.Name,
+			Commit: newSource.Commit,
+		}
+		switch newSourceSpec := newSource.Spec.(type) {
+		case GitSourceSpec:
+			sourceStatus.Git = &v1beta1.GitStatus{
+				Repo:       newSourceSpec.Repo,
+				Revision:   newSourceSpec.Revision,
+				Branch:     newSourceSpec.Branch,
+				Dir:        newSourceSpec.Dir,
+				Submodules: gitSubmoduleStatuses(newSourceSpec.Submodules),
+			}
+		case OCISourceSpec:
+			sourceStatus.Oci = &v1beta1.OciStatus{
+				Image:  newSourceSpec.Image,
+				Dir:    newSourceSpec.Dir,
+				Tag:    newSourceSpec.Tag,
+				Digest: newSourceSpec.Digest,
+			}
+		case HelmSourceSpec:
+			sourceStatus.Helm = &v1beta1.HelmStatus{
+				Repo:    newSourceSpec.Repo,
+				Chart:   newSourceSpec.Chart,
+				Version: newSourceSpec.Version,
+			}
+		}
+		source.Sources = append(source.Sources, sourceStatus)
 	}
 	errorSummary := &v1beta1.ErrorSummary{
 		TotalCount:                len(cse),
@@ -296,32 +347,51 @@ func setRenderingStatusFields(rendering *v1beta1.RenderingStatus, newStatus *Ren
 	switch newSourceSpec := newStatus.Spec.(type) {
 	case GitSourceSpec:
 		rendering.Git = &v1beta1.GitStatus{
-			Repo:     newSourceSpec.Repo,
-			Revision: newSourceSpec.Revision,
-			Branch:   newSourceSpec.Branch,
-			Dir:      newSourceSpec.Dir,
+			Repo:               newSourceSpec.Repo,
+			Revision:           newSourceSpec.Revision,
+			RevisionConstraint: newSourceSpec.RevisionConstraint,
+			Branch:             newSourceSpec.Branch,
+			Dir:                newSourceSpec.Dir,
+			SparsePaths:        newSourceSpec.SparsePaths,
+			Submodules:         gitSubmoduleStatuses(newSourceSpec.Submodules),
 		}
 		rendering.Oci = nil
 		rendering.Helm = nil
+		rendering.Archive = nil
 	case OCISourceSpec:
 		rendering.Oci = &v1beta1.OciStatus{
-			Image: newSourceSpec.Image,
-			Dir:   newSourceSpec.Dir,
+			Image:  newSourceSpec.Image,
+			Dir:    newSourceSpec.Dir,
+			Tag:    newSourceSpec.Tag,
+			Digest: newSourceSpec.Digest,
 		}
 		rendering.Git = nil
 		rendering.Helm = nil
+		rendering.Archive = nil
 	case HelmSourceSpec:
 		rendering.Helm = &v1beta1.HelmStatus{
 			Repo:    newSourceSpec.Repo,
 			Chart:   newSourceSpec.Chart,
 			Version: newSourceSpec.Version,
+			Digest:  newSourceSpec.Digest,
+			Signer:  newSourceSpec.Signer,
+		}
+		rendering.Git = nil
+		rendering.Oci = nil
+		rendering.Archive = nil
+	case ArchiveSourceSpec:
+		rendering.Archive = &v1beta1.ArchiveStatus{
+			URL: newSourceSpec.URL,
+			Dir: newSourceSpec.Dir,
 		}
 		rendering.Git = nil
 		rendering.Oci = nil
+		rendering.Helm = nil
 	default:
 		rendering.Helm = nil
 		rendering.Git = nil
 		rendering.Oci = nil
+		rendering.Archive = nil
 	}
 	rendering.Message = newStatus.Message
 	errorSummary := &v1beta1.ErrorSummary{
@@ -364,45 +434,60 @@ func reconcilerStatusFromRSyncStatus(rsyncStatus v1beta1.Status, sourceType conf
 	case configsync.GitSource:
 		if rsyncStatus.Source.Git != nil {
 			sourceSpec = GitSourceSpec{
-				Repo:     rsyncStatus.Source.Git.Repo,
-				Revision: rsyncStatus.Source.Git.Revision,
-				Branch:   rsyncStatus.Source.Git.Branch,
-				Dir:      rsyncStatus.Source.Git.Dir,
+				Repo:               rsyncStatus.Source.Git.Repo,
+				Revision:           rsyncStatus.Source.Git.Revision,
+				RevisionConstraint: rsyncStatus.Source.Git.RevisionConstraint,
+				Branch:             rsyncStatus.Source.Git.Branch,
+				Dir:                rsyncStatus.Source.Git.Dir,
+				SparsePaths:        rsyncStatus.Source.Git.SparsePaths,
+				Submodules:         gitSubmodulesFromStatuses(rsyncStatus.Source.Git.Submodules),
 			}
 		}
 		if rsyncStatus.Rendering.Git != nil {
 			renderSpec = GitSourceSpec{
-				Repo:     rsyncStatus.Rendering.Git.Repo,
-				Revision: rsyncStatus.Rendering.Git.Revision,
-				Branch:   rsyncStatus.Rendering.Git.Branch,
-				Dir:      rsyncStatus.Rendering.Git.Dir,
+				Repo:               rsyncStatus.Rendering.Git.Repo,
+				Revision:           rsyncStatus.Rendering.Git.Revision,
+				RevisionConstraint: rsyncStatus.Rendering.Git.RevisionConstraint,
+				Branch:             rsyncStatus.Rendering.Git.Branch,
+				Dir:                rsyncStatus.Rendering.Git.Dir,
+				SparsePaths:        rsyncStatus.Rendering.Git.SparsePaths,
+				Submodules:         gitSubmodulesFromStatuses(rsyncStatus.Rendering.Git.Submodules),
 			}
 		}
 		if rsyncStatus.Sync.Git != nil {
 			syncSpec = GitSourceSpec{
-				Repo:     rsyncStatus.Sync.Git.Repo,
-				Revision: rsyncStatus.Sync.Git.Revision,
-				Branch:   rsyncStatus.Sync.Git.Branch,
-				Dir:      rsyncStatus.Sync.Git.Dir,
+				Repo:               rsyncStatus.Sync.Git.Repo,
+				Revision:           rsyncStatus.Sync.Git.Revision,
+				RevisionConstraint: rsyncStatus.Sync.Git.RevisionConstraint,
+				Branch:             rsyncStatus.Sync.Git.Branch,
+				Dir:                rsyncStatus.Sync.Git.Dir,
+				SparsePaths:        rsyncStatus.Sync.Git.SparsePaths,
+				Submodules:         gitSubmodulesFromStatuses(rsyncStatus.Sync.Git.Submodules),
 			}
 		}
 	case configsync.OciSource:
 		if rsyncStatus.Source.Oci != nil {
 			sourceSpec = OCISourceSpec{
-				Image: rsyncStatus.Source.Oci.Image,
-				Dir:   rsyncStatus.Source.Oci.Dir,
+				Image:  rsyncStatus.Source.Oci.Image,
+				Dir:    rsyncStatus.Source.Oci.Dir,
+				Tag:    rsyncStatus.Source.Oci.Tag,
+				Digest: rsyncStatus.Source.Oci.Digest,
 			}
 		}
 		if rsyncStatus.Rendering.Oci != nil {
 			renderSpec = OCISourceSpec{
-				Image: rsyncStatus.Rendering.Oci.Image,
-				Dir:   rsyncStatus.Rendering.Oci.Dir,
+				Image:  rsyncStatus.Rendering.Oci.Image,
+				Dir:    rsyncStatus.Rendering.Oci.Dir,
+				Tag:    rsyncStatus.Rendering.Oci.Tag,
+				Digest: rsyncStatus.Rendering.Oci.Digest,
 			}
 		}
 		if rsyncStatus.Sync.Oci != nil {
 			syncSpec = OCISourceSpec{
-				Image: rsyncStatus.Sync.Oci.Image,
-				Dir:   rsyncStatus.Sync.Oci.Dir,
+				Image:  rsyncStatus.Sync.Oci.Image,
+				Dir:    rsyncStatus.Sync.Oci.Dir,
+				Tag:    rsyncStatus.Sync.Oci.Tag,
+				Digest: rsyncStatus.Sync.Oci.Digest,
 			}
 		}
 	case configsync.HelmSource:
@@ -411,6 +496,8 @@ func reconcilerStatusFromRSyncStatus(rsyncStatus v1beta1.Status, sourceType conf
 				Repo:    rsyncStatus.Source.Helm.Repo,
 				Chart:   rsyncStatus.Source.Helm.Chart,
 				Version: rsyncStatus.Source.Helm.Version,
+				Digest:  rsyncStatus.Source.Helm.Digest,
+				Signer:  rsyncStatus.Source.Helm.Signer,
 			}
 		}
 		if rsyncStatus.Rendering.Helm != nil {
@@ -418,6 +505,8 @@ func reconcilerStatusFromRSyncStatus(rsyncStatus v1beta1.Status, sourceType conf
 				Repo:    rsyncStatus.Rendering.Helm.Repo,
 				Chart:   rsyncStatus.Rendering.Helm.Chart,
 				Version: rsyncStatus.Rendering.Helm.Version,
+				Digest:  rsyncStatus.Rendering.Helm.Digest,
+				Signer:  rsyncStatus.Rendering.Helm.Signer,
 			}
 		}
 		if rsyncStatus.Sync.Helm != nil {
@@ -425,14 +514,36 @@ func reconcilerStatusFromRSyncStatus(rsyncStatus v1beta1.Status, sourceType conf
 				Repo:    rsyncStatus.Sync.Helm.Repo,
 				Chart:   rsyncStatus.Sync.Helm.Chart,
 				Version: rsyncStatus.Sync.Helm.Version,
+				Digest:  rsyncStatus.Sync.Helm.Digest,
+				Signer:  rsyncStatus.Sync.Helm.Signer,
+			}
+		}
+	case configsync.ArchiveSource:
+		if rsyncStatus.Source.Archive != nil {
+			sourceSpec = ArchiveSourceSpec{
+				URL: rsyncStatus.Source.Archive.URL,
+				Dir: rsyncStatus.Source.Archive.Dir,
+			}
+		}
+		if rsyncStatus.Rendering.Archive != nil {
+			renderSpec = ArchiveSourceSpec{
+				URL: rsyncStatus.Rendering.Archive.URL,
+				Dir: rsyncStatus.Rendering.Archive.Dir,
+			}
+		}
+		if rsyncStatus.Sync.Archive != nil {
+			syncSpec = ArchiveSourceSpec{
+				URL: rsyncStatus.Sync.Archive.URL,
+				Dir: rsyncStatus.Sync.Archive.Dir,
 			}
 		}
 	}
 
 	return &ReconcilerStatus{
 		SourceStatus: &SourceStatus{
-			Spec:   sourceSpec,
-			Commit: rsyncStatus.Source.Commit,
+			Spec:    sourceSpec,
+			Commit:  rsyncStatus.Source.Commit,
+			Sources: additionalSourceStatusesFromRSyncStatus(rsyncStatus.Source.Sources),
 			// Can't parse errors.
 			// Errors will be reset the next time the reconciler updates the status.
 			Errs:       nil,
@@ -458,6 +569,8 @@ func reconcilerStatusFromRSyncStatus(rsyncStatus v1beta1.Status, sourceType conf
 			// Errors will be reset the next time the reconciler updates the status.
 			Errs:       nil,
 			LastUpdate: rsyncStatus.Sync.LastUpdate,
+			Waves:      rsyncStatus.Sync.Waves.DeepCopy(),
+			Hooks:      copyHookStatuses(rsyncStatus.Sync.Hooks),
 		},
 	}
 }
@@ -544,6 +657,9 @@ func setSyncStatusFields(syncStatus *v1beta1.Status, newStatus *SyncStatus, deno
 	syncStatus.Sync.Git = syncStatus.Source.Git
 	syncStatus.Sync.Oci = syncStatus.Source.Oci
 	syncStatus.Sync.Helm = syncStatus.Source.Helm
+	syncStatus.Sync.Archive = syncStatus.Source.Archive
+	syncStatus.Sync.Waves = newStatus.Waves.DeepCopy()
+	syncStatus.Sync.Hooks = copyHookStatuses(newStatus.Hooks)
 	setSyncStatusErrors(syncStatus, cse, denominator)
 	syncStatus.Sync.LastUpdate = newStatus.LastUpdate
 }
@@ -592,3 +708,61 @@ func summarizeErrorsForCommit(sourceStatus v1beta1.SourceStatus, renderingStatus
 	}
 	return errorSources, errorSummary
 }
+
+func additionalSourceStatusesFromRSyncStatus(sources []v1beta1.AdditionalSourceStatus) []AdditionalSourceStatus {
+	var result []AdditionalSourceStatus
+	for _, source := range sources {
+		var spec SourceSpec
+		switch {
+		case source.Git != nil:
+			spec = GitSourceSpec{
+				Repo:       source.Git.Repo,
+				Revision:   source.Git.Revision,
+				Branch:     source.Git.Branch,
+				Dir:        source.Git.Dir,
+				Submodules: gitSubmodulesFromStatuses(source.Git.Submodules),
+			}
+		case source.Oci != nil:
+			spec = OCISourceSpec{
+				Image:  source.Oci.Image,
+				Dir:    source.Oci.Dir,
+				Tag:    source.Oci.Tag,
+				Digest: source.Oci.Digest,
+			}
+		case source.Helm != nil:
+			spec = HelmSourceSpec{
+				Repo:    source.Helm.Repo,
+				Chart:   source.Helm.Chart,
+				Version: source.Helm.Version,
+			}
+		}
+		result = append(result, AdditionalSourceStatus{
+			Name:   source.Name,
+			Spec:   spec,
+			Commit: source.Commit,
+		})
+	}
+	return result
+}
+
+func gitSubmoduleStatuses(submodules []hydrate.Submodule) []v1beta1.GitSubmoduleStatus {
+	var result []v1beta1.GitSubmoduleStatus
+	for _, submodule := range submodules {
+		result = append(result, v1beta1.GitSubmoduleStatus{
+			Path:   submodule.Path,
+			Commit: submodule.Commit,
+		})
+	}
+	return result
+}
+
+func gitSubmodulesFromStatuses(submodules []v1beta1.GitSubmoduleStatus) []hydrate.Submodule {
+	var result []hydrate.Submodule
+	for _, submodule := range submodules {
+		result = append(result, hydrate.Submodule{
+			Path:   submodule.Path,
+			Commit: submodule.Commit,
+		})
+	}
+	return result
+}
@@ -17,6 +17,7 @@ package parse
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/elliotchance/orderedmap/v2"
 	corev1 "k8s.io/api/core/v1"
@@ -25,9 +26,11 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/diff"
 	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/analyzer/validation/nonhierarchical"
 	"kpt.dev/configsync/pkg/importer/filesystem"
 	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
 	"kpt.dev/configsync/pkg/importer/reader"
@@ -35,7 +38,9 @@ import (
 	"kpt.dev/configsync/pkg/status"
 	"kpt.dev/configsync/pkg/util/discovery"
 	"kpt.dev/configsync/pkg/validate"
+	"kpt.dev/configsync/pkg/validate/policy"
 	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type rootSyncParser struct {
@@ -70,23 +75,64 @@ func (p *rootSyncParser) ParseSource(ctx context.Context, state *sourceState) ([
 	if err != nil {
 		return nil, err
 	}
+	policies, err := loadPolicies(opts.ConfigParser, state, filePaths)
+	if err != nil {
+		return nil, err
+	}
+
+	// Objects declared by the additional sources, keyed by ID, with the index
+	// of the source declaring them.
+	sourceIndex := make(map[core.ID]int)
+	if len(state.sources) > 0 {
+		names := []string{fmt.Sprintf("spec.%s", opts.SourceType)}
+		objsBySource := [][]ast.FileObject{objs}
+		for i, source := range state.sources {
+			klog.Infof("Parsing files from source %q path: %s", source.name, source.syncPath.OSPath())
+			sourceFilePaths := reader.FilePaths{
+				RootDir:   source.syncPath,
+				PolicyDir: opts.AdditionalSources[i].SyncDir,
+				Files:     source.files,
+			}
+			sourceObjs, err := opts.ConfigParser.Parse(sourceFilePaths)
+			if err != nil {
+				return nil, err
+			}
+			sourcePolicies, err := policy.Load(opts.ConfigParser, sourceFilePaths)
+			if err != nil {
+				return nil, err
+			}
+			policies = append(policies, sourcePolicies...)
+			for _, obj := range sourceObjs {
+				sourceIndex[core.IDOf(obj)] = i
+			}
+			names = append(names, fmt.Sprintf("spec.sources[%s]", source.name))
+			objsBySource = append(objsBySource, sourceObjs)
+			objs = append(objs, sourceObjs...)
+		}
+		if err := sourceCollisions(names, objsBySource); err != nil {
+			return nil, err
+		}
+	}
 
 	options := validate.Options{
-		ClusterName:  opts.ClusterName,
-		SyncName:     opts.SyncName,
-		PolicyDir:    opts.SyncDir,
-		PreviousCRDs: crds,
-		BuildScoper:  builder,
-		Converter:    opts.Converter,
-		Scheme:       opts.Client.Scheme(),
+		ClusterName:    opts.ClusterName,
+		SyncName:       opts.SyncName,
+		PolicyDir:      opts.SyncDir,
+		PreviousCRDs:   crds,
+		BuildScoper:    builder,
+		Converter:      opts.Converter,
+		Scheme:         opts.Client.Scheme(),
+		OpenAPISchemas: opts.OpenAPISchemas,
 		// Enable API call so NamespaceSelector can talk to k8s-api-server.
 		AllowAPICall:             true,
 		DynamicNSSelectorEnabled: opts.DynamicNSSelectorEnabled,
 		NSControllerState:        opts.NSControllerState,
 		WebhookEnabled:           opts.WebhookEnabled,
+		IgnoreRules:              opts.IgnoreRules,
 		FieldManager:             configsync.FieldManager,
 	}
 	options = OptionsForScope(options, opts.Scope)
+	options.Visitors = append(options.Visitors, policy.Visitor(policies))
 
 	if opts.SourceFormat == configsync.SourceFormatUnstructured {
 		if opts.NamespaceStrategy == configsync.NamespaceStrategyImplicit {
@@ -101,8 +147,24 @@ func (p *rootSyncParser) ParseSource(ctx context.Context, state *sourceState) ([
 		return nil, err
 	}
 
+	// Objects declared by the additional sources are annotated with the
+	// context and commit of their source. Other objects, including implicit
+	// Namespaces, are annotated with the context of the primary source.
+	var primaryObjs []ast.FileObject
+	objsBySource := make([][]ast.FileObject, len(state.sources))
+	for _, obj := range objs {
+		if i, found := sourceIndex[core.IDOf(obj)]; found {
+			objsBySource[i] = append(objsBySource[i], obj)
+		} else {
+			primaryObjs = append(primaryObjs, obj)
+		}
+	}
+
 	// Duplicated with namespace.go.
-	e := addAnnotationsAndLabels(objs, declared.RootScope, opts.SyncName, opts.Files.sourceContext(), state.commit)
+	e := addAnnotationsAndLabels(primaryObjs, declared.RootScope, opts.SyncName, opts.Files.sourceContext(), state.commit)
+	for i := 0; e == nil && i < len(state.sources); i++ {
+		e = addAnnotationsAndLabels(objsBySource[i], declared.RootScope, opts.SyncName, opts.AdditionalSources[i].sourceContext(), state.sources[i].commit)
+	}
 	if e != nil {
 		err = status.Append(err, status.InternalErrorf("unable to add annotations and labels: %v", e))
 		return nil, err
@@ -110,6 +172,40 @@ func (p *rootSyncParser) ParseSource(ctx context.Context, state *sourceState) ([
 	return objs, err
 }
 
+// sourceCollisions returns an error for each object declared in more than one
+// source. Duplicates within a single source are reported by the validation of
+// the merged objects.
+func sourceCollisions(names []string, objsBySource [][]ast.FileObject) status.MultiError {
+	type declaration struct {
+		sources []string
+		objs    []client.Object
+	}
+	declarations := make(map[core.ID]*declaration)
+	var ids []core.ID
+	for i, objs := range objsBySource {
+		for _, obj := range objs {
+			id := core.IDOf(obj)
+			d, found := declarations[id]
+			if !found {
+				d = &declaration{}
+				declarations[id] = d
+				ids = append(ids, id)
+			}
+			if !slices.Contains(d.sources, names[i]) {
+				d.sources = append(d.sources, names[i])
+			}
+			d.objs = append(d.objs, obj)
+		}
+	}
+	var errs status.MultiError
+	for _, id := range ids {
+		if d := declarations[id]; len(d.sources) > 1 {
+			errs = status.Append(errs, nonhierarchical.SourceCollisionError(id.GroupKind, id.Namespace, id.Name, d.sources, d.objs...))
+		}
+	}
+	return errs
+}
+
 // addImplicitNamespaces hydrates the given FileObjects by injecting implicit
 // namespaces into the list before returning it. Implicit namespaces are those
 // that are declared by an object's metadata namespace field but are not present
@@ -0,0 +1,246 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/analyzer/validation/nonhierarchical"
+	"kpt.dev/configsync/pkg/importer/filesystem"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
+	fsfake "kpt.dev/configsync/pkg/importer/filesystem/fake"
+	ft "kpt.dev/configsync/pkg/importer/filesystem/filesystemtest"
+	"kpt.dev/configsync/pkg/importer/reader"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	syncertest "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"kpt.dev/configsync/pkg/testing/openapitest"
+	"kpt.dev/configsync/pkg/validate/policy"
+)
+
+func TestRootSyncParser_ParseSource_AdditionalSources(t *testing.T) {
+	const sourceCommit = "source-commit"
+	fileSource := FileSource{
+		SourceType: configsync.GitSource,
+		SourceRepo: "example-repo",
+		SourceRev:  testGitCommit,
+		AdditionalSources: []AdditionalFileSource{{
+			Name: "platform",
+			FileSource: FileSource{
+				SourceType: configsync.GitSource,
+				SourceRepo: "platform-repo",
+				SourceRev:  "HEAD",
+				SyncDir:    "configs",
+			},
+		}},
+	}
+	state := &sourceState{
+		commit:   testGitCommit,
+		syncPath: "/repo/source/rev",
+		files:    []cmpath.Absolute{"/repo/source/rev/role.yaml"},
+		sources: []additionalSourceState{{
+			name: "platform",
+			sourceState: sourceState{
+				commit:   sourceCommit,
+				syncPath: "/repo/sources/platform/rev/configs",
+				files:    []cmpath.Absolute{"/repo/sources/platform/rev/configs/ns.yaml"},
+			},
+		}},
+	}
+
+	converter, err := openapitest.ValueConverterForTest()
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		parseOutputs []fsfake.ParserOutputs
+		wantContexts map[string]string
+		wantTokens   map[string]string
+		wantErrCode  string
+	}{
+		"objects are annotated with the context of their source": {
+			parseOutputs: []fsfake.ParserOutputs{
+				{FileObjects: []ast.FileObject{k8sobjects.RoleAtPath("role.yaml", core.Name("admin"), core.Namespace("foo"))}},
+				{FileObjects: []ast.FileObject{k8sobjects.NamespaceAtPath("ns.yaml", core.Name("foo"))}},
+			},
+			wantContexts: map[string]string{
+				"Role":      `{"repo":"example-repo","rev":"example-commit"}`,
+				"Namespace": `{"repo":"platform-repo","rev":"HEAD"}`,
+			},
+			wantTokens: map[string]string{
+				"Role":      testGitCommit,
+				"Namespace": sourceCommit,
+			},
+		},
+		"objects declared in more than one source": {
+			parseOutputs: []fsfake.ParserOutputs{
+				{FileObjects: []ast.FileObject{k8sobjects.RoleAtPath("role.yaml", core.Name("admin"), core.Namespace("foo"))}},
+				{FileObjects: []ast.FileObject{k8sobjects.RoleAtPath("role.yaml", core.Name("admin"), core.Namespace("foo"))}},
+			},
+			wantErrCode: nonhierarchical.NameCollisionErrorCode,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			fakeConfigParser := &fsfake.ConfigParser{Outputs: tc.parseOutputs}
+			parser := &rootSyncParser{
+				options: &RootOptions{
+					Options: &Options{
+						ConfigParser:      fakeConfigParser,
+						SyncName:          rootSyncName,
+						Scope:             declared.RootScope,
+						Client:            syncertest.NewClient(t, core.Scheme),
+						DiscoveryClient:   syncertest.NewDiscoveryClient(kinds.Namespace(), kinds.Role()),
+						Converter:         converter,
+						Files:             Files{FileSource: fileSource},
+						DeclaredResources: &declared.Resources{},
+					},
+					SourceFormat:      configsync.SourceFormatUnstructured,
+					NamespaceStrategy: configsync.NamespaceStrategyExplicit,
+				},
+			}
+
+			objs, errs := parser.ParseSource(context.Background(), state)
+
+			assert.Equal(t, []fsfake.ParserInputs{
+				{FilePaths: reader.FilePaths{RootDir: state.syncPath, Files: state.files}},
+				{FilePaths: reader.FilePaths{RootDir: state.sources[0].syncPath, PolicyDir: "configs", Files: state.sources[0].files}},
+			}, fakeConfigParser.Inputs)
+			if tc.wantErrCode != "" {
+				require.Error(t, errs)
+				require.Len(t, errs.Errors(), 1)
+				assert.Equal(t, tc.wantErrCode, errs.Errors()[0].Code())
+				assert.Contains(t, errs.Error(), "in sources spec.git, spec.sources[platform]")
+				return
+			}
+			require.NoError(t, errs)
+			require.Len(t, objs, len(tc.wantContexts))
+			for _, obj := range objs {
+				kind := obj.GetObjectKind().GroupVersionKind().Kind
+				assert.Equal(t, tc.wantContexts[kind], core.GetAnnotation(obj, metadata.GitContextKey), kind)
+				assert.Equal(t, tc.wantTokens[kind], core.GetAnnotation(obj, metadata.SyncTokenAnnotationKey), kind)
+			}
+		})
+	}
+}
+
+func TestRootSyncParser_ParseSource_RenderedPolicies(t *testing.T) {
+	// Rendering drops the ValidationPolicies, since they are local
+	// configuration, so they are read from the source.
+	sourceDir := ft.NewTestDir(t,
+		ft.FileContents("kustomization.yaml", "resources:\n- deployment.yaml\n- policy.yaml\n"),
+		ft.FileContents("policy.yaml", `apiVersion: configsync.gke.io/v1alpha1
+kind: ValidationPolicy
+metadata:
+  name: require-replicas
+  annotations:
+    config.kubernetes.io/local-config: "true"
+spec:
+  validations:
+  - expression: "has(object.spec.replicas)"
+`),
+	)
+	hydratedDir := ft.NewTestDir(t,
+		ft.FileContents("deployment.yaml", "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n  namespace: prod\nspec: {}\n"),
+	)
+	state := &sourceState{
+		commit:         testGitCommit,
+		syncPath:       hydratedDir.Root(),
+		files:          hydratedDir.FilePaths("deployment.yaml").Files,
+		sourceSyncPath: sourceDir.Root(),
+		sourceFiles:    sourceDir.FilePaths("kustomization.yaml", "policy.yaml").Files,
+	}
+
+	converter, err := openapitest.ValueConverterForTest()
+	require.NoError(t, err)
+	parser := &rootSyncParser{
+		options: &RootOptions{
+			Options: &Options{
+				ConfigParser:      filesystem.NewParser(&reader.File{}),
+				SyncName:          rootSyncName,
+				Scope:             declared.RootScope,
+				Client:            syncertest.NewClient(t, core.Scheme),
+				DiscoveryClient:   syncertest.NewDiscoveryClient(kinds.Deployment()),
+				Converter:         converter,
+				Files:             Files{FileSource: FileSource{SourceType: configsync.GitSource}},
+				DeclaredResources: &declared.Resources{},
+			},
+			SourceFormat:      configsync.SourceFormatUnstructured,
+			NamespaceStrategy: configsync.NamespaceStrategyExplicit,
+		},
+	}
+
+	_, errs := parser.ParseSource(context.Background(), state)
+	require.Error(t, errs)
+	require.Len(t, errs.Errors(), 1)
+	assert.Equal(t, policy.ValidationPolicyViolationErrorCode, errs.Errors()[0].Code())
+	assert.Contains(t, errs.Error(), "require-replicas")
+}
+
+func TestSourceState_SameSyncPaths(t *testing.T) {
+	source := func(syncPath cmpath.Absolute, sources ...additionalSourceState) *sourceState {
+		return &sourceState{syncPath: syncPath, sources: sources}
+	}
+	platform := func(syncPath cmpath.Absolute) additionalSourceState {
+		return additionalSourceState{name: "platform", sourceState: sourceState{syncPath: syncPath}}
+	}
+
+	testCases := map[string]struct {
+		a, b *sourceState
+		want bool
+	}{
+		"both nil": {
+			want: true,
+		},
+		"one nil": {
+			a: source("/a"),
+		},
+		"same primary path": {
+			a:    source("/a"),
+			b:    source("/a"),
+			want: true,
+		},
+		"different primary path": {
+			a: source("/a"),
+			b: source("/b"),
+		},
+		"same source paths": {
+			a:    source("/a", platform("/p1")),
+			b:    source("/a", platform("/p1")),
+			want: true,
+		},
+		"different source paths": {
+			a: source("/a", platform("/p1")),
+			b: source("/a", platform("/p2")),
+		},
+		"source added": {
+			a: source("/a"),
+			b: source("/a", platform("/p1")),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.a.sameSyncPaths(tc.b))
+			assert.Equal(t, tc.want, tc.b.sameSyncPaths(tc.a))
+		})
+	}
+}
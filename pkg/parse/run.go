@@ -20,17 +20,22 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/git"
 	"kpt.dev/configsync/pkg/hydrate"
 	"kpt.dev/configsync/pkg/importer/analyzer/ast"
 	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
 	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/metrics"
+	"kpt.dev/configsync/pkg/provenance"
 	"kpt.dev/configsync/pkg/status"
 	"kpt.dev/configsync/pkg/util"
 	webhookconfiguration "kpt.dev/configsync/pkg/webhook/configuration"
@@ -52,6 +57,7 @@ const (
 	triggerManagementConflict = "managementConflict"
 	triggerWatchUpdate        = "watchUpdate"
 	triggerNamespaceUpdate    = "namespaceEvent"
+	triggerSourceChange       = "sourceChange"
 )
 
 const (
@@ -61,6 +67,10 @@ const (
 	// RenderingSucceeded means that the configs have been rendered successfully.
 	RenderingSucceeded string = "Rendering succeeded"
 
+	// RenderingCached means that the rendering inputs are unchanged since the
+	// previous commit, so its rendered configs have been reused.
+	RenderingCached string = "Rendering skipped, the rendering inputs are unchanged"
+
 	// RenderingFailed means that the configs have failed to be rendered.
 	RenderingFailed string = "Rendering failed"
 
@@ -88,6 +98,8 @@ type ReconcileResult struct {
 // Reconcile has multiple phases:
 //   - Fetch - Checks the shared filesystem for new source commits fetched by
 //     one of the *-sync sidecars.
+//   - Gate - Waits for the canary cluster group to sync new commits, if a
+//     rollout gate is configured.
 //   - Render - Checks the shared filesystem for source rendered by the
 //     hydration-controller sidecar using helm or kustomize, if required.
 //   - Read - Reads the fetch and render status from the shared filesystem and
@@ -126,7 +138,7 @@ func (r *reconciler) Reconcile(ctx context.Context, trigger string) ReconcileRes
 		state.RecordFullSyncStart(startTime)
 	}
 
-	newSourceStatus, syncPath, errs := r.fetch(ctx)
+	newSourceStatus, srcState, errs := r.fetch(ctx)
 	if errs != nil {
 		state.RecordFailure(opts.Clock, errs)
 		return result
@@ -145,15 +157,15 @@ func (r *reconciler) Reconcile(ctx context.Context, trigger string) ReconcileRes
 	}
 
 	// rendering is done, starts to read the source or hydrated configs.
-	oldSyncPath := state.cache.source.syncPath
-	if errs := r.read(ctx, trigger, newSourceStatus, syncPath); errs != nil {
+	oldSource := state.cache.source
+	if errs := r.read(ctx, trigger, srcState); errs != nil {
 		state.RecordFailure(opts.Clock, errs)
 		return result
 	}
 
-	newSyncPath := state.cache.source.syncPath
+	newSource := state.cache.source
 
-	if newSyncPath != oldSyncPath {
+	if !newSource.sameSyncPaths(oldSource) {
 		// If the commit, branch, or sync dir changed and read succeeded,
 		// trigger retries to start again, if stopped.
 		result.SourceChanged = true
@@ -163,7 +175,7 @@ func (r *reconciler) Reconcile(ctx context.Context, trigger string) ReconcileRes
 	// and there are no new source changes. The reasons are:
 	//   * If a former parse-apply-watch sequence for syncPath succeeded, there is no need to run the sequence again;
 	//   * If all the former parse-apply-watch sequences for syncPath failed, the next retry will call the sequence.
-	if trigger == triggerSync && oldSyncPath == newSyncPath {
+	if trigger == triggerSync && newSource.sameSyncPaths(oldSource) {
 		return result
 	}
 
@@ -201,7 +213,7 @@ func (r *reconciler) Reconcile(ctx context.Context, trigger string) ReconcileRes
 // fetch waits for the *-sync sidecars to fetch the source manifests to the
 // shared source volume.
 // Updates the RSync status (source status and syncing condition).
-func (r *reconciler) fetch(ctx context.Context) (*SourceStatus, cmpath.Absolute, status.MultiError) {
+func (r *reconciler) fetch(ctx context.Context) (*SourceStatus, *sourceState, status.MultiError) {
 	opts := r.Options()
 	state := r.ReconcilerState()
 	var syncPath cmpath.Absolute
@@ -212,6 +224,59 @@ func (r *reconciler) fetch(ctx context.Context) (*SourceStatus, cmpath.Absolute,
 	newSourceStatus.Commit, syncPath, newSourceStatus.Errs = hydrate.SourceCommitAndSyncPathWithRetry(
 		util.SourceRetryBackoff, opts.SourceType, opts.SourceDir, opts.SyncDir, opts.ReconcilerName)
 
+	// pull the commits and directories of the additional sources, if any.
+	var sources []additionalSourceState
+	for _, source := range opts.AdditionalSources {
+		commit, sourceSyncPath, err := hydrate.SourceCommitAndSyncPathWithRetry(
+			util.SourceRetryBackoff, source.SourceType, source.SourceDir, source.SyncDir, opts.ReconcilerName)
+		if err != nil {
+			newSourceStatus.Errs = status.Append(newSourceStatus.Errs, err)
+		} else if source.SourceVerifier != nil {
+			if verifyErr := source.SourceVerifier.Verify(source.SourceDir, commit); verifyErr != nil {
+				newSourceStatus.Errs = status.Append(newSourceStatus.Errs, verifyErr)
+			}
+		}
+		spec := SourceSpecFromFileSource(source.FileSource, source.SourceType, commit)
+		if gitSpec, ok := spec.(GitSourceSpec); ok && err == nil {
+			var checkoutErr status.Error
+			spec, checkoutErr = withGitCheckout(gitSpec, source.FileSource)
+			if checkoutErr != nil {
+				newSourceStatus.Errs = status.Append(newSourceStatus.Errs, checkoutErr)
+			}
+		}
+		if ociSpec, ok := spec.(OCISourceSpec); ok && err == nil {
+			var resolutionErr status.Error
+			spec, resolutionErr = withImageResolution(ociSpec, source.SourceDir, commit)
+			if resolutionErr != nil {
+				newSourceStatus.Errs = status.Append(newSourceStatus.Errs, resolutionErr)
+			}
+		}
+		sources = append(sources, additionalSourceState{
+			name: source.Name,
+			sourceState: sourceState{
+				spec:     spec,
+				commit:   commit,
+				syncPath: sourceSyncPath,
+			},
+		})
+	}
+
+	// Verify the primary source before it is rendered or synced. Unverified
+	// sources block hydration, like any other source error.
+	if newSourceStatus.Errs == nil && opts.SourceVerifier != nil {
+		if err := opts.SourceVerifier.Verify(opts.SourceDir, newSourceStatus.Commit); err != nil {
+			newSourceStatus.Errs = err
+		}
+	}
+
+	// Only sync a new commit after the canary cluster group synced it. The
+	// gated commit is reported as a source error, and is not rendered.
+	if newSourceStatus.Errs == nil && opts.RolloutGate != nil && !state.isSyncedCommit(newSourceStatus.Commit) {
+		if err := opts.RolloutGate.Check(ctx, newSourceStatus.Commit); err != nil {
+			newSourceStatus.Errs = err
+		}
+	}
+
 	// Add pre-sync annotations to the object.
 	// If updating the object fails, it's likely due to a signature verification error
 	// from the webhook. In this case, add the error as a source error.
@@ -231,27 +296,119 @@ func (r *reconciler) fetch(ctx context.Context) (*SourceStatus, cmpath.Absolute,
 
 	// Generate source spec from Reconciler config
 	newSourceStatus.Spec = SourceSpecFromFileSource(opts.FileSource, opts.SourceType, newSourceStatus.Commit)
+	if helmSpec, ok := newSourceStatus.Spec.(HelmSourceSpec); ok && newSourceStatus.Errs == nil {
+		var err status.Error
+		newSourceStatus.Spec, err = withChartVerification(helmSpec, opts.SourceDir, newSourceStatus.Commit)
+		if err != nil {
+			newSourceStatus.Errs = err
+		}
+	}
+	if gitSpec, ok := newSourceStatus.Spec.(GitSourceSpec); ok && newSourceStatus.Errs == nil {
+		var err status.Error
+		newSourceStatus.Spec, err = withGitCheckout(gitSpec, opts.FileSource)
+		if err != nil {
+			newSourceStatus.Errs = err
+		}
+	}
+	if ociSpec, ok := newSourceStatus.Spec.(OCISourceSpec); ok && newSourceStatus.Errs == nil {
+		var err status.Error
+		newSourceStatus.Spec, err = withImageResolution(ociSpec, opts.SourceDir, newSourceStatus.Commit)
+		if err != nil {
+			newSourceStatus.Errs = err
+		}
+	}
+	srcState := &sourceState{
+		spec:     newSourceStatus.Spec,
+		commit:   newSourceStatus.Commit,
+		syncPath: syncPath,
+		sources:  sources,
+	}
+	newSourceStatus.Sources = srcState.sourceStatuses()
 
 	// Only update the source status if there are errors or the commit changed.
 	// Otherwise, parsing errors may be overwritten.
 	// TODO: Decouple fetch & parse stages to use different status fields
-	if newSourceStatus.Errs != nil || state.status.SourceStatus == nil || newSourceStatus.Commit != state.status.SourceStatus.Commit {
+	if newSourceStatus.Errs != nil || state.status.SourceStatus == nil || newSourceStatus.Commit != state.status.SourceStatus.Commit ||
+		!slices.EqualFunc(newSourceStatus.Sources, state.status.SourceStatus.Sources, AdditionalSourceStatus.Equals) {
 		newSourceStatus.LastUpdate = nowMeta(opts.Clock)
 		if state.status.needToSetSourceStatus(newSourceStatus) {
 			klog.V(3).Info("Updating source status (after fetch)")
 			if statusErr := r.syncStatusClient.SetSourceStatus(ctx, newSourceStatus); statusErr != nil {
-				return newSourceStatus, syncPath, status.Append(newSourceStatus.Errs, statusErr)
+				return newSourceStatus, srcState, status.Append(newSourceStatus.Errs, statusErr)
 			}
 			state.status.SourceStatus = newSourceStatus
 		}
 		// If there were fetch errors, stop, log them, and retry later
 		if newSourceStatus.Errs != nil {
-			return newSourceStatus, syncPath, newSourceStatus.Errs
+			return newSourceStatus, srcState, newSourceStatus.Errs
 		}
 	}
 
 	// Fetch successful
-	return newSourceStatus, syncPath, nil
+	return newSourceStatus, srcState, nil
+}
+
+// withChartVerification adds the digest and the signer of the verified Helm
+// chart to the source spec. helm-sync records them next to the rendered chart,
+// in the directory named after the commit.
+func withChartVerification(spec HelmSourceSpec, sourceDir cmpath.Absolute, commit string) (HelmSourceSpec, status.Error) {
+	chartDir := filepath.Join(filepath.Dir(sourceDir.OSPath()), commit)
+	verification, err := provenance.ReadChartVerification(chartDir)
+	if err != nil {
+		return spec, status.SourceError.Wrap(err).Build()
+	}
+	if verification != nil {
+		spec.Digest = verification.Digest
+		spec.Signer = verification.Signer
+	}
+	return spec, nil
+}
+
+// withImageResolution adds the tag and the digest of the pulled OCI image to
+// the source spec. oci-sync records them next to the extracted image, in the
+// directory named after the commit.
+func withImageResolution(spec OCISourceSpec, sourceDir cmpath.Absolute, commit string) (OCISourceSpec, status.Error) {
+	imageDir := filepath.Join(filepath.Dir(sourceDir.OSPath()), commit)
+	resolution, err := provenance.ReadImageResolution(imageDir)
+	if err != nil {
+		return spec, status.SourceError.Wrap(err).Build()
+	}
+	if resolution != nil {
+		spec.Tag = resolution.Tag
+		spec.Digest = resolution.Digest
+	}
+	return spec, nil
+}
+
+// withGitCheckout adds the submodules and the sparse paths checked out by
+// git-sync to the source spec. The reconciler-manager adds the sparse paths to
+// the sparse checkout patterns, so the Kustomize bases outside of the sync
+// directory are checked out too.
+func withGitCheckout(spec GitSourceSpec, source FileSource) (GitSourceSpec, status.Error) {
+	submodules, err := hydrate.ResolveSubmodules(source.SourceDir, source.SyncDir)
+	if err != nil {
+		return spec, status.SourceError.Wrap(err).Build()
+	}
+	spec.Submodules = submodules
+	if spec.RevisionConstraint != "" {
+		// git-sync records the tag resolved from the constraint before
+		// fetching it.
+		revisionFile := source.RepoRoot.Join(cmpath.RelativeSlash(git.ResolvedRevisionFile)).OSPath()
+		tag, err := os.ReadFile(revisionFile)
+		if err != nil {
+			return spec, status.SourceError.Wrap(err).
+				Sprintf("failed to read the tag resolved from the revision constraint %q", spec.RevisionConstraint).Build()
+		}
+		spec.Revision = strings.TrimSpace(string(tag))
+	}
+	if source.SparseCheckout {
+		paths, err := hydrate.SparseCheckoutPaths(source.SourceDir.OSPath(), source.SyncDir.OSPath())
+		if err != nil {
+			return spec, status.SourceError.Wrap(err).Build()
+		}
+		spec.SparsePaths = paths
+	}
+	return spec, nil
 }
 
 // render waits for the hydration-controller sidecar to render the source
@@ -304,15 +461,10 @@ func (r *reconciler) render(ctx context.Context, sourceStatus *SourceStatus) sta
 // read source manifests from the shared source volume.
 // Waits for rendering, if enabled.
 // Updates the RSync status (source, rendering, and syncing condition).
-func (r *reconciler) read(ctx context.Context, trigger string, sourceStatus *SourceStatus, syncPath cmpath.Absolute) status.MultiError {
+func (r *reconciler) read(ctx context.Context, trigger string, srcState *sourceState) status.MultiError {
 	opts := r.Options()
 	state := r.ReconcilerState()
-	sourceState := &sourceState{
-		spec:     sourceStatus.Spec,
-		commit:   sourceStatus.Commit,
-		syncPath: syncPath,
-	}
-	newRenderStatus, newSourceStatus := r.readFromSource(ctx, trigger, sourceState)
+	newRenderStatus, newSourceStatus := r.readFromSource(ctx, trigger, srcState)
 	if opts.RenderingEnabled != newRenderStatus.RequiresRendering {
 		// the reconciler is misconfigured. set the annotation so that the reconciler-manager
 		// will recreate this reconciler with the correct configuration.
@@ -376,10 +528,16 @@ func (r *reconciler) parseHydrationState(srcState *sourceState, newRenderStatus
 		srcState, hydrationErr = opts.readHydratedPathWithRetry(util.HydratedRetryBackoff, absHydratedRoot, opts.ReconcilerName, srcState)
 		if hydrationErr != nil {
 			newRenderStatus.Message = RenderingFailed
-			newRenderStatus.Errs = status.HydrationError(hydrationErr.Code(), hydrationErr)
+			newRenderStatus.Errs = hydrationStatusError(hydrationErr)
 			return srcState, newRenderStatus
 		}
 		newRenderStatus.Message = RenderingSucceeded
+		cacheHitFile := absHydratedRoot.Join(cmpath.RelativeSlash(hydrate.RenderCacheHitFile)).OSPath()
+		if cachedCommit, err := hydrate.ExtractCommit(cacheHitFile); err != nil {
+			klog.Warningf("Unable to check if the rendered configs were reused: %v", err)
+		} else if cachedCommit != "" && cachedCommit == srcState.commit {
+			newRenderStatus.Message = RenderingCached
+		}
 	} else if !os.IsNotExist(err) {
 		newRenderStatus.Message = RenderingFailed
 		newRenderStatus.Errs = status.InternalHydrationError(err, "unable to evaluate the hydrated path %s", absHydratedRoot.OSPath())
@@ -409,8 +567,9 @@ func (r *reconciler) readFromSource(ctx context.Context, trigger string, srcStat
 		RequiresRendering: opts.RenderingEnabled,
 	}
 	newSourceStatus := &SourceStatus{
-		Spec:   srcState.spec,
-		Commit: srcState.commit,
+		Spec:    srcState.spec,
+		Commit:  srcState.commit,
+		Sources: srcState.sourceStatuses(),
 	}
 
 	srcState, newRenderStatus = r.parseHydrationState(srcState, newRenderStatus)
@@ -418,7 +577,7 @@ func (r *reconciler) readFromSource(ctx context.Context, trigger string, srcStat
 		return newRenderStatus, newSourceStatus
 	}
 
-	if srcState.syncPath == recState.cache.source.syncPath {
+	if srcState.sameSyncPaths(recState.cache.source) {
 		klog.V(4).Infof("Reconciler skipping listing source files; sync path unchanged: %s", srcState.syncPath.OSPath())
 		return newRenderStatus, newSourceStatus
 	}
@@ -428,9 +587,10 @@ func (r *reconciler) readFromSource(ctx context.Context, trigger string, srcStat
 	newSourceStatus.Errs = opts.readConfigFiles(srcState)
 
 	if !opts.RenderingEnabled {
-		// Check if any kustomization Files exist
+		// Check if any kustomization Files, renderer markers or Kptfile pipelines exist
 		for _, fi := range srcState.files {
-			if hydrate.HasKustomization(path.Base(fi.OSPath())) {
+			if hydrate.HasKustomization(path.Base(fi.OSPath())) || hydrate.HasKptPipeline(fi.OSPath()) ||
+				hydrate.HasRendererMarker(fi.OSPath(), srcState.syncPath.OSPath()) {
 				// Source of truth requires hydration, but the hydration-controller is not running
 				newRenderStatus.Message = RenderingRequired
 				newRenderStatus.RequiresRendering = true
@@ -481,6 +641,7 @@ func (r *reconciler) parse(ctx context.Context, trigger string) status.MultiErro
 	newSourceStatus := &SourceStatus{
 		Spec:       state.cache.source.spec,
 		Commit:     state.cache.source.commit,
+		Sources:    state.cache.source.sourceStatuses(),
 		Errs:       parseErrs,
 		LastUpdate: nowMeta(opts.Clock),
 	}
@@ -524,6 +685,8 @@ func (r *reconciler) update(ctx context.Context, trigger string) status.MultiErr
 		Commit:     state.cache.source.commit,
 		Errs:       syncErrs,
 		LastUpdate: nowMeta(opts.Clock),
+		Waves:      state.SyncWaves(),
+		Hooks:      state.SyncHooks(),
 	}
 	if statusErr := r.setSyncStatus(ctx, syncStatus); statusErr != nil {
 		return status.Append(syncErrs, statusErr)
@@ -575,6 +738,8 @@ func (r *reconciler) startAsyncStatusUpdates(ctx context.Context) <-chan struct{
 					Commit:     state.cache.source.commit,
 					Errs:       state.SyncErrors(),
 					LastUpdate: nowMeta(opts.Clock),
+					Waves:      state.SyncWaves(),
+					Hooks:      state.SyncHooks(),
 				}
 				if err := r.setSyncStatus(ctx, syncStatus); err != nil {
 					klog.Warningf("failed to update sync status: %v", err)
@@ -628,7 +793,8 @@ func blockHydration(signalFile string) {
 }
 
 // UpdateSyncStatus updates the RSync status to reflect asynchronous status
-// changes made by the remediator between Reconcile calls.
+// changes made by the remediator between Reconcile calls. It also reports the
+// drift recorded by the remediator, if the drift is reported.
 func (r *reconciler) UpdateSyncStatus(ctx context.Context) error {
 	opts := r.Options()
 	// Skip updates if the remediator is not running yet, paused, or watches haven't been updated yet.
@@ -645,8 +811,18 @@ func (r *reconciler) UpdateSyncStatus(ctx context.Context) error {
 		Commit:     state.status.SyncStatus.Commit,
 		Errs:       state.SyncErrors(),
 		LastUpdate: nowMeta(opts.Clock),
+		Waves:      state.SyncWaves(),
+		Hooks:      state.SyncHooks(),
+	}
+	if err := r.setSyncStatus(ctx, syncStatus); err != nil {
+		return err
 	}
-	return r.setSyncStatus(ctx, syncStatus)
+	if opts.DriftReporter != nil {
+		if err := opts.DriftReporter.Report(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func nowMeta(c clock.Clock) metav1.Time {
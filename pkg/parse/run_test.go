@@ -20,6 +20,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"syscall"
 	"testing"
 	"time"
@@ -38,6 +39,7 @@ import (
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/core/k8sobjects"
 	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/git"
 	"kpt.dev/configsync/pkg/hydrate"
 	"kpt.dev/configsync/pkg/importer/analyzer/ast"
 	"kpt.dev/configsync/pkg/importer/filesystem"
@@ -114,6 +116,15 @@ func newRootReconciler(t *testing.T, clock clock.Clock, fakeClient client.Client
 	}
 }
 
+// fakeRolloutGate opens for the commit synced by the canary group.
+type fakeRolloutGate struct {
+	canarySyncedCommit string
+}
+
+func (g *fakeRolloutGate) Check(_ context.Context, commit string) status.Error {
+	return checkCanaryCommit("canary", map[string]string{"canary": g.canarySyncedCommit}, commit)
+}
+
 func createRootDir(rootDir, commit string) error {
 	if err := os.MkdirAll(rootDir, os.ModePerm); err != nil {
 		return err
@@ -131,6 +142,26 @@ func writeFile(rootDir, file, content string) error {
 	return os.WriteFile(errFile, []byte(content), 0644)
 }
 
+func TestWithGitCheckoutResolvedRevision(t *testing.T) {
+	rootDir := t.TempDir()
+	sourceDir := filepath.Join(rootDir, "source", "abc123")
+	require.NoError(t, os.MkdirAll(sourceDir, 0755))
+	source := FileSource{
+		SourceDir: cmpath.Absolute(sourceDir),
+		RepoRoot:  cmpath.Absolute(rootDir),
+	}
+	spec := GitSourceSpec{Repo: "https://example.com/repo.git", RevisionConstraint: ">=1.0.0"}
+
+	_, err := withGitCheckout(spec, source)
+	assert.ErrorContains(t, err, `failed to read the tag resolved from the revision constraint ">=1.0.0"`)
+
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, git.ResolvedRevisionFile), []byte("v1.2.0\n"), 0644))
+	got, err := withGitCheckout(spec, source)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.2.0", got.Revision)
+	assert.Equal(t, ">=1.0.0", got.RevisionConstraint)
+}
+
 func TestSplitObjects(t *testing.T) {
 	testCases := []struct {
 		name             string
@@ -236,6 +267,7 @@ func TestReconciler_Reconcile(t *testing.T) {
 		hydratedError         string
 		hydrationDone         bool
 		imageVerified         bool
+		rolloutGate           RolloutGate
 		expectedSourceChanged bool
 		needRetry             bool
 		parseOutputs          []fsfake.ParserOutputs
@@ -438,6 +470,45 @@ func TestReconciler_Reconcile(t *testing.T) {
 				return rs
 			},
 		},
+		{
+			name:                  "rollout gate blocks a commit the canary group has not synced",
+			trigger:               triggerSync,
+			rolloutGate:           &fakeRolloutGate{canarySyncedCommit: "old123"},
+			expectedSourceChanged: false,
+			needRetry:             true,
+			parseOutputs:          nil, // parse should not be called
+			expectedRootSyncFunc: func(_ string) *v1beta1.RootSync {
+				rs := rootSyncOutput.DeepCopy()
+				// Create + Update (fetch error)
+				rs.ObjectMeta.ResourceVersion = "2"
+				rs.Status.Status.Source = v1beta1.SourceStatus{
+					Git: &v1beta1.GitStatus{
+						Repo:   fileSource.SourceRepo,
+						Branch: fileSource.SourceBranch,
+					},
+					Commit:     sourceCommit,
+					LastUpdate: fakeMetaTime,
+					Errors: status.ToCSE(
+						checkCanaryCommit("canary", map[string]string{"canary": "old123"}, sourceCommit),
+					),
+					ErrorSummary: &v1beta1.ErrorSummary{TotalCount: 1, ErrorCountAfterTruncation: 1},
+				}
+				rs.Status.Conditions = []v1beta1.RootSyncCondition{
+					{
+						Type:               v1beta1.RootSyncSyncing,
+						Status:             metav1.ConditionFalse,
+						LastUpdateTime:     fakeMetaTime,
+						LastTransitionTime: fakeMetaTime,
+						Reason:             "Source",
+						Message:            "Source",
+						Commit:             sourceCommit,
+						ErrorSourceRefs:    []v1beta1.ErrorSource{v1beta1.SourceError},
+						ErrorSummary:       &v1beta1.ErrorSummary{TotalCount: 1, ErrorCountAfterTruncation: 1},
+					},
+				}
+				return rs
+			},
+		},
 		{
 			name:                  "render in progress",
 			trigger:               triggerSync,
@@ -1128,6 +1199,7 @@ func TestReconciler_Reconcile(t *testing.T) {
 				Outputs: tc.parseOutputs,
 			}
 			reconciler := newRootReconciler(t, fakeClock, fakeClient, fakeConfigParser, fs, tc.renderingEnabled)
+			reconciler.options.RolloutGate = tc.rolloutGate
 			if tc.reconcilerStateFunc != nil {
 				// Mutate the ReconcilerState
 				tc.reconcilerStateFunc(reconciler.reconcilerState, sourceRoot)
@@ -1157,3 +1229,70 @@ func TestReconciler_Reconcile(t *testing.T) {
 		})
 	}
 }
+
+// fakeSourceVerifier rejects the commits which are not trusted.
+type fakeSourceVerifier struct {
+	trustedCommits []string
+}
+
+func (v *fakeSourceVerifier) Verify(_ cmpath.Absolute, commit string) status.Error {
+	if slices.Contains(v.trustedCommits, commit) {
+		return nil
+	}
+	return status.SourceVerificationError(fmt.Errorf("commit %s is not signed", commit))
+}
+
+func TestReconciler_FetchVerifiesAdditionalSources(t *testing.T) {
+	util.SourceRetryBackoff = wait.Backoff{Duration: time.Millisecond, Steps: 1}
+	const sourceCommit = "source123"
+	const platformCommit = "platform123"
+	testCases := map[string]struct {
+		trustedCommits []string
+		wantErr        string
+	}{
+		"all sources are verified": {
+			trustedCommits: []string{sourceCommit, platformCommit},
+		},
+		"additional source is not verified": {
+			trustedCommits: []string{sourceCommit},
+			wantErr:        "commit platform123 is not signed",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rootDir := t.TempDir()
+			sourceRoot := filepath.Join(rootDir, "source")
+			platformRoot := filepath.Join(rootDir, "sources", "platform")
+			require.NoError(t, createRootDir(sourceRoot, sourceCommit))
+			require.NoError(t, createRootDir(platformRoot, platformCommit))
+
+			verifier := &fakeSourceVerifier{trustedCommits: tc.trustedCommits}
+			fs := FileSource{
+				SourceDir:            cmpath.Absolute(filepath.Join(sourceRoot, symLink)),
+				RepoRoot:             cmpath.Absolute(rootDir),
+				SourceType:           configsync.OciSource,
+				ReconcilerSignalsDir: cmpath.Absolute(filepath.Join(rootDir, "reconciler-signals")),
+				AdditionalSources: []AdditionalFileSource{{
+					Name: "platform",
+					FileSource: FileSource{
+						SourceDir:  cmpath.Absolute(filepath.Join(platformRoot, symLink)),
+						SourceType: configsync.OciSource,
+					},
+					SourceVerifier: verifier,
+				}},
+			}
+			fakeClient := syncerFake.NewClient(t, core.Scheme, k8sobjects.RootSyncObjectV1Beta1(rootSyncName))
+			reconciler := newRootReconciler(t, fakeclock.NewFakeClock(time.Now()), fakeClient, &fsfake.ConfigParser{}, fs, false)
+			reconciler.options.SourceVerifier = verifier
+			reconciler.reconcilerState.status = &ReconcilerStatus{}
+
+			_, _, errs := reconciler.fetch(context.Background())
+			if tc.wantErr != "" {
+				require.Error(t, errs)
+				assert.Contains(t, errs.Error(), tc.wantErr)
+				return
+			}
+			assert.NoError(t, errs)
+		})
+	}
+}
@@ -43,6 +43,9 @@ type FileSource struct {
 	HydratedLink string
 	// SyncDir is the path to the directory of policies within the source repository.
 	SyncDir cmpath.Relative
+	// SparseCheckout indicates whether git-sync checks out only the paths
+	// needed to sync the SyncDir, which are reported in the source status.
+	SparseCheckout bool
 	// SourceType is the type of the source repository, must be git or oci.
 	SourceType configsync.SourceType
 	// SourceRepo is the source repo to sync.
@@ -50,8 +53,42 @@ type FileSource struct {
 	// SourceBranch is the branch of the source repo to sync.
 	SourceBranch string
 	// SourceRev is the revision of the source repo to sync.
-	SourceRev            string
+	SourceRev string
+	// SourceRevConstraint is the semantic version constraint in SourceRev, if
+	// any. The tag resolved by git-sync is read from the resolved revision file
+	// in the RepoRoot.
+	SourceRevConstraint  string
 	ReconcilerSignalsDir cmpath.Absolute
+	// AdditionalSources are the additional sources of a RootSync, whose files
+	// are read along with the files of the primary source.
+	AdditionalSources []AdditionalFileSource
+}
+
+// AdditionalFileSource configures where a Parser reads the files of an
+// additional source from. Additional sources are never rendered, so only the
+// SourceDir, SyncDir and Source* fields of the FileSource are set.
+type AdditionalFileSource struct {
+	// Name of the source in the RootSync spec.sources.
+	Name string
+	FileSource
+	// SourceVerifier verifies the fetched source, like the SourceVerifier of
+	// the primary source. Nil if the source is not verified by the reconciler.
+	SourceVerifier SourceVerifier
+}
+
+// SourceVerifier verifies the authenticity of the fetched source.
+type SourceVerifier interface {
+	// Verify returns an error if the commit checked out at the source
+	// directory is not trusted.
+	Verify(sourceDir cmpath.Absolute, commit string) status.Error
+}
+
+// FetchTrigger asks the *-sync containers to fetch the source now, instead
+// of waiting for the next poll.
+type FetchTrigger interface {
+	// TriggerFetch returns an error if the *-sync containers could not be
+	// notified.
+	TriggerFetch() error
 }
 
 // Files lists files in a repository and ensures the source repository hasn't been
@@ -72,14 +109,63 @@ type sourceState struct {
 	syncPath cmpath.Absolute
 	// files is the list of all observed files in the sync directory (recursively).
 	files []cmpath.Absolute
+	// sourceSyncPath is the absolute path to the sync directory in the source,
+	// before rendering. It is only set when the configurations are rendered.
+	sourceSyncPath cmpath.Absolute
+	// sourceFiles is the list of all observed files in the sourceSyncPath
+	// (recursively). ValidationPolicies are read from these files, because
+	// rendering drops the objects marked as local configuration.
+	sourceFiles []cmpath.Absolute
+	// sources is the state read from the additional sources, in the order of
+	// the FileSource.
+	sources []additionalSourceState
+}
+
+// additionalSourceState contains all state read from an additional source.
+type additionalSourceState struct {
+	// name of the source in the RootSync spec.sources.
+	name string
+	sourceState
+}
+
+// sameSyncPaths returns true if the sync paths of the primary source and all
+// the additional sources are the same in both states.
+func (s *sourceState) sameSyncPaths(other *sourceState) bool {
+	if s == nil || other == nil {
+		return s == other
+	}
+	if s.syncPath != other.syncPath || len(s.sources) != len(other.sources) {
+		return false
+	}
+	for i := range s.sources {
+		if s.sources[i].name != other.sources[i].name || s.sources[i].syncPath != other.sources[i].syncPath {
+			return false
+		}
+	}
+	return true
+}
+
+// sourceStatuses returns the status of the additional sources.
+func (s *sourceState) sourceStatuses() []AdditionalSourceStatus {
+	var result []AdditionalSourceStatus
+	for _, source := range s.sources {
+		result = append(result, AdditionalSourceStatus{
+			Name:   source.name,
+			Spec:   source.spec,
+			Commit: source.commit,
+		})
+	}
+	return result
 }
 
 // readConfigFiles reads all the files under state.syncPath and sets state.files.
 // - if rendering is enabled, state.syncPath contains the hydrated files.
 // - if rendered is disabled, state.syncPath contains the source files.
+// If rendering is enabled, it also reads the source files under
+// state.sourceSyncPath and sets state.sourceFiles.
 // readConfigFiles should be called after sourceState is populated.
 func (o *Files) readConfigFiles(state *sourceState) status.Error {
-	if state == nil || state.commit == "" || state.syncPath == "" {
+	if state == nil || state.commit == "" || state.syncPath == "" || len(state.sources) != len(o.AdditionalSources) {
 		return status.InternalError("sourceState is not populated yet")
 	}
 	syncPath := state.syncPath
@@ -96,10 +182,34 @@ func (o *Files) readConfigFiles(state *sourceState) status.Error {
 	}
 
 	state.files = fileList
+
+	if state.sourceSyncPath != "" {
+		fileList, err := listFiles(state.sourceSyncPath, map[string]bool{".git": true})
+		if err != nil {
+			return status.PathWrapError(fmt.Errorf("listing files in the source directory: %w", err), state.sourceSyncPath.OSPath())
+		}
+		state.sourceFiles = fileList
+	}
+
+	for i := range state.sources {
+		source := &state.sources[i]
+		sourceDir := o.AdditionalSources[i].SourceDir
+		fileList, err := listFiles(source.syncPath, map[string]bool{".git": true})
+		if err != nil {
+			return status.PathWrapError(fmt.Errorf("listing files in the configs directory of source %q: %w", source.name, err), source.syncPath.OSPath())
+		}
+		newCommit, err := hydrate.ComputeCommit(sourceDir)
+		if err != nil {
+			return status.TransientError(err)
+		} else if newCommit != source.commit {
+			return status.TransientError(fmt.Errorf("commit of source %q changed while listing files, was %s, now %s. It will be retried in the next sync", source.name, source.commit, newCommit))
+		}
+		source.files = fileList
+	}
 	return nil
 }
 
-func (o *Files) sourceContext() sourceContext {
+func (o *FileSource) sourceContext() sourceContext {
 	return sourceContext{
 		Repo:   o.SourceRepo,
 		Branch: o.SourceBranch,
@@ -110,7 +220,8 @@ func (o *Files) sourceContext() sourceContext {
 // readHydratedPathWithRetry returns a sourceState object whose `commit` and `syncPath` fields are set if succeeded with retries.
 func (o *Files) readHydratedPathWithRetry(backoff wait.Backoff, hydratedRoot cmpath.Absolute, reconciler string, srcState *sourceState) (*sourceState, hydrate.HydrationError) {
 	result := &sourceState{
-		spec: srcState.spec,
+		spec:    srcState.spec,
+		sources: srcState.sources,
 	}
 	err := util.RetryWithBackoff(backoff, func() error {
 		var err error
@@ -130,7 +241,8 @@ func (o *Files) readHydratedPathWithRetry(backoff wait.Backoff, hydratedRoot cmp
 // readHydratedPath returns a sourceState object whose `commit` and `syncPath` fields are set if succeeded.
 func (o *Files) readHydratedPath(hydratedRoot cmpath.Absolute, reconciler string, srcState *sourceState) (*sourceState, error) {
 	result := &sourceState{
-		spec: srcState.spec,
+		spec:    srcState.spec,
+		sources: srcState.sources,
 	}
 	errorFile := hydratedRoot.Join(cmpath.RelativeSlash(hydrate.ErrorFile))
 	_, err := os.Stat(errorFile.OSPath())
@@ -169,6 +281,7 @@ func (o *Files) readHydratedPath(hydratedRoot cmpath.Absolute, reconciler string
 			return result, util.NewRetriableError(fmt.Errorf("failed to evaluate symbolic link to the hydrated sync directory %s: %v", logicalSyncPath.OSPath(), err))
 		}
 		result.syncPath = physicalSyncPath
+		result.sourceSyncPath = srcState.syncPath
 	}
 	return result, nil
 }
@@ -218,7 +331,26 @@ func hydratedError(errorFile, label string) hydrate.HydrationError {
 		return hydrate.NewInternalError(err)
 	}
 	if payload.Code == status.ActionableHydrationErrorCode {
+		if len(payload.Results) > 0 {
+			return hydrate.NewPipelineError(payload.Results)
+		}
 		return hydrate.NewActionableError(errors.New(payload.Error))
 	}
 	return hydrate.NewInternalError(errors.New(payload.Error))
 }
+
+// hydrationStatusError converts the hydration error into status errors, with
+// an error for each function result of a Kptfile pipeline, so that they are
+// reported with the path of their source file.
+func hydrationStatusError(hydrationErr hydrate.HydrationError) status.MultiError {
+	pipelineErr, ok := hydrationErr.(hydrate.PipelineError)
+	if !ok {
+		return status.HydrationError(hydrationErr.Code(), hydrationErr)
+	}
+	var errs status.MultiError
+	for _, result := range pipelineErr.Results {
+		errs = status.Append(errs, status.HydrationPathError(hydrationErr.Code(),
+			errors.New(result.String()), cmpath.RelativeSlash(result.Path)))
+	}
+	return errs
+}
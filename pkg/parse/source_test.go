@@ -241,8 +241,9 @@ func TestReadHydratedPathWithRetry(t *testing.T) {
 			}()
 
 			srcState := &sourceState{
-				spec:   nil, // TODO: Add tests for behavior when spec is non-nil
-				commit: originCommit,
+				spec:     nil, // TODO: Add tests for behavior when spec is non-nil
+				commit:   originCommit,
+				syncPath: "/repo/source/rev",
 			}
 
 			files := Files{
@@ -254,8 +255,9 @@ func TestReadHydratedPathWithRetry(t *testing.T) {
 			}
 
 			wantState := &sourceState{
-				commit:   tc.commit,
-				syncPath: cmpath.Absolute(filepath.Join(parserCommitPath, syncDir)),
+				commit:         tc.commit,
+				syncPath:       cmpath.Absolute(filepath.Join(parserCommitPath, syncDir)),
+				sourceSyncPath: srcState.syncPath,
 			}
 
 			t.Logf("start calling readHydratedDirWithRetry at %v", time.Now())
@@ -276,3 +278,21 @@ func TestReadHydratedPathWithRetry(t *testing.T) {
 		})
 	}
 }
+
+func TestHydratedErrorWithFunctionResults(t *testing.T) {
+	errorFile := filepath.Join(t.TempDir(), hydrate.ErrorFile)
+	payload := `{"Code": "1068", "Error": "failed to render the Kptfile pipeline", "Results": [` +
+		`{"Function": "reject", "Message": "too many replicas", "Path": "app/deploy.yaml"},` +
+		`{"Function": "reject", "Message": "missing owner label", "Path": "ns.yaml"}]}`
+	if err := os.WriteFile(errorFile, []byte(payload), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cses := status.ToCSE(hydrationStatusError(hydratedError(errorFile, "unused")))
+	assert.Len(t, cses, 2)
+	assert.Equal(t, status.ActionableHydrationErrorCode, cses[0].Code)
+	assert.Contains(t, cses[0].ErrorMessage, `function "reject": too many replicas`)
+	assert.Equal(t, "app/deploy.yaml", cses[0].Resources[0].SourcePath)
+	assert.Contains(t, cses[1].ErrorMessage, `function "reject": missing owner label`)
+	assert.Equal(t, "ns.yaml", cses[1].Resources[0].SourcePath)
+}
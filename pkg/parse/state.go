@@ -20,6 +20,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
 	"kpt.dev/configsync/pkg/status"
 )
@@ -170,3 +171,19 @@ func (s *ReconcilerState) RecordFullSyncStart(now metav1.Time) {
 func (s *ReconcilerState) SyncErrors() status.MultiError {
 	return s.syncErrorCache.Errors()
 }
+
+// isSyncedCommit returns true if the sync of the commit has already started.
+func (s *ReconcilerState) isSyncedCommit(commit string) bool {
+	return s.status != nil && s.status.SyncStatus != nil && s.status.SyncStatus.Commit == commit
+}
+
+// SyncWaves returns the progress of the sync waves of the latest apply, or nil
+// if the objects were not grouped into sync waves.
+func (s *ReconcilerState) SyncWaves() *v1beta1.SyncWaveStatus {
+	return s.syncErrorCache.SyncWaves()
+}
+
+// SyncHooks returns the outcomes of the sync hooks run by the latest apply.
+func (s *ReconcilerState) SyncHooks() []v1beta1.HookStatus {
+	return s.syncErrorCache.SyncHooks()
+}
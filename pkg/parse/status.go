@@ -15,10 +15,14 @@
 package parse
 
 import (
+	"slices"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/hydrate"
 	"kpt.dev/configsync/pkg/status"
 )
 
@@ -102,10 +106,11 @@ func SourceSpecFromFileSource(source FileSource, sourceType configsync.SourceTyp
 	switch sourceType {
 	case configsync.GitSource:
 		ss = GitSourceSpec{
-			Repo:     source.SourceRepo,
-			Revision: source.SourceRev,
-			Branch:   source.SourceBranch,
-			Dir:      source.SyncDir.SlashPath(),
+			Repo:               source.SourceRepo,
+			Revision:           source.SourceRev,
+			RevisionConstraint: source.SourceRevConstraint,
+			Branch:             source.SourceBranch,
+			Dir:                source.SyncDir.SlashPath(),
 		}
 	case configsync.OciSource:
 		ss = OCISourceSpec{
@@ -118,6 +123,11 @@ func SourceSpecFromFileSource(source FileSource, sourceType configsync.SourceTyp
 			Chart:   source.SyncDir.SlashPath(),
 			Version: getChartVersionFromCommit(source.SourceRev, commit),
 		}
+	case configsync.ArchiveSource:
+		ss = ArchiveSourceSpec{
+			URL: source.SourceRepo,
+			Dir: source.SyncDir.SlashPath(),
+		}
 	}
 	return ss
 }
@@ -140,8 +150,15 @@ func getChartVersionFromCommit(sourceRev, commit string) string {
 type GitSourceSpec struct {
 	Repo     string
 	Revision string
-	Branch   string
-	Dir      string
+	// RevisionConstraint is the semantic version constraint the Revision was
+	// resolved from, if any.
+	RevisionConstraint string
+	Branch             string
+	Dir                string
+	// SparsePaths are the paths checked out by sparse checkout, if enabled.
+	SparsePaths []string
+	// Submodules are the Git submodules checked out with the commit.
+	Submodules []hydrate.Submodule
 }
 
 // Equals returns true if the specified SourceSpec equals this
@@ -153,14 +170,21 @@ func (g GitSourceSpec) Equals(other SourceSpec) bool {
 	}
 	return t.Repo == g.Repo &&
 		t.Revision == g.Revision &&
+		t.RevisionConstraint == g.RevisionConstraint &&
 		t.Branch == g.Branch &&
-		t.Dir == g.Dir
+		t.Dir == g.Dir &&
+		slices.Equal(t.SparsePaths, g.SparsePaths) &&
+		slices.Equal(t.Submodules, g.Submodules)
 }
 
 // OCISourceSpec is a SourceSpec for the OCI SourceType
 type OCISourceSpec struct {
 	Image string
 	Dir   string
+	// Tag and Digest identify the pulled image. Tag is the tag resolved by
+	// the tag policy, if any.
+	Tag    string
+	Digest string
 }
 
 // Equals returns true if the specified SourceSpec equals this
@@ -171,7 +195,9 @@ func (o OCISourceSpec) Equals(other SourceSpec) bool {
 		return false
 	}
 	return t.Image == o.Image &&
-		t.Dir == o.Dir
+		t.Dir == o.Dir &&
+		t.Tag == o.Tag &&
+		t.Digest == o.Digest
 }
 
 // HelmSourceSpec is a SourceSpec for the Helm SourceType
@@ -179,6 +205,9 @@ type HelmSourceSpec struct {
 	Repo    string
 	Version string
 	Chart   string
+	// Digest and Signer are only set when the chart is verified.
+	Digest string
+	Signer string
 }
 
 // Equals returns true if the specified SourceSpec equals this
@@ -190,7 +219,26 @@ func (h HelmSourceSpec) Equals(other SourceSpec) bool {
 	}
 	return t.Repo == h.Repo &&
 		t.Version == h.Version &&
-		t.Chart == h.Chart
+		t.Chart == h.Chart &&
+		t.Digest == h.Digest &&
+		t.Signer == h.Signer
+}
+
+// ArchiveSourceSpec is a SourceSpec for the Archive SourceType
+type ArchiveSourceSpec struct {
+	URL string
+	Dir string
+}
+
+// Equals returns true if the specified SourceSpec equals this
+// ArchiveSourceSpec, including type and all field values.
+func (a ArchiveSourceSpec) Equals(other SourceSpec) bool {
+	t, ok := other.(ArchiveSourceSpec)
+	if !ok {
+		return false
+	}
+	return t.URL == a.URL &&
+		t.Dir == a.Dir
 }
 
 // SourceStatus represents the status of the source stage of the pipeline.
@@ -202,6 +250,8 @@ type SourceStatus struct {
 	Commit     string
 	Errs       status.MultiError
 	LastUpdate metav1.Time
+	// Sources are the status of the additional sources of a RootSync.
+	Sources []AdditionalSourceStatus
 }
 
 // DeepCopy returns a deep copy of the receiver.
@@ -214,6 +264,7 @@ func (gs *SourceStatus) DeepCopy() *SourceStatus {
 		Commit:     gs.Commit,
 		Errs:       gs.Errs,
 		LastUpdate: *gs.LastUpdate.DeepCopy(),
+		Sources:    slices.Clone(gs.Sources),
 	}
 }
 
@@ -225,7 +276,25 @@ func (gs *SourceStatus) Equals(other *SourceStatus) bool {
 	}
 	return gs.Commit == other.Commit &&
 		status.DeepEqual(gs.Errs, other.Errs) &&
-		isSourceSpecEqual(gs.Spec, other.Spec)
+		isSourceSpecEqual(gs.Spec, other.Spec) &&
+		slices.EqualFunc(gs.Sources, other.Sources, AdditionalSourceStatus.Equals)
+}
+
+// AdditionalSourceStatus represents the status of an additional source of a
+// RootSync. Errors are reported in the SourceStatus.
+type AdditionalSourceStatus struct {
+	// Name of the source in the RootSync spec.sources.
+	Name   string
+	Spec   SourceSpec
+	Commit string
+}
+
+// Equals returns true if the specified AdditionalSourceStatus equals this
+// AdditionalSourceStatus.
+func (as AdditionalSourceStatus) Equals(other AdditionalSourceStatus) bool {
+	return as.Name == other.Name &&
+		as.Commit == other.Commit &&
+		isSourceSpecEqual(as.Spec, other.Spec)
 }
 
 // RenderingStatus represents the status of the rendering stage of the pipeline.
@@ -280,6 +349,11 @@ type SyncStatus struct {
 	Commit     string
 	Errs       status.MultiError
 	LastUpdate metav1.Time
+	// Waves is the progress of the sync waves, if the objects are grouped into
+	// more than one sync wave.
+	Waves *v1beta1.SyncWaveStatus
+	// Hooks are the outcomes of the sync hooks run by the latest apply.
+	Hooks []v1beta1.HookStatus
 }
 
 // DeepCopy returns a deep copy of the receiver.
@@ -293,6 +367,8 @@ func (ss *SyncStatus) DeepCopy() *SyncStatus {
 		Commit:     ss.Commit,
 		Errs:       ss.Errs,
 		LastUpdate: *ss.LastUpdate.DeepCopy(),
+		Waves:      ss.Waves.DeepCopy(),
+		Hooks:      copyHookStatuses(ss.Hooks),
 	}
 }
 
@@ -305,9 +381,21 @@ func (ss *SyncStatus) Equals(other *SyncStatus) bool {
 	return ss.Syncing == other.Syncing &&
 		ss.Commit == other.Commit &&
 		status.DeepEqual(ss.Errs, other.Errs) &&
+		equality.Semantic.DeepEqual(ss.Waves, other.Waves) &&
+		equality.Semantic.DeepEqual(ss.Hooks, other.Hooks) &&
 		isSourceSpecEqual(ss.Spec, other.Spec)
 }
 
+// copyHookStatuses returns a copy of the hook statuses.
+func copyHookStatuses(hooks []v1beta1.HookStatus) []v1beta1.HookStatus {
+	if hooks == nil {
+		return nil
+	}
+	out := make([]v1beta1.HookStatus, len(hooks))
+	copy(out, hooks)
+	return out
+}
+
 // isSourceSpecEqual returns true if a & b are Equal, handling nil cases.
 // None of the SourceSpec impls are nillable, but the interface itself is.
 func isSourceSpecEqual(a, b SourceSpec) bool {
@@ -17,6 +17,7 @@ package parse
 import (
 	"sync"
 
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/remediator/conflict"
 	"kpt.dev/configsync/pkg/status"
 	"kpt.dev/configsync/pkg/syncer/reconcile/fight"
@@ -34,6 +35,11 @@ type SyncErrorCache struct {
 	validationErrs status.MultiError
 	applyErrs      status.MultiError
 	watchErrs      status.MultiError
+
+	// Progress of the sync waves from the Updater
+	syncWaves *v1beta1.SyncWaveStatus
+	// Outcomes of the sync hooks from the Updater
+	syncHooks []v1beta1.HookStatus
 }
 
 // NewSyncErrorCache constructs a new SyncErrorCache with shared handlers
@@ -101,3 +107,39 @@ func (s *SyncErrorCache) SetWatchErrs(errs status.MultiError) {
 	defer s.statusMux.Unlock()
 	s.watchErrs = errs
 }
+
+// SyncWaves returns the progress of the sync waves of the latest apply, or nil
+// if the objects were not grouped into sync waves.
+func (s *SyncErrorCache) SyncWaves() *v1beta1.SyncWaveStatus {
+	s.statusMux.RLock()
+	defer s.statusMux.RUnlock()
+	return s.syncWaves.DeepCopy()
+}
+
+// SetSyncWaves replaces the progress of the sync waves.
+func (s *SyncErrorCache) SetSyncWaves(waves *v1beta1.SyncWaveStatus) {
+	s.statusMux.Lock()
+	defer s.statusMux.Unlock()
+	s.syncWaves = waves
+}
+
+// SyncHooks returns the outcomes of the sync hooks run by the latest apply.
+func (s *SyncErrorCache) SyncHooks() []v1beta1.HookStatus {
+	s.statusMux.RLock()
+	defer s.statusMux.RUnlock()
+	return copyHookStatuses(s.syncHooks)
+}
+
+// AddSyncHook adds the outcome of a sync hook.
+func (s *SyncErrorCache) AddSyncHook(hook v1beta1.HookStatus) {
+	s.statusMux.Lock()
+	defer s.statusMux.Unlock()
+	s.syncHooks = append(s.syncHooks, hook)
+}
+
+// ResetSyncHooks deletes all cached sync hook outcomes.
+func (s *SyncErrorCache) ResetSyncHooks() {
+	s.statusMux.Lock()
+	defer s.statusMux.Unlock()
+	s.syncHooks = nil
+}
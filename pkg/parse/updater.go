@@ -19,9 +19,12 @@ import (
 	"sync"
 	"time"
 
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/applier"
+	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/importer/filesystem"
 	"kpt.dev/configsync/pkg/metrics"
@@ -47,6 +50,14 @@ type Updater struct {
 	// sub-components running in parallel. This allows batching updates and
 	// pushing them asynchronously.
 	SyncErrorCache *SyncErrorCache
+	// DryRunner validates the objects which are new or changed since the last
+	// successful apply, before applying them. If any of them fails the dry-run,
+	// none of the objects are applied.
+	// Optional. If nil, the objects are applied without a dry-run.
+	DryRunner DryRunner
+
+	// appliedObjects are the declared objects of the last successful apply.
+	appliedObjects map[core.ID]*unstructured.Unstructured
 
 	updateMux sync.RWMutex
 }
@@ -76,9 +87,10 @@ func (u *Updater) Remediating() bool {
 // 1. Pauses the remediator
 // 2. Validates and sterilizes the objects
 // 3. Updates the declared resource objects in memory
-// 4. Applies the objects
-// 5. Updates the remediator watches
-// 6. Restarts the remediator
+// 4. Dry-runs the changed objects, if the DryRunner is set
+// 5. Applies the objects
+// 6. Updates the remediator watches
+// 7. Restarts the remediator
 //
 // Any errors returned will be prepended with any known conflict errors from the
 // remediator. This is required to preserve errors that have been reported by
@@ -125,9 +137,17 @@ func (u *Updater) update(ctx context.Context, cache *cacheForCommit) status.Mult
 
 	// Apply the declared resources
 	if !cache.applied {
+		// Dry-run the changed objects first, so a commit is either fully
+		// applied or not applied at all, if any of them would be rejected.
+		if u.DryRunner != nil {
+			if err := u.dryRun(ctx); err != nil {
+				return err
+			}
+		}
 		if err := u.apply(ctx, cache.source.commit); err != nil {
 			return err
 		}
+		u.recordAppliedObjects()
 		// Only mark the commit as applied if there were no (non-blocking) parse errors.
 		// This ensures the apply will be retried until parsing fully succeeds.
 		if cache.parse.parserErrs == nil {
@@ -175,6 +195,23 @@ func (u *Updater) apply(ctx context.Context, commit string) status.MultiError {
 	// Collect errors into a MultiError
 	var err status.MultiError
 	eventHandler := func(event applier.Event) {
+		if waveEvent, ok := event.(applier.SyncWaveEvent); ok {
+			u.SyncErrorCache.SetSyncWaves(&v1beta1.SyncWaveStatus{
+				Total:     waveEvent.Total,
+				Completed: waveEvent.Completed,
+				Current:   waveEvent.Wave,
+			})
+		}
+		if hookEvent, ok := event.(applier.HookEvent); ok {
+			u.SyncErrorCache.AddSyncHook(v1beta1.HookStatus{
+				Phase:     hookEvent.Phase.String(),
+				Kind:      hookEvent.ID.Kind,
+				Namespace: hookEvent.ID.Namespace,
+				Name:      hookEvent.ID.Name,
+				Succeeded: hookEvent.Succeeded,
+				Message:   hookEvent.Message,
+			})
+		}
 		if errEvent, ok := event.(applier.ErrorEvent); ok {
 			if err == nil {
 				err = errEvent.Error
@@ -191,6 +228,8 @@ func (u *Updater) apply(ctx context.Context, commit string) status.MultiError {
 	klog.Info("Applier starting...")
 	start := time.Now()
 	u.SyncErrorCache.ResetApplyErrors()
+	u.SyncErrorCache.SetSyncWaves(nil)
+	u.SyncErrorCache.ResetSyncHooks()
 	objStatusMap, syncStats := u.Applier.Apply(ctx, eventHandler, u.Resources)
 	if !syncStats.Empty() {
 		klog.Infof("Applier made new progress: %s", syncStats.String())
@@ -205,6 +244,26 @@ func (u *Updater) apply(ctx context.Context, commit string) status.MultiError {
 	return nil
 }
 
+func (u *Updater) dryRun(ctx context.Context) status.MultiError {
+	objs := u.changedObjects()
+	if len(objs) == 0 {
+		klog.V(3).Info("Server-side dry-run skipped, no objects changed")
+		return nil
+	}
+	klog.Infof("Server-side dry-run of %d changed objects starting...", len(objs))
+	u.SyncErrorCache.ResetApplyErrors()
+	err := u.DryRunner.DryRun(ctx, objs)
+	if err != nil {
+		for _, dryRunErr := range err.Errors() {
+			u.SyncErrorCache.AddApplyError(dryRunErr)
+		}
+		klog.Warningf("Server-side dry-run failed: %v", err)
+		return err
+	}
+	klog.Info("Server-side dry-run succeeded")
+	return nil
+}
+
 // addWatches tells the Remediator to watch additional resources without
 // stopping any.
 func (u *Updater) addWatches(ctx context.Context, gvks map[schema.GroupVersionKind]struct{}, commit string) status.MultiError {
@@ -0,0 +1,73 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provenance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ChartVerificationFile is the name of the file recording how a Helm chart
+// was verified. helm-sync writes it next to the rendered chart.
+const ChartVerificationFile = "verification.json"
+
+// ChartVerification records how a Helm chart was verified.
+type ChartVerification struct {
+	// Digest is the SHA-256 checksum of the chart archive, or the image
+	// digest for OCI charts.
+	Digest string `json:"digest"`
+	// Signer is the identity of the trusted signer of the chart.
+	Signer string `json:"signer"`
+}
+
+// ReadChartVerification reads the verification result of the chart rendered
+// in the specified directory. It returns nil if the chart was not verified.
+func ReadChartVerification(dir string) (*ChartVerification, error) {
+	content, err := os.ReadFile(filepath.Join(dir, ChartVerificationFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading the chart verification: %w", err)
+	}
+	v := &ChartVerification{}
+	if err := json.Unmarshal(content, v); err != nil {
+		return nil, fmt.Errorf("parsing the chart verification: %w", err)
+	}
+	return v, nil
+}
+
+// WriteChartVerification records the verification result of the chart
+// rendered in the specified directory, or removes the previous result if the
+// chart was not verified.
+func WriteChartVerification(dir string, v *ChartVerification) error {
+	path := filepath.Join(dir, ChartVerificationFile)
+	if v == nil {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("removing the chart verification: %w", err)
+		}
+		return nil
+	}
+	content, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encoding the chart verification: %w", err)
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("writing the chart verification: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provenance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChartVerification(t *testing.T) {
+	dir := t.TempDir()
+
+	verification, err := ReadChartVerification(dir)
+	require.NoError(t, err)
+	assert.Nil(t, verification, "unverified charts have no verification")
+
+	want := &ChartVerification{Digest: "sha256:abc", Signer: "ci@example.com"}
+	require.NoError(t, WriteChartVerification(dir, want))
+	verification, err = ReadChartVerification(dir)
+	require.NoError(t, err)
+	assert.Equal(t, want, verification)
+
+	require.NoError(t, WriteChartVerification(dir, nil))
+	verification, err = ReadChartVerification(dir)
+	require.NoError(t, err)
+	assert.Nil(t, verification, "the verification is removed when verification is disabled")
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provenance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// imageResolutionSuffix is the suffix of the file recording which image
+// oci-sync pulled. oci-sync writes it next to the directory of the extracted
+// image, rather than inside it, so it is never parsed as a config file.
+const imageResolutionSuffix = ".json"
+
+// ImageResolution records the tag and the digest of a pulled OCI image.
+type ImageResolution struct {
+	// Tag is the tag the image was pulled by, if any.
+	Tag string `json:"tag,omitempty"`
+	// Digest is the digest of the image, e.g. `sha256:...`.
+	Digest string `json:"digest"`
+}
+
+// ImageResolutionPath returns the path of the file recording the resolution
+// of the image extracted in the specified directory.
+func ImageResolutionPath(dir string) string {
+	return dir + imageResolutionSuffix
+}
+
+// ReadImageResolution reads the resolution of the image extracted in the
+// specified directory. It returns nil if it was not recorded.
+func ReadImageResolution(dir string) (*ImageResolution, error) {
+	content, err := os.ReadFile(ImageResolutionPath(dir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading the image resolution: %w", err)
+	}
+	r := &ImageResolution{}
+	if err := json.Unmarshal(content, r); err != nil {
+		return nil, fmt.Errorf("parsing the image resolution: %w", err)
+	}
+	return r, nil
+}
+
+// WriteImageResolution records the resolution of the image extracted in the
+// specified directory.
+func WriteImageResolution(dir string, r ImageResolution) error {
+	content, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("encoding the image resolution: %w", err)
+	}
+	if err := os.WriteFile(ImageResolutionPath(dir), content, 0644); err != nil {
+		return fmt.Errorf("writing the image resolution: %w", err)
+	}
+	return nil
+}
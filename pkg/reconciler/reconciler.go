@@ -17,6 +17,8 @@ package reconciler
 import (
 	"context"
 	"net/http"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -26,11 +28,14 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
 	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/applier"
 	"kpt.dev/configsync/pkg/applyset"
 	"kpt.dev/configsync/pkg/client/restconfig"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/diff"
+	"kpt.dev/configsync/pkg/git"
 	"kpt.dev/configsync/pkg/importer/filesystem"
 	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
 	"kpt.dev/configsync/pkg/importer/reader"
@@ -39,15 +44,19 @@ import (
 	"kpt.dev/configsync/pkg/parse/events"
 	"kpt.dev/configsync/pkg/reconciler/finalizer"
 	"kpt.dev/configsync/pkg/reconciler/namespacecontroller"
+	"kpt.dev/configsync/pkg/reconciler/sourcechange"
+	"kpt.dev/configsync/pkg/reconcilermanager"
 	"kpt.dev/configsync/pkg/reconcilermanager/controllers"
 	"kpt.dev/configsync/pkg/remediator"
 	"kpt.dev/configsync/pkg/remediator/conflict"
+	"kpt.dev/configsync/pkg/remediator/drift"
 	"kpt.dev/configsync/pkg/remediator/watch"
 	syncerclient "kpt.dev/configsync/pkg/syncer/client"
 	"kpt.dev/configsync/pkg/syncer/metrics"
 	"kpt.dev/configsync/pkg/syncer/reconcile"
 	"kpt.dev/configsync/pkg/syncer/reconcile/fight"
 	"kpt.dev/configsync/pkg/util"
+	utildiscovery "kpt.dev/configsync/pkg/util/discovery"
 	utilwatch "kpt.dev/configsync/pkg/util/watch"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -105,14 +114,23 @@ type Options struct {
 	HydratedLink string
 	// SourceRev is the git revision or a helm chart version being synced.
 	SourceRev string
+	// SourceRevConstraint is the semantic version constraint in the git
+	// revision, if any.
+	SourceRevConstraint string
 	// SourceBranch is the git branch being synced.
 	SourceBranch string
 	// SourceRepo is the git or OCI or Helm repo being synced.
 	SourceRepo string
 	// SourceType is the type of the source repository, must be git or oci or Helm.
 	SourceType configsync.SourceType
+	// SourceVerificationKeysDir is the directory containing the trusted public
+	// keys used to verify the source. Verification is disabled if empty.
+	SourceVerificationKeysDir string
 	// SyncDir is the relative path to the configurations in the source.
 	SyncDir cmpath.Relative
+	// SparseCheckout indicates whether git-sync checks out only the paths
+	// needed to sync the SyncDir.
+	SparseCheckout bool
 	// StatusMode controls the kpt applier to inject the actuation status data or not
 	StatusMode metadata.StatusMode
 	// ReconcileTimeout controls the reconcile/prune Timeout in kpt applier
@@ -133,6 +151,21 @@ type Options struct {
 	// WebhookEnabled is indicates whether the Admission Webhook is currently
 	// installed and running
 	WebhookEnabled bool
+	// SourceWebhookEnabled indicates whether the source webhook receiver of the
+	// reconciler-manager notifies the reconciler of source changes.
+	SourceWebhookEnabled bool
+	// ServerSideDryRun indicates whether the changed objects are validated with
+	// a server-side dry-run before they are applied.
+	ServerSideDryRun bool
+	// SchemaValidation indicates whether the declared objects are validated
+	// against the OpenAPI schemas of their types.
+	SchemaValidation bool
+	// DriftReportMode indicates whether the drift of the managed objects is
+	// reported on the ResourceGroup status, instead of reverted.
+	DriftReportMode bool
+	// IgnoreDifferences select the fields of the managed objects whose
+	// differences from the declared state are ignored.
+	IgnoreDifferences []v1beta1.IgnoreDifference
 	// ReconcilerSignalsDir is the absolute path to the directory of ready-to-render file shared with hydration-controller
 	ReconcilerSignalsDir cmpath.Absolute
 }
@@ -143,6 +176,11 @@ type RootOptions struct {
 	SourceFormat configsync.SourceFormat
 	// NamespaceStrategy indicates the NamespaceStrategy used by this reconciler.
 	NamespaceStrategy configsync.NamespaceStrategy
+	// AdditionalSources are the sources synced along with the primary source.
+	AdditionalSources []reconcilermanager.AdditionalSource
+	// Rollout gates the sync of new commits on the health of a canary
+	// cluster group, if not nil.
+	Rollout *v1beta1.RolloutSpec
 }
 
 // Run configures and starts the various components of a reconciler process.
@@ -202,10 +240,22 @@ func Run(opts Options) {
 	if reconcileTimeout < 0 {
 		klog.Fatalf("Invalid reconcileTimeout: %v, timeout should not be negative", reconcileTimeout)
 	}
+	ignoreRules, err := diff.NewIgnoreRules(opts.IgnoreDifferences)
+	if err != nil {
+		klog.Fatalf("Invalid ignored differences: %v", err)
+	}
 	clientSet, err := applier.NewClientSet(cl, configFlags, opts.ReconcilerScope, opts.SyncName, opts.StatusMode, applySetID)
 	if err != nil {
 		klog.Fatalf("Error creating clients: %v", err)
 	}
+	clientSet.IgnoreRules = ignoreRules
+	// Only record the drift when it is reported instead of reverted.
+	// The applier keeps the recorded drift.
+	var driftHandler drift.Handler
+	if opts.DriftReportMode {
+		driftHandler = drift.NewHandler()
+		clientSet.DriftHandler = driftHandler
+	}
 	supervisor := applier.NewSupervisor(clientSet, opts.ReconcilerScope, opts.SyncName, reconcileTimeout)
 	if err := supervisor.UpdateStatusMode(signalCtx); err != nil {
 		klog.Fatalf("Error setting status mode on ResourceGroup: %v", err)
@@ -233,8 +283,7 @@ func Run(opts Options) {
 	crdController := &controllers.CRDController{}
 	conflictHandler := conflict.NewHandler()
 	fightHandler := fight.NewHandler()
-
-	rem, err := remediator.New(opts.ReconcilerScope, opts.SyncName, watcherFactory, mapper, baseApplier, conflictHandler, fightHandler, crdController, decls, opts.NumWorkers)
+	rem, err := remediator.New(opts.ReconcilerScope, opts.SyncName, watcherFactory, mapper, baseApplier, conflictHandler, fightHandler, driftHandler, ignoreRules, crdController, decls, opts.NumWorkers)
 	if err != nil {
 		klog.Fatalf("Instantiating Remediator: %v", err)
 	}
@@ -247,12 +296,17 @@ func Run(opts Options) {
 		HydratedRoot:         opts.HydratedRoot,
 		HydratedLink:         opts.HydratedLink,
 		SyncDir:              opts.SyncDir,
+		SparseCheckout:       opts.SparseCheckout,
 		SourceType:           opts.SourceType,
 		SourceRepo:           opts.SourceRepo,
 		SourceBranch:         opts.SourceBranch,
 		SourceRev:            opts.SourceRev,
+		SourceRevConstraint:  opts.SourceRevConstraint,
 		ReconcilerSignalsDir: opts.ReconcilerSignalsDir,
 	}
+	if opts.RootOptions != nil {
+		fs.AdditionalSources = additionalFileSources(opts)
+	}
 
 	parseOpts := &parse.Options{
 		Clock:             clock.RealClock{},
@@ -265,8 +319,14 @@ func Run(opts Options) {
 		DiscoveryClient:   discoveryClient,
 		Files:             parse.Files{FileSource: fs},
 		WebhookEnabled:    opts.WebhookEnabled,
+		IgnoreRules:       ignoreRules,
 		DeclaredResources: decls,
 	}
+	// Without schema validation, the unknown fields are pruned by the API
+	// server when the objects are applied.
+	if opts.SchemaValidation {
+		parseOpts.OpenAPISchemas = utildiscovery.OpenAPIV3Schemas(discoveryClient.OpenAPIV3())
+	}
 	// Only instantiate the converter when the webhook is enabled because the
 	// instantiation pulls fresh schemas from the openapi discovery endpoint.
 	if opts.WebhookEnabled {
@@ -303,6 +363,31 @@ func Run(opts Options) {
 		StatusUpdatePeriod: opts.StatusUpdatePeriod,
 		RenderingEnabled:   opts.RenderingEnabled,
 	}
+	if opts.ServerSideDryRun {
+		reconcilerOpts.Updater.DryRunner = &parse.ServerSideDryRunner{Client: cl}
+	}
+	if opts.DriftReportMode {
+		driftReporter := &drift.Reporter{
+			Client:  cl,
+			Key:     client.ObjectKey{Namespace: opts.ReconcilerScope.SyncNamespace(), Name: opts.SyncName},
+			Handler: driftHandler,
+		}
+		if err := driftReporter.Restore(signalCtx); err != nil {
+			klog.Fatalf("Error restoring the reported drift: %v", err)
+		}
+		reconcilerOpts.DriftReporter = driftReporter
+	}
+	if opts.SourceType == configsync.GitSource && opts.SourceVerificationKeysDir != "" {
+		reconcilerOpts.SourceVerifier = &git.CommitVerifier{KeysDir: opts.SourceVerificationKeysDir}
+	}
+	// sourceChanges signals the source changes notified by the source webhook
+	// receiver. It is buffered, so a change can be signaled during a sync.
+	var sourceChanges chan struct{}
+	if opts.SourceWebhookEnabled {
+		sourceChanges = make(chan struct{}, 1)
+		pgBuilder.SourceChanges = sourceChanges
+		reconcilerOpts.FetchTrigger = &git.FetchToucher{}
+	}
 
 	var nsControllerState *namespacecontroller.State
 	if opts.ReconcilerScope == declared.RootScope {
@@ -322,6 +407,9 @@ func Run(opts Options) {
 			// TODO: Trigger namespace events with a buffered channel from the NamespaceController
 			pgBuilder.NamespaceControllerPeriod = time.Second
 		}
+		if opts.Rollout != nil {
+			reconcilerOpts.RolloutGate = rolloutGate(opts.Rollout, cl)
+		}
 		reconciler = parse.NewRootSyncReconciler(reconcilerOpts, rootParseOpts)
 	} else {
 		reconciler = parse.NewRepoSyncReconciler(reconcilerOpts, parseOpts)
@@ -388,6 +476,20 @@ func Run(opts Options) {
 		klog.Fatalf("Instantiating Finalizer: %v", err)
 	}
 
+	// Only create and register the Source Change Controller when the source
+	// webhook receiver is enabled. Otherwise, the source is only polled.
+	if opts.SourceWebhookEnabled {
+		sourceChangeController := &sourcechange.Controller{
+			SyncScope: opts.ReconcilerScope,
+			SyncName:  opts.SyncName,
+			Client:    mgr.GetClient(),
+			Changes:   sourceChanges,
+		}
+		if err := sourceChangeController.SetupWithManager(mgr); err != nil {
+			klog.Fatalf("Instantiating Source Change Controller: %v", err)
+		}
+	}
+
 	// Only create and register the Namespace Controller when the flag is enabled.
 	// If the flag is disabled, no need to watch the Namespace events.
 	// The NamespaceSelector will dis-select those dynamic/on-cluster Namespaces.
@@ -453,3 +555,55 @@ func Run(opts Options) {
 	<-signalCtx.Done()
 	klog.Info("All controllers exited")
 }
+
+// additionalFileSources returns where the parser reads the files of the
+// additional sources from. Each source is fetched into its own directory under
+// the repo root, with the same symlink name as the primary source. The commits
+// of the git sources are verified when trusted keys are configured.
+func additionalFileSources(opts Options) []parse.AdditionalFileSource {
+	var result []parse.AdditionalFileSource
+	link := path.Base(opts.SourceRoot.SlashPath())
+	for _, source := range opts.AdditionalSources {
+		var verifier parse.SourceVerifier
+		if source.SourceType == configsync.GitSource && source.VerificationKeysDir != "" {
+			verifier = &git.CommitVerifier{KeysDir: source.VerificationKeysDir}
+		}
+		result = append(result, parse.AdditionalFileSource{
+			Name: source.Name,
+			FileSource: parse.FileSource{
+				SourceDir:    opts.RepoRoot.Join(cmpath.RelativeSlash(path.Join(reconcilermanager.AdditionalSourcesDir, source.Name, link))),
+				SyncDir:      cmpath.RelativeOS(strings.TrimPrefix(source.Dir, "/")),
+				SourceType:   source.SourceType,
+				SourceRepo:   source.Repo,
+				SourceBranch: source.Branch,
+				SourceRev:    source.Revision,
+			},
+			SourceVerifier: verifier,
+		})
+	}
+	return result
+}
+
+// rolloutHealthTimeout is the timeout of the requests to the HTTP endpoint
+// which serves the health of the cluster groups.
+const rolloutHealthTimeout = 30 * time.Second
+
+// rolloutGate returns the RolloutGate which reads the health of the cluster
+// groups from the ConfigMap or the HTTP endpoint of the rollout spec.
+func rolloutGate(rollout *v1beta1.RolloutSpec, cl client.Reader) parse.RolloutGate {
+	if rollout.ConfigMapRef != nil {
+		return &parse.ConfigMapRolloutGate{
+			Client: cl,
+			Key: client.ObjectKey{
+				Namespace: rollout.ConfigMapRef.Namespace,
+				Name:      rollout.ConfigMapRef.Name,
+			},
+			CanaryGroup: rollout.CanaryGroup,
+		}
+	}
+	return &parse.HTTPRolloutGate{
+		Client:      &http.Client{Timeout: rolloutHealthTimeout},
+		URL:         rollout.URL,
+		CanaryGroup: rollout.CanaryGroup,
+	}
+}
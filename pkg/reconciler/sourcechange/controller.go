@@ -0,0 +1,116 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sourcechange
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configmanagement"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/reconciler/finalizer"
+	"kpt.dev/configsync/pkg/status"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Controller watches the RootSync or RepoSync of the reconciler, and signals a
+// source change when the reconciler-manager updates the
+// `configsync.gke.io/source-change` annotation, after its source webhook
+// receiver is notified of a push to the source.
+type Controller struct {
+	SyncScope declared.Scope
+	SyncName  string
+	Client    client.Client
+	// Changes receives a signal for each source change. Signals are dropped
+	// while a previous one is pending, because a single sync handles them all.
+	Changes chan<- struct{}
+
+	mux       sync.Mutex
+	lastValue string
+}
+
+// SetupWithManager registers the source change Controller with the manager.
+func (c *Controller) SetupWithManager(mgr ctrl.Manager) error {
+	exampleObj := c.newExampleObject()
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("SourceChange").
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: 1,
+		}).
+		For(exampleObj, builder.WithPredicates(
+			predicate.AnnotationChangedPredicate{},
+			finalizer.SingleObjectPredicate(client.ObjectKeyFromObject(exampleObj)),
+		)).
+		Complete(c)
+}
+
+// newExampleObject returns new RootSync or RepoSync with name and namespace set.
+func (c *Controller) newExampleObject() client.Object {
+	if c.SyncScope == declared.RootScope {
+		exampleObj := &v1beta1.RootSync{}
+		exampleObj.Name = c.SyncName
+		exampleObj.Namespace = configmanagement.ControllerNamespace
+		return exampleObj
+	}
+	exampleObj := &v1beta1.RepoSync{}
+	exampleObj.Name = c.SyncName
+	exampleObj.Namespace = string(c.SyncScope)
+	return exampleObj
+}
+
+// Reconcile signals a source change if the annotation value changed.
+func (c *Controller) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	rs := c.newExampleObject()
+	if err := c.Client.Get(ctx, req.NamespacedName, rs); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, status.APIServerError(err, fmt.Sprintf("failed to get %T %s", rs, req.NamespacedName))
+	}
+	value := core.GetAnnotation(rs, metadata.SourceChangeAnnotationKey)
+	if !c.changed(value) {
+		return reconcile.Result{}, nil
+	}
+	klog.Infof("Source change notified: %s", value)
+	select {
+	case c.Changes <- struct{}{}:
+	default:
+		klog.V(3).Info("Source change already pending")
+	}
+	return reconcile.Result{}, nil
+}
+
+// changed records the annotation value, and returns true if it is set and
+// differs from the last recorded value.
+func (c *Controller) changed(value string) bool {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if value == "" || value == c.lastValue {
+		return false
+	}
+	c.lastValue = value
+	return true
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sourcechange
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/metadata"
+	syncertest "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestController_Reconcile(t *testing.T) {
+	rs := k8sobjects.RootSyncObjectV1Beta1(configsync.RootSyncName)
+	fakeClient := syncertest.NewClient(t, core.Scheme, rs)
+	changes := make(chan struct{}, 1)
+	c := &Controller{
+		SyncScope: declared.RootScope,
+		SyncName:  configsync.RootSyncName,
+		Client:    fakeClient,
+		Changes:   changes,
+	}
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(rs)}
+	ctx := context.Background()
+
+	setAnnotation := func(value string) {
+		t.Helper()
+		require.NoError(t, fakeClient.Get(ctx, req.NamespacedName, rs))
+		core.SetAnnotation(rs, metadata.SourceChangeAnnotationKey, value)
+		require.NoError(t, fakeClient.Update(ctx, rs, client.FieldOwner(syncertest.FieldManager)))
+	}
+	pending := func() bool {
+		select {
+		case <-changes:
+			return true
+		default:
+			return false
+		}
+	}
+
+	// No annotation
+	_, err := c.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.False(t, pending())
+
+	// New value
+	setAnnotation("abc123")
+	_, err = c.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.True(t, pending())
+
+	// Same value
+	_, err = c.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.False(t, pending())
+
+	// Changes are coalesced while a signal is pending
+	setAnnotation("def456")
+	_, err = c.Reconcile(ctx, req)
+	require.NoError(t, err)
+	setAnnotation("ghi789")
+	_, err = c.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.True(t, pending())
+	assert.False(t, pending())
+}
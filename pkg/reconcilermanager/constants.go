@@ -71,6 +71,9 @@ const (
 	// HelmSync is the name of the helm-sync container in reconciler pods.
 	HelmSync = "helm-sync"
 
+	// ArchiveSync is the name of the archive-sync container in reconciler pods.
+	ArchiveSync = "archive-sync"
+
 	// HydrationController is the name of the hydration-controller container in reconciler pods.
 	HydrationController = "hydration-controller"
 
@@ -107,6 +110,22 @@ const (
 	// WebhookEnabled tells the reconciler container whether the Admission Webhook
 	// is installed and running on the cluster.
 	WebhookEnabled = "WEBHOOK_ENABLED"
+
+	// SourceWebhookEnabled tells the reconciler container whether the source
+	// webhook receiver of the reconciler-manager notifies it of source changes.
+	SourceWebhookEnabled = "SOURCE_WEBHOOK_ENABLED"
+
+	// ServerSideDryRun tells the reconciler container whether to validate the
+	// changed objects with a server-side dry-run before applying them.
+	ServerSideDryRun = "SERVER_SIDE_DRY_RUN"
+
+	// SchemaValidation tells the reconciler container whether to validate the
+	// declared objects against the OpenAPI schemas of their types.
+	SchemaValidation = "SCHEMA_VALIDATION"
+
+	// DriftReportMode tells the reconciler container whether to report the
+	// drift of the managed objects, instead of reverting it.
+	DriftReportMode = "DRIFT_REPORT_MODE"
 )
 
 const (
@@ -121,6 +140,35 @@ const (
 
 	// SourceRevKey is the OS env variable key for the git or helm revision.
 	SourceRevKey = "SOURCE_REV"
+
+	// SourceRevConstraintKey is the OS env variable key for the semantic
+	// version constraint the git revision was resolved from, if any.
+	SourceRevConstraintKey = "SOURCE_REV_CONSTRAINT"
+
+	// SourceVerificationKeysDirKey is the OS env variable key for the directory
+	// of the trusted public keys used to verify the source.
+	SourceVerificationKeysDirKey = "SOURCE_VERIFICATION_KEYS_DIR"
+
+	// SparseCheckoutKey is the OS env variable key for whether git-sync only
+	// checks out the sync directory and the Kustomize bases it references.
+	SparseCheckoutKey = "SPARSE_CHECKOUT"
+
+	// AdditionalSourcesKey is the OS env variable key for the additional
+	// sources of a RootSync, encoded as a JSON list of AdditionalSource.
+	AdditionalSourcesKey = "ADDITIONAL_SOURCES"
+
+	// AdditionalSourcesDir is the directory, relative to the repo root, where
+	// each additional source is fetched into a directory named after it.
+	AdditionalSourcesDir = "sources"
+
+	// RolloutKey is the OS env variable key for the rollout gate of a
+	// RootSync, encoded as a JSON RolloutSpec.
+	RolloutKey = "ROLLOUT"
+
+	// IgnoreDifferencesKey is the OS env variable key for the fields whose
+	// differences from the declared state are ignored, encoded as a JSON list
+	// of IgnoreDifference.
+	IgnoreDifferencesKey = "IGNORE_DIFFERENCES"
 )
 
 const (
@@ -131,6 +179,14 @@ const (
 	// HydrationPollingPeriod defines how often the hydration controller should
 	// poll the filesystem for rendering the DRY configs.
 	HydrationPollingPeriod = "HYDRATION_POLLING_PERIOD"
+
+	// RenderingHermetic defines whether the hydration controller should reject
+	// the remote sources of the kustomizations.
+	RenderingHermetic = "RENDERING_HERMETIC"
+
+	// RenderingAllowExecFunctions defines whether the hydration controller may
+	// run the exec functions of the Kptfile pipelines.
+	RenderingAllowExecFunctions = "RENDERING_ALLOW_EXEC_FUNCTIONS"
 )
 
 const (
@@ -143,12 +199,51 @@ const (
 	// OciSyncWait is the OS env variable key for the OCI sync wait period in seconds.
 	OciSyncWait = "OCI_SYNC_WAIT"
 
+	// OciSyncVerificationIdentity is the OS env variable key for the expected
+	// identity of keyless image signatures.
+	OciSyncVerificationIdentity = "OCI_SYNC_VERIFICATION_IDENTITY"
+
+	// OciSyncVerificationIssuer is the OS env variable key for the expected
+	// OIDC issuer of keyless image signatures.
+	OciSyncVerificationIssuer = "OCI_SYNC_VERIFICATION_ISSUER"
+
+	// OciSyncVerificationAttestations is the OS env variable key for the
+	// comma-separated predicate types that must be attested for the image.
+	OciSyncVerificationAttestations = "OCI_SYNC_VERIFICATION_ATTESTATIONS"
+
+	// OciSyncTagSemver is the OS env variable key for the semantic version
+	// constraint selecting the tag of the image.
+	OciSyncTagSemver = "OCI_SYNC_TAG_SEMVER"
+
+	// OciSyncTagRegex is the OS env variable key for the regular expression
+	// filtering the tags of the image.
+	OciSyncTagRegex = "OCI_SYNC_TAG_REGEX"
+
+	// OciSyncTagLatest is the OS env variable key for selecting the most
+	// recently created tag of the image.
+	OciSyncTagLatest = "OCI_SYNC_TAG_LATEST"
+
 	// OciCACert is the OS env variable key for the OCI CA cert file path.
 	// This variable is consumed by the underlying crypto library:
 	// - https://pkg.go.dev/crypto/x509#SystemCertPool
 	OciCACert = "SSL_CERT_FILE"
 )
 
+const (
+	// ArchiveSyncURL is the OS env variable key for the archive URL.
+	ArchiveSyncURL = "ARCHIVE_SYNC_URL"
+
+	// ArchiveSyncAuth is the OS env variable key for the archive sync auth type.
+	ArchiveSyncAuth = "ARCHIVE_SYNC_AUTH"
+
+	// ArchiveSyncWait is the OS env variable key for the archive sync wait period in seconds.
+	ArchiveSyncWait = "ARCHIVE_SYNC_WAIT"
+
+	// ArchiveSyncRegion is the OS env variable key for the region of the
+	// S3-compatible bucket, used to sign the requests with 'hmac' auth.
+	ArchiveSyncRegion = "ARCHIVE_SYNC_REGION"
+)
+
 const (
 	// HelmRepo is the OS env variable key for the Helm repository URL.
 	HelmRepo = "HELM_REPO"
@@ -176,6 +271,11 @@ const (
 	// that were mounted from ConfigMaps.
 	HelmValuesFilePaths = "HELM_VALUES_FILE_PATHS"
 
+	// HelmGitValuesFiles is the OS env variable key for a comma-separated list
+	// of the values files of a Helm chart stored in Git, relative to the sync
+	// directory.
+	HelmGitValuesFiles = "HELM_GIT_VALUES_FILES"
+
 	//HelmIncludeCRDs is the OS env variable key for whether to include CRDs in helm rendering output.
 	HelmIncludeCRDs = "HELM_INCLUDE_CRDS"
 
@@ -187,6 +287,14 @@ const (
 
 	// HelmCACert is the OS env variable key for the Helm sync CA cert file path.
 	HelmCACert = "HELM_CA_CERT"
+
+	// HelmVerificationIdentity is the OS env variable key for the expected
+	// identity of keyless chart signatures.
+	HelmVerificationIdentity = "HELM_VERIFICATION_IDENTITY"
+
+	// HelmVerificationIssuer is the OS env variable key for the expected
+	// OIDC issuer of keyless chart signatures.
+	HelmVerificationIssuer = "HELM_VERIFICATION_ISSUER"
 )
 
 const (
@@ -0,0 +1,305 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/reconcilermanager"
+	"kpt.dev/configsync/pkg/rootsync"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// rootFlagPrefix is the prefix of the flag of the *-sync containers which
+// specifies the directory the source is fetched into.
+const rootFlagPrefix = "--root="
+
+// syncContainerName returns the name of the *-sync container in the reconciler
+// deployment template which fetches sources of the specified type.
+func syncContainerName(sourceType configsync.SourceType) (string, error) {
+	switch sourceType {
+	case configsync.GitSource:
+		return reconcilermanager.GitSync, nil
+	case configsync.OciSource:
+		return reconcilermanager.OciSync, nil
+	case configsync.HelmSource:
+		return reconcilermanager.HelmSync, nil
+	default:
+		return "", fmt.Errorf("unsupported source type %q for additional sources", sourceType)
+	}
+}
+
+// additionalSourceAuth returns the auth type and the name of the Secret used
+// to fetch the additional source.
+func additionalSourceAuth(source v1beta1.RootSyncSource) (configsync.AuthType, string) {
+	switch source.SourceType {
+	case configsync.GitSource:
+		return source.Git.Auth, v1beta1.GetSecretName(source.Git.SecretRef)
+	case configsync.OciSource:
+		return source.Oci.Auth, ""
+	case configsync.HelmSource:
+		return source.Helm.Auth, v1beta1.GetSecretName(source.Helm.SecretRef)
+	default:
+		return "", ""
+	}
+}
+
+// additionalSourceVerificationSecretName returns the name of the Secret with
+// the trusted public keys used to verify the additional source, if any.
+func additionalSourceVerificationSecretName(source v1beta1.RootSyncSource) string {
+	switch {
+	case source.SourceType == configsync.GitSource && source.Git != nil && source.Git.Verification != nil:
+		return v1beta1.GetSecretName(source.Git.Verification.TrustedKeysSecretRef)
+	case source.SourceType == configsync.OciSource && source.Oci != nil && source.Oci.Verification != nil:
+		return v1beta1.GetSecretName(source.Oci.Verification.TrustedKeysSecretRef)
+	case source.SourceType == configsync.HelmSource && source.Helm != nil && source.Helm.Verify != nil:
+		return v1beta1.GetSecretName(source.Helm.Verify.KeyringSecretRef)
+	default:
+		return ""
+	}
+}
+
+// additionalSourceVerificationVolumeName returns the name of the volume of the
+// trusted public keys of the additional source.
+func additionalSourceVerificationVolumeName(source v1beta1.RootSyncSource) string {
+	return fmt.Sprintf("%s-%s", source.Name, VerificationKeysVolume)
+}
+
+// mountAdditionalSourcesVerificationKeys mounts the trusted public keys of the
+// additional git sources into the reconciler container, which verifies their
+// commits like the commits of the primary source. The keys of the OCI and Helm
+// sources are mounted into the containers fetching them instead.
+func mountAdditionalSourcesVerificationKeys(templateSpec *corev1.PodSpec, c *corev1.Container, sources []v1beta1.RootSyncSource) {
+	for _, source := range sources {
+		if secretName := additionalSourceVerificationSecretName(source); source.SourceType == configsync.GitSource && secretName != "" {
+			mountVerificationKeysVolume(templateSpec, c, additionalSourceVerificationVolumeName(source),
+				path.Join(AdditionalSourcesVerificationKeysPath, source.Name), secretName)
+		}
+	}
+}
+
+// additionalSourcesEnv returns the environment variable which tells the
+// reconciler where to read the additional sources from.
+func additionalSourcesEnv(sources []v1beta1.RootSyncSource) ([]corev1.EnvVar, error) {
+	if len(sources) == 0 {
+		return nil, nil
+	}
+	var result []reconcilermanager.AdditionalSource
+	for _, source := range sources {
+		repo, branch, revision, dir := sourceLocation(source.SourceType, source.Git, source.Oci, rootsync.GetHelmBase(source.Helm), nil)
+		additionalSource := reconcilermanager.AdditionalSource{
+			Name:       source.Name,
+			SourceType: source.SourceType,
+			Repo:       repo,
+			Branch:     branch,
+			Revision:   revision,
+			Dir:        dir,
+		}
+		if source.SourceType == configsync.GitSource && additionalSourceVerificationSecretName(source) != "" {
+			additionalSource.VerificationKeysDir = path.Join(AdditionalSourcesVerificationKeysPath, source.Name)
+		}
+		result = append(result, additionalSource)
+	}
+	value, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("encoding the additional sources: %w", err)
+	}
+	return []corev1.EnvVar{{
+		Name:  reconcilermanager.AdditionalSourcesKey,
+		Value: string(value),
+	}}, nil
+}
+
+// additionalSourceEnvs returns the environment variables of the container
+// which fetches the additional source.
+func (r *RootSyncReconciler) additionalSourceEnvs(ctx context.Context, rs *v1beta1.RootSync, source v1beta1.RootSyncSource) ([]corev1.EnvVar, error) {
+	switch source.SourceType {
+	case configsync.GitSource:
+		secretName := v1beta1.GetSecretName(source.Git.SecretRef)
+		var keys map[string]bool
+		if !SkipForAuth(source.Git.Auth) {
+			keys = GetSecretKeys(ctx, r.client, client.ObjectKey{Namespace: rs.Namespace, Name: secretName})
+		}
+		result, err := gitSyncEnvs(ctx, options{
+			ref:         source.Git.Revision,
+			branch:      source.Git.Branch,
+			repo:        source.Git.Repo,
+			secretType:  source.Git.Auth,
+			period:      v1beta1.GetPeriod(source.Git.Period, configsync.DefaultReconcilerPollingPeriod),
+			proxy:       source.Git.Proxy,
+			noSSLVerify: source.Git.NoSSLVerify,
+			knownHost:   source.Git.Auth == configsync.AuthSSH && keys[KnownHostsKey],
+			submodules:  source.Git.Submodules,
+			lfs:         source.Git.LFS,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if source.Git.Auth == configsync.AuthToken {
+			result = append(result, gitSyncTokenAuthEnv(secretName)...)
+		}
+		return append(result, gitSyncHTTPSProxyEnv(secretName, keys)...), nil
+	case configsync.OciSource:
+		return ociSyncEnvs(ociOptions{
+			image:        source.Oci.Image,
+			auth:         source.Oci.Auth,
+			period:       v1beta1.GetPeriod(source.Oci.Period, configsync.DefaultReconcilerPollingPeriod).Seconds(),
+			verification: source.Oci.Verification,
+			tagPolicy:    source.Oci.TagPolicy,
+		}), nil
+	case configsync.HelmSource:
+		result := helmSyncEnvs(helmOptions{
+			helmBase:         &source.Helm.HelmBase,
+			releaseNamespace: source.Helm.Namespace,
+			deployNamespace:  source.Helm.DeployNamespace,
+		})
+		if authTypeToken(source.Helm.Auth) {
+			result = append(result, helmSyncTokenAuthEnv(v1beta1.GetSecretName(source.Helm.SecretRef))...)
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("unsupported source type %q for source %q", source.SourceType, source.Name)
+	}
+}
+
+// additionalSourceContainers returns the containers which fetch the additional
+// sources of the RootSync. Each container is a copy of the *-sync container of
+// the deployment template for the source type, which fetches the source into
+// its own directory under AdditionalSourcesDir. Credentials are mounted from
+// a copy of the template credentials volume, referencing the source Secret.
+func (r *RootSyncReconciler) additionalSourceContainers(ctx context.Context, rs *v1beta1.RootSync, templateSpec *corev1.PodSpec,
+	templateContainers []corev1.Container, templateVolumes []corev1.Volume,
+	containerResources []v1beta1.ContainerResourcesSpec, containerLogLevels []v1beta1.ContainerLogLevelOverride) ([]corev1.Container, error) {
+	var result []corev1.Container
+	for _, source := range rs.Spec.Sources {
+		templateName, err := syncContainerName(source.SourceType)
+		if err != nil {
+			return nil, fmt.Errorf("additional source %q: %w", source.Name, err)
+		}
+		var container *corev1.Container
+		for i := range templateContainers {
+			if templateContainers[i].Name == templateName {
+				container = templateContainers[i].DeepCopy()
+				break
+			}
+		}
+		if container == nil {
+			return nil, fmt.Errorf("missing container %q in reconciler deployment template", templateName)
+		}
+		// Resources and log levels are overridden per container type.
+		mutateContainerResource(container, containerResources)
+		if err := mutateContainerLogLevel(container, containerLogLevels); err != nil {
+			return nil, err
+		}
+		// The container is named after the source, so that it is unique.
+		container.Name = fmt.Sprintf("%s-%s", templateName, source.Name)
+		for i, arg := range container.Args {
+			if root, found := strings.CutPrefix(arg, rootFlagPrefix); found {
+				container.Args[i] = rootFlagPrefix + path.Join(path.Dir(root), reconcilermanager.AdditionalSourcesDir, source.Name)
+			}
+		}
+		envs, err := r.additionalSourceEnvs(ctx, rs, source)
+		if err != nil {
+			return nil, err
+		}
+		container.Env = append(container.Env, envs...)
+		if secretName := additionalSourceVerificationSecretName(source); source.SourceType != configsync.GitSource && secretName != "" {
+			mountVerificationKeysVolume(templateSpec, container, additionalSourceVerificationVolumeName(source), VerificationKeysPath, secretName)
+			container.Env = append(container.Env, corev1.EnvVar{
+				Name:  reconcilermanager.SourceVerificationKeysDirKey,
+				Value: VerificationKeysPath,
+			})
+		}
+
+		auth, secretName := additionalSourceAuth(source)
+		container.VolumeMounts = volumeMounts(auth, "", source.SourceType, container.VolumeMounts)
+		for i, mount := range container.VolumeMounts {
+			if mount.Name != GitCredentialVolume && mount.Name != HelmCredentialVolume {
+				continue
+			}
+			volume := credentialVolume(templateVolumes, mount.Name)
+			if volume == nil {
+				return nil, fmt.Errorf("missing volume %q in reconciler deployment template", mount.Name)
+			}
+			volume.Name = container.Name + "-creds"
+			volume.Secret.SecretName = secretName
+			templateSpec.Volumes = append(templateSpec.Volumes, *volume)
+			container.VolumeMounts[i].Name = volume.Name
+		}
+		result = append(result, *container)
+	}
+	return result, nil
+}
+
+// credentialVolume returns a copy of the credentials volume with the specified
+// name, or nil if not found.
+func credentialVolume(volumes []corev1.Volume, name string) *corev1.Volume {
+	for _, volume := range volumes {
+		if volume.Name == name && volume.Secret != nil {
+			return volume.DeepCopy()
+		}
+	}
+	return nil
+}
+
+// validateAdditionalSourceDependencies verifies that the Secrets used to fetch
+// and verify the additional sources exist.
+func (r *RootSyncReconciler) validateAdditionalSourceDependencies(ctx context.Context, rs *v1beta1.RootSync) error {
+	for _, source := range rs.Spec.Sources {
+		if err := r.validateVerificationKeysSecret(ctx, rs.Namespace, additionalSourceVerificationSecretName(source)); err != nil {
+			return err
+		}
+		auth, secretName := additionalSourceAuth(source)
+		if SkipForAuth(auth) {
+			continue
+		}
+		secret, err := validateSecretExist(ctx, secretName, rs.Namespace, r.client)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Errorf("Secret %s not found: create one to allow client authentication to source %q", secretName, source.Name)
+			}
+			return fmt.Errorf("Secret %s get failed: %w", secretName, err)
+		}
+		if source.SourceType == configsync.GitSource {
+			if err := validateSecretData(auth, secret); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// rootSyncAdditionalSourceSecretNames returns the names of the Secrets used to
+// fetch and verify the additional sources.
+func rootSyncAdditionalSourceSecretNames(rs *v1beta1.RootSync) []string {
+	var result []string
+	for _, source := range rs.Spec.Sources {
+		if _, secretName := additionalSourceAuth(source); secretName != "" {
+			result = append(result, secretName)
+		}
+		if secretName := additionalSourceVerificationSecretName(source); secretName != "" {
+			result = append(result, secretName)
+		}
+	}
+	return result
+}
@@ -0,0 +1,209 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/reconcilermanager"
+)
+
+func rootSyncWithSources(sources ...v1beta1.RootSyncSource) *v1beta1.RootSync {
+	rs := k8sobjects.RootSyncObjectV1Beta1(rootsyncName)
+	rs.Spec.SourceFormat = configsync.SourceFormatUnstructured
+	rs.Spec.Sources = sources
+	return rs
+}
+
+func TestAdditionalSourcesEnv(t *testing.T) {
+	rs := rootSyncWithSources(
+		v1beta1.RootSyncSource{
+			Name:       "platform",
+			SourceType: configsync.GitSource,
+			Git:        &v1beta1.Git{Repo: "https://example.com/platform", Dir: "configs", Auth: configsync.AuthNone},
+		},
+		v1beta1.RootSyncSource{
+			Name:       "addons",
+			SourceType: configsync.OciSource,
+			Oci:        &v1beta1.Oci{Image: "example.com/addons:v1", Auth: configsync.AuthNone},
+		},
+		v1beta1.RootSyncSource{
+			Name:       "signed",
+			SourceType: configsync.GitSource,
+			Git: &v1beta1.Git{Repo: "https://example.com/signed", Auth: configsync.AuthNone,
+				Verification: &v1beta1.GitVerification{TrustedKeysSecretRef: &v1beta1.SecretReference{Name: "signed-keys"}}},
+		},
+	)
+
+	envs, err := additionalSourcesEnv(rs.Spec.Sources)
+	require.NoError(t, err)
+	assert.Equal(t, []corev1.EnvVar{{
+		Name: reconcilermanager.AdditionalSourcesKey,
+		Value: `[{"name":"platform","sourceType":"git","repo":"https://example.com/platform","branch":"master","revision":"HEAD","dir":"configs"},` +
+			`{"name":"addons","sourceType":"oci","repo":"example.com/addons:v1"},` +
+			`{"name":"signed","sourceType":"git","repo":"https://example.com/signed","branch":"master","revision":"HEAD","verificationKeysDir":"/etc/source-verification-keys/signed"}]`,
+	}}, envs)
+
+	envs, err = additionalSourcesEnv(nil)
+	require.NoError(t, err)
+	assert.Empty(t, envs)
+}
+
+func TestAdditionalSourceContainers(t *testing.T) {
+	rs := rootSyncWithSources(
+		v1beta1.RootSyncSource{
+			Name:       "platform",
+			SourceType: configsync.GitSource,
+			Git: &v1beta1.Git{
+				Repo:      "git@example.com:platform",
+				Auth:      configsync.AuthSSH,
+				SecretRef: &v1beta1.SecretReference{Name: "platform-creds"},
+			},
+		},
+		v1beta1.RootSyncSource{
+			Name:       "addons",
+			SourceType: configsync.OciSource,
+			Oci: &v1beta1.Oci{Image: "example.com/addons:v1", Auth: configsync.AuthNone,
+				Verification: &v1beta1.OciVerification{TrustedKeysSecretRef: &v1beta1.SecretReference{Name: "addons-keys"}}},
+		},
+	)
+	_, _, testReconciler := setupRootReconciler(t, secretObjWithKnownHosts(t, "platform-creds", core.Namespace(configsync.ControllerNamespace)))
+
+	credsMode := int32(0440)
+	templateVolumes := []corev1.Volume{{
+		Name: GitCredentialVolume,
+		VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+			SecretName:  GitCredentialVolume,
+			DefaultMode: &credsMode,
+		}},
+	}}
+	templateContainers := []corev1.Container{
+		{
+			Name: reconcilermanager.GitSync,
+			Args: []string{"--root=/repo/source", "--link=rev"},
+			VolumeMounts: []corev1.VolumeMount{
+				{Name: "repo", MountPath: "/repo"},
+				{Name: GitCredentialVolume, MountPath: "/etc/git-secret", ReadOnly: true},
+			},
+		},
+		{
+			Name:         reconcilermanager.OciSync,
+			Args:         []string{"--root=/repo/source", "--dest=rev"},
+			VolumeMounts: []corev1.VolumeMount{{Name: "repo", MountPath: "/repo"}},
+		},
+	}
+	templateSpec := &corev1.PodSpec{}
+
+	containers, err := testReconciler.additionalSourceContainers(context.Background(), rs, templateSpec,
+		templateContainers, templateVolumes, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, containers, 2)
+
+	gitSync := containers[0]
+	assert.Equal(t, "git-sync-platform", gitSync.Name)
+	assert.Equal(t, []string{"--root=/repo/sources/platform", "--link=rev"}, gitSync.Args)
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "git-sync-platform-creds", MountPath: "/etc/git-secret", ReadOnly: true},
+		{Name: "repo", MountPath: "/repo"},
+	}, gitSync.VolumeMounts)
+	assert.Contains(t, gitSync.Env, corev1.EnvVar{Name: GitSyncRepo, Value: "git@example.com:platform"})
+	assert.Contains(t, gitSync.Env, corev1.EnvVar{Name: GitSyncKnownHosts, Value: "true"})
+	assert.Equal(t, []corev1.Volume{
+		{
+			Name: "git-sync-platform-creds",
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+				SecretName:  "platform-creds",
+				DefaultMode: &credsMode,
+			}},
+		},
+		{
+			Name: "addons-verification-keys",
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+				SecretName:  "addons-keys",
+				DefaultMode: &defaultMode,
+			}},
+		},
+	}, templateSpec.Volumes)
+
+	ociSync := containers[1]
+	assert.Equal(t, "oci-sync-addons", ociSync.Name)
+	assert.Equal(t, []string{"--root=/repo/sources/addons", "--dest=rev"}, ociSync.Args)
+	assert.Contains(t, ociSync.Env, corev1.EnvVar{Name: reconcilermanager.OciSyncImage, Value: "example.com/addons:v1"})
+	assert.Contains(t, ociSync.Env, corev1.EnvVar{Name: reconcilermanager.SourceVerificationKeysDirKey, Value: VerificationKeysPath})
+	assert.Contains(t, ociSync.VolumeMounts, corev1.VolumeMount{Name: "addons-verification-keys", MountPath: VerificationKeysPath, ReadOnly: true})
+
+	// The template containers must not be modified.
+	assert.Equal(t, reconcilermanager.GitSync, templateContainers[0].Name)
+	assert.Equal(t, "--root=/repo/source", templateContainers[0].Args[0])
+}
+
+func TestMountAdditionalSourcesVerificationKeys(t *testing.T) {
+	sources := []v1beta1.RootSyncSource{
+		{
+			Name:       "platform",
+			SourceType: configsync.GitSource,
+			Git:        &v1beta1.Git{Repo: "https://example.com/platform", Auth: configsync.AuthNone},
+		},
+		{
+			Name:       "signed",
+			SourceType: configsync.GitSource,
+			Git: &v1beta1.Git{Repo: "https://example.com/signed", Auth: configsync.AuthNone,
+				Verification: &v1beta1.GitVerification{TrustedKeysSecretRef: &v1beta1.SecretReference{Name: "signed-keys"}}},
+		},
+		{
+			Name:       "addons",
+			SourceType: configsync.OciSource,
+			Oci: &v1beta1.Oci{Image: "example.com/addons:v1", Auth: configsync.AuthNone,
+				Verification: &v1beta1.OciVerification{TrustedKeysSecretRef: &v1beta1.SecretReference{Name: "addons-keys"}}},
+		},
+	}
+	templateSpec := &corev1.PodSpec{}
+	container := &corev1.Container{Name: reconcilermanager.Reconciler}
+
+	mountAdditionalSourcesVerificationKeys(templateSpec, container, sources)
+	assert.Equal(t, []corev1.Volume{{
+		Name: "signed-verification-keys",
+		VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+			SecretName:  "signed-keys",
+			DefaultMode: &defaultMode,
+		}},
+	}}, templateSpec.Volumes)
+	assert.Equal(t, []corev1.VolumeMount{{
+		Name:      "signed-verification-keys",
+		MountPath: "/etc/source-verification-keys/signed",
+		ReadOnly:  true,
+	}}, container.VolumeMounts)
+}
+
+func TestAdditionalSourceContainersUnsupportedSourceType(t *testing.T) {
+	rs := rootSyncWithSources(v1beta1.RootSyncSource{
+		Name:       "configs",
+		SourceType: configsync.ArchiveSource,
+	})
+	_, _, testReconciler := setupRootReconciler(t)
+	templateContainers := []corev1.Container{{Name: reconcilermanager.GitSync}}
+
+	_, err := testReconciler.additionalSourceContainers(context.Background(), rs, &corev1.PodSpec{},
+		templateContainers, nil, nil, nil)
+	assert.ErrorContains(t, err, `additional source "configs": unsupported source type "archive"`)
+}
@@ -58,3 +58,17 @@ const (
 	// HelmSecretKeyUsername is the key at which a token's username is stored
 	HelmSecretKeyUsername = "username"
 )
+
+// Archive secret data key names
+const (
+	// ArchiveSecretKeyToken is the key at which a bearer token is stored
+	ArchiveSecretKeyToken = "token"
+	// ArchiveSecretKeyUsername is the key at which a basic auth username is stored
+	ArchiveSecretKeyUsername = "username"
+	// ArchiveSecretKeyPassword is the key at which a basic auth password is stored
+	ArchiveSecretKeyPassword = "password"
+	// ArchiveSecretKeyAccessKeyID is the key at which an HMAC access key ID is stored
+	ArchiveSecretKeyAccessKeyID = "access_key_id"
+	// ArchiveSecretKeySecretAccessKey is the key at which an HMAC secret access key is stored
+	ArchiveSecretKeySecretAccessKey = "secret_access_key"
+)
@@ -0,0 +1,130 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"path"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"kpt.dev/configsync/pkg/git"
+	"kpt.dev/configsync/pkg/reconcilermanager"
+)
+
+const (
+	// fetchTriggerVolume is the name of the volume shared by the reconciler
+	// and the git-sync containers, holding the launcher and the trigger file.
+	fetchTriggerVolume = "fetch-trigger"
+	// launcherInstaller is the name of the init container copying the
+	// launcher into the fetch trigger volume.
+	launcherInstaller = "git-sync-launcher-install"
+	// launcherImagePath is the path of the launcher in the reconciler image.
+	launcherImagePath = "/git-sync-launcher"
+	// gitSyncEntrypoint is the entrypoint of the git-sync image.
+	gitSyncEntrypoint = "/git-sync"
+	// repoRoot is the mount path of the repo volume, shared by the git-sync
+	// and reconciler containers.
+	repoRoot = "/repo"
+	// gitSyncErrorFile is the error file of the primary git-sync container,
+	// read by the reconciler.
+	gitSyncErrorFile = repoRoot + "/source/error.json"
+)
+
+// launcherPath is the path of the launcher in the fetch trigger volume.
+var launcherPath = path.Join(git.FetchTriggerDir, "git-sync-launcher")
+
+// isGitSyncContainer returns true if the container is the git-sync container
+// of the primary source, or of an additional source.
+func isGitSyncContainer(name string) bool {
+	return name == reconcilermanager.GitSync || strings.HasPrefix(name, reconcilermanager.GitSync+"-")
+}
+
+// installGitSyncLauncher runs the git-sync containers under the
+// git-sync-launcher. An init container copies the launcher from the reconciler
+// image into a volume mounted by the git-sync containers, since the git-sync
+// image does not ship it. It returns false if the Pod has no reconciler or no
+// git-sync container.
+func installGitSyncLauncher(templateSpec *corev1.PodSpec) bool {
+	var reconciler *corev1.Container
+	hasGitSync := false
+	for i := range templateSpec.Containers {
+		container := &templateSpec.Containers[i]
+		if container.Name == reconcilermanager.Reconciler {
+			reconciler = container
+		} else if isGitSyncContainer(container.Name) {
+			hasGitSync = true
+		}
+	}
+	if reconciler == nil || !hasGitSync {
+		return false
+	}
+	if slices.ContainsFunc(templateSpec.InitContainers, func(c corev1.Container) bool {
+		return c.Name == launcherInstaller
+	}) {
+		// Already installed.
+		return true
+	}
+	templateSpec.Volumes = append(templateSpec.Volumes, corev1.Volume{
+		Name:         fetchTriggerVolume,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	})
+	templateSpec.InitContainers = append(templateSpec.InitContainers, corev1.Container{
+		Name:            launcherInstaller,
+		Image:           reconciler.Image,
+		ImagePullPolicy: reconciler.ImagePullPolicy,
+		Command:         []string{launcherImagePath, "--install=" + launcherPath},
+		SecurityContext: reconciler.SecurityContext.DeepCopy(),
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      fetchTriggerVolume,
+			MountPath: git.FetchTriggerDir,
+		}},
+	})
+	for i := range templateSpec.Containers {
+		container := &templateSpec.Containers[i]
+		if !isGitSyncContainer(container.Name) {
+			continue
+		}
+		container.Command = []string{launcherPath, "--", gitSyncEntrypoint}
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      fetchTriggerVolume,
+			MountPath: git.FetchTriggerDir,
+			ReadOnly:  true,
+		})
+	}
+	return true
+}
+
+// enableRevisionConstraint configures the git-sync container of the primary
+// source to resolve the semantic version constraint of its ref. The launcher
+// lists the remote tags on each period, with the credentials of the git-sync
+// container, and restarts git-sync when a higher tag matches. The resolved tag
+// is recorded in the repo volume, where the reconciler reads it.
+func enableRevisionConstraint(templateSpec *corev1.PodSpec) {
+	if !installGitSyncLauncher(templateSpec) {
+		return
+	}
+	for i := range templateSpec.Containers {
+		container := &templateSpec.Containers[i]
+		if container.Name != reconcilermanager.GitSync {
+			continue
+		}
+		// The launcher flags go before the `--` separating the git-sync
+		// command.
+		container.Command = slices.Insert(container.Command, 1,
+			"--revision-file="+path.Join(repoRoot, git.ResolvedRevisionFile),
+			"--error-file="+gitSyncErrorFile)
+	}
+}
@@ -17,7 +17,9 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"path"
 	"strconv"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -41,6 +43,12 @@ const (
 	GitSyncDepth = "GITSYNC_DEPTH"
 	// gitSyncPeriod represents the environment variable key for specifying the sync interval duration.
 	gitSyncPeriod = "GITSYNC_PERIOD"
+	// GitSyncSparseCheckoutFile represents the environment variable key for the file with the sparse checkout patterns.
+	GitSyncSparseCheckoutFile = "GITSYNC_SPARSE_CHECKOUT_FILE"
+	// gitSyncSubmodules represents the environment variable key for specifying how to fetch the Git submodules.
+	gitSyncSubmodules = "GITSYNC_SUBMODULES"
+	// gitSyncGitConfig represents the environment variable key for additional Git configs, as comma-separated key:value pairs.
+	gitSyncGitConfig = "GITSYNC_GIT_CONFIG"
 
 	// gitSyncSSH represents the environment variable key for specifying the SSH key to use.
 	gitSyncSSH = "GITSYNC_SSH"
@@ -83,6 +91,15 @@ const (
 	SyncDepthRev = "500"
 	// KnownHostsKey is the key for known_hosts information
 	KnownHostsKey = "known_hosts"
+	// lfsGitConfig configures Git to download the LFS objects on checkout.
+	// It requires git-lfs to be installed in the git-sync image, see
+	// docs/git-lfs.md.
+	lfsGitConfig = "filter.lfs.smudge:git-lfs smudge -- %f,filter.lfs.process:git-lfs filter-process,filter.lfs.required:true"
+	// partialCloneGitConfig configures Git to fetch from git-sync's origin
+	// remote as a partial clone, without the blobs. The blobs of the sparse
+	// checkout are fetched lazily on checkout, so the blobs outside of it are
+	// never downloaded. This is the equivalent of `git clone --filter=blob:none`.
+	partialCloneGitConfig = "remote.origin.promisor:true,remote.origin.partialclonefilter:blob:none"
 )
 
 var gceNodeAskpassURL = fmt.Sprintf("http://localhost:%v/git_askpass", gceNodeAskpassPort)
@@ -178,6 +195,13 @@ type options struct {
 	caCertSecretRef string
 	// knownHost specifies whether known_hosts configuration is included
 	knownHost bool
+	// sparseCheckout specifies whether to only check out the paths listed in
+	// the sparse checkout file.
+	sparseCheckout bool
+	// submodules specifies how to fetch the Git submodules.
+	submodules configsync.GitSubmodules
+	// lfs specifies whether to download the Git LFS objects.
+	lfs bool
 }
 
 // gitSyncTokenAuthEnv returns environment variables for git-sync container for 'token' Auth.
@@ -305,6 +329,33 @@ func gitSyncEnvs(_ context.Context, opts options) ([]corev1.EnvVar, error) {
 		Name:  gitSyncPeriod,
 		Value: opts.period.String(),
 	})
+	if opts.sparseCheckout {
+		// The file is projected from the Pod template annotation set by
+		// mountSparseCheckout.
+		result = append(result, corev1.EnvVar{
+			Name:  GitSyncSparseCheckoutFile,
+			Value: path.Join(SparseCheckoutPath, sparseCheckoutFile),
+		})
+	}
+	if opts.submodules != "" {
+		result = append(result, corev1.EnvVar{
+			Name:  gitSyncSubmodules,
+			Value: string(opts.submodules),
+		})
+	}
+	var gitConfigs []string
+	if opts.sparseCheckout {
+		gitConfigs = append(gitConfigs, partialCloneGitConfig)
+	}
+	if opts.lfs {
+		gitConfigs = append(gitConfigs, lfsGitConfig)
+	}
+	if len(gitConfigs) > 0 {
+		result = append(result, corev1.EnvVar{
+			Name:  gitSyncGitConfig,
+			Value: strings.Join(gitConfigs, ","),
+		})
+	}
 	// We can't use default values in git-sync because of the breaking change: https://github.com/kubernetes/git-sync/issues/841.
 	// For backward compatibility, we set gitSyncRef to branch when ref is HEAD.
 	// If ref is HEAD or empty,
@@ -15,10 +15,13 @@
 package controllers
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/core/k8sobjects"
 )
 
@@ -159,3 +162,62 @@ func TestGitSyncEnvVars(t *testing.T) {
 		})
 	}
 }
+
+func TestGitSyncEnvs_SubmodulesAndLFS(t *testing.T) {
+	testCases := map[string]struct {
+		submodules     configsync.GitSubmodules
+		lfs            bool
+		sparseCheckout bool
+		want           []corev1.EnvVar
+		notWant        []string
+	}{
+		"defaults": {
+			notWant: []string{gitSyncSubmodules, gitSyncGitConfig},
+		},
+		"submodules off": {
+			submodules: configsync.GitSubmodulesOff,
+			want:       []corev1.EnvVar{{Name: gitSyncSubmodules, Value: "off"}},
+			notWant:    []string{gitSyncGitConfig},
+		},
+		"shallow submodules with LFS": {
+			submodules: configsync.GitSubmodulesShallow,
+			lfs:        true,
+			want: []corev1.EnvVar{
+				{Name: gitSyncSubmodules, Value: "shallow"},
+				{Name: gitSyncGitConfig, Value: lfsGitConfig},
+			},
+		},
+		"sparse checkout": {
+			sparseCheckout: true,
+			want: []corev1.EnvVar{
+				{Name: GitSyncSparseCheckoutFile, Value: "/etc/sparse-checkout/sparse-checkout"},
+				{Name: gitSyncGitConfig, Value: partialCloneGitConfig},
+			},
+		},
+		"sparse checkout with LFS": {
+			sparseCheckout: true,
+			lfs:            true,
+			want: []corev1.EnvVar{
+				{Name: gitSyncGitConfig, Value: partialCloneGitConfig + "," + lfsGitConfig},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			envs, err := gitSyncEnvs(context.Background(), options{
+				repo:           "https://example.com/repo",
+				secretType:     configsync.AuthNone,
+				submodules:     tc.submodules,
+				lfs:            tc.lfs,
+				sparseCheckout: tc.sparseCheckout,
+			})
+			require.NoError(t, err)
+			for _, env := range tc.want {
+				assert.Contains(t, envs, env)
+			}
+			for _, env := range envs {
+				assert.NotContains(t, tc.notWant, env.Name)
+			}
+		})
+	}
+}
@@ -51,14 +51,43 @@ func getHelmConfigMapCopyRef(nsCMName string, rsRef types.NamespacedName) types.
 	}
 }
 
+// rootSyncValuesFileRefs returns the ValuesFileRefs of the Helm chart synced by
+// the RootSync, from spec.git.helm for a Git source, or from spec.helm.
+func rootSyncValuesFileRefs(rs *v1beta1.RootSync) []v1beta1.ValuesFileRef {
+	switch {
+	case rs.Spec.SourceType == configsync.GitSource:
+		if rs.Spec.Git == nil || rs.Spec.Git.Helm == nil {
+			return nil
+		}
+		return rs.Spec.Git.Helm.ValuesFileRefs
+	case rs.Spec.Helm != nil:
+		return rs.Spec.Helm.ValuesFileRefs
+	default:
+		return nil
+	}
+}
+
+// repoSyncValuesFileRefs returns the ValuesFileRefs of the Helm chart synced by
+// the RepoSync, from spec.git.helm for a Git source, or from spec.helm.
+func repoSyncValuesFileRefs(rs *v1beta1.RepoSync) []v1beta1.ValuesFileRef {
+	switch {
+	case rs.Spec.SourceType == configsync.GitSource:
+		if rs.Spec.Git == nil || rs.Spec.Git.Helm == nil {
+			return nil
+		}
+		return rs.Spec.Git.Helm.ValuesFileRefs
+	case rs.Spec.SourceType == configsync.HelmSource && rs.Spec.Helm != nil:
+		return rs.Spec.Helm.ValuesFileRefs
+	default:
+		return nil
+	}
+}
+
 // getReconcilerHelmConfigMapRefs returns a list of ValuesFileRefs with the
 // associated data key.
 func (r *RootSyncReconciler) getReconcilerHelmConfigMapRefs(rs *v1beta1.RootSync) []v1beta1.ValuesFileRef {
-	if rs.Spec.Helm == nil {
-		return nil
-	}
 	var cmsCMRefs []v1beta1.ValuesFileRef
-	for _, vfRef := range rs.Spec.Helm.ValuesFileRefs {
+	for _, vfRef := range rootSyncValuesFileRefs(rs) {
 		cmsCMRefs = append(cmsCMRefs, v1beta1.ValuesFileRef{
 			Name:    vfRef.Name,
 			DataKey: validate.HelmValuesFileDataKeyOrDefault(vfRef.DataKey),
@@ -71,11 +100,8 @@ func (r *RootSyncReconciler) getReconcilerHelmConfigMapRefs(rs *v1beta1.RootSync
 // names of the HelmValuesFile ConfigMap copies in the config-management-system
 // namespace with the associated data key.
 func (r *RepoSyncReconciler) getReconcilerHelmConfigMapRefs(rs *v1beta1.RepoSync) []v1beta1.ValuesFileRef {
-	if rs.Spec.Helm == nil {
-		return nil
-	}
 	var cmsCMRefs []v1beta1.ValuesFileRef
-	for _, vfRef := range rs.Spec.Helm.ValuesFileRefs {
+	for _, vfRef := range repoSyncValuesFileRefs(rs) {
 		copyCMRef := getHelmConfigMapCopyRef(vfRef.Name, client.ObjectKeyFromObject(rs))
 		cmsCMRefs = append(cmsCMRefs, v1beta1.ValuesFileRef{
 			Name:    copyCMRef.Name,
@@ -93,9 +119,9 @@ func (r *RepoSyncReconciler) getReconcilerHelmConfigMapRefs(rs *v1beta1.RepoSync
 func (r *RepoSyncReconciler) upsertHelmConfigMaps(ctx context.Context, rs *v1beta1.RepoSync, labelMap map[string]string) error {
 	rsRef := client.ObjectKeyFromObject(rs)
 	var cmNamesToKeep map[string]struct{}
-	if rs.Spec.SourceType == configsync.HelmSource && rs.Spec.Helm != nil {
-		cmNamesToKeep = make(map[string]struct{}, len(rs.Spec.Helm.ValuesFileRefs))
-		for _, vfRef := range rs.Spec.Helm.ValuesFileRefs {
+	if valuesFileRefs := repoSyncValuesFileRefs(rs); len(valuesFileRefs) > 0 {
+		cmNamesToKeep = make(map[string]struct{}, len(valuesFileRefs))
+		for _, vfRef := range valuesFileRefs {
 			userCMRef := types.NamespacedName{
 				Namespace: rsRef.Namespace,
 				Name:      vfRef.Name,
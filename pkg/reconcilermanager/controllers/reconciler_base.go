@@ -107,6 +107,7 @@ type reconcilerBase struct {
 	knownHostExist          bool
 	githubApp               githubAppSpec
 	webhookEnabled          bool
+	sourceWebhookEnabled    bool
 
 	// syncGVK is the GroupVersionKind of the sync object: RootSync or RepoSync.
 	syncGVK schema.GroupVersionKind
@@ -447,7 +448,7 @@ func (r *reconcilerBase) deployment(ctx context.Context, dRef client.ObjectKey)
 }
 
 // mountConfigMapValuesFiles mounts the helm values files from the referenced ConfigMaps as files in the helm-sync
-// container.
+// container, or in the hydration-controller container for a Helm chart stored in Git.
 func mountConfigMapValuesFiles(templateSpec *corev1.PodSpec, c *corev1.Container, valuesFileRefs []v1beta1.ValuesFileRef) {
 	var valuesFiles []string
 
@@ -579,6 +580,42 @@ func (r *reconcilerBase) validateCACertSecret(ctx context.Context, namespace, ca
 	return nil
 }
 
+// validateVerificationKeysSecret verifies that the Secret with the trusted
+// public keys exists and contains at least one key.
+func (r *reconcilerBase) validateVerificationKeysSecret(ctx context.Context, namespace, secretName string) error {
+	if secretName == "" {
+		return nil
+	}
+	secret, err := validateSecretExist(ctx, secretName, namespace, r.client)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("Secret %s not found, create one with the trusted public keys to allow source verification", secretName)
+		}
+		return fmt.Errorf("Secret %s get failed: %w", secretName, err)
+	}
+	if len(secret.Data) == 0 {
+		return fmt.Errorf("trustedKeysSecretRef was set, but no keys are present in %s Secret", secretName)
+	}
+	return nil
+}
+
+// validateArchiveSecret verifies that the Secret with the credentials of the
+// archive exists and contains the keys required by the auth type.
+func (r *reconcilerBase) validateArchiveSecret(ctx context.Context, namespace string, archive *v1beta1.Archive) error {
+	if SkipForAuth(archive.Auth) {
+		return nil
+	}
+	secretName := v1beta1.GetSecretName(archive.SecretRef)
+	secret, err := validateSecretExist(ctx, secretName, namespace, r.client)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("Secret %s not found: create one to allow client authentication", secretName)
+		}
+		return fmt.Errorf("Secret %s get failed: %w", secretName, err)
+	}
+	return validateArchiveSecretData(archive.Auth, secret)
+}
+
 // addTypeInformationToObject looks up and adds GVK to a runtime.Object based upon the loaded Scheme
 func (r *reconcilerBase) addTypeInformationToObject(obj runtime.Object) error {
 	gvk, err := kinds.Lookup(obj, r.scheme)
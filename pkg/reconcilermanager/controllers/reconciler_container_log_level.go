@@ -46,6 +46,10 @@ func ReconcilerContainerLogLevelDefaults() map[string]v1beta1.ContainerLogLevelO
 			ContainerName: reconcilermanager.HelmSync,
 			LogLevel:      0,
 		},
+		reconcilermanager.ArchiveSync: {
+			ContainerName: reconcilermanager.ArchiveSync,
+			LogLevel:      0,
+		},
 		reconcilermanager.GitSync: {
 			ContainerName: reconcilermanager.GitSync,
 			LogLevel:      5, // git-sync default is 5 so the logs will store git commands ran
@@ -48,6 +48,11 @@ func ReconcilerContainerResourceDefaults() map[string]v1beta1.ContainerResources
 			CPURequest:    resource.MustParse("75m"),
 			MemoryRequest: resource.MustParse("128Mi"),
 		},
+		reconcilermanager.ArchiveSync: {
+			ContainerName: reconcilermanager.ArchiveSync,
+			CPURequest:    resource.MustParse("25m"),
+			MemoryRequest: resource.MustParse("32Mi"),
+		},
 		reconcilermanager.GitSync: {
 			ContainerName: reconcilermanager.GitSync,
 			CPURequest:    resource.MustParse("10m"),
@@ -103,6 +108,13 @@ func ReconcilerContainerResourceDefaultsForAutopilot() map[string]v1beta1.Contai
 			MemoryRequest: resource.MustParse("384Mi"),
 			MemoryLimit:   resource.MustParse("384Mi"),
 		},
+		reconcilermanager.ArchiveSync: {
+			ContainerName: reconcilermanager.ArchiveSync,
+			CPURequest:    resource.MustParse("50m"),
+			CPULimit:      resource.MustParse("50m"),
+			MemoryRequest: resource.MustParse("64Mi"),
+			MemoryLimit:   resource.MustParse("64Mi"),
+		},
 		reconcilermanager.GitSync: {
 			ContainerName: reconcilermanager.GitSync,
 			CPURequest:    resource.MustParse("20m"),
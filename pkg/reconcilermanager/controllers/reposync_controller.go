@@ -236,7 +236,14 @@ func (r *RepoSyncReconciler) upsertManagedObjects(ctx context.Context, reconcile
 		return fmt.Errorf("upserting CA cert secret: %w", err)
 	}
 
-	if err := r.deleteSecrets(ctx, reconcilerRef, authSecret.Name, caSecret.Name); err != nil {
+	// Create secret in config-management-system namespace using the
+	// existing secret in the reposync.namespace.
+	verificationSecret, err := r.upsertVerificationKeysSecret(ctx, rs, reconcilerRef, labelMap)
+	if err != nil {
+		return fmt.Errorf("upserting verification keys secret: %w", err)
+	}
+
+	if err := r.deleteSecrets(ctx, reconcilerRef, authSecret.Name, caSecret.Name, verificationSecret.Name); err != nil {
 		return fmt.Errorf("garbage collecting secrets: %w", err)
 	}
 
@@ -253,6 +260,8 @@ func (r *RepoSyncReconciler) upsertManagedObjects(ctx context.Context, reconcile
 	case configsync.HelmSource:
 		auth = rs.Spec.Helm.Auth
 		gcpSAEmail = rs.Spec.Helm.GCPServiceAccountEmail
+	case configsync.ArchiveSource:
+		auth = rs.Spec.Archive.Auth
 	default:
 		// Should have been caught by validation
 		return fmt.Errorf("invalid source type: %s", rs.Spec.SourceType)
@@ -541,8 +550,7 @@ func (r *RepoSyncReconciler) Register(mgr controllerruntime.Manager, watchFleetM
 func (r *RepoSyncReconciler) watchConfigMaps(ctx context.Context, rs *v1beta1.RepoSync) error {
 	// We add watches dynamically at runtime based on the RepoSync namespace
 	// in order to avoid watching ConfigMaps in the entire cluster.
-	if rs == nil || rs.Spec.SourceType != configsync.HelmSource || rs.Spec.Helm == nil ||
-		len(rs.Spec.Helm.ValuesFileRefs) == 0 {
+	if rs == nil || len(repoSyncValuesFileRefs(rs)) == 0 {
 		// TODO: When it's available, we should remove unneeded watches from the controller
 		// when all RepoSyncs with ConfigMap references in a particular namespace are
 		// deleted (or are no longer referencing ConfigMaps).
@@ -598,6 +606,8 @@ func (r *RepoSyncReconciler) mapMembershipToRepoSyncs(ctx context.Context, obj c
 // RepoSync objects via the following fields:
 // - `spec.git.secretRef.name`
 // - `spec.git.caCertSecretRef.name`
+// - `spec.git.verification.trustedKeysSecretRef.name`
+// - `spec.oci.verification.trustedKeysSecretRef.name`
 // - `spec.helm.secretRef.name`
 // The update to the Secret object will trigger a reconciliation of the RepoSync objects.
 func (r *RepoSyncReconciler) mapSecretToRepoSyncs(ctx context.Context, secret client.Object) []reconcile.Request {
@@ -665,9 +675,9 @@ func (r *RepoSyncReconciler) mapSecretToRepoSyncs(ctx context.Context, secret cl
 	for _, rs := range attachedRepoSyncs.Items {
 		// Only enqueue a request for the RSync if it references the Secret that triggered the event
 		switch sRef.Name {
-		case repoSyncGitSecretName(&rs), repoSyncGitCACertSecretName(&rs),
-			repoSyncOCICACertSecretName(&rs), repoSyncHelmCACertSecretName(&rs),
-			repoSyncHelmSecretName(&rs):
+		case repoSyncGitSecretName(&rs), repoSyncGitCACertSecretName(&rs), repoSyncGitVerificationSecretName(&rs),
+			repoSyncOCICACertSecretName(&rs), repoSyncOCIVerificationSecretName(&rs),
+			repoSyncHelmCACertSecretName(&rs), repoSyncHelmSecretName(&rs), repoSyncHelmVerificationSecretName(&rs):
 			attachedRSNames = append(attachedRSNames, rs.GetName())
 			requests = append(requests, reconcile.Request{
 				NamespacedName: client.ObjectKeyFromObject(&rs),
@@ -714,6 +724,45 @@ func repoSyncGitCACertSecretName(rs *v1beta1.RepoSync) string {
 	return rs.Spec.Git.CACertSecretRef.Name
 }
 
+func repoSyncGitVerificationSecretName(rs *v1beta1.RepoSync) string {
+	if rs == nil {
+		return ""
+	}
+	if rs.Spec.Git == nil {
+		return ""
+	}
+	if rs.Spec.Git.Verification == nil {
+		return ""
+	}
+	return v1beta1.GetSecretName(rs.Spec.Git.Verification.TrustedKeysSecretRef)
+}
+
+func repoSyncOCIVerificationSecretName(rs *v1beta1.RepoSync) string {
+	if rs == nil {
+		return ""
+	}
+	if rs.Spec.Oci == nil {
+		return ""
+	}
+	if rs.Spec.Oci.Verification == nil {
+		return ""
+	}
+	return v1beta1.GetSecretName(rs.Spec.Oci.Verification.TrustedKeysSecretRef)
+}
+
+func repoSyncHelmVerificationSecretName(rs *v1beta1.RepoSync) string {
+	if rs == nil {
+		return ""
+	}
+	if rs.Spec.Helm == nil {
+		return ""
+	}
+	if rs.Spec.Helm.Verify == nil {
+		return ""
+	}
+	return v1beta1.GetSecretName(rs.Spec.Helm.Verify.KeyringSecretRef)
+}
+
 func repoSyncOCICACertSecretName(rs *v1beta1.RepoSync) string {
 	if rs == nil {
 		return ""
@@ -814,14 +863,12 @@ func repoSyncHelmValuesFileNames(rs *v1beta1.RepoSync) []string {
 	if rs == nil {
 		return nil
 	}
-	if rs.Spec.Helm == nil {
-		return nil
-	}
-	if rs.Spec.Helm.ValuesFileRefs == nil {
+	valuesFileRefs := repoSyncValuesFileRefs(rs)
+	if valuesFileRefs == nil {
 		return nil
 	}
-	names := make([]string, len(rs.Spec.Helm.ValuesFileRefs))
-	for i, ref := range rs.Spec.Helm.ValuesFileRefs {
+	names := make([]string, len(valuesFileRefs))
+	for i, ref := range valuesFileRefs {
 		names[i] = ref.Name
 	}
 	return names
@@ -894,9 +941,12 @@ func (r *RepoSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1be
 			sourceType:     rs.Spec.SourceType,
 			gitConfig:      rs.Spec.Git,
 			ociConfig:      rs.Spec.Oci,
+			archiveConfig:  rs.Spec.Archive,
 			scope:          declared.Scope(rs.Namespace),
 			reconcilerName: reconcilerName,
 			pollPeriod:     r.hydrationPollingPeriod.String(),
+			hermetic:       rs.Spec.SafeOverride().IsHermeticRendering(),
+			allowExec:      rs.Spec.SafeOverride().AllowsExecFunctions(),
 		}),
 		reconcilermanager.Reconciler: reconcilerEnvs(reconcilerOptions{
 			clusterName:       r.clusterName,
@@ -908,6 +958,7 @@ func (r *RepoSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1be
 			gitConfig:         rs.Spec.Git,
 			ociConfig:         rs.Spec.Oci,
 			helmConfig:        reposync.GetHelmBase(rs.Spec.Helm),
+			archiveConfig:     rs.Spec.Archive,
 			pollPeriod:        r.reconcilerPollingPeriod.String(),
 			statusMode:        metadata.StatusMode(rs.Spec.SafeOverride().StatusMode),
 			reconcileTimeout:  v1beta1.GetReconcileTimeout(rs.Spec.SafeOverride().ReconcileTimeout),
@@ -916,10 +967,18 @@ func (r *RepoSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1be
 			// Namespace reconciler doesn't support NamespaceSelector at all.
 			dynamicNSSelectorEnabled: false,
 			webhookEnabled:           r.webhookEnabled,
+			serverSideDryRun:         rs.Spec.SafeOverride().IsServerSideDryRun(),
+			schemaValidation:         rs.Spec.SafeOverride().IsSchemaValidation(),
+			driftReportMode:          rs.Spec.SafeOverride().IsDriftReportMode(),
+			revisionConstraint:       revisionConstraint(rs.Spec.SourceType, rs.Spec.Git),
 		}),
 	}
 
-	var err error
+	ignoreEnvs, err := ignoreDifferencesEnv(rs.Spec.SafeOverride().IgnoreDifferences)
+	if err != nil {
+		return nil, err
+	}
+	result[reconcilermanager.Reconciler] = append(result[reconcilermanager.Reconciler], ignoreEnvs...)
 
 	switch rs.Spec.SourceType {
 	case configsync.GitSource:
@@ -934,6 +993,9 @@ func (r *RepoSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1be
 			noSSLVerify:     rs.Spec.Git.NoSSLVerify,
 			caCertSecretRef: v1beta1.GetSecretName(rs.Spec.Git.CACertSecretRef),
 			knownHost:       r.isKnownHostsEnabled(rs.Spec.Git.Auth),
+			sparseCheckout:  sparseCheckoutPatterns(rs.Spec.Git, rs.Status.Source.Git) != "",
+			submodules:      rs.Spec.Git.Submodules,
+			lfs:             rs.Spec.Git.LFS,
 		})
 		if err != nil {
 			return nil, err
@@ -947,6 +1009,8 @@ func (r *RepoSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1be
 			auth:            rs.Spec.Oci.Auth,
 			period:          v1beta1.GetPeriod(rs.Spec.Oci.Period, configsync.DefaultReconcilerPollingPeriod).Seconds(),
 			caCertSecretRef: v1beta1.GetSecretName(rs.Spec.Oci.CACertSecretRef),
+			verification:    rs.Spec.Oci.Verification,
+			tagPolicy:       rs.Spec.Oci.TagPolicy,
 		})
 	case configsync.HelmSource:
 		result[reconcilermanager.HelmSync] = helmSyncEnvs(helmOptions{
@@ -956,6 +1020,14 @@ func (r *RepoSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1be
 			deployNamespace: "",
 			caCertSecretRef: v1beta1.GetSecretName(rs.Spec.Helm.CACertSecretRef),
 		})
+	case configsync.ArchiveSource:
+		result[reconcilermanager.ArchiveSync] = archiveSyncEnvs(archiveOptions{
+			url:             rs.Spec.Archive.URL,
+			auth:            rs.Spec.Archive.Auth,
+			region:          rs.Spec.Archive.Region,
+			period:          v1beta1.GetPeriod(rs.Spec.Archive.Period, configsync.DefaultReconcilerPollingPeriod).Seconds(),
+			caCertSecretRef: v1beta1.GetSecretName(rs.Spec.Archive.CACertSecretRef),
+		})
 	}
 	return result, nil
 }
@@ -1002,6 +1074,8 @@ func (r *RepoSyncReconciler) validateDependencies(ctx context.Context, rs *v1bet
 		return r.validateOciDependencies(ctx, rs)
 	case configsync.HelmSource:
 		return r.validateHelmDependencies(ctx, rs)
+	case configsync.ArchiveSource:
+		return r.validateArchiveDependencies(ctx, rs)
 	default:
 		return validate.InvalidSourceType(r.syncGVK.Kind)
 	}
@@ -1011,20 +1085,39 @@ func (r *RepoSyncReconciler) validateGitDependencies(ctx context.Context, rs *v1
 	if err := r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Git.CACertSecretRef)); err != nil {
 		return err
 	}
+	if err := r.validateVerificationKeysSecret(ctx, rs.Namespace, repoSyncGitVerificationSecretName(rs)); err != nil {
+		return err
+	}
+	if err := validate.ValuesFileRefs(ctx, r.client, r.syncGVK.Kind, rs.Namespace, repoSyncValuesFileRefs(rs)); err != nil {
+		return err
+	}
 	return r.validateNamespaceSecret(ctx, rs, reconcilerName)
 }
 
 func (r *RepoSyncReconciler) validateOciDependencies(ctx context.Context, rs *v1beta1.RepoSync) error {
-	return r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Oci.CACertSecretRef))
+	if err := r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Oci.CACertSecretRef)); err != nil {
+		return err
+	}
+	return r.validateVerificationKeysSecret(ctx, rs.Namespace, repoSyncOCIVerificationSecretName(rs))
 }
 
 func (r *RepoSyncReconciler) validateHelmDependencies(ctx context.Context, rs *v1beta1.RepoSync) error {
 	if err := r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Helm.CACertSecretRef)); err != nil {
 		return err
 	}
+	if err := r.validateVerificationKeysSecret(ctx, rs.Namespace, repoSyncHelmVerificationSecretName(rs)); err != nil {
+		return err
+	}
 	return validate.ValuesFileRefs(ctx, r.client, r.syncGVK.Kind, rs.Namespace, rs.Spec.Helm.ValuesFileRefs)
 }
 
+func (r *RepoSyncReconciler) validateArchiveDependencies(ctx context.Context, rs *v1beta1.RepoSync) error {
+	if err := r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Archive.CACertSecretRef)); err != nil {
+		return err
+	}
+	return r.validateArchiveSecret(ctx, rs.Namespace, rs.Spec.Archive)
+}
+
 // validateNamespaceSecret verify that any necessary Secret is present before creating ConfigMaps and Deployments.
 func (r *RepoSyncReconciler) validateNamespaceSecret(ctx context.Context, repoSync *v1beta1.RepoSync, reconcilerName string) error {
 	var authType configsync.AuthType
@@ -1168,6 +1261,10 @@ func (r *RepoSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RepoS
 			gcpSAEmail = rs.Spec.Helm.GCPServiceAccountEmail
 			secretRefName = v1beta1.GetSecretName(rs.Spec.Helm.SecretRef)
 			caCertSecretRefName = v1beta1.GetSecretName(rs.Spec.Helm.CACertSecretRef)
+		case configsync.ArchiveSource:
+			auth = rs.Spec.Archive.Auth
+			secretRefName = v1beta1.GetSecretName(rs.Spec.Archive.SecretRef)
+			caCertSecretRefName = v1beta1.GetSecretName(rs.Spec.Archive.CACertSecretRef)
 		}
 		injectFWICreds := useFWIAuth(auth, r.membership)
 		if injectFWICreds {
@@ -1199,6 +1296,10 @@ func (r *RepoSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RepoS
 		if useCACert(caCertSecretRefName) {
 			caCertSecretRefName = ReconcilerResourceName(reconcilerName, caCertSecretRefName)
 		}
+		var verificationSecretName string
+		if name := getVerificationKeysName(rs); name != "" {
+			verificationSecretName = ReconcilerResourceName(reconcilerName, name)
+		}
 		templateSpec.Volumes = filterVolumes(templateSpec.Volumes, auth, secretName, caCertSecretRefName, rs.Spec.SourceType, r.membership)
 
 		autopilot, err := r.isAutopilot()
@@ -1225,6 +1326,9 @@ func (r *RepoSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RepoS
 			switch container.Name {
 			case reconcilermanager.Reconciler:
 				container.Env = append(container.Env, containerEnvs[container.Name]...)
+				if rs.Spec.SourceType == configsync.GitSource && verificationSecretName != "" {
+					mountVerificationKeys(templateSpec, &container, verificationSecretName)
+				}
 			case reconcilermanager.HydrationController:
 				if !r.isAnnotationValueTrue(ctx, rs, metadata.RequiresRenderingAnnotationKey) {
 					// if the sync source does not require rendering, omit the hydration controller
@@ -1233,6 +1337,9 @@ func (r *RepoSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RepoS
 				} else {
 					container.Env = append(container.Env, containerEnvs[container.Name]...)
 					container.Image = updateHydrationControllerImage(container.Image, rs.Spec.SafeOverride().OverrideSpec)
+					if rs.Spec.SourceType == configsync.GitSource {
+						mountConfigMapValuesFiles(templateSpec, &container, r.getReconcilerHelmConfigMapRefs(rs))
+					}
 				}
 			case reconcilermanager.OciSync:
 				// Don't add the oci-sync container when sourceType is NOT oci.
@@ -1241,6 +1348,9 @@ func (r *RepoSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RepoS
 				} else {
 					container.Env = append(container.Env, containerEnvs[container.Name]...)
 					container.VolumeMounts = volumeMounts(rs.Spec.Oci.Auth, caCertSecretRefName, rs.Spec.SourceType, container.VolumeMounts)
+					if verificationSecretName != "" {
+						mountVerificationKeys(templateSpec, &container, verificationSecretName)
+					}
 					injectFWICredsToContainer(&container, injectFWICreds)
 				}
 			case reconcilermanager.HelmSync:
@@ -1254,8 +1364,20 @@ func (r *RepoSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RepoS
 						container.Env = append(container.Env, helmSyncTokenAuthEnv(secretName)...)
 					}
 					mountConfigMapValuesFiles(templateSpec, &container, r.getReconcilerHelmConfigMapRefs(rs))
+					if verificationSecretName != "" {
+						mountVerificationKeys(templateSpec, &container, verificationSecretName)
+					}
 					injectFWICredsToContainer(&container, injectFWICreds)
 				}
+			case reconcilermanager.ArchiveSync:
+				// Don't add the archive-sync container when sourceType is NOT archive.
+				if rs.Spec.SourceType != configsync.ArchiveSource {
+					addContainer = false
+				} else {
+					container.Env = append(container.Env, containerEnvs[container.Name]...)
+					container.VolumeMounts = volumeMounts(rs.Spec.Archive.Auth, caCertSecretRefName, rs.Spec.SourceType, container.VolumeMounts)
+					container.Env = append(container.Env, archiveSyncAuthEnv(rs.Spec.Archive.Auth, secretName)...)
+				}
 			case reconcilermanager.GitSync:
 				// Don't add the git-sync container when sourceType is NOT git.
 				if rs.Spec.SourceType != configsync.GitSource {
@@ -1273,6 +1395,9 @@ func (r *RepoSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RepoS
 					sRef := client.ObjectKey{Namespace: rs.Namespace, Name: v1beta1.GetSecretName(rs.Spec.SecretRef)}
 					keys := GetSecretKeys(ctx, r.client, sRef)
 					container.Env = append(container.Env, gitSyncHTTPSProxyEnv(secretName, keys)...)
+					if patterns := sparseCheckoutPatterns(rs.Spec.Git, rs.Status.Source.Git); patterns != "" {
+						mountSparseCheckout(&d.Spec.Template, &container, patterns)
+					}
 				}
 			case reconcilermanager.GCENodeAskpassSidecar:
 				if !EnableAskpassSidecar(rs.Spec.SourceType, auth) {
@@ -1298,6 +1423,12 @@ func (r *RepoSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RepoS
 		}
 
 		templateSpec.Containers = updatedContainers
+		if r.sourceWebhookEnabled {
+			enableSourceWebhook(templateSpec)
+		}
+		if revisionConstraint(rs.Spec.SourceType, rs.Spec.Git) != "" {
+			enableRevisionConstraint(templateSpec)
+		}
 		return nil
 	}
 }
@@ -4042,12 +4042,14 @@ func TestRepoSyncReconcileStaleClientCache(t *testing.T) {
 func TestPopulateRepoContainerEnvs(t *testing.T) {
 	defaults := map[string]map[string]string{
 		reconcilermanager.HydrationController: {
-			reconcilermanager.HydrationPollingPeriod: hydrationPollingPeriod.String(),
-			reconcilermanager.NamespaceNameKey:       reposyncNs,
-			reconcilermanager.ReconcilerNameKey:      nsReconcilerName,
-			reconcilermanager.ScopeKey:               reposyncNs,
-			reconcilermanager.SourceTypeKey:          string(gitSource),
-			reconcilermanager.SyncDirKey:             reposyncDir,
+			reconcilermanager.HydrationPollingPeriod:      hydrationPollingPeriod.String(),
+			reconcilermanager.RenderingHermetic:           "false",
+			reconcilermanager.RenderingAllowExecFunctions: "false",
+			reconcilermanager.NamespaceNameKey:            reposyncNs,
+			reconcilermanager.ReconcilerNameKey:           nsReconcilerName,
+			reconcilermanager.ScopeKey:                    reposyncNs,
+			reconcilermanager.SourceTypeKey:               string(gitSource),
+			reconcilermanager.SyncDirKey:                  reposyncDir,
 		},
 		reconcilermanager.Reconciler: {
 			reconcilermanager.ClusterNameKey:          testCluster,
@@ -0,0 +1,35 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/git"
+)
+
+// revisionConstraint returns the semantic version constraint of the Git
+// revision, or an empty string if the revision is not a constraint.
+//
+// The constraint is passed as is to git-sync, which runs under the
+// git-sync-launcher. The launcher resolves it on each period, with the
+// credentials of the git-sync container, so a new tag neither requires the
+// reconciler-manager to reach the repository, nor rolls out a new Pod.
+func revisionConstraint(sourceType configsync.SourceType, gitConfig *v1beta1.Git) string {
+	if sourceType != configsync.GitSource || gitConfig == nil || !git.IsRevisionConstraint(gitConfig.Revision) {
+		return ""
+	}
+	return gitConfig.Revision
+}
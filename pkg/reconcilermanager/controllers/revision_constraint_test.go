@@ -0,0 +1,83 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/git"
+	"kpt.dev/configsync/pkg/reconcilermanager"
+)
+
+func envValue(envs []corev1.EnvVar, name string) string {
+	for _, env := range envs {
+		if env.Name == name {
+			return env.Value
+		}
+	}
+	return ""
+}
+
+func TestRootSyncRevisionConstraint(t *testing.T) {
+	// Mock out parseDeployment for testing.
+	parseDeployment = parsedDeployment
+
+	// The constraint is resolved by the git-sync container, so the
+	// reconciler-manager does not need credentials to list the tags, whatever
+	// the auth type.
+	rs := rootSyncWithGit(rootsyncName, rootsyncRef(">=1.4.0 <2.0.0"),
+		rootsyncSecretType(configsync.AuthGCENode))
+	_, _, testReconciler := setupRootReconciler(t, rs)
+
+	envs, err := testReconciler.populateContainerEnvs(context.Background(), rs, rootReconcilerName)
+	require.NoError(t, err)
+	assert.Equal(t, ">=1.4.0 <2.0.0", envValue(envs[reconcilermanager.GitSync], gitSyncRef))
+	assert.Equal(t, ">=1.4.0 <2.0.0", envValue(envs[reconcilermanager.Reconciler], reconcilermanager.SourceRevConstraintKey))
+}
+
+func TestEnableRevisionConstraint(t *testing.T) {
+	spec := corev1.PodSpec{
+		Containers: []corev1.Container{
+			{Name: reconcilermanager.Reconciler, Image: "reconciler"},
+			{Name: reconcilermanager.GitSync, Image: "git-sync"},
+		},
+	}
+	enableRevisionConstraint(&spec)
+	// Installing the launcher again, for the source webhook, is a no-op.
+	enableSourceWebhook(&spec)
+
+	require.Len(t, spec.InitContainers, 1)
+	assert.Equal(t, launcherInstaller, spec.InitContainers[0].Name)
+	assert.Equal(t, "reconciler", spec.InitContainers[0].Image)
+	require.Len(t, spec.Volumes, 1)
+	assert.Equal(t, fetchTriggerVolume, spec.Volumes[0].Name)
+
+	gitSync := spec.Containers[1]
+	assert.Equal(t, []string{
+		"/fetch-trigger/git-sync-launcher",
+		"--revision-file=/repo/" + git.ResolvedRevisionFile,
+		"--error-file=/repo/source/error.json",
+		"--",
+		"/git-sync",
+	}, gitSync.Command)
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: fetchTriggerVolume, MountPath: git.FetchTriggerDir, ReadOnly: true},
+	}, gitSync.VolumeMounts)
+}
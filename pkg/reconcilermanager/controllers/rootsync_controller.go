@@ -206,6 +206,8 @@ func (r *RootSyncReconciler) upsertManagedObjects(ctx context.Context, reconcile
 	case configsync.HelmSource:
 		auth = rs.Spec.Helm.Auth
 		gcpSAEmail = rs.Spec.Helm.GCPServiceAccountEmail
+	case configsync.ArchiveSource:
+		auth = rs.Spec.Archive.Auth
 	default:
 		// Should have been caught by validation
 		return fmt.Errorf("invalid source type: %s", rs.Spec.SourceType)
@@ -546,14 +548,12 @@ func rootSyncHelmValuesFileNames(rs *v1beta1.RootSync) []string {
 	if rs == nil {
 		return nil
 	}
-	if rs.Spec.Helm == nil {
-		return nil
-	}
-	if rs.Spec.Helm.ValuesFileRefs == nil {
+	valuesFileRefs := rootSyncValuesFileRefs(rs)
+	if valuesFileRefs == nil {
 		return nil
 	}
-	names := make([]string, len(rs.Spec.Helm.ValuesFileRefs))
-	for i, ref := range rs.Spec.Helm.ValuesFileRefs {
+	names := make([]string, len(valuesFileRefs))
+	for i, ref := range valuesFileRefs {
 		names[i] = ref.Name
 	}
 	return names
@@ -640,6 +640,8 @@ func (r *RootSyncReconciler) mapAdmissionWebhookToRootSync(ctx context.Context,
 // RootSync objects via the following fields:
 // - `spec.git.secretRef.name`
 // - `spec.git.caCertSecretRef.name`
+// - `spec.git.verification.trustedKeysSecretRef.name`
+// - `spec.oci.verification.trustedKeysSecretRef.name`
 // - `spec.helm.secretRef.name`
 // The update to the Secret object will trigger a reconciliation of the RootSync objects.
 func (r *RootSyncReconciler) mapSecretToRootSyncs(ctx context.Context, secret client.Object) []reconcile.Request {
@@ -668,14 +670,18 @@ func (r *RootSyncReconciler) mapSecretToRootSyncs(ctx context.Context, secret cl
 	for _, rs := range attachedRootSyncs.Items {
 		// Only enqueue a request for the RSync if it references the Secret that triggered the event
 		switch sRef.Name {
-		case rootSyncGitSecretName(&rs), rootSyncGitCACertSecretName(&rs),
-			rootSyncOCICACertSecretName(&rs), rootSyncHelmCACertSecretName(&rs),
-			rootSyncHelmSecretName(&rs):
-			attachedRSNames = append(attachedRSNames, rs.GetName())
-			requests = append(requests, reconcile.Request{
-				NamespacedName: client.ObjectKeyFromObject(&rs),
-			})
+		case rootSyncGitSecretName(&rs), rootSyncGitCACertSecretName(&rs), rootSyncGitVerificationSecretName(&rs),
+			rootSyncOCICACertSecretName(&rs), rootSyncOCIVerificationSecretName(&rs),
+			rootSyncHelmCACertSecretName(&rs), rootSyncHelmSecretName(&rs), rootSyncHelmVerificationSecretName(&rs):
+		default:
+			if !slices.Contains(rootSyncAdditionalSourceSecretNames(&rs), sRef.Name) {
+				continue
+			}
 		}
+		attachedRSNames = append(attachedRSNames, rs.GetName())
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&rs),
+		})
 	}
 	if len(requests) > 0 {
 		r.Logger(ctx).Info(fmt.Sprintf("Changes to %s triggers a reconciliation for the RootSync objects: %s",
@@ -710,6 +716,45 @@ func rootSyncGitCACertSecretName(rs *v1beta1.RootSync) string {
 	return rs.Spec.Git.CACertSecretRef.Name
 }
 
+func rootSyncGitVerificationSecretName(rs *v1beta1.RootSync) string {
+	if rs == nil {
+		return ""
+	}
+	if rs.Spec.Git == nil {
+		return ""
+	}
+	if rs.Spec.Git.Verification == nil {
+		return ""
+	}
+	return v1beta1.GetSecretName(rs.Spec.Git.Verification.TrustedKeysSecretRef)
+}
+
+func rootSyncOCIVerificationSecretName(rs *v1beta1.RootSync) string {
+	if rs == nil {
+		return ""
+	}
+	if rs.Spec.Oci == nil {
+		return ""
+	}
+	if rs.Spec.Oci.Verification == nil {
+		return ""
+	}
+	return v1beta1.GetSecretName(rs.Spec.Oci.Verification.TrustedKeysSecretRef)
+}
+
+func rootSyncHelmVerificationSecretName(rs *v1beta1.RootSync) string {
+	if rs == nil {
+		return ""
+	}
+	if rs.Spec.Helm == nil {
+		return ""
+	}
+	if rs.Spec.Helm.Verify == nil {
+		return ""
+	}
+	return v1beta1.GetSecretName(rs.Spec.Helm.Verify.KeyringSecretRef)
+}
+
 func rootSyncOCICACertSecretName(rs *v1beta1.RootSync) string {
 	if rs == nil {
 		return ""
@@ -755,9 +800,12 @@ func (r *RootSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1be
 			sourceType:     rs.Spec.SourceType,
 			gitConfig:      rs.Spec.Git,
 			ociConfig:      rs.Spec.Oci,
+			archiveConfig:  rs.Spec.Archive,
 			scope:          declared.RootScope,
 			reconcilerName: reconcilerName,
 			pollPeriod:     r.hydrationPollingPeriod.String(),
+			hermetic:       rs.Spec.SafeOverride().IsHermeticRendering(),
+			allowExec:      rs.Spec.SafeOverride().AllowsExecFunctions(),
 		}),
 		reconcilermanager.Reconciler: append(
 			reconcilerEnvs(reconcilerOptions{
@@ -770,6 +818,7 @@ func (r *RootSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1be
 				gitConfig:                rs.Spec.Git,
 				ociConfig:                rs.Spec.Oci,
 				helmConfig:               rootsync.GetHelmBase(rs.Spec.Helm),
+				archiveConfig:            rs.Spec.Archive,
 				pollPeriod:               r.reconcilerPollingPeriod.String(),
 				statusMode:               metadata.StatusMode(rs.Spec.SafeOverride().StatusMode),
 				reconcileTimeout:         v1beta1.GetReconcileTimeout(rs.Spec.SafeOverride().ReconcileTimeout),
@@ -777,13 +826,33 @@ func (r *RootSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1be
 				requiresRendering:        r.isAnnotationValueTrue(ctx, rs, metadata.RequiresRenderingAnnotationKey),
 				dynamicNSSelectorEnabled: r.isAnnotationValueTrue(ctx, rs, metadata.DynamicNSSelectorEnabledAnnotationKey),
 				webhookEnabled:           r.webhookEnabled,
+				serverSideDryRun:         rs.Spec.SafeOverride().IsServerSideDryRun(),
+				schemaValidation:         rs.Spec.SafeOverride().IsSchemaValidation(),
+				driftReportMode:          rs.Spec.SafeOverride().IsDriftReportMode(),
+				revisionConstraint:       revisionConstraint(rs.Spec.SourceType, rs.Spec.Git),
 			}),
 			sourceFormatEnv(rs.Spec.SourceFormat),
 			namespaceStrategyEnv(rs.Spec.SafeOverride().NamespaceStrategy),
 		),
 	}
 
-	var err error
+	sourcesEnv, err := additionalSourcesEnv(rs.Spec.Sources)
+	if err != nil {
+		return nil, err
+	}
+	result[reconcilermanager.Reconciler] = append(result[reconcilermanager.Reconciler], sourcesEnv...)
+
+	rolloutEnvs, err := rolloutEnv(rs.Spec.Rollout)
+	if err != nil {
+		return nil, err
+	}
+	result[reconcilermanager.Reconciler] = append(result[reconcilermanager.Reconciler], rolloutEnvs...)
+
+	ignoreEnvs, err := ignoreDifferencesEnv(rs.Spec.SafeOverride().IgnoreDifferences)
+	if err != nil {
+		return nil, err
+	}
+	result[reconcilermanager.Reconciler] = append(result[reconcilermanager.Reconciler], ignoreEnvs...)
 
 	switch rs.Spec.SourceType {
 	case configsync.GitSource:
@@ -798,6 +867,9 @@ func (r *RootSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1be
 			noSSLVerify:     rs.Spec.Git.NoSSLVerify,
 			caCertSecretRef: v1beta1.GetSecretName(rs.Spec.Git.CACertSecretRef),
 			knownHost:       r.isKnownHostsEnabled(rs.Spec.Git.Auth),
+			sparseCheckout:  sparseCheckoutPatterns(rs.Spec.Git, rs.Status.Source.Git) != "",
+			submodules:      rs.Spec.Git.Submodules,
+			lfs:             rs.Spec.Git.LFS,
 		})
 		if err != nil {
 			return nil, err
@@ -811,6 +883,8 @@ func (r *RootSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1be
 			auth:            rs.Spec.Oci.Auth,
 			period:          v1beta1.GetPeriod(rs.Spec.Oci.Period, configsync.DefaultReconcilerPollingPeriod).Seconds(),
 			caCertSecretRef: v1beta1.GetSecretName(rs.Spec.Oci.CACertSecretRef),
+			verification:    rs.Spec.Oci.Verification,
+			tagPolicy:       rs.Spec.Oci.TagPolicy,
 		})
 	case configsync.HelmSource:
 		result[reconcilermanager.HelmSync] = helmSyncEnvs(helmOptions{
@@ -819,6 +893,14 @@ func (r *RootSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1be
 			deployNamespace:  rs.Spec.Helm.DeployNamespace,
 			caCertSecretRef:  v1beta1.GetSecretName(rs.Spec.Helm.CACertSecretRef),
 		})
+	case configsync.ArchiveSource:
+		result[reconcilermanager.ArchiveSync] = archiveSyncEnvs(archiveOptions{
+			url:             rs.Spec.Archive.URL,
+			auth:            rs.Spec.Archive.Auth,
+			region:          rs.Spec.Archive.Region,
+			period:          v1beta1.GetPeriod(rs.Spec.Archive.Period, configsync.DefaultReconcilerPollingPeriod).Seconds(),
+			caCertSecretRef: v1beta1.GetSecretName(rs.Spec.Archive.CACertSecretRef),
+		})
 	}
 	return result, nil
 }
@@ -854,7 +936,10 @@ func (r *RootSyncReconciler) validateRootSync(ctx context.Context, rs *v1beta1.R
 		return err
 	}
 
-	return r.validateDependencies(ctx, rs)
+	if err := r.validateDependencies(ctx, rs); err != nil {
+		return err
+	}
+	return r.validateAdditionalSourceDependencies(ctx, rs)
 }
 
 func (r *RootSyncReconciler) validateDependencies(ctx context.Context, rs *v1beta1.RootSync) error {
@@ -865,6 +950,8 @@ func (r *RootSyncReconciler) validateDependencies(ctx context.Context, rs *v1bet
 		return r.validateOciDependencies(ctx, rs)
 	case configsync.HelmSource:
 		return r.validateHelmDependencies(ctx, rs)
+	case configsync.ArchiveSource:
+		return r.validateArchiveDependencies(ctx, rs)
 	default:
 		return validate.InvalidSourceType(r.syncGVK.Kind)
 	}
@@ -874,20 +961,39 @@ func (r *RootSyncReconciler) validateGitDependencies(ctx context.Context, rs *v1
 	if err := r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Git.CACertSecretRef)); err != nil {
 		return err
 	}
+	if err := r.validateVerificationKeysSecret(ctx, rs.Namespace, rootSyncGitVerificationSecretName(rs)); err != nil {
+		return err
+	}
+	if err := validate.ValuesFileRefs(ctx, r.client, r.syncGVK.Kind, rs.Namespace, rootSyncValuesFileRefs(rs)); err != nil {
+		return err
+	}
 	return r.validateRootSecret(ctx, rs)
 }
 
 func (r *RootSyncReconciler) validateOciDependencies(ctx context.Context, rs *v1beta1.RootSync) error {
-	return r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Oci.CACertSecretRef))
+	if err := r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Oci.CACertSecretRef)); err != nil {
+		return err
+	}
+	return r.validateVerificationKeysSecret(ctx, rs.Namespace, rootSyncOCIVerificationSecretName(rs))
 }
 
 func (r *RootSyncReconciler) validateHelmDependencies(ctx context.Context, rs *v1beta1.RootSync) error {
 	if err := r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Helm.CACertSecretRef)); err != nil {
 		return err
 	}
+	if err := r.validateVerificationKeysSecret(ctx, rs.Namespace, rootSyncHelmVerificationSecretName(rs)); err != nil {
+		return err
+	}
 	return validate.ValuesFileRefs(ctx, r.client, r.syncGVK.Kind, rs.Namespace, rs.Spec.Helm.ValuesFileRefs)
 }
 
+func (r *RootSyncReconciler) validateArchiveDependencies(ctx context.Context, rs *v1beta1.RootSync) error {
+	if err := r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Archive.CACertSecretRef)); err != nil {
+		return err
+	}
+	return r.validateArchiveSecret(ctx, rs.Namespace, rs.Spec.Archive)
+}
+
 // validateRootSecret verify that any necessary Secret is present before creating ConfigMaps and Deployments.
 func (r *RootSyncReconciler) validateRootSecret(ctx context.Context, rootSync *v1beta1.RootSync) error {
 	if SkipForAuth(rootSync.Spec.Auth) {
@@ -1140,6 +1246,10 @@ func (r *RootSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RootS
 			gcpSAEmail = rs.Spec.Helm.GCPServiceAccountEmail
 			secretRefName = v1beta1.GetSecretName(rs.Spec.Helm.SecretRef)
 			caCertSecretRefName = v1beta1.GetSecretName(rs.Spec.Helm.CACertSecretRef)
+		case configsync.ArchiveSource:
+			auth = rs.Spec.Archive.Auth
+			secretRefName = v1beta1.GetSecretName(rs.Spec.Archive.SecretRef)
+			caCertSecretRefName = v1beta1.GetSecretName(rs.Spec.Archive.CACertSecretRef)
 		}
 		injectFWICreds := useFWIAuth(auth, r.membership)
 		if injectFWICreds {
@@ -1169,6 +1279,7 @@ func (r *RootSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RootS
 		// Secret reference is the name of the secret used by git-sync or helm-sync container to
 		// authenticate with the git or helm repository using the authorization method specified
 		// in the RootSync CR.
+		templateVolumes := templateSpec.Volumes
 		templateSpec.Volumes = filterVolumes(templateSpec.Volumes, auth, secretRefName, caCertSecretRefName, rs.Spec.SourceType, r.membership)
 
 		autopilot, err := r.isAutopilot()
@@ -1194,6 +1305,10 @@ func (r *RootSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RootS
 			switch container.Name {
 			case reconcilermanager.Reconciler:
 				container.Env = append(container.Env, containerEnvs[container.Name]...)
+				if name := rootSyncGitVerificationSecretName(rs); rs.Spec.SourceType == configsync.GitSource && name != "" {
+					mountVerificationKeys(templateSpec, &container, name)
+				}
+				mountAdditionalSourcesVerificationKeys(templateSpec, &container, rs.Spec.Sources)
 			case reconcilermanager.HydrationController:
 				if !r.isAnnotationValueTrue(ctx, rs, metadata.RequiresRenderingAnnotationKey) {
 					// if the sync source does not require rendering, omit the hydration controller
@@ -1202,6 +1317,9 @@ func (r *RootSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RootS
 				} else {
 					container.Env = append(container.Env, containerEnvs[container.Name]...)
 					container.Image = updateHydrationControllerImage(container.Image, rs.Spec.SafeOverride().OverrideSpec)
+					if rs.Spec.SourceType == configsync.GitSource {
+						mountConfigMapValuesFiles(templateSpec, &container, r.getReconcilerHelmConfigMapRefs(rs))
+					}
 				}
 			case reconcilermanager.OciSync:
 				// Don't add the oci-sync container when sourceType is NOT oci.
@@ -1210,6 +1328,9 @@ func (r *RootSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RootS
 				} else {
 					container.Env = append(container.Env, containerEnvs[container.Name]...)
 					container.VolumeMounts = volumeMounts(rs.Spec.Oci.Auth, caCertSecretRefName, rs.Spec.SourceType, container.VolumeMounts)
+					if name := rootSyncOCIVerificationSecretName(rs); name != "" {
+						mountVerificationKeys(templateSpec, &container, name)
+					}
 					injectFWICredsToContainer(&container, injectFWICreds)
 				}
 			case reconcilermanager.HelmSync:
@@ -1223,8 +1344,20 @@ func (r *RootSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RootS
 						container.Env = append(container.Env, helmSyncTokenAuthEnv(secretRefName)...)
 					}
 					mountConfigMapValuesFiles(templateSpec, &container, r.getReconcilerHelmConfigMapRefs(rs))
+					if name := rootSyncHelmVerificationSecretName(rs); name != "" {
+						mountVerificationKeys(templateSpec, &container, name)
+					}
 					injectFWICredsToContainer(&container, injectFWICreds)
 				}
+			case reconcilermanager.ArchiveSync:
+				// Don't add the archive-sync container when sourceType is NOT archive.
+				if rs.Spec.SourceType != configsync.ArchiveSource {
+					addContainer = false
+				} else {
+					container.Env = append(container.Env, containerEnvs[container.Name]...)
+					container.VolumeMounts = volumeMounts(rs.Spec.Archive.Auth, caCertSecretRefName, rs.Spec.SourceType, container.VolumeMounts)
+					container.Env = append(container.Env, archiveSyncAuthEnv(rs.Spec.Archive.Auth, secretRefName)...)
+				}
 			case reconcilermanager.GitSync:
 				// Don't add the git-sync container when sourceType is NOT git.
 				if rs.Spec.SourceType != configsync.GitSource {
@@ -1243,6 +1376,9 @@ func (r *RootSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RootS
 					sRef := client.ObjectKey{Namespace: rs.Namespace, Name: secretName}
 					keys := GetSecretKeys(ctx, r.client, sRef)
 					container.Env = append(container.Env, gitSyncHTTPSProxyEnv(secretName, keys)...)
+					if patterns := sparseCheckoutPatterns(rs.Spec.Git, rs.Status.Source.Git); patterns != "" {
+						mountSparseCheckout(&d.Spec.Template, &container, patterns)
+					}
 				}
 			case reconcilermanager.GCENodeAskpassSidecar:
 				if !EnableAskpassSidecar(rs.Spec.SourceType, auth) {
@@ -1267,7 +1403,18 @@ func (r *RootSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RootS
 			}
 		}
 
-		templateSpec.Containers = updatedContainers
+		sourceContainers, err := r.additionalSourceContainers(ctx, rs, templateSpec, templateSpec.Containers, templateVolumes,
+			containerResources, containerLogLevels)
+		if err != nil {
+			return err
+		}
+		templateSpec.Containers = append(updatedContainers, sourceContainers...)
+		if r.sourceWebhookEnabled {
+			enableSourceWebhook(templateSpec)
+		}
+		if revisionConstraint(rs.Spec.SourceType, rs.Spec.Git) != "" {
+			enableRevisionConstraint(templateSpec)
+		}
 		return nil
 	}
 }
@@ -293,6 +293,48 @@ func rootsyncOverrideAPIServerTimeout(apiServerTimout metav1.Duration) func(*v1b
 	}
 }
 
+func rootsyncOverrideHermeticRendering(hermetic bool) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().Rendering = &v1beta1.RenderingOverride{Hermetic: &hermetic}
+	}
+}
+
+func rootsyncOverrideAllowExecFunctions(allowExec bool) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().Rendering = &v1beta1.RenderingOverride{AllowExecFunctions: &allowExec}
+	}
+}
+
+func rootsyncOverrideServerSideDryRun(dryRun bool) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().ServerSideDryRun = &dryRun
+	}
+}
+
+func rootsyncOverrideSchemaValidation(enabled bool) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().SchemaValidation = &enabled
+	}
+}
+
+func rootsyncOverrideDriftMode(mode configsync.DriftMode) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().DriftMode = mode
+	}
+}
+
+func rootsyncOverrideIgnoreDifferences(ignores ...v1beta1.IgnoreDifference) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SafeOverride().IgnoreDifferences = ignores
+	}
+}
+
+func rootsyncRollout(rollout *v1beta1.RolloutSpec) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.Rollout = rollout
+	}
+}
+
 func rootsyncOverrideRoleRefs(roleRefs ...v1beta1.RootSyncRoleRef) func(*v1beta1.RootSync) {
 	return func(rs *v1beta1.RootSync) {
 		rs.Spec.SafeOverride().RoleRefs = roleRefs
@@ -318,6 +360,14 @@ func rootsyncCACert(sourceType configsync.SourceType, caCertSecretRef string) fu
 	}
 }
 
+func rootsyncVerification(trustedKeysSecretRef string) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.Git.Verification = &v1beta1.GitVerification{
+			TrustedKeysSecretRef: &v1beta1.SecretReference{Name: trustedKeysSecretRef},
+		}
+	}
+}
+
 func rootsyncRenderingRequired(renderingRequired bool) func(*v1beta1.RootSync) {
 	return func(rs *v1beta1.RootSync) {
 		val := strconv.FormatBool(renderingRequired)
@@ -996,6 +1046,45 @@ func TestRootSyncCreateWithCACertSecret(t *testing.T) {
 	t.Log("Deployment successfully created")
 }
 
+func TestRootSyncCreateWithVerificationKeysSecret(t *testing.T) {
+	// Mock out parseDeployment for testing.
+	parseDeployment = parsedDeployment
+	trustedKeysSecret := "trusted-keys"
+	rs := rootSyncWithGit(rootsyncName, rootsyncRef(gitRevision), rootsyncBranch(branch),
+		rootsyncSecretType(configsync.AuthNone), rootsyncVerification(trustedKeysSecret))
+	reqNamespacedName := namespacedName(rs.Name, rs.Namespace)
+	keysSecret := k8sobjects.SecretObject(trustedKeysSecret, core.Namespace(rs.Namespace))
+	keysSecret.Data = map[string][]byte{"ssh": []byte("ssh-ed25519 AAAA")}
+	fakeClient, fakeDynamicClient, testReconciler := setupRootReconciler(t, rs, keysSecret)
+
+	// Test creating Deployment resources.
+	ctx := context.Background()
+	if _, err := testReconciler.Reconcile(ctx, reqNamespacedName); err != nil {
+		t.Fatalf("unexpected reconciliation error, got error: %q, want error: nil", err)
+	}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rs), rs); err != nil {
+		t.Fatalf("failed to get the root sync: %v", err)
+	}
+
+	rootContainerEnvs, err := testReconciler.populateContainerEnvs(ctx, rs, rootReconcilerName)
+	require.NoError(t, err)
+	resourceOverrides := setContainerResourceDefaults(nil, ReconcilerContainerResourceDefaults())
+	rootDeployment := rootSyncDeployment(rootReconcilerName,
+		setServiceAccountName(rootReconcilerName),
+		containersWithRepoVolumeMutator(noneGitContainers()),
+		containerResourcesMutator(resourceOverrides),
+		containerEnvMutator(rootContainerEnvs),
+		verificationKeysMutator(trustedKeysSecret),
+		setUID("1"), setResourceVersion("1"), setGeneration(1),
+	)
+	wantDeployments := map[core.ID]*appsv1.Deployment{core.IDOf(rootDeployment): rootDeployment}
+
+	if err := validateDeployments(wantDeployments, fakeDynamicClient); err != nil {
+		t.Errorf("Deployment validation failed. err: %v", err)
+	}
+	t.Log("Deployment successfully created")
+}
+
 func TestRootSyncUpdateCACertSecret(t *testing.T) {
 	// Mock out parseDeployment for testing.
 	parseDeployment = parsedDeployment
@@ -3917,12 +4006,14 @@ func TestRootSyncReconcileStaleClientCache(t *testing.T) {
 func TestPopulateRootContainerEnvs(t *testing.T) {
 	defaults := map[string]map[string]string{
 		reconcilermanager.HydrationController: {
-			reconcilermanager.HydrationPollingPeriod: hydrationPollingPeriod.String(),
-			reconcilermanager.NamespaceNameKey:       ":root",
-			reconcilermanager.ReconcilerNameKey:      rootReconcilerName,
-			reconcilermanager.ScopeKey:               ":root",
-			reconcilermanager.SourceTypeKey:          string(gitSource),
-			reconcilermanager.SyncDirKey:             rootsyncDir,
+			reconcilermanager.HydrationPollingPeriod:      hydrationPollingPeriod.String(),
+			reconcilermanager.RenderingHermetic:           "false",
+			reconcilermanager.RenderingAllowExecFunctions: "false",
+			reconcilermanager.NamespaceNameKey:            ":root",
+			reconcilermanager.ReconcilerNameKey:           rootReconcilerName,
+			reconcilermanager.ScopeKey:                    ":root",
+			reconcilermanager.SourceTypeKey:               string(gitSource),
+			reconcilermanager.SyncDirKey:                  rootsyncDir,
 		},
 		reconcilermanager.Reconciler: {
 			reconcilermanager.ClusterNameKey:          testCluster,
@@ -4004,6 +4095,87 @@ func TestPopulateRootContainerEnvs(t *testing.T) {
 				reconcilermanager.Reconciler: {reconcilermanager.APIServerTimeout: "40s"},
 			}),
 		},
+		{
+			name: "hermetic rendering override sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncOverrideHermeticRendering(true),
+				rootsyncRenderingRequired(false),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.HydrationController: {reconcilermanager.RenderingHermetic: "true"},
+			}),
+		},
+		{
+			name: "exec functions override sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncOverrideAllowExecFunctions(true),
+				rootsyncRenderingRequired(false),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.HydrationController: {reconcilermanager.RenderingAllowExecFunctions: "true"},
+			}),
+		},
+		{
+			name: "server-side dry-run override sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncOverrideServerSideDryRun(true),
+				rootsyncRenderingRequired(false),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.Reconciler: {reconcilermanager.ServerSideDryRun: "true"},
+			}),
+		},
+		{
+			name: "schema validation override sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncOverrideSchemaValidation(true),
+				rootsyncRenderingRequired(false),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.Reconciler: {reconcilermanager.SchemaValidation: "true"},
+			}),
+		},
+		{
+			name: "drift report mode sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncOverrideDriftMode(configsync.DriftModeReport),
+				rootsyncRenderingRequired(false),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.Reconciler: {reconcilermanager.DriftReportMode: "true"},
+			}),
+		},
+		{
+			name: "ignore differences sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncOverrideIgnoreDifferences(v1beta1.IgnoreDifference{
+					Group:     "apps",
+					Kind:      "Deployment",
+					JSONPaths: []string{".spec.replicas"},
+				}),
+				rootsyncRenderingRequired(false),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.Reconciler: {
+					reconcilermanager.IgnoreDifferencesKey: `[{"group":"apps","kind":"Deployment","jsonPaths":[".spec.replicas"]}]`,
+				},
+			}),
+		},
+		{
+			name: "rollout sets env var",
+			rootSync: rootSyncWithGit(rootsyncName,
+				rootsyncRollout(&v1beta1.RolloutSpec{
+					CanaryGroup:  "canary",
+					ConfigMapRef: &v1beta1.RolloutConfigMapRef{Name: "rollout", Namespace: "fleet"},
+				}),
+				rootsyncRenderingRequired(false),
+			),
+			expected: createEnv(map[string]map[string]string{
+				reconcilermanager.Reconciler: {
+					reconcilermanager.RolloutKey: `{"canaryGroup":"canary","configMapRef":{"name":"rollout","namespace":"fleet"}}`,
+				},
+			}),
+		},
 		{
 			name: "rendering-required annotation sets env var",
 			rootSync: rootSyncWithGit(rootsyncName,
@@ -4619,6 +4791,17 @@ func caCertSecretMutator(secretName, caCertSecretName string) depMutator {
 	}
 }
 
+func verificationKeysMutator(secretName string) depMutator {
+	return func(dep *appsv1.Deployment) {
+		templateSpec := &dep.Spec.Template.Spec
+		for i, con := range templateSpec.Containers {
+			if con.Name == reconcilermanager.Reconciler {
+				mountVerificationKeys(templateSpec, &templateSpec.Containers[i], secretName)
+			}
+		}
+	}
+}
+
 func envVarMutator(envName, secretName, key string) depMutator {
 	return func(dep *appsv1.Deployment) {
 		for i, con := range dep.Spec.Template.Spec.Containers {
@@ -35,12 +35,18 @@ func isUpsertedSecret(rs *v1beta1.RepoSync, secretName string) bool {
 	if name, ok := getCACertName(rs); ok && useCACert(name) && secretName == ReconcilerResourceName(reconcilerName, name) {
 		return true
 	}
+	if name := getVerificationKeysName(rs); name != "" && secretName == ReconcilerResourceName(reconcilerName, name) {
+		return true
+	}
 	if shouldUpsertGitSecret(rs) && secretName == ReconcilerResourceName(reconcilerName, v1beta1.GetSecretName(rs.Spec.Git.SecretRef)) {
 		return true
 	}
 	if shouldUpsertHelmSecret(rs) && secretName == ReconcilerResourceName(reconcilerName, v1beta1.GetSecretName(rs.Spec.Helm.SecretRef)) {
 		return true
 	}
+	if shouldUpsertArchiveSecret(rs) && secretName == ReconcilerResourceName(reconcilerName, v1beta1.GetSecretName(rs.Spec.Archive.SecretRef)) {
+		return true
+	}
 	return false
 }
 
@@ -61,11 +67,41 @@ func getCACertName(rs *v1beta1.RepoSync) (string, bool) {
 			return "", false
 		}
 		return v1beta1.GetSecretName(rs.Spec.Helm.CACertSecretRef), true
+	case configsync.ArchiveSource:
+		if rs.Spec.Archive == nil || rs.Spec.Archive.CACertSecretRef == nil {
+			return "", false
+		}
+		return v1beta1.GetSecretName(rs.Spec.Archive.CACertSecretRef), true
 	default:
 		return "", false
 	}
 }
 
+// getVerificationKeysName returns the name of the Secret with the trusted
+// public keys used to verify the source, or an empty string if verification is
+// disabled.
+func getVerificationKeysName(rs *v1beta1.RepoSync) string {
+	switch rs.Spec.SourceType {
+	case configsync.GitSource:
+		if rs.Spec.Git == nil || rs.Spec.Git.Verification == nil {
+			return ""
+		}
+		return v1beta1.GetSecretName(rs.Spec.Git.Verification.TrustedKeysSecretRef)
+	case configsync.OciSource:
+		if rs.Spec.Oci == nil || rs.Spec.Oci.Verification == nil {
+			return ""
+		}
+		return v1beta1.GetSecretName(rs.Spec.Oci.Verification.TrustedKeysSecretRef)
+	case configsync.HelmSource:
+		if rs.Spec.Helm == nil || rs.Spec.Helm.Verify == nil {
+			return ""
+		}
+		return v1beta1.GetSecretName(rs.Spec.Helm.Verify.KeyringSecretRef)
+	default:
+		return ""
+	}
+}
+
 func shouldUpsertGitSecret(rs *v1beta1.RepoSync) bool {
 	return rs.Spec.SourceType == configsync.GitSource && rs.Spec.Git != nil && rs.Spec.Git.SecretRef != nil && !SkipForAuth(rs.Spec.Auth)
 }
@@ -74,6 +110,10 @@ func shouldUpsertHelmSecret(rs *v1beta1.RepoSync) bool {
 	return rs.Spec.SourceType == configsync.HelmSource && rs.Spec.Helm != nil && rs.Spec.Helm.SecretRef != nil && !SkipForAuth(rs.Spec.Helm.Auth)
 }
 
+func shouldUpsertArchiveSecret(rs *v1beta1.RepoSync) bool {
+	return rs.Spec.SourceType == configsync.ArchiveSource && rs.Spec.Archive != nil && rs.Spec.Archive.SecretRef != nil && !SkipForAuth(rs.Spec.Archive.Auth)
+}
+
 // upsertAuthSecret creates or updates the auth secret in the
 // config-management-system namespace using an existing secret in the RepoSync
 // namespace.
@@ -96,6 +136,14 @@ func (r *reconcilerBase) upsertAuthSecret(ctx context.Context, rs *v1beta1.RepoS
 		}
 		_, err = r.upsertSecret(ctx, cmsSecretRef, userSecret, labelMap)
 		return cmsSecretRef, err
+	case shouldUpsertArchiveSecret(rs):
+		nsSecretRef, cmsSecretRef := getSecretRefs(rsRef, reconcilerRef, v1beta1.GetSecretName(rs.Spec.Archive.SecretRef))
+		userSecret, err := getUserSecret(ctx, r.client, nsSecretRef)
+		if err != nil {
+			return cmsSecretRef, fmt.Errorf("user secret required for archive client authentication: %w", err)
+		}
+		_, err = r.upsertSecret(ctx, cmsSecretRef, userSecret, labelMap)
+		return cmsSecretRef, err
 	default:
 		// No secret required
 		return client.ObjectKey{}, nil
@@ -120,6 +168,24 @@ func (r *reconcilerBase) upsertCACertSecret(ctx context.Context, rs *v1beta1.Rep
 	return client.ObjectKey{}, nil
 }
 
+// upsertVerificationKeysSecret creates or updates the trusted keys secret in
+// the config-management-system namespace using an existing secret in the
+// RepoSync namespace.
+func (r *reconcilerBase) upsertVerificationKeysSecret(ctx context.Context, rs *v1beta1.RepoSync, reconcilerRef types.NamespacedName, labelMap map[string]string) (client.ObjectKey, error) {
+	rsRef := client.ObjectKeyFromObject(rs)
+	if secretName := getVerificationKeysName(rs); secretName != "" {
+		nsSecretRef, cmsSecretRef := getSecretRefs(rsRef, reconcilerRef, secretName)
+		userSecret, err := getUserSecret(ctx, r.client, nsSecretRef)
+		if err != nil {
+			return cmsSecretRef, fmt.Errorf("user secret required for source verification: %w", err)
+		}
+		_, err = r.upsertSecret(ctx, cmsSecretRef, userSecret, labelMap)
+		return cmsSecretRef, err
+	}
+	// No secret required
+	return client.ObjectKey{}, nil
+}
+
 func getSecretRefs(rsRef, reconcilerRef client.ObjectKey, secretName string) (nsSecretRef, cmsSecretRef client.ObjectKey) {
 	// User managed secret
 	nsSecretRef = client.ObjectKey{
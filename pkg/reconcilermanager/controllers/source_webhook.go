@@ -0,0 +1,71 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"kpt.dev/configsync/pkg/git"
+	"kpt.dev/configsync/pkg/reconcilermanager"
+)
+
+const (
+	// gitSyncSyncOnSignal is the git-sync env var naming the signal which
+	// triggers a fetch, without waiting for the next poll.
+	gitSyncSyncOnSignal = "GITSYNC_SYNC_ON_SIGNAL"
+	// sourceWebhookSignal is the signal sent to git-sync by the launcher.
+	sourceWebhookSignal = "SIGHUP"
+)
+
+// EnableSourceWebhook configures the reconcilers to fetch their Git sources
+// when the reconciler-manager receives a push webhook.
+func (r *reconcilerBase) EnableSourceWebhook() {
+	r.sourceWebhookEnabled = true
+}
+
+// enableSourceWebhook configures the reconciler Pod, so the reconciler can ask
+// the git-sync containers to fetch when notified of a source change:
+//   - git-sync runs under the git-sync-launcher, and fetches on SIGHUP, in
+//     addition to polling.
+//   - The reconciler touches the trigger file in the volume shared with the
+//     git-sync containers, and the launcher signals git-sync, which runs in
+//     the same container.
+//
+// It does nothing if the Pod has no git-sync container.
+func enableSourceWebhook(templateSpec *corev1.PodSpec) {
+	if !installGitSyncLauncher(templateSpec) {
+		return
+	}
+	for i := range templateSpec.Containers {
+		container := &templateSpec.Containers[i]
+		switch {
+		case container.Name == reconcilermanager.Reconciler:
+			container.Env = append(container.Env, corev1.EnvVar{
+				Name:  reconcilermanager.SourceWebhookEnabled,
+				Value: strconv.FormatBool(true),
+			})
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      fetchTriggerVolume,
+				MountPath: git.FetchTriggerDir,
+			})
+		case isGitSyncContainer(container.Name):
+			container.Env = append(container.Env, corev1.EnvVar{
+				Name:  gitSyncSyncOnSignal,
+				Value: sourceWebhookSignal,
+			})
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"kpt.dev/configsync/pkg/git"
+	"kpt.dev/configsync/pkg/reconcilermanager"
+)
+
+func TestEnableSourceWebhook(t *testing.T) {
+	dropAll := func() *corev1.SecurityContext {
+		return &corev1.SecurityContext{
+			Capabilities: &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		}
+	}
+	triggerMount := corev1.VolumeMount{Name: fetchTriggerVolume, MountPath: git.FetchTriggerDir}
+	readOnlyTriggerMount := corev1.VolumeMount{Name: fetchTriggerVolume, MountPath: git.FetchTriggerDir, ReadOnly: true}
+	launchedGitSync := func(name string) corev1.Container {
+		return corev1.Container{
+			Name:         name,
+			Image:        "git-sync",
+			Args:         []string{"--root=/repo/source"},
+			Command:      []string{"/fetch-trigger/git-sync-launcher", "--", "/git-sync"},
+			Env:          []corev1.EnvVar{{Name: gitSyncSyncOnSignal, Value: sourceWebhookSignal}},
+			VolumeMounts: []corev1.VolumeMount{readOnlyTriggerMount},
+		}
+	}
+	testCases := map[string]struct {
+		spec corev1.PodSpec
+		want corev1.PodSpec
+	}{
+		"git sources": {
+			spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: reconcilermanager.Reconciler, Image: "reconciler", SecurityContext: dropAll()},
+					{Name: reconcilermanager.GitSync, Image: "git-sync", Args: []string{"--root=/repo/source"}},
+					{Name: reconcilermanager.GitSync + "-platform", Image: "git-sync", Args: []string{"--root=/repo/source"}},
+				},
+			},
+			want: corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					{
+						Name:            launcherInstaller,
+						Image:           "reconciler",
+						Command:         []string{"/git-sync-launcher", "--install=/fetch-trigger/git-sync-launcher"},
+						SecurityContext: dropAll(),
+						VolumeMounts:    []corev1.VolumeMount{triggerMount},
+					},
+				},
+				Containers: []corev1.Container{
+					{
+						Name:  reconcilermanager.Reconciler,
+						Image: "reconciler",
+						Env: []corev1.EnvVar{
+							{Name: reconcilermanager.SourceWebhookEnabled, Value: "true"},
+						},
+						SecurityContext: dropAll(),
+						VolumeMounts:    []corev1.VolumeMount{triggerMount},
+					},
+					launchedGitSync(reconcilermanager.GitSync),
+					launchedGitSync(reconcilermanager.GitSync + "-platform"),
+				},
+				Volumes: []corev1.Volume{{
+					Name:         fetchTriggerVolume,
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+				}},
+			},
+		},
+		"no git source": {
+			spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: reconcilermanager.Reconciler, SecurityContext: dropAll()},
+					{Name: reconcilermanager.OciSync},
+				},
+			},
+			want: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: reconcilermanager.Reconciler, SecurityContext: dropAll()},
+					{Name: reconcilermanager.OciSync},
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			spec := tc.spec.DeepCopy()
+			enableSourceWebhook(spec)
+			assert.Equal(t, tc.want, *spec)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/metadata"
+)
+
+// SparseCheckoutVolume is the volume name of the git-sync sparse checkout file.
+const SparseCheckoutVolume = "sparse-checkout"
+
+// SparseCheckoutPath is the path where the sparse checkout file is mounted.
+const SparseCheckoutPath = "/etc/sparse-checkout"
+
+// sparseCheckoutFile is the name of the sparse checkout file.
+const sparseCheckoutFile = "sparse-checkout"
+
+// kustomizationFilePatterns match the Kustomization files in any directory.
+// They are always checked out, so the reconciler can find the Kustomize bases
+// referenced from the sync directory, and report them in the sparse paths.
+var kustomizationFilePatterns = []string{"kustomization.yaml", "kustomization.yml", "Kustomization"}
+
+// sparseCheckoutPatterns returns the content of the git-sync sparse checkout
+// file, or an empty string if sparse checkout is disabled or pointless.
+// The patterns include the sync directory, and the sparse paths last reported
+// by the reconciler for the same repository, which include the Kustomize bases
+// referenced from the sync directory.
+//
+// The bases outside of the sync directory are only known once the reconciler
+// has rendered a commit referencing them. Until the Pod is rolled out with the
+// new patterns, rendering that commit fails, so the first sync of a new base
+// takes an extra rollout.
+func sparseCheckoutPatterns(git *v1beta1.Git, gitStatus *v1beta1.GitStatus) string {
+	if git == nil || !git.SparseCheckout {
+		return ""
+	}
+	dir := path.Clean(strings.Trim(git.Dir, "/"))
+	if dir == "." {
+		// The whole repository is synced.
+		return ""
+	}
+	paths := []string{dir}
+	if gitStatus != nil && gitStatus.Repo == git.Repo {
+		paths = append(paths, gitStatus.SparsePaths...)
+	}
+	var patterns []string
+	for _, p := range paths {
+		pattern := "/" + path.Clean(strings.Trim(p, "/"))
+		if !slices.Contains(patterns, pattern) {
+			patterns = append(patterns, pattern)
+		}
+	}
+	slices.Sort(patterns)
+	patterns = append(patterns, kustomizationFilePatterns...)
+	return strings.Join(patterns, "\n") + "\n"
+}
+
+// mountSparseCheckout sets the sparse checkout patterns on the Pod template,
+// and mounts them as a file into the git-sync container. Changing the patterns
+// rolls out a new Pod, which checks out the repository with the new patterns.
+func mountSparseCheckout(template *corev1.PodTemplateSpec, c *corev1.Container, patterns string) {
+	core.SetAnnotation(template, metadata.SparseCheckoutPatterns, patterns)
+	template.Spec.Volumes = append(template.Spec.Volumes, corev1.Volume{
+		Name: SparseCheckoutVolume,
+		VolumeSource: corev1.VolumeSource{
+			DownwardAPI: &corev1.DownwardAPIVolumeSource{
+				Items: []corev1.DownwardAPIVolumeFile{{
+					Path: sparseCheckoutFile,
+					FieldRef: &corev1.ObjectFieldSelector{
+						APIVersion: "v1",
+						FieldPath:  fmt.Sprintf("metadata.annotations['%s']", metadata.SparseCheckoutPatterns),
+					},
+				}},
+				DefaultMode: &defaultMode,
+			},
+		},
+	})
+	c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+		Name:      SparseCheckoutVolume,
+		MountPath: SparseCheckoutPath,
+		ReadOnly:  true,
+	})
+}
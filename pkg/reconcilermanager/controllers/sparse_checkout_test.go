@@ -0,0 +1,93 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/metadata"
+)
+
+func TestSparseCheckoutPatterns(t *testing.T) {
+	const repo = "https://example.com/repo"
+	testCases := map[string]struct {
+		git       *v1beta1.Git
+		gitStatus *v1beta1.GitStatus
+		want      string
+	}{
+		"disabled": {
+			git: &v1beta1.Git{Repo: repo, Dir: "configs"},
+		},
+		"whole repository": {
+			git: &v1beta1.Git{Repo: repo, Dir: "/", SparseCheckout: true},
+		},
+		"sync directory only": {
+			git:  &v1beta1.Git{Repo: repo, Dir: "/clusters/prod/", SparseCheckout: true},
+			want: "/clusters/prod\nkustomization.yaml\nkustomization.yml\nKustomization\n",
+		},
+		"reported sparse paths": {
+			git: &v1beta1.Git{Repo: repo, Dir: "clusters/prod", SparseCheckout: true},
+			gitStatus: &v1beta1.GitStatus{
+				Repo:        repo,
+				SparsePaths: []string{"clusters/prod", "base", "common/rbac.yaml"},
+			},
+			want: "/base\n/clusters/prod\n/common/rbac.yaml\nkustomization.yaml\nkustomization.yml\nKustomization\n",
+		},
+		"sparse paths of another repository": {
+			git: &v1beta1.Git{Repo: repo, Dir: "clusters/prod", SparseCheckout: true},
+			gitStatus: &v1beta1.GitStatus{
+				Repo:        "https://example.com/other",
+				SparsePaths: []string{"base"},
+			},
+			want: "/clusters/prod\nkustomization.yaml\nkustomization.yml\nKustomization\n",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, sparseCheckoutPatterns(tc.git, tc.gitStatus))
+		})
+	}
+}
+
+func TestMountSparseCheckout(t *testing.T) {
+	template := &corev1.PodTemplateSpec{}
+	container := &corev1.Container{}
+	mountSparseCheckout(template, container, "/configs\n")
+
+	assert.Equal(t, "/configs\n", template.Annotations[metadata.SparseCheckoutPatterns])
+	assert.Equal(t, []corev1.Volume{{
+		Name: SparseCheckoutVolume,
+		VolumeSource: corev1.VolumeSource{
+			DownwardAPI: &corev1.DownwardAPIVolumeSource{
+				Items: []corev1.DownwardAPIVolumeFile{{
+					Path: sparseCheckoutFile,
+					FieldRef: &corev1.ObjectFieldSelector{
+						APIVersion: "v1",
+						FieldPath:  "metadata.annotations['configsync.gke.io/sparse-checkout']",
+					},
+				}},
+				DefaultMode: &defaultMode,
+			},
+		},
+	}}, template.Spec.Volumes)
+	assert.Equal(t, []corev1.VolumeMount{{
+		Name:      SparseCheckoutVolume,
+		MountPath: SparseCheckoutPath,
+		ReadOnly:  true,
+	}}, container.VolumeMounts)
+}
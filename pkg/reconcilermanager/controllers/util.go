@@ -15,6 +15,7 @@
 package controllers
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
@@ -30,6 +31,7 @@ import (
 	"kpt.dev/configsync/pkg/reconcilermanager"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 )
 
 // updateHydrationControllerImage sets the image of hydration-controller based
@@ -45,9 +47,12 @@ type hydrationOptions struct {
 	sourceType     configsync.SourceType
 	gitConfig      *v1beta1.Git
 	ociConfig      *v1beta1.Oci
+	archiveConfig  *v1beta1.Archive
 	scope          declared.Scope
 	reconcilerName string
 	pollPeriod     string
+	hermetic       bool
+	allowExec      bool
 }
 
 // hydrationEnvs returns environment variables for the hydration controller.
@@ -61,6 +66,8 @@ func hydrationEnvs(opts hydrationOptions) []corev1.EnvVar {
 		syncDir = opts.gitConfig.Dir
 	case configsync.HelmSource:
 		syncDir = "."
+	case configsync.ArchiveSource:
+		syncDir = opts.archiveConfig.Dir
 	}
 
 	result = append(result,
@@ -88,10 +95,40 @@ func hydrationEnvs(opts hydrationOptions) []corev1.EnvVar {
 		corev1.EnvVar{
 			Name:  reconcilermanager.HydrationPollingPeriod,
 			Value: opts.pollPeriod,
+		},
+		corev1.EnvVar{
+			Name:  reconcilermanager.RenderingHermetic,
+			Value: strconv.FormatBool(opts.hermetic),
+		},
+		corev1.EnvVar{
+			Name:  reconcilermanager.RenderingAllowExecFunctions,
+			Value: strconv.FormatBool(opts.allowExec),
 		})
+	if opts.sourceType == configsync.GitSource && opts.gitConfig.Helm != nil {
+		result = append(result, gitHelmEnvs(opts.gitConfig.Helm)...)
+	}
 	return result
 }
 
+// gitHelmEnvs returns the environment variables for the hydration controller
+// to render a Helm chart stored in Git. The values files from ConfigMaps are
+// mounted separately.
+func gitHelmEnvs(helm *v1beta1.GitHelm) []corev1.EnvVar {
+	return []corev1.EnvVar{{
+		Name:  reconcilermanager.HelmReleaseName,
+		Value: helm.ReleaseName,
+	}, {
+		Name:  reconcilermanager.HelmReleaseNamespace,
+		Value: helm.Namespace,
+	}, {
+		Name:  reconcilermanager.HelmGitValuesFiles,
+		Value: strings.Join(helm.ValuesFiles, ","),
+	}, {
+		Name:  reconcilermanager.HelmIncludeCRDs,
+		Value: fmt.Sprint(helm.IncludeCRDs),
+	}}
+}
+
 type reconcilerOptions struct {
 	clusterName              string
 	syncName                 string
@@ -102,6 +139,7 @@ type reconcilerOptions struct {
 	gitConfig                *v1beta1.Git
 	ociConfig                *v1beta1.Oci
 	helmConfig               *v1beta1.HelmBase
+	archiveConfig            *v1beta1.Archive
 	pollPeriod               string
 	statusMode               metadata.StatusMode
 	reconcileTimeout         string
@@ -109,45 +147,58 @@ type reconcilerOptions struct {
 	requiresRendering        bool
 	dynamicNSSelectorEnabled bool
 	webhookEnabled           bool
+	serverSideDryRun         bool
+	schemaValidation         bool
+	driftReportMode          bool
+	// revisionConstraint is the semantic version constraint the git revision
+	// was resolved from, if any.
+	revisionConstraint string
 }
 
-// reconcilerEnvs returns environment variables for namespace reconciler.
-func reconcilerEnvs(opts reconcilerOptions) []corev1.EnvVar {
-	var result []corev1.EnvVar
-	statusMode := opts.statusMode
-	if statusMode == "" {
-		statusMode = metadata.StatusEnabled
-	}
-	var syncRepo string
-	var syncBranch string
-	var syncRevision string
-	var syncDir string
-	switch opts.sourceType {
+// sourceLocation returns the repo, branch, revision and directory of the
+// source, depending on its type. The branch and revision default to the ones
+// synced by the *-sync sidecars.
+func sourceLocation(sourceType configsync.SourceType, gitConfig *v1beta1.Git, ociConfig *v1beta1.Oci, helmConfig *v1beta1.HelmBase, archiveConfig *v1beta1.Archive) (repo, branch, revision, dir string) {
+	switch sourceType {
 	case configsync.OciSource:
-		syncRepo = opts.ociConfig.Image
-		syncDir = opts.ociConfig.Dir
+		repo = ociConfig.Image
+		dir = ociConfig.Dir
 	case configsync.HelmSource:
-		syncRepo = opts.helmConfig.Repo
-		syncDir = opts.helmConfig.Chart
-		if opts.helmConfig.Version != "" {
-			syncRevision = opts.helmConfig.Version
+		repo = helmConfig.Repo
+		dir = helmConfig.Chart
+		if helmConfig.Version != "" {
+			revision = helmConfig.Version
 		} else {
-			syncRevision = "latest"
+			revision = "latest"
 		}
+	case configsync.ArchiveSource:
+		repo = archiveConfig.URL
+		dir = archiveConfig.Dir
 	case configsync.GitSource:
-		syncRepo = opts.gitConfig.Repo
-		syncDir = opts.gitConfig.Dir
-		if opts.gitConfig.Branch != "" {
-			syncBranch = opts.gitConfig.Branch
+		repo = gitConfig.Repo
+		dir = gitConfig.Dir
+		if gitConfig.Branch != "" {
+			branch = gitConfig.Branch
 		} else {
-			syncBranch = "master"
+			branch = "master"
 		}
-		if opts.gitConfig.Revision != "" {
-			syncRevision = opts.gitConfig.Revision
+		if gitConfig.Revision != "" {
+			revision = gitConfig.Revision
 		} else {
-			syncRevision = "HEAD"
+			revision = "HEAD"
 		}
 	}
+	return repo, branch, revision, dir
+}
+
+// reconcilerEnvs returns environment variables for namespace reconciler.
+func reconcilerEnvs(opts reconcilerOptions) []corev1.EnvVar {
+	var result []corev1.EnvVar
+	statusMode := opts.statusMode
+	if statusMode == "" {
+		statusMode = metadata.StatusEnabled
+	}
+	syncRepo, syncBranch, syncRevision, syncDir := sourceLocation(opts.sourceType, opts.gitConfig, opts.ociConfig, opts.helmConfig, opts.archiveConfig)
 
 	result = append(result,
 		corev1.EnvVar{
@@ -218,6 +269,33 @@ func reconcilerEnvs(opts reconcilerOptions) []corev1.EnvVar {
 		)
 	}
 
+	if opts.serverSideDryRun {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.ServerSideDryRun,
+				Value: strconv.FormatBool(opts.serverSideDryRun),
+			},
+		)
+	}
+
+	if opts.schemaValidation {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.SchemaValidation,
+				Value: strconv.FormatBool(opts.schemaValidation),
+			},
+		)
+	}
+
+	if opts.driftReportMode {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.DriftReportMode,
+				Value: strconv.FormatBool(opts.driftReportMode),
+			},
+		)
+	}
+
 	if opts.dynamicNSSelectorEnabled {
 		result = append(result,
 			corev1.EnvVar{
@@ -227,6 +305,13 @@ func reconcilerEnvs(opts reconcilerOptions) []corev1.EnvVar {
 		)
 	}
 
+	if opts.sourceType == configsync.GitSource && sparseCheckoutPatterns(opts.gitConfig, nil) != "" {
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.SparseCheckoutKey,
+			Value: "true",
+		})
+	}
+
 	if syncBranch != "" {
 		result = append(result, corev1.EnvVar{
 			Name:  reconcilermanager.SourceBranchKey,
@@ -239,6 +324,12 @@ func reconcilerEnvs(opts reconcilerOptions) []corev1.EnvVar {
 			Value: syncRevision,
 		})
 	}
+	if opts.revisionConstraint != "" {
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.SourceRevConstraintKey,
+			Value: opts.revisionConstraint,
+		})
+	}
 	return result
 }
 
@@ -261,11 +352,45 @@ func namespaceStrategyEnv(strategy configsync.NamespaceStrategy) corev1.EnvVar {
 	}
 }
 
+// rolloutEnv returns the environment variable for ROLLOUT in the reconciler
+// container, or nil if the rollout gate is not specified.
+func rolloutEnv(rollout *v1beta1.RolloutSpec) ([]corev1.EnvVar, error) {
+	if rollout == nil {
+		return nil, nil
+	}
+	value, err := json.Marshal(rollout)
+	if err != nil {
+		return nil, fmt.Errorf("encoding the rollout gate: %w", err)
+	}
+	return []corev1.EnvVar{{
+		Name:  reconcilermanager.RolloutKey,
+		Value: string(value),
+	}}, nil
+}
+
+// ignoreDifferencesEnv returns the environment variable for IGNORE_DIFFERENCES
+// in the reconciler container, or nil if no differences are ignored.
+func ignoreDifferencesEnv(ignores []v1beta1.IgnoreDifference) ([]corev1.EnvVar, error) {
+	if len(ignores) == 0 {
+		return nil, nil
+	}
+	value, err := json.Marshal(ignores)
+	if err != nil {
+		return nil, fmt.Errorf("encoding the ignored differences: %w", err)
+	}
+	return []corev1.EnvVar{{
+		Name:  reconcilermanager.IgnoreDifferencesKey,
+		Value: string(value),
+	}}, nil
+}
+
 type ociOptions struct {
 	image           string
 	auth            configsync.AuthType
 	period          float64
 	caCertSecretRef string
+	verification    *v1beta1.OciVerification
+	tagPolicy       *v1beta1.OciTagPolicy
 }
 
 // ociSyncEnvs returns the environment variables for the oci-sync container.
@@ -287,6 +412,43 @@ func ociSyncEnvs(opts ociOptions) []corev1.EnvVar {
 			Value: fmt.Sprintf("%s/%s", CACertPath, CACertSecretKey),
 		})
 	}
+	if opts.verification != nil {
+		if opts.verification.Keyless != nil {
+			result = append(result, corev1.EnvVar{
+				Name:  reconcilermanager.OciSyncVerificationIdentity,
+				Value: opts.verification.Keyless.Identity,
+			}, corev1.EnvVar{
+				Name:  reconcilermanager.OciSyncVerificationIssuer,
+				Value: opts.verification.Keyless.Issuer,
+			})
+		}
+		if len(opts.verification.Attestations) > 0 {
+			result = append(result, corev1.EnvVar{
+				Name:  reconcilermanager.OciSyncVerificationAttestations,
+				Value: strings.Join(opts.verification.Attestations, ","),
+			})
+		}
+	}
+	if opts.tagPolicy != nil {
+		if opts.tagPolicy.Semver != "" {
+			result = append(result, corev1.EnvVar{
+				Name:  reconcilermanager.OciSyncTagSemver,
+				Value: opts.tagPolicy.Semver,
+			})
+		}
+		if opts.tagPolicy.Regex != "" {
+			result = append(result, corev1.EnvVar{
+				Name:  reconcilermanager.OciSyncTagRegex,
+				Value: opts.tagPolicy.Regex,
+			})
+		}
+		if opts.tagPolicy.Latest {
+			result = append(result, corev1.EnvVar{
+				Name:  reconcilermanager.OciSyncTagLatest,
+				Value: "true",
+			})
+		}
+	}
 	return result
 }
 
@@ -347,6 +509,15 @@ func helmSyncEnvs(opts helmOptions) []corev1.EnvVar {
 			Value: fmt.Sprintf("%s/%s", CACertPath, CACertSecretKey),
 		})
 	}
+	if opts.helmBase.Verify != nil && opts.helmBase.Verify.Keyless != nil {
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.HelmVerificationIdentity,
+			Value: opts.helmBase.Verify.Keyless.Identity,
+		}, corev1.EnvVar{
+			Name:  reconcilermanager.HelmVerificationIssuer,
+			Value: opts.helmBase.Verify.Keyless.Issuer,
+		})
+	}
 	return result
 }
 
@@ -382,6 +553,87 @@ func helmSyncTokenAuthEnv(secretRef string) []corev1.EnvVar {
 	}
 }
 
+const (
+	// archive-sync container specific environment variables.
+	archiveSyncToken           = "ARCHIVE_SYNC_TOKEN"
+	archiveSyncUsername        = "ARCHIVE_SYNC_USERNAME"
+	archiveSyncPassword        = "ARCHIVE_SYNC_PASSWORD"
+	archiveSyncAccessKeyID     = "ARCHIVE_SYNC_ACCESS_KEY_ID"
+	archiveSyncSecretAccessKey = "ARCHIVE_SYNC_SECRET_ACCESS_KEY"
+)
+
+type archiveOptions struct {
+	url             string
+	auth            configsync.AuthType
+	region          string
+	period          float64
+	caCertSecretRef string
+}
+
+// archiveSyncEnvs returns the environment variables for the archive-sync container.
+func archiveSyncEnvs(opts archiveOptions) []corev1.EnvVar {
+	var result []corev1.EnvVar
+	result = append(result, corev1.EnvVar{
+		Name:  reconcilermanager.ArchiveSyncURL,
+		Value: opts.url,
+	}, corev1.EnvVar{
+		Name:  reconcilermanager.ArchiveSyncAuth,
+		Value: string(opts.auth),
+	}, corev1.EnvVar{
+		Name:  reconcilermanager.ArchiveSyncWait,
+		Value: fmt.Sprintf("%f", opts.period),
+	})
+	if opts.region != "" {
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.ArchiveSyncRegion,
+			Value: opts.region,
+		})
+	}
+	if useCACert(opts.caCertSecretRef) {
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.OciCACert,
+			Value: fmt.Sprintf("%s/%s", CACertPath, CACertSecretKey),
+		})
+	}
+	return result
+}
+
+// archiveSyncAuthEnv returns the environment variables for the archive-sync
+// container with the credentials of the 'token' or 'hmac' Auth. The keys are
+// optional, as 'token' auth accepts either a bearer token or a username and
+// password.
+func archiveSyncAuthEnv(auth configsync.AuthType, secretRef string) []corev1.EnvVar {
+	secretKeyRef := func(name, key string) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: secretRef,
+					},
+					Key:      key,
+					Optional: ptr.To(true),
+				},
+			},
+		}
+	}
+	switch auth {
+	case configsync.AuthToken:
+		return []corev1.EnvVar{
+			secretKeyRef(archiveSyncToken, ArchiveSecretKeyToken),
+			secretKeyRef(archiveSyncUsername, ArchiveSecretKeyUsername),
+			secretKeyRef(archiveSyncPassword, ArchiveSecretKeyPassword),
+		}
+	case configsync.AuthHMAC:
+		return []corev1.EnvVar{
+			secretKeyRef(archiveSyncAccessKeyID, ArchiveSecretKeyAccessKeyID),
+			secretKeyRef(archiveSyncSecretAccessKey, ArchiveSecretKeySecretAccessKey),
+		}
+	default:
+		return nil
+	}
+}
+
 // PollingPeriod parses the polling duration from the environment variable.
 // If the variable is not present, it returns the default value.
 func PollingPeriod(envName string, defaultValue time.Duration) time.Duration {
@@ -115,6 +115,40 @@ func TestHelmSyncEnvs(t *testing.T) {
 				{Name: reconcilermanager.HelmCACert, Value: "/etc/ca-cert/cert"},
 			},
 		},
+		"with keyless verification": {
+			options: helmOptions{
+				helmBase: &v1beta1.HelmBase{
+					Repo:        "oci://example.com/repo",
+					Chart:       "my-chart",
+					Version:     "1.0.0",
+					ReleaseName: "release-name",
+					Auth:        "none",
+					Verify: &v1beta1.HelmVerification{
+						KeyringSecretRef: &v1beta1.SecretReference{Name: "trust-root"},
+						Keyless: &v1beta1.OciKeylessVerification{
+							Identity: "ci@example.com",
+							Issuer:   "https://accounts.google.com",
+						},
+					},
+				},
+				releaseNamespace: "releaseNamespace",
+				deployNamespace:  "deployNamespace",
+			},
+			expected: []corev1.EnvVar{
+				{Name: reconcilermanager.HelmRepo, Value: "oci://example.com/repo"},
+				{Name: reconcilermanager.HelmChart, Value: "my-chart"},
+				{Name: reconcilermanager.HelmChartVersion, Value: "1.0.0"},
+				{Name: reconcilermanager.HelmReleaseName, Value: "release-name"},
+				{Name: reconcilermanager.HelmReleaseNamespace, Value: "releaseNamespace"},
+				{Name: reconcilermanager.HelmDeployNamespace, Value: "deployNamespace"},
+				{Name: reconcilermanager.HelmValuesYAML, Value: ""},
+				{Name: reconcilermanager.HelmIncludeCRDs, Value: "false"},
+				{Name: reconcilermanager.HelmAuthType, Value: "none"},
+				{Name: reconcilermanager.HelmSyncWait, Value: "3600.000000"},
+				{Name: reconcilermanager.HelmVerificationIdentity, Value: "ci@example.com"},
+				{Name: reconcilermanager.HelmVerificationIssuer, Value: "https://accounts.google.com"},
+			},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -213,6 +247,47 @@ func TestOCISyncEnvs(t *testing.T) {
 				{Name: "OCI_SYNC_WAIT", Value: "30.000000"},
 			},
 		},
+		"oci-sync with keyless verification": {
+			options: ociOptions{
+				image:  "registry/some/image:v1",
+				period: 30,
+				auth:   configsync.AuthNone,
+				verification: &v1beta1.OciVerification{
+					TrustedKeysSecretRef: &v1beta1.SecretReference{Name: "trust-root"},
+					Keyless: &v1beta1.OciKeylessVerification{
+						Identity: "ci@example.com",
+						Issuer:   "https://accounts.google.com",
+					},
+					Attestations: []string{"https://slsa.dev/provenance/v1", "https://spdx.dev/Document"},
+				},
+			},
+			expectedEnvs: []corev1.EnvVar{
+				{Name: "OCI_SYNC_IMAGE", Value: "registry/some/image:v1"},
+				{Name: "OCI_SYNC_AUTH", Value: "none"},
+				{Name: "OCI_SYNC_WAIT", Value: "30.000000"},
+				{Name: "OCI_SYNC_VERIFICATION_IDENTITY", Value: "ci@example.com"},
+				{Name: "OCI_SYNC_VERIFICATION_ISSUER", Value: "https://accounts.google.com"},
+				{Name: "OCI_SYNC_VERIFICATION_ATTESTATIONS", Value: "https://slsa.dev/provenance/v1,https://spdx.dev/Document"},
+			},
+		},
+		"oci-sync with tag policy": {
+			options: ociOptions{
+				image:  "registry/some/image",
+				period: 30,
+				auth:   configsync.AuthNone,
+				tagPolicy: &v1beta1.OciTagPolicy{
+					Semver: ">=1.4.0 <2.0.0",
+					Regex:  "^v",
+				},
+			},
+			expectedEnvs: []corev1.EnvVar{
+				{Name: "OCI_SYNC_IMAGE", Value: "registry/some/image"},
+				{Name: "OCI_SYNC_AUTH", Value: "none"},
+				{Name: "OCI_SYNC_WAIT", Value: "30.000000"},
+				{Name: "OCI_SYNC_TAG_SEMVER", Value: ">=1.4.0 <2.0.0"},
+				{Name: "OCI_SYNC_TAG_REGEX", Value: "^v"},
+			},
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -221,3 +296,45 @@ func TestOCISyncEnvs(t *testing.T) {
 		})
 	}
 }
+
+func TestArchiveSyncEnvs(t *testing.T) {
+	testCases := map[string]struct {
+		options      archiveOptions
+		expectedEnvs []corev1.EnvVar
+	}{
+		"archive-sync with no auth": {
+			options: archiveOptions{
+				url:    "https://storage.example.com/bucket/configs.tar.gz",
+				period: 15,
+				auth:   configsync.AuthNone,
+			},
+			expectedEnvs: []corev1.EnvVar{
+				{Name: "ARCHIVE_SYNC_URL", Value: "https://storage.example.com/bucket/configs.tar.gz"},
+				{Name: "ARCHIVE_SYNC_AUTH", Value: "none"},
+				{Name: "ARCHIVE_SYNC_WAIT", Value: "15.000000"},
+			},
+		},
+		"archive-sync with hmac auth and ca cert": {
+			options: archiveOptions{
+				url:             "https://s3.eu-west-1.amazonaws.com/bucket/configs.tar.gz",
+				period:          15,
+				auth:            configsync.AuthHMAC,
+				region:          "eu-west-1",
+				caCertSecretRef: "ca-cert",
+			},
+			expectedEnvs: []corev1.EnvVar{
+				{Name: "ARCHIVE_SYNC_URL", Value: "https://s3.eu-west-1.amazonaws.com/bucket/configs.tar.gz"},
+				{Name: "ARCHIVE_SYNC_AUTH", Value: "hmac"},
+				{Name: "ARCHIVE_SYNC_WAIT", Value: "15.000000"},
+				{Name: "ARCHIVE_SYNC_REGION", Value: "eu-west-1"},
+				{Name: "SSL_CERT_FILE", Value: "/etc/ca-cert/cert"},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			envs := archiveSyncEnvs(tc.options)
+			assert.Equal(t, tc.expectedEnvs, envs)
+		})
+	}
+}
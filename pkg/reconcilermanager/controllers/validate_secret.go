@@ -85,3 +85,31 @@ func validateSecretData(auth configsync.AuthType, secret *corev1.Secret) error {
 	}
 	return nil
 }
+
+// validateArchiveSecretData verify the archive secret data for the given auth
+// type.
+func validateArchiveSecretData(auth configsync.AuthType, secret *corev1.Secret) error {
+	hasDataKey := func(key string) bool {
+		val, ok := secret.Data[key]
+		return ok && len(val) > 0
+	}
+	switch auth {
+	case configsync.AuthToken:
+		if hasDataKey(ArchiveSecretKeyToken) {
+			return nil
+		}
+		if !hasDataKey(ArchiveSecretKeyUsername) || !hasDataKey(ArchiveSecretKeyPassword) {
+			return fmt.Errorf("archive auth was set as %q but neither the %s key nor the %s and %s keys are present in %v secret",
+				auth, ArchiveSecretKeyToken, ArchiveSecretKeyUsername, ArchiveSecretKeyPassword, secret.Name)
+		}
+	case configsync.AuthHMAC:
+		if !hasDataKey(ArchiveSecretKeyAccessKeyID) || !hasDataKey(ArchiveSecretKeySecretAccessKey) {
+			return fmt.Errorf("archive auth was set as %q but the %s and %s keys are not present in %v secret",
+				auth, ArchiveSecretKeyAccessKeyID, ArchiveSecretKeySecretAccessKey, secret.Name)
+		}
+	case configsync.AuthNone:
+	default:
+		return fmt.Errorf("archive auth is set to unsupported value: %q", auth)
+	}
+	return nil
+}
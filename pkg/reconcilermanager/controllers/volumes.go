@@ -23,6 +23,7 @@ import (
 	"kpt.dev/configsync/pkg/api/configsync"
 	hubv1 "kpt.dev/configsync/pkg/api/hub/v1"
 	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/reconcilermanager"
 )
 
 // GitCredentialVolume is the volume name of the git credentials.
@@ -40,6 +41,18 @@ const CACertSecretKey = "cert"
 // CACertPath is the path where the certificate is mounted.
 const CACertPath = "/etc/ca-cert"
 
+// VerificationKeysVolume is the volume name of the trusted public keys used to
+// verify the source.
+const VerificationKeysVolume = "verification-keys"
+
+// VerificationKeysPath is the path where the trusted public keys are mounted.
+const VerificationKeysPath = "/etc/verification-keys"
+
+// AdditionalSourcesVerificationKeysPath is the path where the trusted public
+// keys of the additional git sources are mounted in the reconciler container,
+// in a directory named after the source.
+const AdditionalSourcesVerificationKeysPath = "/etc/source-verification-keys"
+
 // defaultMode is the default permission of the `gcp-ksa` volume.
 var defaultMode int32 = 0644
 
@@ -147,3 +160,32 @@ func volumeMounts(auth configsync.AuthType, caCertSecretRef string, sourceType c
 	})
 	return volumeMount
 }
+
+// mountVerificationKeys mounts the Secret with the trusted public keys into the
+// container, and tells the container where to find them.
+func mountVerificationKeys(templateSpec *corev1.PodSpec, c *corev1.Container, secretName string) {
+	mountVerificationKeysVolume(templateSpec, c, VerificationKeysVolume, VerificationKeysPath, secretName)
+	c.Env = append(c.Env, corev1.EnvVar{
+		Name:  reconcilermanager.SourceVerificationKeysDirKey,
+		Value: VerificationKeysPath,
+	})
+}
+
+// mountVerificationKeysVolume mounts the Secret with the trusted public keys
+// into the container, with the specified volume name and mount path.
+func mountVerificationKeysVolume(templateSpec *corev1.PodSpec, c *corev1.Container, volumeName, mountPath, secretName string) {
+	templateSpec.Volumes = append(templateSpec.Volumes, corev1.Volume{
+		Name: volumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName:  secretName,
+				DefaultMode: &defaultMode,
+			},
+		},
+	})
+	c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+		Name:      volumeName,
+		MountPath: mountPath,
+		ReadOnly:  true,
+	})
+}
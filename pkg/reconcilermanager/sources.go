@@ -0,0 +1,38 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcilermanager
+
+import "kpt.dev/configsync/pkg/api/configsync"
+
+// AdditionalSource describes an additional source of truth of a RootSync to
+// the reconciler. It mirrors the SOURCE_* variables of the primary source.
+type AdditionalSource struct {
+	// Name of the source, which is also the name of the directory the source
+	// is fetched into, under AdditionalSourcesDir.
+	Name string `json:"name"`
+	// SourceType is the type of the source, must be git or oci or helm.
+	SourceType configsync.SourceType `json:"sourceType"`
+	// Repo is the git or OCI or Helm repo URL.
+	Repo string `json:"repo"`
+	// Branch is the git branch name. It doesn't apply to OCI and helm.
+	Branch string `json:"branch,omitempty"`
+	// Revision is the git or helm revision.
+	Revision string `json:"revision,omitempty"`
+	// Dir is the directory of the configs in the source, or the Helm chart.
+	Dir string `json:"dir,omitempty"`
+	// VerificationKeysDir is the directory of the trusted public keys used to
+	// verify the commits of a git source, if any.
+	VerificationKeysDir string `json:"verificationKeysDir,omitempty"`
+}
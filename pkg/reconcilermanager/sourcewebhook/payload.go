@@ -0,0 +1,246 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sourcewebhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Provider is a Git hosting service sending push webhooks.
+type Provider string
+
+const (
+	// GitHub sends the `X-GitHub-Event` header, and signs the payload with
+	// HMAC-SHA256 in the `X-Hub-Signature-256` header.
+	GitHub Provider = "github"
+	// GitLab sends the `X-Gitlab-Event` header, and the secret token in the
+	// `X-Gitlab-Token` header.
+	GitLab Provider = "gitlab"
+	// Bitbucket sends the `X-Event-Key` header, and signs the payload with
+	// HMAC-SHA256 in the `X-Hub-Signature` header.
+	Bitbucket Provider = "bitbucket"
+	// Generic payloads are signed with HMAC-SHA256 in the `X-Signature-256`
+	// header.
+	Generic Provider = "generic"
+)
+
+const (
+	githubEventHeader        = "X-GitHub-Event"
+	githubSignatureHeader    = "X-Hub-Signature-256"
+	gitlabEventHeader        = "X-Gitlab-Event"
+	gitlabTokenHeader        = "X-Gitlab-Token"
+	bitbucketEventHeader     = "X-Event-Key"
+	bitbucketSignatureHeader = "X-Hub-Signature"
+	genericSignatureHeader   = "X-Signature-256"
+
+	signaturePrefix = "sha256="
+
+	branchRefPrefix = "refs/heads/"
+	tagRefPrefix    = "refs/tags/"
+)
+
+// Push is a change pushed to a Git repository.
+type Push struct {
+	// Repos are the URLs of the repository, as listed in the payload.
+	Repos []string
+	// Ref is the pushed ref, either a full ref like `refs/heads/main`, or a
+	// branch name.
+	Ref string
+	// Commit is the pushed commit, if known.
+	Commit string
+}
+
+// detectProvider returns the provider which sent the request.
+func detectProvider(header http.Header) Provider {
+	switch {
+	case header.Get(githubEventHeader) != "":
+		return GitHub
+	case header.Get(gitlabEventHeader) != "":
+		return GitLab
+	case header.Get(bitbucketEventHeader) != "":
+		return Bitbucket
+	default:
+		return Generic
+	}
+}
+
+// authenticate returns an error if the request was not sent with the secret.
+func authenticate(provider Provider, header http.Header, body, secret []byte) error {
+	switch provider {
+	case GitLab:
+		token := header.Get(gitlabTokenHeader)
+		if subtle.ConstantTimeCompare([]byte(token), secret) != 1 {
+			return fmt.Errorf("invalid %s header", gitlabTokenHeader)
+		}
+		return nil
+	case GitHub:
+		return verifySignature(header, githubSignatureHeader, body, secret)
+	case Bitbucket:
+		return verifySignature(header, bitbucketSignatureHeader, body, secret)
+	default:
+		return verifySignature(header, genericSignatureHeader, body, secret)
+	}
+}
+
+// verifySignature verifies the HMAC-SHA256 signature of the body, set in the
+// header as `sha256=<hex>`.
+func verifySignature(header http.Header, key string, body, secret []byte) error {
+	signature, found := strings.CutPrefix(header.Get(key), signaturePrefix)
+	if !found {
+		return fmt.Errorf("missing %s header", key)
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("invalid %s header: %w", key, err)
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return fmt.Errorf("invalid %s header: signature mismatch", key)
+	}
+	return nil
+}
+
+// isPushEvent returns true if the request notifies a push. Other events, like
+// the ping sent when a webhook is created, are ignored.
+func isPushEvent(provider Provider, header http.Header) bool {
+	switch provider {
+	case GitHub:
+		return header.Get(githubEventHeader) == "push"
+	case GitLab:
+		event := header.Get(gitlabEventHeader)
+		return event == "Push Hook" || event == "Tag Push Hook"
+	case Bitbucket:
+		return header.Get(bitbucketEventHeader) == "repo:push"
+	default:
+		return true
+	}
+}
+
+type githubPayload struct {
+	Ref        string `json:"ref"`
+	After      string `json:"after"`
+	Repository struct {
+		CloneURL string `json:"clone_url"`
+		SSHURL   string `json:"ssh_url"`
+		HTMLURL  string `json:"html_url"`
+	} `json:"repository"`
+}
+
+type gitlabPayload struct {
+	Ref     string `json:"ref"`
+	After   string `json:"after"`
+	Project struct {
+		GitHTTPURL string `json:"git_http_url"`
+		GitSSHURL  string `json:"git_ssh_url"`
+		WebURL     string `json:"web_url"`
+	} `json:"project"`
+}
+
+type bitbucketPayload struct {
+	Push struct {
+		Changes []struct {
+			New *struct {
+				Type   string `json:"type"`
+				Name   string `json:"name"`
+				Target struct {
+					Hash string `json:"hash"`
+				} `json:"target"`
+			} `json:"new"`
+		} `json:"changes"`
+	} `json:"push"`
+	Repository struct {
+		FullName string `json:"full_name"`
+		Links    struct {
+			HTML struct {
+				Href string `json:"href"`
+			} `json:"html"`
+		} `json:"links"`
+	} `json:"repository"`
+}
+
+type genericPayload struct {
+	Repository string `json:"repository"`
+	Ref        string `json:"ref"`
+	Commit     string `json:"commit"`
+}
+
+// parsePushes returns the pushes notified by the payload.
+func parsePushes(provider Provider, body []byte) ([]Push, error) {
+	switch provider {
+	case GitHub:
+		payload := &githubPayload{}
+		if err := json.Unmarshal(body, payload); err != nil {
+			return nil, fmt.Errorf("parsing the %s payload: %w", provider, err)
+		}
+		return []Push{{
+			Repos:  []string{payload.Repository.CloneURL, payload.Repository.SSHURL, payload.Repository.HTMLURL},
+			Ref:    payload.Ref,
+			Commit: payload.After,
+		}}, nil
+	case GitLab:
+		payload := &gitlabPayload{}
+		if err := json.Unmarshal(body, payload); err != nil {
+			return nil, fmt.Errorf("parsing the %s payload: %w", provider, err)
+		}
+		return []Push{{
+			Repos:  []string{payload.Project.GitHTTPURL, payload.Project.GitSSHURL, payload.Project.WebURL},
+			Ref:    payload.Ref,
+			Commit: payload.After,
+		}}, nil
+	case Bitbucket:
+		payload := &bitbucketPayload{}
+		if err := json.Unmarshal(body, payload); err != nil {
+			return nil, fmt.Errorf("parsing the %s payload: %w", provider, err)
+		}
+		repos := []string{payload.Repository.Links.HTML.Href}
+		if payload.Repository.FullName != "" {
+			repos = append(repos, "bitbucket.org/"+payload.Repository.FullName)
+		}
+		var pushes []Push
+		for _, change := range payload.Push.Changes {
+			if change.New == nil {
+				// The branch or tag was deleted.
+				continue
+			}
+			ref := branchRefPrefix + change.New.Name
+			if change.New.Type == "tag" {
+				ref = tagRefPrefix + change.New.Name
+			}
+			pushes = append(pushes, Push{Repos: repos, Ref: ref, Commit: change.New.Target.Hash})
+		}
+		return pushes, nil
+	default:
+		payload := &genericPayload{}
+		if err := json.Unmarshal(body, payload); err != nil {
+			return nil, fmt.Errorf("parsing the %s payload: %w", provider, err)
+		}
+		if payload.Repository == "" {
+			return nil, fmt.Errorf("parsing the %s payload: missing repository", provider)
+		}
+		return []Push{{
+			Repos:  []string{payload.Repository},
+			Ref:    payload.Ref,
+			Commit: payload.Commit,
+		}}, nil
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sourcewebhook receives push webhooks from Git hosting services, and
+// notifies the reconcilers syncing from the pushed repository and ref, so they
+// fetch the change without waiting for the next poll.
+package sourcewebhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	gitutil "kpt.dev/configsync/pkg/git"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/reconcilermanager"
+	"kpt.dev/configsync/pkg/reconcilermanager/controllers"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// maxPayloadBytes is the maximum size of a webhook payload. GitHub caps
+// payloads at 25MB.
+const maxPayloadBytes = 25 << 20
+
+// Receiver is an http.Handler receiving push webhooks. For each push, it sets
+// the `configsync.gke.io/source-change` annotation on the RootSyncs and
+// RepoSyncs syncing from the pushed repository and ref. The reconcilers watch
+// the annotation and fetch the source immediately. Polling remains the
+// fallback when a webhook is missed.
+type Receiver struct {
+	// Client reads and patches RootSyncs and RepoSyncs.
+	Client client.Client
+	// Secret is the shared secret used to sign or authenticate payloads.
+	Secret []byte
+	// Now returns the current time. Default: time.Now.
+	Now func() time.Time
+}
+
+// ServeHTTP implements http.Handler.
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxPayloadBytes+1))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("reading payload: %v", err), http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxPayloadBytes {
+		http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	provider := detectProvider(req.Header)
+	if err := authenticate(provider, req.Header, body, r.Secret); err != nil {
+		klog.Warningf("Rejected %s webhook: %v", provider, err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if !isPushEvent(provider, req.Header) {
+		klog.V(3).Infof("Ignored %s webhook: not a push event", provider)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	pushes, err := parsePushes(provider, body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	notified, err := r.Notify(req.Context(), pushes...)
+	if err != nil {
+		klog.Errorf("Failed to notify %s push: %v", provider, err)
+		http.Error(w, "failed to notify reconcilers", http.StatusInternalServerError)
+		return
+	}
+	klog.Infof("Notified %d RootSyncs and RepoSyncs of %s push", notified, provider)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// Notify sets the source change annotation on every RootSync and RepoSync
+// syncing from one of the pushes, and returns how many were notified.
+func (r *Receiver) Notify(ctx context.Context, pushes ...Push) (int, error) {
+	if len(pushes) == 0 {
+		return 0, nil
+	}
+	var syncObjs []client.Object
+	rootSyncs := &v1beta1.RootSyncList{}
+	if err := r.Client.List(ctx, rootSyncs); err != nil {
+		return 0, status.APIServerError(err, "failed to list RootSyncs")
+	}
+	for i := range rootSyncs.Items {
+		rs := &rootSyncs.Items[i]
+		gitSources := []*v1beta1.Git{}
+		if rs.Spec.SourceType == configsync.GitSource || rs.Spec.SourceType == "" {
+			gitSources = append(gitSources, rs.Spec.Git)
+		}
+		for _, source := range rs.Spec.Sources {
+			if source.SourceType == configsync.GitSource || source.SourceType == "" {
+				gitSources = append(gitSources, source.Git)
+			}
+		}
+		if matchesAnyPush(pushes, gitSources...) {
+			syncObjs = append(syncObjs, rs)
+		}
+	}
+	repoSyncs := &v1beta1.RepoSyncList{}
+	if err := r.Client.List(ctx, repoSyncs); err != nil {
+		return 0, status.APIServerError(err, "failed to list RepoSyncs")
+	}
+	for i := range repoSyncs.Items {
+		rs := &repoSyncs.Items[i]
+		if rs.Spec.SourceType != configsync.GitSource && rs.Spec.SourceType != "" {
+			continue
+		}
+		if matchesAnyPush(pushes, rs.Spec.Git) {
+			syncObjs = append(syncObjs, rs)
+		}
+	}
+
+	changeID := r.changeID(pushes)
+	var errs []error
+	for _, obj := range syncObjs {
+		if err := r.annotate(ctx, obj, changeID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return len(syncObjs) - len(errs), errors.Join(errs...)
+}
+
+// changeID returns the value of the source change annotation: the pushed
+// commit if there is only one, or the current time otherwise.
+func (r *Receiver) changeID(pushes []Push) string {
+	if len(pushes) == 1 && pushes[0].Commit != "" {
+		return pushes[0].Commit
+	}
+	now := time.Now
+	if r.Now != nil {
+		now = r.Now
+	}
+	return now().UTC().Format(time.RFC3339Nano)
+}
+
+// annotate patches the source change annotation on the RootSync or RepoSync.
+func (r *Receiver) annotate(ctx context.Context, obj client.Object, value string) error {
+	patch := fmt.Sprintf(`{"metadata": {"annotations": {%q: %q}}}`,
+		metadata.SourceChangeAnnotationKey, value)
+	err := r.Client.Patch(ctx, obj, client.RawPatch(types.MergePatchType, []byte(patch)),
+		client.FieldOwner(reconcilermanager.FieldManager))
+	if err != nil {
+		return status.APIServerError(err, fmt.Sprintf("failed to annotate %T %s", obj, client.ObjectKeyFromObject(obj)))
+	}
+	return nil
+}
+
+// matchesAnyPush returns true if one of the Git sources syncs from one of the
+// pushes.
+func matchesAnyPush(pushes []Push, gitSources ...*v1beta1.Git) bool {
+	for _, git := range gitSources {
+		if git == nil {
+			continue
+		}
+		for _, push := range pushes {
+			if matchesRepo(git.Repo, push.Repos) && matchesRef(git, push.Ref) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchesRepo returns true if the repository URL identifies the same
+// repository as one of the pushed repository URLs.
+func matchesRepo(repo string, pushedRepos []string) bool {
+	want := normalizeRepo(repo)
+	if want == "" {
+		return false
+	}
+	for _, pushed := range pushedRepos {
+		if normalizeRepo(pushed) == want {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesRef returns true if the Git source syncs from the pushed ref.
+// Without a ref, every push to the repository matches. With a revision
+// constraint, pushed tags matching the constraint match.
+func matchesRef(git *v1beta1.Git, pushedRef string) bool {
+	if pushedRef == "" {
+		return true
+	}
+	if gitutil.IsRevisionConstraint(git.Revision) {
+		// Any pushed tag matching the constraint may be the new highest tag.
+		tag, found := strings.CutPrefix(pushedRef, tagRefPrefix)
+		return found && gitutil.MatchesConstraint(git.Revision, tag)
+	}
+	ref := git.Revision
+	if ref == "" || ref == controllers.DefaultSyncRev {
+		ref = git.Branch
+		if ref == "" {
+			ref = controllers.DefaultSyncBranch
+		}
+	}
+	switch pushedRef {
+	case ref, branchRefPrefix + ref, tagRefPrefix + ref:
+		return true
+	}
+	return strings.TrimPrefix(pushedRef, branchRefPrefix) == strings.TrimPrefix(ref, branchRefPrefix)
+}
+
+// normalizeRepo returns the host and path of a repository URL, so the HTTPS,
+// SSH and web URLs of a repository are equal. For example,
+// `https://github.com/org/repo.git`, `git@github.com:org/repo.git` and
+// `ssh://git@github.com:22/org/repo` all return `github.com/org/repo`.
+func normalizeRepo(repo string) string {
+	repo = strings.TrimSpace(repo)
+	if repo == "" {
+		return ""
+	}
+	if strings.Contains(repo, "://") {
+		if u, err := url.Parse(repo); err == nil {
+			repo = u.Hostname() + u.Path
+		}
+	} else if host, path, found := strings.Cut(repo, ":"); found && !strings.Contains(host, "/") {
+		// scp-like syntax: [user@]host:path
+		if _, h, found := strings.Cut(host, "@"); found {
+			host = h
+		}
+		repo = host + "/" + strings.TrimPrefix(path, "/")
+	}
+	repo = strings.TrimSuffix(repo, "/")
+	repo = strings.TrimSuffix(repo, ".git")
+	return strings.ToLower(repo)
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package drift records the drift the remediator detects when the drift is
+// reported instead of reverted, and reports it on the ResourceGroup status.
+package drift
+
+import (
+	"sync"
+
+	"github.com/elliotchance/orderedmap/v2"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/diff"
+)
+
+// Drift describes how a managed object drifted from its declared state.
+type Drift struct {
+	// ID of the drifted object.
+	ID core.ID
+	// Operation is the operation which would revert the drift.
+	Operation diff.Operation
+	// Fields are the paths of the declared fields which differ on the
+	// cluster, when the Operation is diff.Update.
+	Fields []string
+}
+
+// Handler is the generic interface of the drift handler.
+type Handler interface {
+	// AddDrift records the drift of an object, replacing its previous drift.
+	AddDrift(Drift)
+	// RemoveDrift removes the drift of an object, if any.
+	RemoveDrift(core.ID)
+	// Drifts returns the drift of all the drifted objects.
+	Drifts() []Drift
+}
+
+// handler implements Handler.
+type handler struct {
+	// mux guards the drifts
+	mux    sync.RWMutex
+	drifts *orderedmap.OrderedMap[core.ID, Drift]
+}
+
+var _ Handler = &handler{}
+
+// NewHandler instantiates a drift handler
+func NewHandler() Handler {
+	return &handler{
+		drifts: orderedmap.NewOrderedMap[core.ID, Drift](),
+	}
+}
+
+func (h *handler) AddDrift(d Drift) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	if _, found := h.drifts.Get(d.ID); !found {
+		klog.Infof("Drift detected for %s: %s %v", d.ID, d.Operation, d.Fields)
+	}
+	h.drifts.Set(d.ID, d)
+}
+
+func (h *handler) RemoveDrift(id core.ID) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	if h.drifts.Delete(id) {
+		klog.Infof("Drift resolved for %s", id)
+	}
+}
+
+func (h *handler) Drifts() []Drift {
+	h.mux.RLock()
+	defer h.mux.RUnlock()
+
+	// Return a copy
+	var result []Drift
+	for pair := h.drifts.Front(); pair != nil; pair = pair.Next() {
+		result = append(result, pair.Value)
+	}
+	return result
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift
+
+import (
+	"context"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/retry"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/kpt.dev/v1alpha1"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/diff"
+	"kpt.dev/configsync/pkg/metrics"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Reporter reports the drift recorded by a Handler on the status of the
+// ResourceGroup inventory of the RSync, and as a metric.
+type Reporter struct {
+	// Client reads and updates the ResourceGroup.
+	Client client.Client
+	// Key is the namespace and name of the ResourceGroup.
+	Key client.ObjectKey
+	// Handler records the drift.
+	Handler Handler
+}
+
+// Report updates the ResourceGroup status with the current drift, if it
+// changed. The ResourceGroup is created by the applier, so nothing is reported
+// until it exists.
+func (r *Reporter) Report(ctx context.Context) status.Error {
+	drifted := r.driftedResources()
+	metrics.RecordDriftedResources(ctx, len(drifted))
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		rg := &v1alpha1.ResourceGroup{}
+		if err := r.Client.Get(ctx, r.Key, rg); err != nil {
+			return err
+		}
+		if apiequality.Semantic.DeepEqual(rg.Status.DriftedResources, drifted) {
+			return nil
+		}
+		rg.Status.DriftedResources = drifted
+		return r.Client.Status().Update(ctx, rg, client.FieldOwner(configsync.FieldManager))
+	})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return status.APIServerErrorf(err, "failed to update the drifted resources of ResourceGroup %s", r.Key)
+	}
+	return nil
+}
+
+// Restore records the drift reported on the ResourceGroup status, if any, so
+// the drift reported before a restart is not reverted by the applier before
+// the remediator detects it again.
+func (r *Reporter) Restore(ctx context.Context) status.Error {
+	rg := &v1alpha1.ResourceGroup{}
+	if err := r.Client.Get(ctx, r.Key, rg); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return status.APIServerErrorf(err, "failed to get the drifted resources of ResourceGroup %s", r.Key)
+	}
+	for _, d := range rg.Status.DriftedResources {
+		r.Handler.AddDrift(Drift{
+			ID: core.ID{
+				GroupKind: schema.GroupKind{Group: d.Group, Kind: d.Kind},
+				ObjectKey: client.ObjectKey{Namespace: d.Namespace, Name: d.Name},
+			},
+			Operation: diff.Operation(d.Operation),
+			Fields:    d.Fields,
+		})
+	}
+	return nil
+}
+
+func (r *Reporter) driftedResources() []v1alpha1.DriftedResource {
+	var drifted []v1alpha1.DriftedResource
+	for _, d := range r.Handler.Drifts() {
+		drifted = append(drifted, v1alpha1.DriftedResource{
+			ObjMetadata: v1alpha1.ObjMetadata{
+				Namespace: d.ID.Namespace,
+				Name:      d.ID.Name,
+				GroupKind: v1alpha1.GroupKind{
+					Group: d.ID.Group,
+					Kind:  d.ID.Kind,
+				},
+			},
+			Operation: string(d.Operation),
+			Fields:    d.Fields,
+		})
+	}
+	return drifted
+}
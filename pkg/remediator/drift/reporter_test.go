@@ -0,0 +1,109 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"kpt.dev/configsync/pkg/api/kpt.dev/v1alpha1"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/diff"
+	syncertest "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestReporter(t *testing.T) {
+	key := client.ObjectKey{Namespace: "config-management-system", Name: "root-sync"}
+	deploymentID := core.ID{
+		GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+		ObjectKey: client.ObjectKey{Namespace: "prod", Name: "app"},
+	}
+	configMapID := core.ID{
+		GroupKind: schema.GroupKind{Kind: "ConfigMap"},
+		ObjectKey: client.ObjectKey{Namespace: "prod", Name: "settings"},
+	}
+
+	rg := &v1alpha1.ResourceGroup{}
+	rg.Name = key.Name
+	rg.Namespace = key.Namespace
+	fakeClient := syncertest.NewClient(t, core.Scheme, rg)
+
+	h := NewHandler()
+	h.AddDrift(Drift{ID: deploymentID, Operation: diff.Update, Fields: []string{".spec.replicas"}})
+	h.AddDrift(Drift{ID: configMapID, Operation: diff.Create})
+	r := &Reporter{Client: fakeClient, Key: key, Handler: h}
+	require.NoError(t, r.Report(context.Background()))
+
+	got := &v1alpha1.ResourceGroup{}
+	require.NoError(t, fakeClient.Get(context.Background(), key, got))
+	assert.Equal(t, []v1alpha1.DriftedResource{
+		{
+			ObjMetadata: v1alpha1.ObjMetadata{Namespace: "prod", Name: "app", GroupKind: v1alpha1.GroupKind{Group: "apps", Kind: "Deployment"}},
+			Operation:   "update",
+			Fields:      []string{".spec.replicas"},
+		},
+		{
+			ObjMetadata: v1alpha1.ObjMetadata{Namespace: "prod", Name: "settings", GroupKind: v1alpha1.GroupKind{Kind: "ConfigMap"}},
+			Operation:   "create",
+		},
+	}, got.Status.DriftedResources)
+
+	// Resolved drift is removed from the status.
+	h.RemoveDrift(deploymentID)
+	h.RemoveDrift(configMapID)
+	require.NoError(t, r.Report(context.Background()))
+	require.NoError(t, fakeClient.Get(context.Background(), key, got))
+	assert.Empty(t, got.Status.DriftedResources)
+
+	// Nothing is reported until the applier creates the ResourceGroup.
+	r.Key = client.ObjectKey{Namespace: "prod", Name: "repo-sync"}
+	assert.NoError(t, r.Report(context.Background()))
+}
+
+func TestReporterRestore(t *testing.T) {
+	key := client.ObjectKey{Namespace: "config-management-system", Name: "root-sync"}
+	rg := &v1alpha1.ResourceGroup{}
+	rg.Name = key.Name
+	rg.Namespace = key.Namespace
+	rg.Status.DriftedResources = []v1alpha1.DriftedResource{{
+		ObjMetadata: v1alpha1.ObjMetadata{Namespace: "prod", Name: "app", GroupKind: v1alpha1.GroupKind{Group: "apps", Kind: "Deployment"}},
+		Operation:   "update",
+		Fields:      []string{".spec.replicas"},
+	}}
+	fakeClient := syncertest.NewClient(t, core.Scheme, rg)
+
+	// The drift reported before a restart is recorded again.
+	h := NewHandler()
+	r := &Reporter{Client: fakeClient, Key: key, Handler: h}
+	require.NoError(t, r.Restore(context.Background()))
+	assert.Equal(t, []Drift{{
+		ID: core.ID{
+			GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+			ObjectKey: client.ObjectKey{Namespace: "prod", Name: "app"},
+		},
+		Operation: diff.Update,
+		Fields:    []string{".spec.replicas"},
+	}}, h.Drifts())
+
+	// Nothing is restored before the applier creates the ResourceGroup.
+	h = NewHandler()
+	r = &Reporter{Client: fakeClient, Key: client.ObjectKey{Namespace: "prod", Name: "repo-sync"}, Handler: h}
+	require.NoError(t, r.Restore(context.Background()))
+	assert.Empty(t, h.Drifts())
+}
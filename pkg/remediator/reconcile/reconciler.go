@@ -18,6 +18,7 @@ import (
 	"context"
 	"time"
 
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/declared"
@@ -26,6 +27,7 @@ import (
 	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/metrics"
 	"kpt.dev/configsync/pkg/remediator/conflict"
+	"kpt.dev/configsync/pkg/remediator/drift"
 	"kpt.dev/configsync/pkg/status"
 	syncerclient "kpt.dev/configsync/pkg/syncer/client"
 	"kpt.dev/configsync/pkg/syncer/reconcile"
@@ -51,6 +53,11 @@ type reconciler struct {
 
 	conflictHandler conflict.Handler
 	fightHandler    fight.Handler
+	// driftHandler records the drift instead of reverting it, if not nil.
+	driftHandler drift.Handler
+	// ignoreRules select the fields whose drift is neither reverted nor
+	// reported.
+	ignoreRules diff.IgnoreRules
 }
 
 // newReconciler instantiates a new reconciler.
@@ -61,6 +68,8 @@ func newReconciler(
 	declared *declared.Resources,
 	conflictHandler conflict.Handler,
 	fightHandler fight.Handler,
+	driftHandler drift.Handler,
+	ignoreRules diff.IgnoreRules,
 ) *reconciler {
 	return &reconciler{
 		scope:           scope,
@@ -69,6 +78,8 @@ func newReconciler(
 		declared:        declared,
 		conflictHandler: conflictHandler,
 		fightHandler:    fightHandler,
+		driftHandler:    driftHandler,
+		ignoreRules:     ignoreRules,
 	}
 }
 
@@ -123,7 +134,20 @@ func (r *reconciler) Remediate(ctx context.Context, id core.ID, obj client.Objec
 // Remediate takes diff (declared & actual) and ensures the server matches the
 // declared state.
 func (r *reconciler) remediate(ctx context.Context, id core.ID, objDiff diff.Diff) status.Error {
-	switch t := objDiff.Operation(r.scope, r.syncName); t {
+	objDiff, err := objDiff.IgnoringDifferences(r.ignoreRules)
+	if err != nil {
+		return err
+	}
+	t := objDiff.Operation(r.scope, r.syncName)
+	if r.driftHandler != nil {
+		switch t {
+		case diff.NoOp, diff.Create, diff.Update, diff.Delete:
+			if reported, err := r.reportDrift(id, objDiff, t); err != nil || reported {
+				return err
+			}
+		}
+	}
+	switch t {
 	case diff.NoOp:
 		return nil
 	case diff.ManagementConflict:
@@ -200,6 +224,56 @@ func (r *reconciler) remediate(ctx context.Context, id core.ID, objDiff diff.Dif
 	}
 }
 
+// reportDrift records the drift of the object instead of reverting it.
+// Changes to the Config Sync metadata alone are not drift, so they are not
+// reported, and are still handled by remediate.
+//
+// Returns true if the object was handled, and false if it must be remediated.
+func (r *reconciler) reportDrift(id core.ID, objDiff diff.Diff, operation diff.Operation) (bool, status.Error) {
+	switch operation {
+	case diff.NoOp:
+		r.driftHandler.RemoveDrift(id)
+	case diff.Update:
+		fields, err := driftedFieldsWithoutMetadata(objDiff)
+		if err != nil {
+			return true, err
+		}
+		if len(fields) == 0 {
+			r.driftHandler.RemoveDrift(id)
+			return false, nil
+		}
+		klog.V(3).Infof("Remediator reporting drifted object: %v: %v", id, fields)
+		r.driftHandler.AddDrift(drift.Drift{ID: id, Operation: operation, Fields: fields})
+	default:
+		klog.V(3).Infof("Remediator reporting drifted object: %v: %s", id, operation)
+		r.driftHandler.AddDrift(drift.Drift{ID: id, Operation: operation})
+	}
+	return true, nil
+}
+
+// driftedFieldsWithoutMetadata returns the drifted fields of the object,
+// ignoring the Config Sync metadata.
+func driftedFieldsWithoutMetadata(objDiff diff.Diff) ([]string, status.Error) {
+	declared, err := objDiff.UnstructuredDeclared()
+	if err != nil {
+		return nil, err
+	}
+	actual, err := objDiff.UnstructuredActual()
+	if err != nil {
+		return nil, err
+	}
+	if declared == nil || actual == nil {
+		return nil, nil
+	}
+	declared = declared.DeepCopy()
+	actual = actual.DeepCopy()
+	for _, obj := range []*unstructured.Unstructured{declared, actual} {
+		metadata.RemoveConfigSyncMetadata(obj)
+		core.RemoveLabels(obj, metadata.ApplySetPartOfLabel)
+	}
+	return diff.Diff{Declared: declared, Actual: actual}.DriftedFields()
+}
+
 // GetClient returns the reconciler's underlying client.Client.
 func (r *reconciler) GetClient() client.Client {
 	return r.applier.GetClient()
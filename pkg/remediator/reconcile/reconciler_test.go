@@ -19,20 +19,24 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/core/k8sobjects"
 	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/diff"
 	"kpt.dev/configsync/pkg/importer/analyzer/validation/nonhierarchical"
 	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/metrics"
 	"kpt.dev/configsync/pkg/policycontroller"
 	"kpt.dev/configsync/pkg/remediator/conflict"
+	"kpt.dev/configsync/pkg/remediator/drift"
 	"kpt.dev/configsync/pkg/status"
 	syncerclient "kpt.dev/configsync/pkg/syncer/client"
 	"kpt.dev/configsync/pkg/syncer/syncertest"
@@ -407,7 +411,7 @@ func TestRemediator_Reconcile(t *testing.T) {
 			}
 
 			r := newReconciler(declared.RootScope, configsync.RootSyncName, c.Applier(configsync.FieldManager), d,
-				tc.conflictHandler, testingfake.NewFightHandler())
+				tc.conflictHandler, testingfake.NewFightHandler(), nil, nil)
 
 			// Get the triggering object for the reconcile event.
 			var obj client.Object
@@ -436,6 +440,160 @@ func TestRemediator_Reconcile(t *testing.T) {
 	}
 }
 
+func TestRemediator_Reconcile_DriftReportMode(t *testing.T) {
+	manager := core.Annotation(metadata.ResourceManagerKey, declared.ResourceManager(declared.RootScope, configsync.RootSyncName))
+	declaredObj := k8sobjects.NamespaceObject("test-namespace", syncertest.ManagementEnabled, manager,
+		core.Label("new-label", "one"))
+	id := core.IDOf(declaredObj)
+
+	testCases := []struct {
+		name string
+		// declared is the declared state of the object, if not declaredObj.
+		declared client.Object
+		// actual is the current state of the object on the cluster.
+		actual client.Object
+		// existingDrift is the drift previously recorded for the object.
+		existingDrift []drift.Drift
+		// want is the state of the object on the cluster after remediation.
+		want client.Object
+		// wantDrifts is the recorded drift after remediation.
+		wantDrifts []drift.Drift
+	}{
+		{
+			name: "changed object is reported, not reverted",
+			actual: k8sobjects.NamespaceObject("test-namespace", syncertest.ManagementEnabled, manager,
+				core.Label("new-label", "two")),
+			want: k8sobjects.NamespaceObject("test-namespace", syncertest.ManagementEnabled, manager,
+				core.Label("new-label", "two"),
+				core.UID("1"), core.ResourceVersion("1"), core.Generation(1)),
+			wantDrifts: []drift.Drift{
+				{ID: id, Operation: diff.Update, Fields: []string{".metadata.labels.new-label"}},
+			},
+		},
+		{
+			name: "changed Config Sync metadata is updated, not reported",
+			declared: k8sobjects.NamespaceObject("test-namespace", syncertest.ManagementEnabled, manager,
+				core.Label("new-label", "one"),
+				core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/new.yaml")),
+			actual: k8sobjects.NamespaceObject("test-namespace", syncertest.ManagementEnabled, manager,
+				core.Label("new-label", "one"),
+				core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/old.yaml")),
+			existingDrift: []drift.Drift{{ID: id, Operation: diff.Create}},
+			want: k8sobjects.NamespaceObject("test-namespace", syncertest.ManagementEnabled, manager,
+				core.Label("new-label", "one"),
+				core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/new.yaml"),
+				core.UID("1"), core.ResourceVersion("2"), core.Generation(1)),
+		},
+		{
+			name:       "deleted object is reported, not re-created",
+			wantDrifts: []drift.Drift{{ID: id, Operation: diff.Create}},
+		},
+		{
+			name: "reverted change resolves the drift",
+			actual: k8sobjects.NamespaceObject("test-namespace", syncertest.ManagementEnabled, manager,
+				core.Label("new-label", "one")),
+			existingDrift: []drift.Drift{{ID: id, Operation: diff.Create}},
+			want: k8sobjects.NamespaceObject("test-namespace", syncertest.ManagementEnabled, manager,
+				core.Label("new-label", "one"),
+				core.UID("1"), core.ResourceVersion("1"), core.Generation(1)),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var existingObjs []client.Object
+			if tc.actual != nil {
+				existingObjs = append(existingObjs, tc.actual)
+			}
+			c := testingfake.NewClient(t, core.Scheme, existingObjs...)
+			var decl client.Object = declaredObj
+			if tc.declared != nil {
+				decl = tc.declared
+			}
+			d := makeDeclared(t, "unused", decl)
+			driftHandler := drift.NewHandler()
+			for _, existing := range tc.existingDrift {
+				driftHandler.AddDrift(existing)
+			}
+
+			r := newReconciler(declared.RootScope, configsync.RootSyncName, c.Applier(configsync.FieldManager), d,
+				testingfake.NewConflictHandler(), testingfake.NewFightHandler(), driftHandler, nil)
+
+			err := r.Remediate(context.Background(), id, tc.actual)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantDrifts, driftHandler.Drifts())
+
+			if tc.want == nil {
+				c.Check(t)
+			} else {
+				c.Check(t, tc.want)
+			}
+		})
+	}
+}
+
+func TestRemediator_Reconcile_IgnoreDifferences(t *testing.T) {
+	manager := core.Annotation(metadata.ResourceManagerKey, declared.ResourceManager(declared.RootScope, configsync.RootSyncName))
+	declaredObj := k8sobjects.NamespaceObject("test-namespace", syncertest.ManagementEnabled, manager,
+		core.Label("new-label", "one"), core.Label("ignored-label", "one"))
+	id := core.IDOf(declaredObj)
+	ignoreRules, err := diff.NewIgnoreRules([]v1beta1.IgnoreDifference{{
+		Kind:      "Namespace",
+		JSONPaths: []string{".metadata.labels.ignored-label"},
+	}})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		// driftHandler is nil in the enforce mode.
+		driftHandler drift.Handler
+		// actual is the current state of the object on the cluster.
+		actual client.Object
+		// want is the state of the object on the cluster after remediation.
+		want client.Object
+		// wantDrifts is the recorded drift after remediation.
+		wantDrifts []drift.Drift
+	}{
+		{
+			name: "ignored field is not reverted",
+			actual: k8sobjects.NamespaceObject("test-namespace", syncertest.ManagementEnabled, manager,
+				core.Label("new-label", "one"), core.Label("ignored-label", "two")),
+			want: k8sobjects.NamespaceObject("test-namespace", syncertest.ManagementEnabled, manager,
+				core.Label("new-label", "one"), core.Label("ignored-label", "two"),
+				core.UID("1"), core.ResourceVersion("1"), core.Generation(1)),
+		},
+		{
+			name:         "ignored field is not reported",
+			driftHandler: drift.NewHandler(),
+			actual: k8sobjects.NamespaceObject("test-namespace", syncertest.ManagementEnabled, manager,
+				core.Label("new-label", "two"), core.Label("ignored-label", "two")),
+			want: k8sobjects.NamespaceObject("test-namespace", syncertest.ManagementEnabled, manager,
+				core.Label("new-label", "two"), core.Label("ignored-label", "two"),
+				core.UID("1"), core.ResourceVersion("1"), core.Generation(1)),
+			wantDrifts: []drift.Drift{
+				{ID: id, Operation: diff.Update, Fields: []string{".metadata.labels.new-label"}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := testingfake.NewClient(t, core.Scheme, tc.actual)
+			d := makeDeclared(t, "unused", declaredObj)
+
+			r := newReconciler(declared.RootScope, configsync.RootSyncName, c.Applier(configsync.FieldManager), d,
+				testingfake.NewConflictHandler(), testingfake.NewFightHandler(), tc.driftHandler, ignoreRules)
+
+			err := r.Remediate(context.Background(), id, tc.actual)
+			assert.NoError(t, err)
+			if tc.driftHandler != nil {
+				assert.Equal(t, tc.wantDrifts, tc.driftHandler.Drifts())
+			}
+			c.Check(t, tc.want)
+		})
+	}
+}
+
 func TestRemediator_Reconcile_Metrics(t *testing.T) {
 	testCases := []struct {
 		name string
@@ -571,7 +729,7 @@ func TestRemediator_Reconcile_Metrics(t *testing.T) {
 			fakeApplier.DeleteError = tc.deleteError
 
 			reconciler := newReconciler(declared.RootScope, configsync.RootSyncName, fakeApplier, d,
-				testingfake.NewConflictHandler(), testingfake.NewFightHandler())
+				testingfake.NewConflictHandler(), testingfake.NewFightHandler(), nil, nil)
 
 			// Get the triggering object for the reconcile event.
 			var obj client.Object
@@ -22,8 +22,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/diff"
 	"kpt.dev/configsync/pkg/reconcilermanager/controllers"
 	"kpt.dev/configsync/pkg/remediator/conflict"
+	"kpt.dev/configsync/pkg/remediator/drift"
 	"kpt.dev/configsync/pkg/remediator/queue"
 	"kpt.dev/configsync/pkg/remediator/reconcile"
 	"kpt.dev/configsync/pkg/remediator/watch"
@@ -95,6 +97,10 @@ var _ Interface = &Remediator{}
 //
 // It is safe for decls to be modified after they have been passed into the
 // Remediator.
+//
+// If driftHandler is not nil, the drift is recorded by the driftHandler
+// instead of reverted. The fields selected by ignoreRules are neither reverted
+// nor reported.
 func New(
 	scope declared.Scope,
 	syncName string,
@@ -103,6 +109,8 @@ func New(
 	applier syncerreconcile.Applier,
 	conflictHandler conflict.Handler,
 	fightHandler fight.Handler,
+	driftHandler drift.Handler,
+	ignoreRules diff.IgnoreRules,
 	crdController *controllers.CRDController,
 	decls *declared.Resources,
 	numWorkers int,
@@ -110,7 +118,7 @@ func New(
 	q := queue.New(scope.String())
 	workers := make([]*reconcile.Worker, numWorkers)
 	for i := 0; i < numWorkers; i++ {
-		workers[i] = reconcile.NewWorker(scope, syncName, applier, q, decls, conflictHandler, fightHandler)
+		workers[i] = reconcile.NewWorker(scope, syncName, applier, q, decls, conflictHandler, fightHandler, driftHandler, ignoreRules)
 	}
 
 	remediator := &Remediator{
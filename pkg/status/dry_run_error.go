@@ -0,0 +1,35 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"kpt.dev/configsync/pkg/core"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DryRunErrorCode is the error code for a status Error returned when the
+// server-side dry-run of a declared object fails before the apply.
+const DryRunErrorCode = "2019"
+
+var dryRunErrorBuilder = NewErrorBuilder(DryRunErrorCode)
+
+// DryRunError indicates that the API server or an admission webhook rejected
+// the server-side dry-run of the object, so none of the objects were applied.
+func DryRunError(err error, obj client.Object) Error {
+	return dryRunErrorBuilder.
+		Sprintf("server-side dry-run failed for %s, none of the objects were applied", core.IDOf(obj)).
+		Wrap(err).
+		BuildWithResources(obj)
+}
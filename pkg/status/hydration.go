@@ -14,6 +14,8 @@
 
 package status
 
+import "kpt.dev/configsync/pkg/importer/id"
+
 // InternalHydrationErrorCode is the error code for an internal Error related to the hydration process.
 const InternalHydrationErrorCode = "2015"
 
@@ -42,3 +44,14 @@ func HydrationError(code string, err error) Error {
 		return internalHydrationErrorBuilder.Wrap(err).Build()
 	}
 }
+
+// HydrationPathError returns a hydration error about the source files at the
+// paths.
+func HydrationPathError(code string, err error, paths ...id.Path) Error {
+	switch code {
+	case ActionableHydrationErrorCode:
+		return actionableHydrationErrorBuilder.Wrap(err).BuildWithPaths(paths...)
+	default:
+		return internalHydrationErrorBuilder.Wrap(err).BuildWithPaths(paths...)
+	}
+}
@@ -14,8 +14,46 @@
 
 package status
 
+import (
+	"errors"
+	"strings"
+)
+
 // SourceErrorCode is the error code for a status Error related to the repo's source of truth.
 const SourceErrorCode = "2004"
 
 // SourceError is an ErrorBuilder for errors related to the repo's source of truth.
 var SourceError = NewErrorBuilder(SourceErrorCode)
+
+// SourceVerificationErrorCode is the error code for a status Error returned
+// when the signature of the source of truth cannot be verified.
+const SourceVerificationErrorCode = "2018"
+
+// sourceVerificationErrorBuilder is an ErrorBuilder for errors related to the
+// verification of the source of truth.
+var sourceVerificationErrorBuilder = NewErrorBuilder(SourceVerificationErrorCode)
+
+// SourceVerificationError returns an error indicating the fetched source
+// could not be verified against the trusted keys.
+func SourceVerificationError(err error) Error {
+	return sourceVerificationErrorBuilder.Wrap(err).Build()
+}
+
+// IsSourceVerificationErrorMessage returns whether the error message, as
+// reported by a *-sync container, was caused by a SourceVerificationError.
+func IsSourceVerificationErrorMessage(msg string) bool {
+	return strings.Contains(msg, prefix(knv(SourceVerificationErrorCode)))
+}
+
+// SourceVerificationErrorFromMessage returns the SourceVerificationError
+// reported by a *-sync container in its error file, prefixed with the context.
+// The error code and documentation link already included in the message are
+// stripped, so they are not repeated.
+func SourceVerificationErrorFromMessage(context, msg string) Error {
+	body := msg
+	if i := strings.Index(body, prefix(knv(SourceVerificationErrorCode))); i >= 0 {
+		body = body[i+len(prefix(knv(SourceVerificationErrorCode))):]
+	}
+	body = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(body), url(SourceVerificationErrorCode)))
+	return sourceVerificationErrorBuilder.Sprint(context).Wrap(errors.New(body)).Build()
+}
@@ -952,6 +952,14 @@ func (ms *MemoryStorage) Patch(ctx context.Context, obj client.Object, patch cli
 	} else {
 		uObj.SetGeneration(1)
 	}
+	if opts != nil && len(opts.DryRun) > 0 {
+		// don't store the result, but return it like the apiserver
+		if err := convertUnstructuredIntoObject(uObj, obj, ms.scheme); err != nil {
+			return fmt.Errorf("MemoryStorage.Patch: failed to update input object list: %w", err)
+		}
+		return nil
+	}
+
 	klog.V(5).Infof("Patching %s (Found: %v, Generation: %v, ResourceVersion: %q)",
 		id, found,
 		uObj.GetGeneration(), uObj.GetResourceVersion())
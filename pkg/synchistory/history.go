@@ -0,0 +1,218 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package synchistory records what the syncs of a RootSync or RepoSync changed
+// on the cluster, in a ConfigMap next to the RSync.
+package synchistory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/metadata"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Operation is the operation a sync performed on an object.
+type Operation string
+
+const (
+	// Create means the object was created.
+	Create Operation = "create"
+	// Update means the declared fields of the object were changed.
+	Update Operation = "update"
+	// Prune means the object was deleted, because it is no longer declared.
+	Prune Operation = "prune"
+	// Skip means the object was neither applied nor pruned, for example
+	// because its dependencies did not reconcile.
+	Skip Operation = "skip"
+)
+
+// ObjectChange describes the change a sync made to an object.
+type ObjectChange struct {
+	Group     string    `json:"group,omitempty"`
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace,omitempty"`
+	Name      string    `json:"name"`
+	Operation Operation `json:"operation"`
+	// Fields are the paths of the declared fields which changed, in the
+	// JSONPath notation, when the Operation is Update.
+	Fields []string `json:"fields,omitempty"`
+}
+
+// Entry records the changes of one sync. Objects which were unchanged are not
+// recorded.
+type Entry struct {
+	// Commit is the source commit which was synced.
+	Commit string `json:"commit"`
+	// Time is when the sync finished.
+	Time metav1.Time `json:"time"`
+	// Objects are the changed objects.
+	Objects []ObjectChange `json:"objects,omitempty"`
+	// Truncated is true if some changed objects were not recorded, to keep the
+	// history small.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+const (
+	// dataKey is the key of the JSON encoded entries in the ConfigMap.
+	dataKey = "history.json"
+	// MaxEntries is the number of syncs kept in the history.
+	MaxEntries = 10
+	// maxObjects is the number of changed objects recorded per sync.
+	maxObjects = 1000
+	// maxBytes bounds the size of the encoded entries, well below the 1MiB
+	// size limit of a ConfigMap.
+	maxBytes = 512 * 1024
+)
+
+// ConfigMapName returns the name of the ConfigMap which stores the history of
+// the RSync, in the namespace of the RSync.
+func ConfigMapName(syncName string) string {
+	return syncName + "-history"
+}
+
+// Record appends the entry to the history of the RSync, creating the history
+// ConfigMap if needed. The entry is merged into the latest entry instead if
+// both are for the same commit, so the retries of a failed sync do not fill the
+// history. The oldest entries are dropped to keep the history bounded.
+func Record(ctx context.Context, c client.Client, syncKind string, syncKey client.ObjectKey, entry Entry) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm := &corev1.ConfigMap{}
+		key := client.ObjectKey{Namespace: syncKey.Namespace, Name: ConfigMapName(syncKey.Name)}
+		err := c.Get(ctx, key, cm)
+		create := apierrors.IsNotFound(err)
+		if err != nil && !create {
+			return err
+		}
+		entries, err := decode(cm)
+		if err != nil {
+			// Start over rather than failing every sync on a corrupted history.
+			entries = nil
+		}
+		if last := len(entries) - 1; last >= 0 && entries[last].Commit == entry.Commit {
+			entries[last] = merge(entries[last], entry)
+		} else {
+			entries = append(entries, merge(Entry{}, entry))
+		}
+		if len(entries) > MaxEntries {
+			entries = entries[len(entries)-MaxEntries:]
+		}
+		data, err := encode(entries)
+		if err != nil {
+			return err
+		}
+
+		if create {
+			cm.Name = key.Name
+			cm.Namespace = key.Namespace
+		}
+		cm.Labels = map[string]string{
+			metadata.SyncKindLabel:      syncKind,
+			metadata.SyncNamespaceLabel: syncKey.Namespace,
+			metadata.SyncNameLabel:      syncKey.Name,
+		}
+		cm.Data = map[string]string{dataKey: data}
+		if create {
+			return c.Create(ctx, cm, client.FieldOwner(configsync.FieldManager))
+		}
+		return c.Update(ctx, cm, client.FieldOwner(configsync.FieldManager))
+	})
+}
+
+// Get returns the history of the RSync, oldest first.
+func Get(ctx context.Context, c client.Reader, syncKey client.ObjectKey) ([]Entry, error) {
+	cm := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: syncKey.Namespace, Name: ConfigMapName(syncKey.Name)}
+	if err := c.Get(ctx, key, cm); err != nil {
+		return nil, err
+	}
+	return decode(cm)
+}
+
+// ForCommit returns the entries of the history which synced the commit, or a
+// commit starting with the given prefix.
+func ForCommit(entries []Entry, commit string) []Entry {
+	var result []Entry
+	for _, e := range entries {
+		if commit != "" && strings.HasPrefix(e.Commit, commit) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// merge returns the previous entry with the changes of the next entry, and the
+// time of the next entry. An object changed by both entries with the same
+// operation is only recorded once.
+func merge(previous, next Entry) Entry {
+	result := Entry{
+		Commit:    next.Commit,
+		Time:      next.Time,
+		Objects:   slices.Clone(previous.Objects),
+		Truncated: previous.Truncated || next.Truncated,
+	}
+	for _, obj := range next.Objects {
+		i := slices.IndexFunc(result.Objects, func(o ObjectChange) bool {
+			return o.Group == obj.Group && o.Kind == obj.Kind && o.Namespace == obj.Namespace &&
+				o.Name == obj.Name && o.Operation == obj.Operation
+		})
+		switch {
+		case i >= 0:
+			fields := append(slices.Clone(result.Objects[i].Fields), obj.Fields...)
+			slices.Sort(fields)
+			result.Objects[i].Fields = slices.Compact(fields)
+		case len(result.Objects) < maxObjects:
+			result.Objects = append(result.Objects, obj)
+		default:
+			result.Truncated = true
+		}
+	}
+	return result
+}
+
+func decode(cm *corev1.ConfigMap) ([]Entry, error) {
+	data, found := cm.Data[dataKey]
+	if !found {
+		return nil, nil
+	}
+	var entries []Entry
+	if err := json.Unmarshal([]byte(data), &entries); err != nil {
+		return nil, fmt.Errorf("decoding the sync history %s/%s: %w", cm.Namespace, cm.Name, err)
+	}
+	return entries, nil
+}
+
+// encode encodes the entries, dropping the oldest ones while they are too
+// large.
+func encode(entries []Entry) (string, error) {
+	for {
+		data, err := json.Marshal(entries)
+		if err != nil {
+			return "", fmt.Errorf("encoding the sync history: %w", err)
+		}
+		if len(data) <= maxBytes || len(entries) == 1 {
+			return string(data), nil
+		}
+		entries = entries[1:]
+	}
+}
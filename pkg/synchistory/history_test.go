@@ -0,0 +1,91 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchistory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/metadata"
+	syncertest "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRecord(t *testing.T) {
+	ctx := context.Background()
+	syncKey := client.ObjectKey{Namespace: configsync.ControllerNamespace, Name: configsync.RootSyncName}
+	fakeClient := syncertest.NewClient(t, core.Scheme)
+
+	_, err := Get(ctx, fakeClient, syncKey)
+	assert.True(t, apierrors.IsNotFound(err), "expected NotFound, got %v", err)
+
+	deployment := ObjectChange{Group: "apps", Kind: "Deployment", Namespace: "prod", Name: "app", Operation: Update, Fields: []string{".spec.replicas"}}
+	configMap := ObjectChange{Kind: "ConfigMap", Namespace: "prod", Name: "settings", Operation: Create}
+	require.NoError(t, Record(ctx, fakeClient, configsync.RootSyncKind, syncKey, Entry{Commit: "abc123", Objects: []ObjectChange{deployment}}))
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: syncKey.Namespace, Name: "root-sync-history"}, cm))
+	assert.Equal(t, configsync.RootSyncKind, cm.Labels[metadata.SyncKindLabel])
+
+	// A retry of the same commit is merged into its entry.
+	retry := deployment
+	retry.Fields = []string{".spec.template"}
+	require.NoError(t, Record(ctx, fakeClient, configsync.RootSyncKind, syncKey, Entry{Commit: "abc123", Objects: []ObjectChange{retry, configMap}}))
+	entries, err := Get(ctx, fakeClient, syncKey)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	merged := deployment
+	merged.Fields = []string{".spec.replicas", ".spec.template"}
+	assert.Equal(t, []ObjectChange{merged, configMap}, entries[0].Objects)
+
+	// Only the latest entries are kept.
+	for i := 0; i < MaxEntries; i++ {
+		entry := Entry{Commit: fmt.Sprintf("def%d", i), Objects: []ObjectChange{configMap}}
+		require.NoError(t, Record(ctx, fakeClient, configsync.RootSyncKind, syncKey, entry))
+	}
+	entries, err = Get(ctx, fakeClient, syncKey)
+	require.NoError(t, err)
+	require.Len(t, entries, MaxEntries)
+	assert.Equal(t, "def0", entries[0].Commit)
+	assert.Empty(t, ForCommit(entries, "abc"))
+	assert.Len(t, ForCommit(entries, "def"), MaxEntries)
+	assert.Equal(t, "def9", ForCommit(entries, "def9")[0].Commit)
+	assert.Empty(t, ForCommit(entries, ""))
+}
+
+func TestRecordTruncates(t *testing.T) {
+	ctx := context.Background()
+	syncKey := client.ObjectKey{Namespace: "bookstore", Name: configsync.RepoSyncName}
+	fakeClient := syncertest.NewClient(t, core.Scheme)
+
+	var objects []ObjectChange
+	for i := 0; i < maxObjects+1; i++ {
+		objects = append(objects, ObjectChange{Kind: "ConfigMap", Namespace: "bookstore", Name: fmt.Sprintf("cm-%d", i), Operation: Create})
+	}
+	require.NoError(t, Record(ctx, fakeClient, configsync.RepoSyncKind, syncKey, Entry{Commit: "abc123", Objects: objects}))
+
+	entries, err := Get(ctx, fakeClient, syncKey)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Len(t, entries[0].Objects, maxObjects)
+	assert.True(t, entries[0].Truncated)
+}
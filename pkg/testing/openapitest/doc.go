@@ -24,6 +24,7 @@ import (
 	// the new non-deprecated proto library.
 	"github.com/golang/protobuf/proto" //nolint:staticcheck
 	openapiv2 "github.com/google/gnostic-models/openapiv2"
+	"k8s.io/kube-openapi/pkg/validation/spec"
 	"kpt.dev/configsync/pkg/declared"
 )
 
@@ -49,6 +50,24 @@ func Doc() (*openapiv2.Document, error) {
 	return doc, err
 }
 
+// Schemas returns the schemas of the openapi Document, keyed by their names.
+func Schemas() (map[string]*spec.Schema, error) {
+	doc, err := Doc()
+	if err != nil {
+		return nil, err
+	}
+	swagger := &spec.Swagger{}
+	if _, err := swagger.FromGnostic(doc); err != nil {
+		return nil, err
+	}
+	schemas := make(map[string]*spec.Schema, len(swagger.Definitions))
+	for name := range swagger.Definitions {
+		s := swagger.Definitions[name]
+		schemas[name] = &s
+	}
+	return schemas, nil
+}
+
 func pathToTestFile() (string, error) {
 	_, filename, _, ok := runtime.Caller(0)
 	if !ok {
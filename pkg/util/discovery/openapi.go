@@ -0,0 +1,119 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/openapi"
+	"k8s.io/kube-openapi/pkg/spec3"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"kpt.dev/configsync/pkg/status"
+)
+
+// OpenAPISchemas are the OpenAPI schemas of the types served by the cluster.
+type OpenAPISchemas struct {
+	// Schemas are keyed by the names used in their references.
+	Schemas map[string]*spec.Schema
+	// Generation changes whenever the schemas change, so the values computed
+	// from the schemas can be cached.
+	Generation string
+}
+
+// OpenAPISchemasFunc returns the OpenAPI schemas of the types served by the
+// cluster.
+type OpenAPISchemasFunc func() (OpenAPISchemas, status.MultiError)
+
+// NoOpenAPISchemas is an OpenAPISchemasFunc for when the cluster is not
+// available. Only the schemas of the declared CRDs are used.
+func NoOpenAPISchemas() (OpenAPISchemas, status.MultiError) {
+	return OpenAPISchemas{}, nil
+}
+
+// OpenAPIV3Schemas returns an OpenAPISchemasFunc which reads the schemas from
+// the OpenAPI v3 endpoint of the API server. The schemas of each group version
+// are cached until their hash changes, and the generation is derived from the
+// hashes. No schemas are returned if the API server doesn't serve OpenAPI v3.
+func OpenAPIV3Schemas(c openapi.Client) OpenAPISchemasFunc {
+	var mux sync.Mutex
+	// cache maps the server-relative URLs of the group versions, which include
+	// the hash of their schemas, to their schemas.
+	cache := make(map[string]map[string]*spec.Schema)
+	return func() (OpenAPISchemas, status.MultiError) {
+		mux.Lock()
+		defer mux.Unlock()
+
+		paths, err := c.Paths()
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return OpenAPISchemas{}, nil
+			}
+			return OpenAPISchemas{}, status.APIServerError(err, "failed to list the OpenAPI v3 schemas")
+		}
+		result := make(map[string]*spec.Schema)
+		newCache := make(map[string]map[string]*spec.Schema)
+		var urls []string
+		for path, gv := range paths {
+			url := gv.ServerRelativeURL()
+			schemas, found := cache[url]
+			if !found {
+				schemas, err = fetchOpenAPIV3Schemas(gv)
+				if err != nil {
+					return OpenAPISchemas{}, status.APIServerError(err, fmt.Sprintf("failed to get the OpenAPI v3 schemas of %s", path))
+				}
+			}
+			newCache[url] = schemas
+			urls = append(urls, url)
+			for name, s := range schemas {
+				result[name] = s
+			}
+		}
+		cache = newCache
+		return OpenAPISchemas{Schemas: result, Generation: generation(urls)}, nil
+	}
+}
+
+// generation returns the hash of the URLs of the group versions, which include
+// the hashes of their schemas.
+func generation(urls []string) string {
+	sort.Strings(urls)
+	h := sha256.New()
+	for _, url := range urls {
+		h.Write([]byte(url))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func fetchOpenAPIV3Schemas(gv openapi.GroupVersion) (map[string]*spec.Schema, error) {
+	data, err := gv.Schema("application/json")
+	if err != nil {
+		return nil, err
+	}
+	doc := &spec3.OpenAPI{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, err
+	}
+	if doc.Components == nil {
+		return nil, nil
+	}
+	return doc.Components.Schemas, nil
+}
@@ -19,6 +19,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/diff"
 	"kpt.dev/configsync/pkg/importer/analyzer/ast"
 	"kpt.dev/configsync/pkg/importer/customresources"
 	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
@@ -46,6 +47,9 @@ type Raw struct {
 	Converter         *declared.ValueConverter
 	Scheme            *runtime.Scheme
 	AllowUnknownKinds bool
+	// OpenAPISchemas returns the OpenAPI schemas of the types served by the
+	// cluster. Schema validation is skipped when nil.
+	OpenAPISchemas utildiscovery.OpenAPISchemasFunc
 	// AllowAPICall indicates whether the hydration process can send k8s API
 	// calls. Currently, only dynamic NamespaceSelector requires talking to
 	// k8s-api-server.
@@ -58,6 +62,9 @@ type Raw struct {
 	NSControllerState *namespacecontroller.State
 	// WebhookEnabled indicates whether Webhook configuration is enabled
 	WebhookEnabled bool
+	// IgnoreRules select the fields which are left out of the declared fields
+	// annotation, so the webhook does not protect them.
+	IgnoreRules diff.IgnoreRules
 }
 
 // Scoped builds a Scoped collection of objects from the Raw objects.
@@ -0,0 +1,50 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ValidationPolicyViolationErrorCode is the error code for an object which
+// violates a ValidationPolicy.
+const ValidationPolicyViolationErrorCode = "1072"
+
+var validationPolicyViolationErrorBuilder = status.NewErrorBuilder(ValidationPolicyViolationErrorCode)
+
+// ValidationPolicyViolationError reports that the object doesn't satisfy an
+// expression of the ValidationPolicy declared in the policy file.
+func ValidationPolicyViolationError(o client.Object, policy, policyFile, message string) status.Error {
+	return validationPolicyViolationErrorBuilder.
+		Sprintf("%s %q violates the ValidationPolicy %q declared in %s: %s",
+			o.GetObjectKind().GroupVersionKind().Kind, o.GetName(), policy, policyFile, message).
+		BuildWithResources(o)
+}
+
+// InvalidValidationPolicyErrorCode is the error code for an invalid
+// ValidationPolicy.
+const InvalidValidationPolicyErrorCode = "1073"
+
+var invalidValidationPolicyErrorBuilder = status.NewErrorBuilder(InvalidValidationPolicyErrorCode)
+
+// InvalidValidationPolicyError reports that the ValidationPolicy can't be
+// decoded or compiled.
+func InvalidValidationPolicyError(o ast.FileObject, message string) status.Error {
+	return invalidValidationPolicyErrorBuilder.
+		Sprintf("ValidationPolicy %q is invalid: %s", o.GetName(), message).
+		BuildWithPaths(o)
+}
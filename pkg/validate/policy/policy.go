@@ -0,0 +1,277 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policy evaluates the ValidationPolicies declared in the source
+// against the other objects declared in the source.
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/interpreter"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/filesystem"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
+	"kpt.dev/configsync/pkg/importer/reader"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/validate"
+)
+
+// ValidationPolicyGVK is the GroupVersionKind of the ValidationPolicy.
+// ValidationPolicies must be marked as local configuration, so they are never
+// applied to the cluster.
+var ValidationPolicyGVK = schema.GroupVersionKind{
+	Group:   configsync.GroupName,
+	Version: "v1alpha1",
+	Kind:    "ValidationPolicy",
+}
+
+// wildcard matches any API group or kind.
+const wildcard = "*"
+
+// objectVariable is the name of the CEL variable holding the validated
+// object.
+const objectVariable = "object"
+
+// costLimit is the maximum runtime cost of evaluating an expression against an
+// object, the same as the per-expression limit of the Kubernetes
+// ValidatingAdmissionPolicies. It bounds the time spent evaluating the
+// expressions declared in the source.
+const costLimit = 1000000
+
+// ValidationPolicy declares CEL expressions which every matching object in the
+// source must satisfy.
+type ValidationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ValidationPolicySpec `json:"spec"`
+}
+
+// ValidationPolicySpec is the spec of a ValidationPolicy.
+type ValidationPolicySpec struct {
+	// Match selects the objects validated by the policy.
+	// All objects are validated when empty.
+	Match Match `json:"match,omitempty"`
+
+	// Validations are the expressions which must all evaluate to true for the
+	// matching objects.
+	Validations []Validation `json:"validations"`
+}
+
+// Match selects objects by type and Namespace.
+type Match struct {
+	// Kinds selects the objects of the listed types.
+	// Objects of any type are selected when empty.
+	Kinds []KindMatch `json:"kinds,omitempty"`
+
+	// Namespaces selects the objects in the listed Namespaces.
+	// Objects in any Namespace, or cluster-scoped, are selected when empty.
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// KindMatch selects the objects of the listed kinds in the listed API groups.
+// "*" matches any API group or kind.
+type KindMatch struct {
+	APIGroups []string `json:"apiGroups,omitempty"`
+	Kinds     []string `json:"kinds,omitempty"`
+}
+
+// Validation is a CEL expression evaluated against the object, available as
+// the `object` variable.
+type Validation struct {
+	// Expression must evaluate to true for the object to be valid.
+	Expression string `json:"expression"`
+
+	// Message is reported when the expression evaluates to false.
+	// Defaults to the expression.
+	Message string `json:"message,omitempty"`
+}
+
+// Policy is a ValidationPolicy with its compiled expressions.
+type Policy struct {
+	source   ast.FileObject
+	policy   *ValidationPolicy
+	programs []cel.Program
+}
+
+// Load reads the ValidationPolicies from the local configuration in the files,
+// and compiles their expressions.
+func Load(parser filesystem.ConfigParser, filePaths reader.FilePaths) ([]*Policy, status.MultiError) {
+	objs, errs := parser.ReadLocalConfig(filePaths)
+	if errs != nil {
+		return nil, errs
+	}
+	return compileAll(objs)
+}
+
+// LoadSource reads the ValidationPolicies from the local configuration in the
+// source files of rendered configurations, and compiles their expressions.
+// Rendering drops the objects marked as local configuration, so policies must
+// be read from the source before rendering. Files which cannot be read as
+// Kubernetes objects, like Helm values, are inputs to the rendering and are
+// skipped.
+func LoadSource(parser filesystem.ConfigParser, filePaths reader.FilePaths) ([]*Policy, status.MultiError) {
+	var objs []ast.FileObject
+	for _, f := range filePaths.Files {
+		fileObjs, errs := parser.ReadLocalConfig(reader.FilePaths{
+			RootDir:   filePaths.RootDir,
+			PolicyDir: filePaths.PolicyDir,
+			Files:     []cmpath.Absolute{f},
+		})
+		if errs != nil {
+			klog.V(4).Infof("Skipping source file %s when loading ValidationPolicies: %v", f.OSPath(), errs)
+			continue
+		}
+		objs = append(objs, fileObjs...)
+	}
+	return compileAll(objs)
+}
+
+// compileAll compiles the ValidationPolicies in the objects.
+func compileAll(objs []ast.FileObject) ([]*Policy, status.MultiError) {
+	var errs status.MultiError
+	var policies []*Policy
+	for _, obj := range objs {
+		if obj.GetObjectKind().GroupVersionKind() != ValidationPolicyGVK {
+			continue
+		}
+		p, err := Compile(obj)
+		if err != nil {
+			errs = status.Append(errs, err)
+			continue
+		}
+		policies = append(policies, p)
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return policies, nil
+}
+
+// Compile decodes the ValidationPolicy and compiles its expressions.
+func Compile(obj ast.FileObject) (*Policy, status.Error) {
+	vp := &ValidationPolicy{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructuredWithValidation(obj.Unstructured.Object, vp, true); err != nil {
+		return nil, InvalidValidationPolicyError(obj, err.Error())
+	}
+	if len(vp.Spec.Validations) == 0 {
+		return nil, InvalidValidationPolicyError(obj, "spec.validations must not be empty")
+	}
+
+	env, err := cel.NewEnv(cel.Variable(objectVariable, cel.DynType))
+	if err != nil {
+		return nil, status.InternalErrorBuilder.Wrap(err).Sprint("failed to create the CEL environment").Build()
+	}
+	p := &Policy{source: obj, policy: vp}
+	for i, v := range vp.Spec.Validations {
+		compiled, issues := env.Compile(v.Expression)
+		if issues.Err() != nil {
+			return nil, InvalidValidationPolicyError(obj,
+				fmt.Sprintf("spec.validations[%d].expression is invalid: %v", i, issues.Err()))
+		}
+		if compiled.OutputType() != cel.BoolType && compiled.OutputType() != cel.DynType {
+			return nil, InvalidValidationPolicyError(obj,
+				fmt.Sprintf("spec.validations[%d].expression must evaluate to a bool, not %s", i, compiled.OutputType()))
+		}
+		program, err := env.Program(compiled, cel.CostLimit(costLimit))
+		if err != nil {
+			return nil, InvalidValidationPolicyError(obj,
+				fmt.Sprintf("spec.validations[%d].expression is invalid: %v", i, err))
+		}
+		p.programs = append(p.programs, program)
+	}
+	return p, nil
+}
+
+// Name returns the name of the ValidationPolicy.
+func (p *Policy) Name() string {
+	return p.policy.Name
+}
+
+// matches returns true if the policy validates the object.
+func (p *Policy) matches(obj ast.FileObject) bool {
+	match := p.policy.Spec.Match
+	if len(match.Namespaces) > 0 && !slices.Contains(match.Namespaces, obj.GetNamespace()) {
+		return false
+	}
+	if len(match.Kinds) == 0 {
+		return true
+	}
+	gk := obj.GetObjectKind().GroupVersionKind().GroupKind()
+	for _, km := range match.Kinds {
+		if matchesAny(km.APIGroups, gk.Group) && matchesAny(km.Kinds, gk.Kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate evaluates the expressions of the policy against the object, and
+// returns an error for each expression which doesn't evaluate to true.
+func (p *Policy) Validate(obj ast.FileObject) status.MultiError {
+	if !p.matches(obj) {
+		return nil
+	}
+	var errs status.MultiError
+	for i, program := range p.programs {
+		v := p.policy.Spec.Validations[i]
+		message := v.Message
+		if message == "" {
+			message = fmt.Sprintf("failed expression: %s", v.Expression)
+		}
+		out, _, err := program.Eval(map[string]interface{}{objectVariable: obj.Unstructured.Object})
+		var cancelled interpreter.EvalCancelledError
+		if errors.As(err, &cancelled) && cancelled.Cause == interpreter.CostLimitExceeded {
+			errs = status.Append(errs, ValidationPolicyViolationError(obj, p.Name(), p.source.SlashPath(),
+				fmt.Sprintf("%s: the expression exceeded the cost limit of %d", message, costLimit)))
+			continue
+		}
+		if err != nil {
+			errs = status.Append(errs, ValidationPolicyViolationError(obj, p.Name(), p.source.SlashPath(),
+				fmt.Sprintf("%s: %v", message, err)))
+			continue
+		}
+		if valid, ok := out.Value().(bool); !ok || !valid {
+			errs = status.Append(errs, ValidationPolicyViolationError(obj, p.Name(), p.source.SlashPath(), message))
+		}
+	}
+	return errs
+}
+
+// Visitor returns a VisitorFunc which validates the objects against the
+// policies. The objects are not modified.
+func Visitor(policies []*Policy) validate.VisitorFunc {
+	return func(objs []ast.FileObject) ([]ast.FileObject, status.MultiError) {
+		var errs status.MultiError
+		for _, obj := range objs {
+			for _, p := range policies {
+				errs = status.Append(errs, p.Validate(obj))
+			}
+		}
+		return objs, errs
+	}
+}
+
+func matchesAny(values []string, value string) bool {
+	return len(values) == 0 || slices.Contains(values, wildcard) || slices.Contains(values, value)
+}
@@ -0,0 +1,214 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/filesystem"
+	ft "kpt.dev/configsync/pkg/importer/filesystem/filesystemtest"
+	"kpt.dev/configsync/pkg/importer/reader"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/status"
+)
+
+const requireLimitsPolicy = `apiVersion: configsync.gke.io/v1alpha1
+kind: ValidationPolicy
+metadata:
+  name: require-limits
+  annotations:
+    config.kubernetes.io/local-config: "true"
+spec:
+  match:
+    kinds:
+    - apiGroups: ["apps"]
+      kinds: ["Deployment"]
+  validations:
+  - expression: "object.spec.template.spec.containers.all(c, has(c.resources) && has(c.resources.limits))"
+    message: "every container must set resource limits"
+`
+
+const requireTeamLabelPolicy = `apiVersion: configsync.gke.io/v1alpha1
+kind: ValidationPolicy
+metadata:
+  name: require-team
+  annotations:
+    config.kubernetes.io/local-config: "true"
+spec:
+  match:
+    namespaces: ["prod"]
+  validations:
+  - expression: "'team' in object.metadata.labels"
+`
+
+func deployment(namespace string, limits bool) ast.FileObject {
+	container := map[string]interface{}{"name": "web", "image": "nginx"}
+	if limits {
+		container["resources"] = map[string]interface{}{
+			"limits": map[string]interface{}{"memory": "128Mi"},
+		}
+	}
+	obj := k8sobjects.UnstructuredAtPath(kinds.Deployment(), "namespaces/deployment.yaml",
+		core.Namespace(namespace), core.Name("web"))
+	obj.Unstructured.Object["spec"] = map[string]interface{}{
+		"template": map[string]interface{}{
+			"spec": map[string]interface{}{
+				"containers": []interface{}{container},
+			},
+		},
+	}
+	return obj
+}
+
+func TestValidateCostLimit(t *testing.T) {
+	dir := ft.NewTestDir(t,
+		ft.FileContents("policy.yaml", `apiVersion: configsync.gke.io/v1alpha1
+kind: ValidationPolicy
+metadata:
+  name: pairs
+  annotations:
+    config.kubernetes.io/local-config: "true"
+spec:
+  validations:
+  - expression: "object.data.values.all(a, object.data.values.all(b, a + b >= 0))"
+    message: "values must not be negative"
+`),
+	)
+	policies, errs := Load(filesystem.NewParser(&reader.File{}), dir.FilePaths("policy.yaml"))
+	require.NoError(t, errs)
+
+	values := make([]interface{}, 2000)
+	for i := range values {
+		values[i] = int64(i)
+	}
+	obj := k8sobjects.UnstructuredAtPath(kinds.ConfigMap(), "namespaces/cm.yaml", core.Namespace("dev"), core.Name("cm"))
+	obj.Unstructured.Object["data"] = map[string]interface{}{"values": values}
+
+	_, errs = Visitor(policies)([]ast.FileObject{obj})
+	cses := status.ToCSE(errs)
+	require.Len(t, cses, 1, "%v", errs)
+	assert.Equal(t, ValidationPolicyViolationErrorCode, cses[0].Code)
+	assert.Contains(t, cses[0].ErrorMessage,
+		`ConfigMap "cm" violates the ValidationPolicy "pairs" declared in policy.yaml: values must not be negative: the expression exceeded the cost limit of 1000000`)
+}
+
+func TestLoad(t *testing.T) {
+	dir := ft.NewTestDir(t,
+		ft.FileContents("policies.yaml", requireLimitsPolicy+"---\n"+requireTeamLabelPolicy),
+		// Other local configuration is ignored.
+		ft.FileContents("local.yaml", "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: local\n  annotations:\n    config.kubernetes.io/local-config: \"true\"\n"),
+		// Policies which are not local configuration are not loaded, as they
+		// are declared objects.
+		ft.FileContents("declared.yaml", "apiVersion: configsync.gke.io/v1alpha1\nkind: ValidationPolicy\nmetadata:\n  name: declared\nspec:\n  validations:\n  - expression: \"false\"\n"),
+	)
+	policies, errs := Load(filesystem.NewParser(&reader.File{}), dir.FilePaths("policies.yaml", "local.yaml", "declared.yaml"))
+	require.NoError(t, errs)
+	require.Len(t, policies, 2)
+	assert.Equal(t, "require-limits", policies[0].Name())
+	assert.Equal(t, "require-team", policies[1].Name())
+}
+
+func TestLoadSource(t *testing.T) {
+	dir := ft.NewTestDir(t,
+		ft.FileContents("policies.yaml", requireLimitsPolicy),
+		ft.FileContents("kustomization.yaml", "resources:\n- deployment.yaml\n"),
+		// Rendering inputs which are not Kubernetes objects are skipped.
+		ft.FileContents("values.yaml", "replicas: 3\n"),
+	)
+	policies, errs := LoadSource(filesystem.NewParser(&reader.File{}), dir.FilePaths("policies.yaml", "kustomization.yaml", "values.yaml"))
+	require.NoError(t, errs)
+	require.Len(t, policies, 1)
+	assert.Equal(t, "require-limits", policies[0].Name())
+
+	// Invalid policies are still reported.
+	dir = ft.NewTestDir(t,
+		ft.FileContents("policy.yaml", "apiVersion: configsync.gke.io/v1alpha1\nkind: ValidationPolicy\nmetadata:\n  name: invalid\n  annotations:\n    config.kubernetes.io/local-config: \"true\"\nspec: {}\n"),
+	)
+	_, errs = LoadSource(filesystem.NewParser(&reader.File{}), dir.FilePaths("policy.yaml"))
+	require.Error(t, errs)
+	assert.Equal(t, InvalidValidationPolicyErrorCode, errs.Errors()[0].Code())
+}
+
+func TestLoadInvalid(t *testing.T) {
+	testCases := map[string]string{
+		"unknown field":       "spec:\n  validation: []\n",
+		"no validations":      "spec: {}\n",
+		"invalid expression":  "spec:\n  validations:\n  - expression: \"object.spec.(\"\n",
+		"non-bool expression": "spec:\n  validations:\n  - expression: \"'a' + 'b'\"\n",
+	}
+	for name, spec := range testCases {
+		t.Run(name, func(t *testing.T) {
+			dir := ft.NewTestDir(t,
+				ft.FileContents("policy.yaml", "apiVersion: configsync.gke.io/v1alpha1\nkind: ValidationPolicy\nmetadata:\n  name: invalid\n  annotations:\n    config.kubernetes.io/local-config: \"true\"\n"+spec),
+			)
+			_, errs := Load(filesystem.NewParser(&reader.File{}), dir.FilePaths("policy.yaml"))
+			cses := status.ToCSE(errs)
+			require.Len(t, cses, 1)
+			assert.Equal(t, InvalidValidationPolicyErrorCode, cses[0].Code)
+		})
+	}
+}
+
+func TestVisitor(t *testing.T) {
+	dir := ft.NewTestDir(t,
+		ft.FileContents("policies.yaml", requireLimitsPolicy+"---\n"+requireTeamLabelPolicy),
+	)
+	policies, errs := Load(filesystem.NewParser(&reader.File{}), dir.FilePaths("policies.yaml"))
+	require.NoError(t, errs)
+
+	testCases := map[string]struct {
+		objs     []ast.FileObject
+		wantErrs []string
+	}{
+		"valid objects": {
+			objs: []ast.FileObject{
+				deployment("dev", true),
+				k8sobjects.FileObject(k8sobjects.ConfigMapObject(core.Name("cm"), core.Namespace("prod"), core.Label("team", "web")), "namespaces/cm.yaml"),
+			},
+		},
+		"deployment without limits": {
+			objs: []ast.FileObject{deployment("dev", false)},
+			wantErrs: []string{
+				`Deployment "web" violates the ValidationPolicy "require-limits" declared in policies.yaml: every container must set resource limits`,
+			},
+		},
+		"objects without the team label": {
+			objs: []ast.FileObject{
+				deployment("prod", true),
+				k8sobjects.FileObject(k8sobjects.ConfigMapObject(core.Name("cm"), core.Namespace("dev")), "namespaces/cm.yaml"),
+			},
+			wantErrs: []string{
+				`Deployment "web" violates the ValidationPolicy "require-team" declared in policies.yaml: failed expression: 'team' in object.metadata.labels`,
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			objs, errs := Visitor(policies)(tc.objs)
+			assert.Equal(t, tc.objs, objs)
+			cses := status.ToCSE(errs)
+			require.Len(t, cses, len(tc.wantErrs), "%v", errs)
+			for i, want := range tc.wantErrs {
+				assert.Equal(t, ValidationPolicyViolationErrorCode, cses[i].Code)
+				assert.Contains(t, cses[i].ErrorMessage, want)
+			}
+		})
+	}
+}
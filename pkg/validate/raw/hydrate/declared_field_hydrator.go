@@ -25,6 +25,7 @@ import (
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/diff"
 	"kpt.dev/configsync/pkg/kinds"
 	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/status"
@@ -45,7 +46,7 @@ func DeclaredFields(objs *fileobjects.Raw) status.MultiError {
 	var errs status.MultiError
 	needRefresh := false
 	for _, obj := range objs.Objects {
-		fields, err := encodeDeclaredFields(objs.Converter, obj.Unstructured)
+		fields, err := encodeDeclaredFields(objs.Converter, obj.Unstructured, objs.IgnoreRules)
 		if err != nil {
 			switch err.(type) {
 			case status.MultiError:
@@ -91,8 +92,9 @@ var identityFields = fieldpath.NewSet(
 )
 
 // encodeDeclaredFields encodes the fields of the given object into a format that
-// is compatible with server-side apply.
-func encodeDeclaredFields(converter *declared.ValueConverter, obj runtime.Object) ([]byte, error) {
+// is compatible with server-side apply. The fields selected by ignoreRules are
+// left out.
+func encodeDeclaredFields(converter *declared.ValueConverter, obj runtime.Object, ignoreRules diff.IgnoreRules) ([]byte, error) {
 	var err error
 	u, isUnstructured := obj.(*unstructured.Unstructured)
 	if isUnstructured {
@@ -100,6 +102,11 @@ func encodeDeclaredFields(converter *declared.ValueConverter, obj runtime.Object
 		if err != nil {
 			return nil, err
 		}
+		if ignoreRules.Matches(u) {
+			u = u.DeepCopy()
+			ignoreRules.Strip(u)
+			obj = u
+		}
 	}
 
 	val, err := converter.TypedValue(obj)
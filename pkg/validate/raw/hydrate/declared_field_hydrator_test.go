@@ -21,7 +21,9 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/diff"
 	"kpt.dev/configsync/pkg/importer/analyzer/ast"
 	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/testing/openapitest"
@@ -34,6 +36,15 @@ func TestDeclaredFields(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	ignoreRules, err := diff.NewIgnoreRules([]v1beta1.IgnoreDifference{{
+		Group:     "acme.com",
+		Kind:      "Anvil",
+		JSONPaths: []string{".spec.lbs"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	testCases := []struct {
 		name string
 		objs *fileobjects.Raw
@@ -175,16 +186,62 @@ func TestDeclaredFields(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "leave ignored fields out for Custom Resource",
+			objs: &fileobjects.Raw{
+				Converter:   converter,
+				IgnoreRules: ignoreRules,
+				Objects: []ast.FileObject{
+					k8sobjects.FileObject(&unstructured.Unstructured{
+						Object: map[string]interface{}{
+							"apiVersion": "acme.com/v1",
+							"kind":       "Anvil",
+							"metadata": map[string]interface{}{
+								"name":      "heavy",
+								"namespace": "foo",
+							},
+							"spec": map[string]interface{}{
+								"lbs":   123,
+								"color": "black",
+							},
+						},
+					}, "anvil.yaml"),
+				},
+			},
+			want: &fileobjects.Raw{
+				Converter:   converter,
+				IgnoreRules: ignoreRules,
+				Objects: []ast.FileObject{
+					k8sobjects.FileObject(&unstructured.Unstructured{
+						Object: map[string]interface{}{
+							"apiVersion": "acme.com/v1",
+							"kind":       "Anvil",
+							"metadata": map[string]interface{}{
+								"name":      "heavy",
+								"namespace": "foo",
+								"annotations": map[string]interface{}{
+									metadata.DeclaredFieldsKey: `{"f:metadata":{"f:annotations":{},"f:labels":{}},"f:spec":{".":{},"f:color":{}}}`,
+								},
+							},
+							"spec": map[string]interface{}{
+								"lbs":   123,
+								"color": "black",
+							},
+						},
+					}, "anvil.yaml"),
+				},
+			},
+		},
 	}
 
-	ignoreConverter := cmpopts.IgnoreFields(fileobjects.Raw{}, "Converter")
+	ignoreFields := cmpopts.IgnoreFields(fileobjects.Raw{}, "Converter", "IgnoreRules")
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			if errs := DeclaredFields(tc.objs); errs != nil {
 				t.Errorf("Got DeclaredFields() error %v, want nil", errs)
 			}
-			if diff := cmp.Diff(tc.want, tc.objs, ast.CompareFileObject, ignoreConverter); diff != "" {
+			if diff := cmp.Diff(tc.want, tc.objs, ast.CompareFileObject, ignoreFields); diff != "" {
 				t.Error(diff)
 			}
 		})
@@ -45,11 +45,14 @@ func Hierarchical(objs *fileobjects.Raw) status.MultiError {
 		fileobjects.VisitAllRaw(validate.Directory),
 		fileobjects.VisitAllRaw(validate.HNCLabels),
 		fileobjects.VisitAllRaw(validate.ManagementAnnotation),
+		fileobjects.VisitAllRaw(validate.SyncWaveAnnotation),
+		fileobjects.VisitAllRaw(validate.HookAnnotation),
 		fileobjects.VisitAllRaw(validate.IllegalCRD),
 		fileobjects.VisitAllRaw(validate.CRDName),
 		fileobjects.VisitAllRaw(validate.SelfReconcile(declared.ReconcilerNameFromScope(objs.Scope, objs.SyncName))),
 		validate.DisallowedFields,
 		validate.RemovedCRDs,
+		validate.Schemas,
 		validate.ClusterSelectorsForHierarchical,
 		validate.Repo,
 	}
@@ -99,11 +102,14 @@ func Unstructured(objs *fileobjects.Raw) status.MultiError {
 		fileobjects.VisitAllRaw(validate.Name),
 		fileobjects.VisitAllRaw(validate.Namespace),
 		fileobjects.VisitAllRaw(validate.ManagementAnnotation),
+		fileobjects.VisitAllRaw(validate.SyncWaveAnnotation),
+		fileobjects.VisitAllRaw(validate.HookAnnotation),
 		fileobjects.VisitAllRaw(validate.IllegalCRD),
 		fileobjects.VisitAllRaw(validate.CRDName),
 		fileobjects.VisitAllRaw(validate.SelfReconcile(declared.ReconcilerNameFromScope(objs.Scope, objs.SyncName))),
 		validate.DisallowedFields,
 		validate.RemovedCRDs,
+		validate.Schemas,
 		validate.ClusterSelectorsForUnstructured,
 	}
 	for _, validator := range validators {
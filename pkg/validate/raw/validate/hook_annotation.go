@@ -0,0 +1,43 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"slices"
+
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/analyzer/validation/nonhierarchical"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+)
+
+// HookAnnotation returns an Error if the user-specified hook annotation is set
+// on an object which is not a Job or a Pod, or has an invalid value.
+func HookAnnotation(obj ast.FileObject) status.Error {
+	if !metadata.IsHook(obj) {
+		return nil
+	}
+	value := core.GetAnnotation(obj, metadata.HookAnnotationKey)
+	gk := obj.GetObjectKind().GroupVersionKind().GroupKind()
+	if gk != kinds.Job().GroupKind() && gk != kinds.Pod().GroupKind() {
+		return nonhierarchical.IllegalHookAnnotationError(obj, value)
+	}
+	if !slices.Contains(metadata.HookPhases, metadata.HookPhase(value)) {
+		return nonhierarchical.IllegalHookAnnotationError(obj, value)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"testing"
+
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/analyzer/validation/nonhierarchical"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/testing/testerrors"
+)
+
+func TestHookAnnotation(t *testing.T) {
+	testCases := []struct {
+		name string
+		obj  ast.FileObject
+		want status.Error
+	}{
+		{
+			name: "no hook annotation passes",
+			obj:  k8sobjects.Role(),
+		},
+		{
+			name: "pre-sync Job passes",
+			obj:  k8sobjects.UnstructuredAtPath(kinds.Job(), "namespaces/foo/job.yaml", metadata.WithHookPhase(metadata.HookPreSync)),
+		},
+		{
+			name: "post-sync Pod passes",
+			obj:  k8sobjects.UnstructuredAtPath(kinds.Pod(), "namespaces/foo/pod.yaml", metadata.WithHookPhase(metadata.HookPostSync)),
+		},
+		{
+			name: "invalid hook phase fails",
+			obj:  k8sobjects.UnstructuredAtPath(kinds.Job(), "namespaces/foo/job.yaml", core.Annotation(metadata.HookAnnotationKey, "pre-apply")),
+			want: nonhierarchical.IllegalHookAnnotationError(k8sobjects.UnstructuredAtPath(kinds.Job(), "namespaces/foo/job.yaml"), "pre-apply"),
+		},
+		{
+			name: "hook on a Role fails",
+			obj:  k8sobjects.Role(metadata.WithHookPhase(metadata.HookPreSync)),
+			want: nonhierarchical.IllegalHookAnnotationError(k8sobjects.Role(), metadata.HookPreSync.String()),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := HookAnnotation(tc.obj)
+			testerrors.AssertEqual(t, tc.want, err)
+		})
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/managedfields"
+	"k8s.io/klog/v2"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/customresources"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	utildiscovery "kpt.dev/configsync/pkg/util/discovery"
+	"kpt.dev/configsync/pkg/validate/fileobjects"
+)
+
+const (
+	// gvkExtension is the OpenAPI extension listing the GroupVersionKinds of
+	// the schema.
+	gvkExtension = "x-kubernetes-group-version-kind"
+	// objectMetaSchema is the name of the ObjectMeta schema.
+	objectMetaSchema = "io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta"
+)
+
+// newTypeConverter builds the type converter of the schemas. It is a variable
+// so tests can count the converters built.
+var newTypeConverter = managedfields.NewTypeConverter
+
+// converterCache holds the type converter of the latest schemas, which are the
+// same for every parse until the schemas served by the cluster or the declared
+// CRDs change.
+var converterCache struct {
+	mux       sync.Mutex
+	key       string
+	converter managedfields.TypeConverter
+	gvks      map[schema.GroupVersionKind]bool
+}
+
+// Schemas verifies that the Raw objects match the OpenAPI schemas of their
+// types. The schemas of the CRDs declared in the Raw objects take precedence
+// over the schemas served by the cluster. Objects of types without a schema
+// are not validated. If the schemas served by the cluster are not available,
+// only the objects of the declared CRDs are validated.
+func Schemas(objs *fileobjects.Raw) status.MultiError {
+	if objs.OpenAPISchemas == nil {
+		return nil
+	}
+	clusterSchemas, errs := objs.OpenAPISchemas()
+	if errs != nil {
+		// The objects are still validated by the API server when applied.
+		klog.Warningf("Skipping the schema validation of the types served by the cluster: %v", errs)
+		clusterSchemas = utildiscovery.OpenAPISchemas{}
+	}
+	crds, errs := customresources.GetCRDs(objs.Objects, objs.Scheme)
+	if errs != nil {
+		return errs
+	}
+	converter, gvks, err := typeConverter(clusterSchemas, crds)
+	if err != nil {
+		return status.InternalErrorBuilder.Wrap(err).Sprint("failed to build the OpenAPI schemas").Build()
+	}
+	if len(gvks) == 0 {
+		return nil
+	}
+
+	for _, obj := range objs.Objects {
+		if !gvks[obj.GetObjectKind().GroupVersionKind()] {
+			continue
+		}
+		if _, err := converter.ObjectToTyped(obj.Unstructured); err != nil {
+			errs = status.Append(errs, SchemaError(obj, err))
+		}
+	}
+	return errs
+}
+
+// typeConverter returns the type converter of the cluster schemas and the
+// schemas of the declared CRDs, and the GroupVersionKinds which have a schema.
+// The type converter is cached until the schemas change.
+func typeConverter(clusterSchemas utildiscovery.OpenAPISchemas, crds []*apiextensionsv1.CustomResourceDefinition) (managedfields.TypeConverter, map[schema.GroupVersionKind]bool, error) {
+	key, err := converterKey(clusterSchemas, crds)
+	if err != nil {
+		return nil, nil, err
+	}
+	converterCache.mux.Lock()
+	defer converterCache.mux.Unlock()
+	if key != "" && key == converterCache.key {
+		return converterCache.converter, converterCache.gvks, nil
+	}
+
+	schemas, gvks := withCRDSchemas(clusterSchemas.Schemas, crds)
+	var converter managedfields.TypeConverter
+	if len(gvks) > 0 {
+		converter, err = newTypeConverter(schemas, false)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if key != "" {
+		converterCache.key = key
+		converterCache.converter = converter
+		converterCache.gvks = gvks
+	}
+	return converter, gvks, nil
+}
+
+// converterKey returns the key of the type converter of the schemas, or "" if
+// the cluster schemas have no generation, so the type converter can't be
+// cached.
+func converterKey(clusterSchemas utildiscovery.OpenAPISchemas, crds []*apiextensionsv1.CustomResourceDefinition) (string, error) {
+	if len(clusterSchemas.Schemas) > 0 && clusterSchemas.Generation == "" {
+		return "", nil
+	}
+	h := sha256.New()
+	h.Write([]byte(clusterSchemas.Generation))
+	for _, crd := range crds {
+		data, err := json.Marshal(crd.Spec)
+		if err != nil {
+			return "", err
+		}
+		h.Write(data)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// withCRDSchemas returns the schemas, with the schemas of the served versions
+// of the CRDs replacing the schemas of the same types, and the
+// GroupVersionKinds which have a schema.
+func withCRDSchemas(schemas map[string]*spec.Schema, crds []*apiextensionsv1.CustomResourceDefinition) (map[string]*spec.Schema, map[schema.GroupVersionKind]bool) {
+	declared := make(map[schema.GroupKind]bool)
+	for _, crd := range crds {
+		declared[schema.GroupKind{Group: crd.Spec.Group, Kind: crd.Spec.Names.Kind}] = true
+	}
+
+	result := make(map[string]*spec.Schema)
+	gvks := make(map[schema.GroupVersionKind]bool)
+	for name, s := range schemas {
+		schemaGVKs := schemaGroupVersionKinds(s)
+		overridden := false
+		for _, gvk := range schemaGVKs {
+			overridden = overridden || declared[gvk.GroupKind()]
+		}
+		if overridden {
+			continue
+		}
+		result[name] = s
+		for _, gvk := range schemaGVKs {
+			gvks[gvk] = true
+		}
+	}
+
+	_, hasObjectMeta := result[objectMetaSchema]
+	for _, crd := range crds {
+		for _, version := range crd.Spec.Versions {
+			if !version.Served || version.Schema == nil || version.Schema.OpenAPIV3Schema == nil {
+				continue
+			}
+			gvk := schema.GroupVersionKind{Group: crd.Spec.Group, Version: version.Name, Kind: crd.Spec.Names.Kind}
+			s, err := crdSchema(version.Schema.OpenAPIV3Schema, gvk, hasObjectMeta)
+			if err != nil {
+				// The CRD is validated by the API server when applied.
+				continue
+			}
+			result[fmt.Sprintf("%s.%s.%s", gvk.Group, gvk.Version, gvk.Kind)] = s
+			gvks[gvk] = true
+		}
+	}
+	return result, gvks
+}
+
+// crdSchema converts the schema of a CRD version to the schema of its type, as
+// served by the API server.
+func crdSchema(props *apiextensionsv1.JSONSchemaProps, gvk schema.GroupVersionKind, hasObjectMeta bool) (*spec.Schema, error) {
+	data, err := json.Marshal(props)
+	if err != nil {
+		return nil, err
+	}
+	s := &spec.Schema{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	if s.Properties == nil {
+		s.Properties = make(map[string]spec.Schema)
+	}
+	s.Properties["apiVersion"] = *spec.StringProperty()
+	s.Properties["kind"] = *spec.StringProperty()
+	if hasObjectMeta {
+		s.Properties["metadata"] = *spec.RefSchema("#/components/schemas/" + objectMetaSchema)
+	} else {
+		metadata := spec.MapProperty(nil)
+		metadata.AddExtension("x-kubernetes-preserve-unknown-fields", true)
+		s.Properties["metadata"] = *metadata
+	}
+	s.AddExtension(gvkExtension, []interface{}{
+		map[string]interface{}{"group": gvk.Group, "version": gvk.Version, "kind": gvk.Kind},
+	})
+	return s, nil
+}
+
+// schemaGroupVersionKinds returns the GroupVersionKinds of the schema.
+func schemaGroupVersionKinds(s *spec.Schema) []schema.GroupVersionKind {
+	list, ok := s.Extensions[gvkExtension].([]interface{})
+	if !ok {
+		return nil
+	}
+	var result []schema.GroupVersionKind
+	for _, item := range list {
+		var gvk schema.GroupVersionKind
+		switch m := item.(type) {
+		case map[string]interface{}:
+			gvk.Group, _ = m["group"].(string)
+			gvk.Version, _ = m["version"].(string)
+			gvk.Kind, _ = m["kind"].(string)
+		case map[interface{}]interface{}:
+			gvk.Group, _ = m["group"].(string)
+			gvk.Version, _ = m["version"].(string)
+			gvk.Kind, _ = m["kind"].(string)
+		}
+		if gvk.Kind != "" {
+			result = append(result, gvk)
+		}
+	}
+	return result
+}
+
+// SchemaErrorCode is the error code for an object which does not match the
+// OpenAPI schema of its type.
+const SchemaErrorCode = "1071"
+
+var schemaErrorBuilder = status.NewErrorBuilder(SchemaErrorCode)
+
+// SchemaError reports that the object does not match the OpenAPI schema of its
+// type, for example because of an unknown field or a field of the wrong type.
+func SchemaError(o ast.FileObject, err error) status.Error {
+	// Schema validation runs before the objects are annotated with their source
+	// paths, so annotate a copy to report the path of the invalid object.
+	obj := o.DeepCopy()
+	if status.GetSourceAnnotation(obj) == "" {
+		core.SetAnnotation(&obj, metadata.SourcePathAnnotationKey, o.SlashPath())
+	}
+	kind := o.GetObjectKind().GroupVersionKind().Kind
+	return schemaErrorBuilder.
+		Sprintf("%s %q does not match the schema of its type", kind, o.GetName()).
+		Wrap(err).
+		BuildWithResources(&obj)
+}
@@ -0,0 +1,231 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/managedfields"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/testing/openapitest"
+	utildiscovery "kpt.dev/configsync/pkg/util/discovery"
+	"kpt.dev/configsync/pkg/validate/fileobjects"
+)
+
+func fileObjectFromMap(path string, obj map[string]interface{}) ast.FileObject {
+	return ast.NewFileObject(&unstructured.Unstructured{Object: obj}, cmpath.RelativeSlash(path))
+}
+
+func deploymentWithSpec(spec map[string]interface{}) ast.FileObject {
+	return fileObjectFromMap("namespaces/foo/deployment.yaml", map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":      "web",
+			"namespace": "foo",
+		},
+		"spec": spec,
+	})
+}
+
+func anvilWithSpec(spec map[string]interface{}) ast.FileObject {
+	return fileObjectFromMap("namespaces/foo/anvil.yaml", map[string]interface{}{
+		"apiVersion": kinds.Anvil().GroupVersion().String(),
+		"kind":       kinds.Anvil().Kind,
+		"metadata": map[string]interface{}{
+			"name":      "anvil",
+			"namespace": "foo",
+		},
+		"spec": spec,
+	})
+}
+
+func anvilCRDWithSchema() ast.FileObject {
+	crd := k8sobjects.CRDV1ObjectForGVK(kinds.Anvil(), apiextensionsv1.NamespaceScoped)
+	crd.Spec.Versions[0].Schema = &apiextensionsv1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensionsv1.JSONSchemaProps{
+				"spec": {
+					Type: "object",
+					Properties: map[string]apiextensionsv1.JSONSchemaProps{
+						"lbs": {Type: "integer"},
+					},
+				},
+			},
+		},
+	}
+	return k8sobjects.FileObject(crd, "cluster/crd.yaml")
+}
+
+func TestSchemas(t *testing.T) {
+	schemas, err := openapitest.Schemas()
+	require.NoError(t, err)
+	clusterSchemas := func() (utildiscovery.OpenAPISchemas, status.MultiError) {
+		return utildiscovery.OpenAPISchemas{Schemas: schemas, Generation: "1"}, nil
+	}
+	unavailableSchemas := func() (utildiscovery.OpenAPISchemas, status.MultiError) {
+		return utildiscovery.OpenAPISchemas{}, status.APIServerError(errors.New("connection refused"), "failed to list the OpenAPI v3 schemas")
+	}
+	validDeploymentSpec := map[string]interface{}{
+		"replicas": int64(2),
+		"selector": map[string]interface{}{
+			"matchLabels": map[string]interface{}{"app": "web"},
+		},
+		"template": map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"labels": map[string]interface{}{"app": "web"},
+			},
+			"spec": map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{"name": "web", "image": "nginx"},
+				},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		schemas  utildiscovery.OpenAPISchemasFunc
+		objs     []ast.FileObject
+		wantErrs int
+	}{
+		"valid object": {
+			schemas: clusterSchemas,
+			objs:    []ast.FileObject{deploymentWithSpec(validDeploymentSpec)},
+		},
+		"unknown field": {
+			schemas: clusterSchemas,
+			objs: []ast.FileObject{deploymentWithSpec(map[string]interface{}{
+				"replicas": int64(2),
+				"replicaz": int64(2),
+			})},
+			wantErrs: 1,
+		},
+		"wrong type": {
+			schemas: clusterSchemas,
+			objs: []ast.FileObject{deploymentWithSpec(map[string]interface{}{
+				"replicas": "two",
+			})},
+			wantErrs: 1,
+		},
+		"object of a type without a schema": {
+			schemas: clusterSchemas,
+			objs:    []ast.FileObject{anvilWithSpec(map[string]interface{}{"anything": true})},
+		},
+		"valid object of a declared CRD": {
+			schemas: clusterSchemas,
+			objs: []ast.FileObject{
+				anvilCRDWithSchema(),
+				anvilWithSpec(map[string]interface{}{"lbs": int64(10)}),
+			},
+		},
+		"invalid object of a declared CRD": {
+			schemas: clusterSchemas,
+			objs: []ast.FileObject{
+				anvilCRDWithSchema(),
+				anvilWithSpec(map[string]interface{}{"lbs": "ten", "color": "black"}),
+			},
+			wantErrs: 1,
+		},
+		"declared CRD without cluster schemas": {
+			schemas: utildiscovery.NoOpenAPISchemas,
+			objs: []ast.FileObject{
+				anvilCRDWithSchema(),
+				anvilWithSpec(map[string]interface{}{"lbs": "ten"}),
+				deploymentWithSpec(map[string]interface{}{"replicaz": int64(2)}),
+			},
+			wantErrs: 1,
+		},
+		"cluster schemas unavailable": {
+			schemas: unavailableSchemas,
+			objs: []ast.FileObject{
+				anvilCRDWithSchema(),
+				anvilWithSpec(map[string]interface{}{"lbs": "ten"}),
+				deploymentWithSpec(map[string]interface{}{"replicaz": int64(2)}),
+			},
+			wantErrs: 1,
+		},
+		"schema validation disabled": {
+			objs: []ast.FileObject{deploymentWithSpec(map[string]interface{}{"replicaz": int64(2)})},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			objs := &fileobjects.Raw{
+				Objects:        tc.objs,
+				Scheme:         core.Scheme,
+				OpenAPISchemas: tc.schemas,
+			}
+			errs := status.ToCSE(Schemas(objs))
+			require.Len(t, errs, tc.wantErrs, "%v", errs)
+			for _, err := range errs {
+				assert.Equal(t, SchemaErrorCode, err.Code)
+				require.Len(t, err.Resources, 1)
+				assert.NotEmpty(t, err.Resources[0].SourcePath)
+			}
+		})
+	}
+}
+
+func TestSchemasCachesTypeConverter(t *testing.T) {
+	defer func(f func(map[string]*spec.Schema, bool) (managedfields.TypeConverter, error)) {
+		newTypeConverter = f
+	}(newTypeConverter)
+	// The other tests may have cached a type converter of the same schemas.
+	converterCache.mux.Lock()
+	converterCache.key = ""
+	converterCache.mux.Unlock()
+	built := 0
+	newTypeConverter = func(schemas map[string]*spec.Schema, preserveUnknownFields bool) (managedfields.TypeConverter, error) {
+		built++
+		return managedfields.NewTypeConverter(schemas, preserveUnknownFields)
+	}
+	schemas, err := openapitest.Schemas()
+	require.NoError(t, err)
+	generation := "1"
+	clusterSchemas := func() (utildiscovery.OpenAPISchemas, status.MultiError) {
+		return utildiscovery.OpenAPISchemas{Schemas: schemas, Generation: generation}, nil
+	}
+	validate := func(objs ...ast.FileObject) int {
+		return len(status.ToCSE(Schemas(&fileobjects.Raw{
+			Objects:        objs,
+			Scheme:         core.Scheme,
+			OpenAPISchemas: clusterSchemas,
+		})))
+	}
+	invalid := deploymentWithSpec(map[string]interface{}{"replicaz": int64(2)})
+
+	assert.Equal(t, 1, validate(invalid))
+	assert.Equal(t, 1, validate(invalid))
+	assert.Equal(t, 1, built, "the type converter is reused for the same schemas")
+
+	assert.Equal(t, 2, validate(invalid, anvilCRDWithSchema(), anvilWithSpec(map[string]interface{}{"lbs": "ten"})))
+	assert.Equal(t, 2, built, "the type converter is rebuilt when the declared CRDs change")
+
+	generation = "2"
+	assert.Equal(t, 1, validate(invalid))
+	assert.Equal(t, 3, built, "the type converter is rebuilt when the cluster schemas change")
+}
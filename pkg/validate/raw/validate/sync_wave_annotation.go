@@ -0,0 +1,33 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/analyzer/validation/nonhierarchical"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+)
+
+// SyncWaveAnnotation returns an Error if the user-specified sync-wave
+// annotation is not an integer.
+func SyncWaveAnnotation(obj ast.FileObject) status.Error {
+	if _, err := metadata.GetSyncWave(obj); err != nil {
+		return nonhierarchical.IllegalSyncWaveAnnotationError(obj,
+			core.GetAnnotation(obj, metadata.SyncWaveAnnotationKey))
+	}
+	return nil
+}
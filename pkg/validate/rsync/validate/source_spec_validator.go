@@ -16,12 +16,20 @@ package validate
 
 import (
 	"context"
+	"net/url"
+	"path"
+	"regexp"
 	"strings"
 
+	semverrange "github.com/Masterminds/semver/v3"
+	"github.com/google/go-containerregistry/pkg/name"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/diff"
+	gitutil "kpt.dev/configsync/pkg/git"
 	"kpt.dev/configsync/pkg/reposync"
 	"kpt.dev/configsync/pkg/rootsync"
 	"kpt.dev/configsync/pkg/status"
@@ -36,6 +44,10 @@ const gcpSASuffix = ".iam.gserviceaccount.com"
 // spec.helm.valuesFileRefs.dataKey is not specified.
 const HelmValuesFileDefaultDataKey = "values.yaml"
 
+// maxSourceNameLength is the maximum length of the name of an additional
+// source, which is used to name its container and volumes.
+const maxSourceNameLength = 40
+
 // HelmValuesFileDataKeyOrDefault returns the key or the default if the key is
 // empty.
 func HelmValuesFileDataKeyOrDefault(key string) string {
@@ -61,6 +73,10 @@ func RepoSyncSpec(spec v1beta1.RepoSyncSpec) status.Error {
 		if err := RepoSyncHelmSpec(spec.Helm); err != nil {
 			return err
 		}
+	case configsync.ArchiveSource:
+		if err := ArchiveSpec(spec.Archive, syncKind); err != nil {
+			return err
+		}
 	default:
 		return InvalidSourceType(syncKind)
 	}
@@ -83,12 +99,134 @@ func RootSyncSpec(spec v1beta1.RootSyncSpec) status.Error {
 		if err := RootSyncHelmSpec(spec.Helm); err != nil {
 			return err
 		}
+	case configsync.ArchiveSource:
+		if err := ArchiveSpec(spec.Archive, syncKind); err != nil {
+			return err
+		}
 	default:
 		return InvalidSourceType(syncKind)
 	}
+	if err := RootSyncSources(spec); err != nil {
+		return err
+	}
+	if err := RootSyncRollout(spec.Rollout); err != nil {
+		return err
+	}
 	return RootSyncOverrideSpec(spec.Override)
 }
 
+// RootSyncRollout validates the rollout gate of a RootSync.
+func RootSyncRollout(rollout *v1beta1.RolloutSpec) status.Error {
+	if rollout == nil {
+		return nil
+	}
+	syncKind := configsync.RootSyncKind
+	if rollout.CanaryGroup == "" {
+		return MissingRolloutCanaryGroup(syncKind)
+	}
+	hasConfigMap := rollout.ConfigMapRef != nil
+	if hasConfigMap == (rollout.URL != "") {
+		return InvalidRolloutHealthSource(syncKind)
+	}
+	if hasConfigMap && (rollout.ConfigMapRef.Name == "" || rollout.ConfigMapRef.Namespace == "") {
+		return InvalidRolloutHealthSource(syncKind)
+	}
+	return nil
+}
+
+// RootSyncSources validates the additional sources of a RootSync.
+func RootSyncSources(spec v1beta1.RootSyncSpec) status.Error {
+	if len(spec.Sources) == 0 {
+		return nil
+	}
+	syncKind := configsync.RootSyncKind
+	if spec.SourceFormat != configsync.SourceFormatUnstructured {
+		return SourcesRequireUnstructured(syncKind)
+	}
+	names := make(map[string]bool, len(spec.Sources))
+	for _, source := range spec.Sources {
+		if len(source.Name) > maxSourceNameLength || len(validation.IsDNS1123Label(source.Name)) > 0 {
+			return InvalidSourceName(source.Name, syncKind)
+		}
+		if names[source.Name] {
+			return DuplicateSourceName(source.Name, syncKind)
+		}
+		names[source.Name] = true
+		if err := additionalSourceSpec(source, syncKind); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// additionalSourceSpec validates the specification of an additional source.
+// Additional sources share the Pod of the reconciler, so they only support the
+// auth types and the options which don't need dedicated volumes or sidecars.
+func additionalSourceSpec(source v1beta1.RootSyncSource, syncKind string) status.Error {
+	switch source.SourceType {
+	case configsync.GitSource:
+		if err := GitSpec(source.Git, syncKind); err != nil {
+			return err
+		}
+		switch source.Git.Auth {
+		case configsync.AuthNone, configsync.AuthSSH, configsync.AuthToken:
+		default:
+			return UnsupportedSourceAuthType(source.Name, source.SourceType, source.Git.Auth, syncKind)
+		}
+		if source.Git.CACertSecretRef != nil {
+			return UnsupportedSourceField(source.Name, "git.caCertSecretRef", syncKind)
+		}
+		if source.Git.Verification != nil {
+			return UnsupportedSourceField(source.Name, "git.verification", syncKind)
+		}
+		if source.Git.SparseCheckout {
+			return UnsupportedSourceField(source.Name, "git.sparseCheckout", syncKind)
+		}
+		if gitutil.IsRevisionConstraint(source.Git.Revision) {
+			return UnsupportedSourceRevisionConstraint(source.Name, syncKind)
+		}
+		if source.Git.Helm != nil {
+			return UnsupportedSourceField(source.Name, "git.helm", syncKind)
+		}
+	case configsync.OciSource:
+		if err := OciSpec(source.Oci, syncKind); err != nil {
+			return err
+		}
+		switch source.Oci.Auth {
+		case configsync.AuthNone, configsync.AuthK8sServiceAccount:
+		default:
+			return UnsupportedSourceAuthType(source.Name, source.SourceType, source.Oci.Auth, syncKind)
+		}
+		if source.Oci.CACertSecretRef != nil {
+			return UnsupportedSourceField(source.Name, "oci.caCertSecretRef", syncKind)
+		}
+		if source.Oci.Verification != nil {
+			return UnsupportedSourceField(source.Name, "oci.verification", syncKind)
+		}
+	case configsync.HelmSource:
+		if err := RootSyncHelmSpec(source.Helm); err != nil {
+			return err
+		}
+		switch source.Helm.Auth {
+		case configsync.AuthNone, configsync.AuthToken, configsync.AuthK8sServiceAccount:
+		default:
+			return UnsupportedSourceAuthType(source.Name, source.SourceType, source.Helm.Auth, syncKind)
+		}
+		if source.Helm.CACertSecretRef != nil {
+			return UnsupportedSourceField(source.Name, "helm.caCertSecretRef", syncKind)
+		}
+		if len(source.Helm.ValuesFileRefs) > 0 {
+			return UnsupportedSourceField(source.Name, "helm.valuesFileRefs", syncKind)
+		}
+		if source.Helm.Verify != nil {
+			return UnsupportedSourceField(source.Name, "helm.verify", syncKind)
+		}
+	default:
+		return InvalidAdditionalSourceType(source.Name, syncKind)
+	}
+	return nil
+}
+
 // GitSpec validates the git specification.
 func GitSpec(git *v1beta1.Git, syncKind string) status.Error {
 	if git == nil {
@@ -133,6 +271,25 @@ func GitSpec(git *v1beta1.Git, syncKind string) status.Error {
 		}
 	}
 
+	if git.Verification != nil && v1beta1.GetSecretName(git.Verification.TrustedKeysSecretRef) == "" {
+		return MissingVerificationKeysSecretRef("spec.git.verification", syncKind)
+	}
+
+	if git.Helm != nil {
+		for _, vf := range git.Helm.ValuesFiles {
+			// The values files are read from the repo, so they must be
+			// relative to the sync directory.
+			if vf == "" || path.IsAbs(vf) || strings.HasPrefix(path.Clean(vf), "..") {
+				return InvalidGitHelmValuesFile(vf, syncKind)
+			}
+		}
+		for _, vf := range git.Helm.ValuesFileRefs {
+			if vf.Name == "" {
+				return MissingGitHelmValuesFileRefsName(syncKind)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -162,6 +319,70 @@ func OciSpec(oci *v1beta1.Oci, syncKind string) status.Error {
 	default:
 		return InvalidOciAuthType(syncKind)
 	}
+
+	if oci.Verification != nil {
+		if v1beta1.GetSecretName(oci.Verification.TrustedKeysSecretRef) == "" {
+			return MissingVerificationKeysSecretRef("spec.oci.verification", syncKind)
+		}
+		if keyless := oci.Verification.Keyless; keyless != nil && (keyless.Identity == "" || keyless.Issuer == "") {
+			return MissingKeylessIdentity("spec.oci.verification.keyless", syncKind)
+		}
+	}
+
+	if policy := oci.TagPolicy; policy != nil {
+		// The tag is selected by the policy, so the image must be a repository.
+		if _, err := name.NewRepository(oci.Image); err != nil {
+			return InvalidOciTagPolicy("spec.oci.image must not specify a tag or digest", syncKind)
+		}
+		if (policy.Semver == "") == !policy.Latest {
+			return InvalidOciTagPolicy("exactly one of spec.oci.tagPolicy.semver and spec.oci.tagPolicy.latest must be specified", syncKind)
+		}
+		if policy.Latest && policy.Regex == "" {
+			return InvalidOciTagPolicy("spec.oci.tagPolicy.regex must be specified with spec.oci.tagPolicy.latest", syncKind)
+		}
+		if policy.Semver != "" {
+			if _, err := semverrange.NewConstraint(policy.Semver); err != nil {
+				return InvalidOciTagPolicy("spec.oci.tagPolicy.semver is not a valid semantic version constraint", syncKind)
+			}
+		}
+		if policy.Regex != "" {
+			if _, err := regexp.Compile(policy.Regex); err != nil {
+				return InvalidOciTagPolicy("spec.oci.tagPolicy.regex is not a valid regular expression", syncKind)
+			}
+		}
+	}
+	return nil
+}
+
+// ArchiveSpec validates the archive specification.
+func ArchiveSpec(archive *v1beta1.Archive, syncKind string) status.Error {
+	if archive == nil {
+		return MissingArchiveSpec(syncKind)
+	}
+
+	// We can't download the archive if we don't have the URL.
+	if archive.URL == "" {
+		return MissingArchiveURL(syncKind)
+	}
+	if u, err := url.Parse(archive.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return InvalidArchiveURL(syncKind)
+	}
+
+	// Ensure auth is a valid value.
+	// Note that Auth is a case-sensitive field, so ones with arbitrary capitalization
+	// will fail to apply.
+	switch archive.Auth {
+	case configsync.AuthNone:
+		if archive.SecretRef != nil && archive.SecretRef.Name != "" {
+			return IllegalSecretRef(configsync.ArchiveSource, syncKind)
+		}
+	case configsync.AuthToken, configsync.AuthHMAC:
+		if archive.SecretRef == nil || archive.SecretRef.Name == "" {
+			return MissingSecretRef(configsync.ArchiveSource, syncKind)
+		}
+	default:
+		return InvalidArchiveAuthType(syncKind)
+	}
 	return nil
 }
 
@@ -231,6 +452,20 @@ func HelmSpec(helm *v1beta1.HelmBase, syncKind string) status.Error {
 		}
 	}
 
+	if helm.Verify != nil {
+		if v1beta1.GetSecretName(helm.Verify.KeyringSecretRef) == "" {
+			return MissingHelmKeyringSecretRef(syncKind)
+		}
+		if keyless := helm.Verify.Keyless; keyless != nil {
+			if !strings.HasPrefix(helm.Repo, "oci://") {
+				return HelmKeylessRequiresOCI(syncKind)
+			}
+			if keyless.Identity == "" || keyless.Issuer == "" {
+				return MissingKeylessIdentity("spec.helm.verify.keyless", syncKind)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -298,6 +533,9 @@ func OverrideSpec(override *v1beta1.OverrideSpec, syncKind string) status.Error
 			return OverrideResourceQuantityNegative("memoryLimit", syncKind)
 		}
 	}
+	if _, err := diff.NewIgnoreRules(override.IgnoreDifferences); err != nil {
+		return InvalidIgnoreDifferences(err, syncKind)
+	}
 	return nil
 }
 
@@ -359,6 +597,24 @@ func MissingSecretRef(sourceType configsync.SourceType, syncKind string) status.
 		Build()
 }
 
+// MissingVerificationKeysSecretRef reports that a RootSync/RepoSync enables
+// source verification, but does not specify the Secret with the trusted keys.
+func MissingVerificationKeysSecretRef(field, syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss which specify %s must also specify %s.trustedKeysSecretRef",
+			syncKind, field, field).
+		Build()
+}
+
+// MissingKeylessIdentity reports that a RootSync/RepoSync enables keyless
+// verification, but does not specify both the expected identity and issuer.
+func MissingKeylessIdentity(field, syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss which specify %s must also specify both %s.identity and %s.issuer",
+			syncKind, field, field, field).
+		Build()
+}
+
 // InvalidGCPSAEmail reports that a RepoSync/RootSync Resource doesn't have the
 //
 //	correct gcp service account suffix.
@@ -399,7 +655,15 @@ func validGCPServiceAccountEmail(email string) bool {
 // supported source types.
 func InvalidSourceType(syncKind string) status.Error {
 	return invalidSyncBuilder.
-		Sprintf("%ss must specify spec.sourceType to be one of %q, %q, %q", syncKind, configsync.GitSource, configsync.OciSource, configsync.HelmSource).
+		Sprintf("%ss must specify spec.sourceType to be one of %q, %q, %q, %q", syncKind, configsync.GitSource, configsync.OciSource, configsync.HelmSource, configsync.ArchiveSource).
+		Build()
+}
+
+// InvalidAdditionalSourceType reports that an additional source of a RootSync
+// doesn't use one of the source types supported by additional sources.
+func InvalidAdditionalSourceType(name, syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify spec.sources[%s].sourceType to be one of %q, %q, %q", syncKind, name, configsync.GitSource, configsync.OciSource, configsync.HelmSource).
 		Build()
 }
 
@@ -429,6 +693,40 @@ func InvalidOciAuthType(syncKind string) status.Error {
 		Build()
 }
 
+// MissingArchiveSpec reports that a RootSync/RepoSync doesn't declare the
+// archive spec when spec.sourceType is set to `archive`.
+func MissingArchiveSpec(syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify spec.archive when spec.sourceType is %q", syncKind, configsync.ArchiveSource).
+		Build()
+}
+
+// MissingArchiveURL reports that a RootSync/RepoSync doesn't declare the URL
+// of the archive it is supposed to download.
+func MissingArchiveURL(syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify spec.archive.url when spec.sourceType is %q", syncKind, configsync.ArchiveSource).
+		Build()
+}
+
+// InvalidArchiveURL reports that a RootSync/RepoSync declares an archive URL
+// which is not an HTTP(S) URL.
+func InvalidArchiveURL(syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify spec.archive.url as an http or https URL", syncKind).
+		Build()
+}
+
+// InvalidArchiveAuthType reports that a RootSync/RepoSync doesn't use one of
+// the known auth methods for archives.
+func InvalidArchiveAuthType(syncKind string) status.Error {
+	types := []string{string(configsync.AuthHMAC), string(configsync.AuthNone), string(configsync.AuthToken)}
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify spec.archive.auth to be one of %s", syncKind,
+			strings.Join(types, ",")).
+		Build()
+}
+
 // MissingHelmSpec reports that a RootSync/RepoSync doesn't declare the Helm spec
 // when spec.sourceType is set to `helm`.
 func MissingHelmSpec(syncKind string) status.Error {
@@ -478,6 +776,37 @@ func MissingHelmValuesFileRefsName(syncKind string) status.Error {
 		Build()
 }
 
+// MissingHelmKeyringSecretRef reports that a RootSync/RepoSync specifies
+// spec.helm.verify, but not the Secret with the trusted keys.
+func MissingHelmKeyringSecretRef(syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss which specify spec.helm.verify must also specify spec.helm.verify.keyringSecretRef", syncKind).
+		Build()
+}
+
+// HelmKeylessRequiresOCI reports that a RootSync/RepoSync specifies keyless
+// verification for a chart which is not stored in an OCI registry.
+func HelmKeylessRequiresOCI(syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss may only specify spec.helm.verify.keyless when spec.helm.repo is an OCI registry (oci://)", syncKind).
+		Build()
+}
+
+// InvalidGitHelmValuesFile reports that an RSync specifies a values file which
+// is not a relative path in spec.git.helm.valuesFiles.
+func InvalidGitHelmValuesFile(valuesFile, syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify paths relative to spec.git.dir in spec.git.helm.valuesFiles, got %q", syncKind, valuesFile).
+		Build()
+}
+
+// MissingGitHelmValuesFileRefsName reports that an RSync is missing spec.git.helm.valuesFileRefs.name
+func MissingGitHelmValuesFileRefsName(syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify spec.git.helm.valuesFileRefs.name", syncKind).
+		Build()
+}
+
 // HelmValuesMissingConfigMap reports that an RSync is referencing a ConfigMap that doesn't exist.
 func HelmValuesMissingConfigMap(syncKind string, err error) status.Error {
 	return invalidSyncBuilder.
@@ -500,6 +829,85 @@ func HelmValuesConfigMapMustBeImmutable(syncKind, cmName, cmNamespace string) st
 		Build()
 }
 
+// SourcesRequireUnstructured reports that a RootSync declares additional
+// sources, but doesn't use the unstructured format.
+func SourcesRequireUnstructured(syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss which specify spec.sources must also specify spec.sourceFormat as %q",
+			syncKind, configsync.SourceFormatUnstructured).
+		Build()
+}
+
+// InvalidSourceName reports that the name of an additional source is not a
+// valid DNS label.
+func InvalidSourceName(name, syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify spec.sources[].name as a DNS label of at most %d characters, found %q",
+			syncKind, maxSourceNameLength, name).
+		Build()
+}
+
+// MissingRolloutCanaryGroup reports that a RootSync specifies a rollout gate
+// without a canary cluster group.
+func MissingRolloutCanaryGroup(syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss which specify spec.rollout must also specify spec.rollout.canaryGroup", syncKind).
+		Build()
+}
+
+// InvalidRolloutHealthSource reports that a RootSync specifies a rollout gate
+// without exactly one health source.
+func InvalidRolloutHealthSource(syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss which specify spec.rollout must also specify either spec.rollout.configMapRef, with a name and a namespace, or spec.rollout.url",
+			syncKind).
+		Build()
+}
+
+// DuplicateSourceName reports that multiple additional sources have the same
+// name.
+func DuplicateSourceName(name, syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify unique names in spec.sources, found %q more than once",
+			syncKind, name).
+		Build()
+}
+
+// UnsupportedSourceAuthType reports that an additional source uses an auth
+// type which is only supported by the primary source.
+func UnsupportedSourceAuthType(name string, sourceType configsync.SourceType, auth configsync.AuthType, syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must not specify spec.sources[%s].%s.auth as %q: the auth type is not supported by additional sources",
+			syncKind, name, sourceType, auth).
+		Build()
+}
+
+// UnsupportedSourceField reports that an additional source specifies a field
+// which is only supported by the primary source.
+func UnsupportedSourceField(name, field, syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must not specify spec.sources[%s].%s: the field is not supported by additional sources",
+			syncKind, name, field).
+		Build()
+}
+
+// UnsupportedSourceRevisionConstraint reports that an additional Git source
+// declares a semantic version constraint as revision.
+func UnsupportedSourceRevisionConstraint(name, syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must not specify a semantic version constraint in spec.sources[%s].git.revision: constraints are not supported by additional sources",
+			syncKind, name).
+		Build()
+}
+
+// InvalidOciTagPolicy reports that a RootSync/RepoSync declares an invalid
+// spec.oci.tagPolicy.
+func InvalidOciTagPolicy(reason, syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify a valid spec.oci.tagPolicy: %s", syncKind, reason).
+		Build()
+}
+
 // OverrideRoleRefNamespace reports that a RootSync needs
 // `spec.override.roleRefs.namespace` when  `spec.override.roleRefs.kind` is
 // "Role".
@@ -517,3 +925,11 @@ func OverrideResourceQuantityNegative(fieldName, syncKind string) status.Error {
 		Sprintf("%s field 'spec.override.resources.%s' must not be negative", syncKind, fieldName).
 		Build()
 }
+
+// InvalidIgnoreDifferences reports that a RootSync/RepoSync declares an invalid
+// `spec.override.ignoreDifferences`.
+func InvalidIgnoreDifferences(err error, syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify a valid spec.override.%v", syncKind, err).
+		Build()
+}
@@ -15,6 +15,7 @@
 package validate
 
 import (
+	"errors"
 	"testing"
 
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -63,6 +64,39 @@ func secret(secretName string) func(*v1beta1.RepoSync) {
 	}
 }
 
+func verification(secretName string) func(*v1beta1.RepoSync) {
+	return func(sync *v1beta1.RepoSync) {
+		sync.Spec.Git.Verification = &v1beta1.GitVerification{
+			TrustedKeysSecretRef: &v1beta1.SecretReference{
+				Name: secretName,
+			},
+		}
+	}
+}
+
+func ociVerification(secretName string, keyless *v1beta1.OciKeylessVerification) func(*v1beta1.RepoSync) {
+	return func(sync *v1beta1.RepoSync) {
+		sync.Spec.Oci.Verification = &v1beta1.OciVerification{
+			TrustedKeysSecretRef: &v1beta1.SecretReference{
+				Name: secretName,
+			},
+			Keyless: keyless,
+		}
+	}
+}
+
+func helmVerify(repo, secretName string, keyless *v1beta1.OciKeylessVerification) func(*v1beta1.RepoSync) {
+	return func(sync *v1beta1.RepoSync) {
+		sync.Spec.Helm.Repo = repo
+		sync.Spec.Helm.Verify = &v1beta1.HelmVerification{
+			KeyringSecretRef: &v1beta1.SecretReference{
+				Name: secretName,
+			},
+			Keyless: keyless,
+		}
+	}
+}
+
 func gcpSAEmail(email string) func(sync *v1beta1.RepoSync) {
 	return func(sync *v1beta1.RepoSync) {
 		sync.Spec.GCPServiceAccountEmail = email
@@ -98,6 +132,19 @@ func repoSyncWithGit(opts ...func(*v1beta1.RepoSync)) *v1beta1.RepoSync {
 	return rs
 }
 
+func gitHelm(helm *v1beta1.GitHelm) func(*v1beta1.RepoSync) {
+	return func(sync *v1beta1.RepoSync) {
+		sync.Spec.Git.Helm = helm
+	}
+}
+
+func ociTagPolicy(image string, policy *v1beta1.OciTagPolicy) func(*v1beta1.RepoSync) {
+	return func(sync *v1beta1.RepoSync) {
+		sync.Spec.Oci.Image = image
+		sync.Spec.Oci.TagPolicy = policy
+	}
+}
+
 func repoSyncWithOci(opts ...func(*v1beta1.RepoSync)) *v1beta1.RepoSync {
 	rs := k8sobjects.RepoSyncObjectV1Beta1("test-ns", configsync.RepoSyncName)
 	rs.Spec.SourceType = configsync.OciSource
@@ -111,6 +158,34 @@ func repoSyncWithOci(opts ...func(*v1beta1.RepoSync)) *v1beta1.RepoSync {
 	return rs
 }
 
+func archiveAuth(authType configsync.AuthType, secretName string) func(*v1beta1.RepoSync) {
+	return func(sync *v1beta1.RepoSync) {
+		sync.Spec.Archive.Auth = authType
+		if secretName != "" {
+			sync.Spec.Archive.SecretRef = &v1beta1.SecretReference{Name: secretName}
+		}
+	}
+}
+
+func archiveURL(url string) func(*v1beta1.RepoSync) {
+	return func(sync *v1beta1.RepoSync) {
+		sync.Spec.Archive.URL = url
+	}
+}
+
+func repoSyncWithArchive(opts ...func(*v1beta1.RepoSync)) *v1beta1.RepoSync {
+	rs := k8sobjects.RepoSyncObjectV1Beta1("test-ns", configsync.RepoSyncName)
+	rs.Spec.SourceType = configsync.ArchiveSource
+	rs.Spec.Archive = &v1beta1.Archive{
+		URL:  "https://storage.example.com/bucket/configs.tar.gz",
+		Auth: configsync.AuthNone,
+	}
+	for _, opt := range opts {
+		opt(rs)
+	}
+	return rs
+}
+
 func repoSyncWithHelm(opts ...func(*v1beta1.RepoSync)) *v1beta1.RepoSync {
 	rs := k8sobjects.RepoSyncObjectV1Beta1("test-ns", configsync.RepoSyncName)
 	rs.Spec.SourceType = configsync.HelmSource
@@ -178,6 +253,25 @@ func rootSyncWithHelm(opts ...func(*v1beta1.RootSync)) *v1beta1.RootSync {
 	return rs
 }
 
+func rootSyncSources(format configsync.SourceFormat, sources ...v1beta1.RootSyncSource) func(*v1beta1.RootSync) {
+	return func(rs *v1beta1.RootSync) {
+		rs.Spec.SourceFormat = format
+		rs.Spec.Sources = sources
+	}
+}
+
+func gitSource(name string, auth configsync.AuthType) v1beta1.RootSyncSource {
+	return v1beta1.RootSyncSource{
+		Name:       name,
+		SourceType: configsync.GitSource,
+		Git: &v1beta1.Git{
+			Repo:      "https://example.com/" + name,
+			Auth:      auth,
+			SecretRef: &v1beta1.SecretReference{Name: name + "-creds"},
+		},
+	}
+}
+
 func TestValidateRepoSyncSpec(t *testing.T) {
 	testCases := []struct {
 		name    string
@@ -230,6 +324,15 @@ func TestValidateRepoSyncSpec(t *testing.T) {
 			obj:     repoSyncWithGit(auth(configsync.AuthSSH)),
 			wantErr: MissingSecretRef(configsync.GitSource, configsync.RepoSyncKind),
 		},
+		{
+			name: "valid verification",
+			obj:  repoSyncWithGit(verification("trusted-keys")),
+		},
+		{
+			name:    "missing verification secret",
+			obj:     repoSyncWithGit(verification("")),
+			wantErr: MissingVerificationKeysSecretRef("spec.git.verification", configsync.RepoSyncKind),
+		},
 		{
 			name:    "invalid GCP serviceaccount email",
 			obj:     repoSyncWithGit(auth(configsync.AuthGCPServiceAccount), gcpSAEmail("invalid_gcp_sa@gserviceaccount.com")),
@@ -270,6 +373,128 @@ func TestValidateRepoSyncSpec(t *testing.T) {
 			obj:     repoSyncWithOci(ociAuth(configsync.AuthGCPServiceAccount)),
 			wantErr: MissingGCPSAEmail(configsync.OciSource, configsync.RepoSyncKind),
 		},
+		{
+			name: "valid git helm",
+			obj: repoSyncWithGit(gitHelm(&v1beta1.GitHelm{
+				ValuesFiles:    []string{"values-prod.yaml", "chart-values/prod.yaml"},
+				ValuesFileRefs: []v1beta1.ValuesFileRef{{Name: "values"}},
+			})),
+		},
+		{
+			name:    "git helm values file outside of the sync directory",
+			obj:     repoSyncWithGit(gitHelm(&v1beta1.GitHelm{ValuesFiles: []string{"../values.yaml"}})),
+			wantErr: InvalidGitHelmValuesFile("../values.yaml", configsync.RepoSyncKind),
+		},
+		{
+			name:    "git helm values file with an absolute path",
+			obj:     repoSyncWithGit(gitHelm(&v1beta1.GitHelm{ValuesFiles: []string{"/values.yaml"}})),
+			wantErr: InvalidGitHelmValuesFile("/values.yaml", configsync.RepoSyncKind),
+		},
+		{
+			name:    "missing git helm valuesFileRefs name",
+			obj:     repoSyncWithGit(gitHelm(&v1beta1.GitHelm{ValuesFileRefs: []v1beta1.ValuesFileRef{{DataKey: "values.yaml"}}})),
+			wantErr: MissingGitHelmValuesFileRefsName(configsync.RepoSyncKind),
+		},
+		{
+			name: "valid oci verification",
+			obj:  repoSyncWithOci(ociVerification("trusted-keys", nil)),
+		},
+		{
+			name: "valid oci keyless verification",
+			obj: repoSyncWithOci(ociVerification("trust-root", &v1beta1.OciKeylessVerification{
+				Identity: "ci@example.com",
+				Issuer:   "https://accounts.google.com",
+			})),
+		},
+		{
+			name:    "missing oci verification secret",
+			obj:     repoSyncWithOci(ociVerification("", nil)),
+			wantErr: MissingVerificationKeysSecretRef("spec.oci.verification", configsync.RepoSyncKind),
+		},
+		{
+			name: "missing oci keyless issuer",
+			obj: repoSyncWithOci(ociVerification("trust-root", &v1beta1.OciKeylessVerification{
+				Identity: "ci@example.com",
+			})),
+			wantErr: MissingKeylessIdentity("spec.oci.verification.keyless", configsync.RepoSyncKind),
+		},
+		{
+			name: "valid oci semver tag policy",
+			obj: repoSyncWithOci(ociTagPolicy("us-docker.pkg.dev/project/repo/configs",
+				&v1beta1.OciTagPolicy{Semver: ">=1.4.0 <2.0.0", Regex: "^v"})),
+		},
+		{
+			name: "valid oci latest tag policy",
+			obj: repoSyncWithOci(ociTagPolicy("us-docker.pkg.dev/project/repo/configs",
+				&v1beta1.OciTagPolicy{Latest: true, Regex: "^main-[0-9a-f]+$"})),
+		},
+		{
+			name: "oci tag policy with a tagged image",
+			obj: repoSyncWithOci(ociTagPolicy("us-docker.pkg.dev/project/repo/configs:v1",
+				&v1beta1.OciTagPolicy{Latest: true, Regex: "^main-"})),
+			wantErr: InvalidOciTagPolicy("spec.oci.image must not specify a tag or digest", configsync.RepoSyncKind),
+		},
+		{
+			name: "oci tag policy with both semver and latest",
+			obj: repoSyncWithOci(ociTagPolicy("us-docker.pkg.dev/project/repo/configs",
+				&v1beta1.OciTagPolicy{Semver: "~1.4", Latest: true})),
+			wantErr: InvalidOciTagPolicy("exactly one of spec.oci.tagPolicy.semver and spec.oci.tagPolicy.latest must be specified", configsync.RepoSyncKind),
+		},
+		{
+			name: "oci latest tag policy without a regex",
+			obj: repoSyncWithOci(ociTagPolicy("us-docker.pkg.dev/project/repo/configs",
+				&v1beta1.OciTagPolicy{Latest: true})),
+			wantErr: InvalidOciTagPolicy("spec.oci.tagPolicy.regex must be specified with spec.oci.tagPolicy.latest", configsync.RepoSyncKind),
+		},
+		{
+			name: "oci tag policy with an invalid regex",
+			obj: repoSyncWithOci(ociTagPolicy("us-docker.pkg.dev/project/repo/configs",
+				&v1beta1.OciTagPolicy{Latest: true, Regex: "("})),
+			wantErr: InvalidOciTagPolicy("spec.oci.tagPolicy.regex is not a valid regular expression", configsync.RepoSyncKind),
+		},
+		// Validate archive spec
+		{
+			name: "valid archive",
+			obj:  repoSyncWithArchive(),
+		},
+		{
+			name: "valid archive with token auth",
+			obj:  repoSyncWithArchive(archiveAuth(configsync.AuthToken, "archive-creds")),
+		},
+		{
+			name: "valid archive with hmac auth",
+			obj:  repoSyncWithArchive(archiveAuth(configsync.AuthHMAC, "archive-creds")),
+		},
+		{
+			name:    "missing archive spec",
+			obj:     k8sobjects.RepoSyncObjectV1Beta1("test-ns", configsync.RepoSyncName, k8sobjects.WithRepoSyncSourceType(configsync.ArchiveSource)),
+			wantErr: MissingArchiveSpec(configsync.RepoSyncKind),
+		},
+		{
+			name:    "missing archive url",
+			obj:     repoSyncWithArchive(archiveURL("")),
+			wantErr: MissingArchiveURL(configsync.RepoSyncKind),
+		},
+		{
+			name:    "archive url without http scheme",
+			obj:     repoSyncWithArchive(archiveURL("s3://bucket/configs.tar.gz")),
+			wantErr: InvalidArchiveURL(configsync.RepoSyncKind),
+		},
+		{
+			name:    "invalid archive auth type",
+			obj:     repoSyncWithArchive(archiveAuth(configsync.AuthGCENode, "")),
+			wantErr: InvalidArchiveAuthType(configsync.RepoSyncKind),
+		},
+		{
+			name:    "missing archive secret",
+			obj:     repoSyncWithArchive(archiveAuth(configsync.AuthHMAC, "")),
+			wantErr: MissingSecretRef(configsync.ArchiveSource, configsync.RepoSyncKind),
+		},
+		{
+			name:    "illegal archive secret",
+			obj:     repoSyncWithArchive(archiveAuth(configsync.AuthNone, "archive-creds")),
+			wantErr: IllegalSecretRef(configsync.ArchiveSource, configsync.RepoSyncKind),
+		},
 		{
 			name:    "invalid source type",
 			obj:     k8sobjects.RepoSyncObjectV1Beta1("test-ns", configsync.RepoSyncName, k8sobjects.WithRepoSyncSourceType("invalid")),
@@ -310,6 +535,37 @@ func TestValidateRepoSyncSpec(t *testing.T) {
 			obj:     repoSyncWithHelm(helmAuth(configsync.AuthGCPServiceAccount)),
 			wantErr: MissingGCPSAEmail(configsync.HelmSource, configsync.RepoSyncKind),
 		},
+		{
+			name: "valid helm verification",
+			obj:  repoSyncWithHelm(helmVerify("https://example.com/charts", "keyring", nil)),
+		},
+		{
+			name: "valid helm keyless verification",
+			obj: repoSyncWithHelm(helmVerify("oci://example.com/charts", "trust-root", &v1beta1.OciKeylessVerification{
+				Identity: "ci@example.com",
+				Issuer:   "https://accounts.google.com",
+			})),
+		},
+		{
+			name:    "missing helm keyring secret",
+			obj:     repoSyncWithHelm(helmVerify("https://example.com/charts", "", nil)),
+			wantErr: MissingHelmKeyringSecretRef(configsync.RepoSyncKind),
+		},
+		{
+			name: "helm keyless verification for a non-OCI repo",
+			obj: repoSyncWithHelm(helmVerify("https://example.com/charts", "trust-root", &v1beta1.OciKeylessVerification{
+				Identity: "ci@example.com",
+				Issuer:   "https://accounts.google.com",
+			})),
+			wantErr: HelmKeylessRequiresOCI(configsync.RepoSyncKind),
+		},
+		{
+			name: "missing helm keyless identity",
+			obj: repoSyncWithHelm(helmVerify("oci://example.com/charts", "trust-root", &v1beta1.OciKeylessVerification{
+				Issuer: "https://accounts.google.com",
+			})),
+			wantErr: MissingKeylessIdentity("spec.helm.verify.keyless", configsync.RepoSyncKind),
+		},
 		{
 			name:    "redundant Helm spec",
 			obj:     repoSyncWithGit(withHelm()),
@@ -418,6 +674,53 @@ func TestValidateRootSyncSpec(t *testing.T) {
 			}),
 			wantErr: HelmNSAndDeployNS(configsync.RootSyncKind),
 		},
+		{
+			name: "valid spec.rollout with a ConfigMap",
+			obj: rootSyncWithGit(func(sync *v1beta1.RootSync) {
+				sync.Spec.Rollout = &v1beta1.RolloutSpec{
+					CanaryGroup:  "canary",
+					ConfigMapRef: &v1beta1.RolloutConfigMapRef{Name: "rollout", Namespace: "fleet"},
+				}
+			}),
+		},
+		{
+			name: "valid spec.rollout with a URL",
+			obj: rootSyncWithGit(func(sync *v1beta1.RootSync) {
+				sync.Spec.Rollout = &v1beta1.RolloutSpec{
+					CanaryGroup: "canary",
+					URL:         "http://localhost:8080/health",
+				}
+			}),
+		},
+		{
+			name: "invalid spec.rollout without canaryGroup",
+			obj: rootSyncWithGit(func(sync *v1beta1.RootSync) {
+				sync.Spec.Rollout = &v1beta1.RolloutSpec{
+					URL: "http://localhost:8080/health",
+				}
+			}),
+			wantErr: MissingRolloutCanaryGroup(configsync.RootSyncKind),
+		},
+		{
+			name: "invalid spec.rollout with both configMapRef and url",
+			obj: rootSyncWithGit(func(sync *v1beta1.RootSync) {
+				sync.Spec.Rollout = &v1beta1.RolloutSpec{
+					CanaryGroup:  "canary",
+					ConfigMapRef: &v1beta1.RolloutConfigMapRef{Name: "rollout", Namespace: "fleet"},
+					URL:          "http://localhost:8080/health",
+				}
+			}),
+			wantErr: InvalidRolloutHealthSource(configsync.RootSyncKind),
+		},
+		{
+			name: "invalid spec.rollout without a health source",
+			obj: rootSyncWithGit(func(sync *v1beta1.RootSync) {
+				sync.Spec.Rollout = &v1beta1.RolloutSpec{
+					CanaryGroup: "canary",
+				}
+			}),
+			wantErr: InvalidRolloutHealthSource(configsync.RootSyncKind),
+		},
 		{
 			name: "valid spec.override.roleRefs Role",
 			obj: rootSyncWithGit(func(sync *v1beta1.RootSync) {
@@ -531,6 +834,133 @@ func TestValidateRootSyncSpec(t *testing.T) {
 			}),
 			wantErr: OverrideResourceQuantityNegative("memoryLimit", configsync.RootSyncKind),
 		},
+		{
+			name: "valid spec.override.ignoreDifferences",
+			obj: rootSyncWithGit(func(rs *v1beta1.RootSync) {
+				rs.Spec.SafeOverride().IgnoreDifferences = []v1beta1.IgnoreDifference{
+					{
+						Group:     "apps",
+						Kind:      "Deployment",
+						JSONPaths: []string{".spec.replicas"},
+					},
+				}
+			}),
+			wantErr: nil,
+		},
+		{
+			name: "invalid spec.override.ignoreDifferences JSONPath",
+			obj: rootSyncWithGit(func(rs *v1beta1.RootSync) {
+				rs.Spec.SafeOverride().IgnoreDifferences = []v1beta1.IgnoreDifference{
+					{
+						Group:     "apps",
+						Kind:      "Deployment",
+						JSONPaths: []string{"spec.replicas"},
+					},
+				}
+			}),
+			wantErr: InvalidIgnoreDifferences(
+				errors.New(`ignoreDifferences[0]: invalid JSONPath "spec.replicas": must start with '.'`),
+				configsync.RootSyncKind),
+		},
+		{
+			name: "valid spec.sources",
+			obj: rootSyncWithGit(rootSyncSources(configsync.SourceFormatUnstructured,
+				gitSource("platform", configsync.AuthSSH),
+				v1beta1.RootSyncSource{
+					Name:       "addons",
+					SourceType: configsync.OciSource,
+					Oci:        &v1beta1.Oci{Image: "example.com/addons", Auth: configsync.AuthNone},
+				})),
+		},
+		{
+			name:    "spec.sources with the hierarchy format",
+			obj:     rootSyncWithGit(rootSyncSources(configsync.SourceFormatHierarchy, gitSource("platform", configsync.AuthSSH))),
+			wantErr: SourcesRequireUnstructured(configsync.RootSyncKind),
+		},
+		{
+			name:    "invalid spec.sources name",
+			obj:     rootSyncWithGit(rootSyncSources(configsync.SourceFormatUnstructured, gitSource("Platform", configsync.AuthSSH))),
+			wantErr: InvalidSourceName("Platform", configsync.RootSyncKind),
+		},
+		{
+			name: "duplicate spec.sources name",
+			obj: rootSyncWithGit(rootSyncSources(configsync.SourceFormatUnstructured,
+				gitSource("platform", configsync.AuthSSH), gitSource("platform", configsync.AuthToken))),
+			wantErr: DuplicateSourceName("platform", configsync.RootSyncKind),
+		},
+		{
+			name: "invalid spec.sources git spec",
+			obj: rootSyncWithGit(rootSyncSources(configsync.SourceFormatUnstructured,
+				v1beta1.RootSyncSource{Name: "platform", SourceType: configsync.GitSource})),
+			wantErr: MissingGitSpec(configsync.RootSyncKind),
+		},
+		{
+			name: "invalid spec.sources source type",
+			obj: rootSyncWithGit(rootSyncSources(configsync.SourceFormatUnstructured,
+				v1beta1.RootSyncSource{Name: "platform", SourceType: configsync.ArchiveSource})),
+			wantErr: InvalidAdditionalSourceType("platform", configsync.RootSyncKind),
+		},
+		{
+			name: "unsupported spec.sources auth type",
+			obj: rootSyncWithGit(rootSyncSources(configsync.SourceFormatUnstructured,
+				v1beta1.RootSyncSource{
+					Name:       "platform",
+					SourceType: configsync.GitSource,
+					Git:        &v1beta1.Git{Repo: "https://example.com/platform", Auth: configsync.AuthGCENode},
+				})),
+			wantErr: UnsupportedSourceAuthType("platform", configsync.GitSource, configsync.AuthGCENode, configsync.RootSyncKind),
+		},
+		{
+			name: "unsupported spec.sources field",
+			obj: rootSyncWithGit(rootSyncSources(configsync.SourceFormatUnstructured,
+				v1beta1.RootSyncSource{
+					Name:       "charts",
+					SourceType: configsync.HelmSource,
+					Helm: &v1beta1.HelmRootSync{HelmBase: v1beta1.HelmBase{
+						Repo:           "https://example.com/charts",
+						Chart:          "my-chart",
+						Auth:           configsync.AuthNone,
+						ValuesFileRefs: []v1beta1.ValuesFileRef{{Name: "values"}},
+					}},
+				})),
+			wantErr: UnsupportedSourceField("charts", "helm.valuesFileRefs", configsync.RootSyncKind),
+		},
+		{
+			name: "sparse checkout of spec.sources",
+			obj: rootSyncWithGit(rootSyncSources(configsync.SourceFormatUnstructured,
+				v1beta1.RootSyncSource{
+					Name:       "platform",
+					SourceType: configsync.GitSource,
+					Git:        &v1beta1.Git{Repo: "https://example.com/platform", Auth: configsync.AuthNone, SparseCheckout: true},
+				})),
+			wantErr: UnsupportedSourceField("platform", "git.sparseCheckout", configsync.RootSyncKind),
+		},
+		{
+			name: "helm chart of spec.sources",
+			obj: rootSyncWithGit(rootSyncSources(configsync.SourceFormatUnstructured,
+				v1beta1.RootSyncSource{
+					Name:       "platform",
+					SourceType: configsync.GitSource,
+					Git:        &v1beta1.Git{Repo: "https://example.com/platform", Auth: configsync.AuthNone, Helm: &v1beta1.GitHelm{}},
+				})),
+			wantErr: UnsupportedSourceField("platform", "git.helm", configsync.RootSyncKind),
+		},
+		{
+			name: "revision constraint of spec.sources",
+			obj: rootSyncWithGit(rootSyncSources(configsync.SourceFormatUnstructured,
+				v1beta1.RootSyncSource{
+					Name:       "platform",
+					SourceType: configsync.GitSource,
+					Git:        &v1beta1.Git{Repo: "https://example.com/platform", Auth: configsync.AuthNone, Revision: ">=1.4.0 <2.0.0"},
+				})),
+			wantErr: UnsupportedSourceRevisionConstraint("platform", configsync.RootSyncKind),
+		},
+		{
+			name: "valid revision constraint",
+			obj: rootSyncWithGit(func(rs *v1beta1.RootSync) {
+				rs.Spec.Git.Revision = "~1.4"
+			}),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -20,6 +20,7 @@ import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/diff"
 	"kpt.dev/configsync/pkg/importer/analyzer/ast"
 	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
 	"kpt.dev/configsync/pkg/reconciler/namespacecontroller"
@@ -60,6 +61,10 @@ type Options struct {
 	// BuildScoper is a function that builds a Scoper to identify which objects
 	// are cluster-scoped or namespace-scoped.
 	BuildScoper discovery.BuildScoperFunc
+	// OpenAPISchemas is a function that returns the OpenAPI schemas of the
+	// types served by the cluster, used to validate the declared objects.
+	// Schema validation is skipped when nil.
+	OpenAPISchemas discovery.OpenAPISchemasFunc
 	// Converter is used to encode the declared fields of each object into an
 	// annotation on that object so that the validating admission webhook can
 	// prevent those fields from being changed.
@@ -86,6 +91,9 @@ type Options struct {
 	NSControllerState *namespacecontroller.State
 	// WebhookEnabled indicates whether the admission webhook configuration is enabled
 	WebhookEnabled bool
+	// IgnoreRules select the fields whose differences from the declared state
+	// are ignored, so they are not protected by the admission webhook.
+	IgnoreRules diff.IgnoreRules
 	// FieldManager to use when performing cluster operations
 	FieldManager string
 	// MaxObjectCount is the maximum number of objects allowed in a single
@@ -111,10 +119,12 @@ func Hierarchical(objs []ast.FileObject, opts Options) ([]ast.FileObject, status
 		Objects:           objs,
 		PreviousCRDs:      opts.PreviousCRDs,
 		BuildScoper:       opts.BuildScoper,
+		OpenAPISchemas:    opts.OpenAPISchemas,
 		Converter:         opts.Converter,
 		Scheme:            opts.Scheme,
 		AllowUnknownKinds: opts.AllowUnknownKinds,
 		WebhookEnabled:    opts.WebhookEnabled,
+		IgnoreRules:       opts.IgnoreRules,
 	}
 
 	// nonBlockingErrs tracks the errors which do not block the apply stage
@@ -198,6 +208,7 @@ func Unstructured(ctx context.Context, c client.Client, objs []ast.FileObject, o
 		Objects:                  objs,
 		PreviousCRDs:             opts.PreviousCRDs,
 		BuildScoper:              opts.BuildScoper,
+		OpenAPISchemas:           opts.OpenAPISchemas,
 		Converter:                opts.Converter,
 		Scheme:                   opts.Scheme,
 		AllowUnknownKinds:        opts.AllowUnknownKinds,
@@ -205,6 +216,7 @@ func Unstructured(ctx context.Context, c client.Client, objs []ast.FileObject, o
 		DynamicNSSelectorEnabled: opts.DynamicNSSelectorEnabled,
 		NSControllerState:        opts.NSControllerState,
 		WebhookEnabled:           opts.WebhookEnabled,
+		IgnoreRules:              opts.IgnoreRules,
 	}
 
 	// nonBlockingErrs tracks the errors which do not block the apply stage